@@ -1,7 +1,11 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"log"
+	"log/slog"
+	"os"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/cmd/server"
 )
@@ -9,7 +13,10 @@ import (
 func main() {
 
 	// conf and env
-	app, err := server.LoadServerConf()
+	app, err := server.LoadServerConf(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
 		log.Fatal(err.Error())
 	}
@@ -17,12 +24,13 @@ func main() {
 	// set up globalRouter and api config
 	globalRouter, err := app.SetUp()
 	if err != nil {
-		log.Fatal(err.Error())
+		app.Logger.Error("failed to set up the server", slog.Any("error", err))
+		os.Exit(1)
 	}
 
 	// - run
 	if err := app.Run(globalRouter); err != nil {
-		log.Fatal(err.Error())
-		return
+		app.Logger.Error("server stopped", slog.Any("error", err))
+		os.Exit(1)
 	}
 }
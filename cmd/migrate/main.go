@@ -8,17 +8,31 @@ import (
 	"sort"
 	"strings"
 
-	config "github.com/aaguero_meli/W17-G6-Bootcamp/cmd/db"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/config"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/storage"
 )
 
 func main() {
-	db := config.MustOpenDB()
+	cfg, err := config.LoadFromOS("migrate", os.Args[1:])
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	// the database is needed even when the api is set to the memory storage
+	if err := cfg.Database.Validate(); err != nil {
+		log.Fatalf("Invalid database configuration: %v", err)
+	}
+	log.Printf("Configuration: %s", cfg)
+
+	db, err := storage.InitOrCreateMySQLConnection(cfg.Database)
+	if err != nil {
+		log.Fatalf("Could not connect to the database: %v", err)
+	}
 	defer db.Close()
 
 	migrationsDir := "docs/db/migrations"
 
 	// -- Ensures the migrations table exists --
-	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
 		id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
 		filename VARCHAR(255) NOT NULL UNIQUE,
 		applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
@@ -5,11 +5,25 @@ import (
 	"log"
 	"os"
 
-	config "github.com/aaguero_meli/W17-G6-Bootcamp/cmd/db"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/config"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/storage"
 )
 
 func main() {
-	db := config.MustOpenDB()
+	cfg, err := config.LoadFromOS("seed", os.Args[1:])
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	// the database is needed even when the api is set to the memory storage
+	if err := cfg.Database.Validate(); err != nil {
+		log.Fatalf("Invalid database configuration: %v", err)
+	}
+	log.Printf("Configuration: %s", cfg)
+
+	db, err := storage.InitOrCreateMySQLConnection(cfg.Database)
+	if err != nil {
+		log.Fatalf("Could not connect to the database: %v", err)
+	}
 	defer db.Close()
 
 	dumpPath := "docs/db/seed/dump.sql"
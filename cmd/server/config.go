@@ -1,100 +1,115 @@
 package server
 
 import (
-	"fmt"
-	"net/http"
+	"database/sql"
+	"log/slog"
 	"os"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/application"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/config"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/storage"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/logging"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/metrics"
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-sql-driver/mysql"
-	"github.com/joho/godotenv"
 )
 
+// Version is the build version reported by the health endpoints, set with
+// -ldflags "-X github.com/aaguero_meli/W17-G6-Bootcamp/cmd/server.Version=..."
+var Version = "dev"
+
 type ServerChi struct {
-	ServerAddr     string
-	DatabaseConfig mysql.Config
+	Config config.Config
+	Logger *slog.Logger
+
+	// db is opened by SetUp and closed once Run shuts down
+	db *sql.DB
 }
 
-func LoadServerConf() (*ServerChi, error) {
-	// load env file but can be preloaded in the terminal
-	err := godotenv.Load()
+// LoadServerConf loads the config from the config file, the env and args, the
+// command line without the program name, and logs it with its secrets redacted.
+func LoadServerConf(args []string) (*ServerChi, error) {
+	cfg, err := config.LoadFromOS("server", args)
 	if err != nil {
-		// just print the error but continue with the execution with docker
-		fmt.Println("failed to load env file:", err, " .Assuming preload of env already done")
-	}
-
-	// default values
-	serverAddr := os.Getenv("ADDRESS")
-
-	if serverAddr == "" {
-		serverAddr = "8080"
+		return nil, err
 	}
 
-	Host := os.Getenv("DB_HOST")
-	Port := os.Getenv("DB_PORT")
-	Name := os.Getenv("DB_NAME")
-	User := os.Getenv("DB_USER")
-	Pass := os.Getenv("DB_PASS")
-	if Host == "" ||
-		Port == "" ||
-		Name == "" ||
-		User == "" {
-		return nil, fmt.Errorf("DB conn settings not established")
-	}
-	dbConfig := storage.NewMySQLConfig(Host, Port, User, Pass, Name)
-	serverAddr = ":" + serverAddr // add two point to the address
+	// validated by Load
+	logLevel, _ := cfg.Log.LogLevel()
+	logger := logging.New(os.Stdout, logLevel)
+	// the standard log package and slog's package functions write through it too
+	slog.SetDefault(logger)
+	logger.Info("effective configuration", slog.Any("config", cfg.Redacted()))
 
-	return &ServerChi{
-		ServerAddr:     serverAddr,
-		DatabaseConfig: dbConfig,
-	}, nil
+	return &ServerChi{Config: cfg, Logger: logger}, nil
 }
 
 func (a *ServerChi) SetUp() (chi.Router, error) {
 	router := chi.NewRouter()
-	router.Use(middleware.Logger) // logger
+	router.Use(logging.Middleware(a.Logger)) // request IDs and access logs
 
-	freshDB, err := storage.InitMySQLConnection(a.DatabaseConfig)
-	if err != nil {
-		return nil, err
+	// prometheus metrics: requests per route, errors returned and the DB pool
+	features := a.Config.Features
+	registry := metrics.NewRegistry()
+	if features.Metrics {
+		router.Use(metrics.NewHTTPMetrics(registry).Middleware)
+		errorCounter := metrics.NewErrorCounter(registry)
+		httperrors.ObserveErrors(func(errorType string) { errorCounter.Inc(errorType) })
+	}
+
+	var repos application.Repositories
+	if a.Config.Storage == config.StorageMemory {
+		repos = application.NewRepositoriesMemory(repository.NewMemoryStore())
+	} else {
+		freshDB, err := storage.InitMySQLConnection(a.Config.Database)
+		if err != nil {
+			return nil, err
+		}
+		a.db = freshDB
+		metrics.RegisterDBStats(registry, freshDB)
+		repos = application.NewRepositoriesDB(freshDB)
 	}
 
-	healthRouter := application.HealthRouter()
-	productRouter := application.ProductRouter(freshDB)
-	productRecordRouter := application.ProductRecordRouter(freshDB)
-	buyersRouter := application.BuyersRouter(freshDB)
-	warehouseRouter := application.WarehouseRouter(freshDB)
-	sellerRouter := application.SellerRouter(freshDB)
-	employeeRouter := application.EmployeeRouter(freshDB)
-	sectionRouter := application.SectionRouter(freshDB)
-	carryRouter := application.CarryRouter(freshDB)
-	localitiesRouter := application.LocalityRouter(freshDB)
-	purchaseOrderRouter := application.PurchaseOrderRouter(freshDB)
-	inboundOrderRouter := application.InboundOrderRouter(freshDB)
-	productBatchRouter := application.ProductBatchRouter(freshDB)
+	healthRouter := application.HealthRouter(repos, Version)
+	productRouter := application.ProductRouter(repos)
+	productRecordRouter := application.ProductRecordRouter(repos)
+	productTypeRouter := application.ProductTypeRouter(repos)
+	buyersRouter := application.BuyersRouter(repos)
+	warehouseRouter := application.WarehouseRouter(repos, features)
+	sellerRouter := application.SellerRouter(repos)
+	employeeRouter := application.EmployeeRouter(repos)
+	sectionRouter := application.SectionRouter(repos)
+	carryRouter := application.CarryRouter(repos)
+	localitiesRouter := application.LocalityRouter(repos)
+	provinceRouter := application.ProvinceRouter(repos)
+	countryRouter := application.CountryRouter(repos)
+	purchaseOrderRouter := application.PurchaseOrderRouter(repos)
+	inboundOrderRouter := application.InboundOrderRouter(repos)
+	productBatchRouter := application.ProductBatchRouter(repos)
+	alertRouter := application.AlertRouter(repos)
 
 	router.Mount("/healthcheck", healthRouter)
+	if features.Metrics {
+		router.Handle("/metrics", registry.Handler())
+	}
 	router.Route("/api/v1", func(r chi.Router) {
 		r.Mount("/products", productRouter)
 		r.Mount("/productRecords", productRecordRouter)
+		r.Mount("/productTypes", productTypeRouter)
 		r.Mount("/warehouses", warehouseRouter)
 		r.Mount("/buyers", buyersRouter)
 		r.Mount("/sellers", sellerRouter)
 		r.Mount("/employees", employeeRouter)
 		r.Mount("/sections", sectionRouter)
 		r.Mount("/localities", localitiesRouter)
+		r.Mount("/provinces", provinceRouter)
+		r.Mount("/countries", countryRouter)
 		r.Mount("/carries", carryRouter)
 		r.Mount("/purchaseOrders", purchaseOrderRouter)
 		r.Mount("/inboundOrders", inboundOrderRouter)
 		r.Mount("/productBatches", productBatchRouter)
+		r.Mount("/alerts", alertRouter)
 	})
 	return router, nil
 }
-
-func (a *ServerChi) Run(router chi.Router) (err error) {
-	err = http.ListenAndServe(a.ServerAddr, router)
-	return
-}
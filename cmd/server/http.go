@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Run serves router on the configured address until SIGINT or SIGTERM is received, then
+// shuts down gracefully.
+func (a *ServerChi) Run(router http.Handler) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", a.Config.Server.Addr())
+	if err != nil {
+		return err
+	}
+	return a.Serve(ctx, listener, router)
+}
+
+// Serve serves router on listener until ctx is done. It then stops accepting
+// connections, waits up to ShutdownTimeout for the requests in flight and
+// closes the database.
+func (a *ServerChi) Serve(ctx context.Context, listener net.Listener, router http.Handler) error {
+	cfg := a.Config.Server
+	srv := &http.Server{
+		Handler:           router,
+		ReadHeaderTimeout: time.Duration(cfg.ReadHeaderTimeout),
+		ReadTimeout:       time.Duration(cfg.ReadTimeout),
+		WriteTimeout:      time.Duration(cfg.WriteTimeout),
+		IdleTimeout:       time.Duration(cfg.IdleTimeout),
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		ErrorLog:          slog.NewLogLogger(a.Logger.Handler(), slog.LevelWarn),
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		a.Logger.Info("server listening", slog.String("address", listener.Addr().String()))
+		serveErr <- srv.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		// the server failed before any shutdown was asked for
+		return errors.Join(err, a.closeDB())
+	case <-ctx.Done():
+	}
+
+	a.Logger.Info("shutting down, waiting for the requests in flight", slog.String("timeout", cfg.ShutdownTimeout.String()))
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeout))
+	defer cancel()
+	shutdownErr := srv.Shutdown(shutdownCtx)
+	if shutdownErr != nil {
+		// the deadline passed, cut the remaining connections
+		shutdownErr = errors.Join(fmt.Errorf("graceful shutdown: %w", shutdownErr), srv.Close())
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		shutdownErr = errors.Join(shutdownErr, err)
+	}
+	if err := a.closeDB(); err != nil {
+		shutdownErr = errors.Join(shutdownErr, err)
+	}
+	if shutdownErr == nil {
+		a.Logger.Info("server stopped")
+	}
+	return shutdownErr
+}
+
+// closeDB closes the database opened by SetUp, if any
+func (a *ServerChi) closeDB() error {
+	if a.db == nil {
+		return nil
+	}
+	db := a.db
+	a.db = nil
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("closing the database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerChi_Serve(t *testing.T) {
+	// newServer returns a server whose handler holds every request until release is closed
+	newServer := func(t *testing.T, shutdownTimeout time.Duration) (*ServerChi, sqlmock.Sqlmock, http.Handler, chan struct{}, chan struct{}) {
+		db, dbMock, err := sqlmock.New()
+		require.NoError(t, err)
+		cfg := config.Default()
+		cfg.Server.ShutdownTimeout = config.Duration(shutdownTimeout)
+		app := &ServerChi{Config: cfg, Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), db: db}
+
+		started, release := make(chan struct{}), make(chan struct{})
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+			w.Write([]byte("OK"))
+		})
+		return app, dbMock, handler, started, release
+	}
+
+	t.Run("lets the request in flight finish, then closes the database", func(t *testing.T) {
+		// arrange
+		app, dbMock, handler, started, release := newServer(t, 5*time.Second)
+		dbMock.ExpectClose()
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		served := make(chan error, 1)
+		go func() { served <- app.Serve(ctx, listener, handler) }()
+
+		responses := make(chan *http.Response, 1)
+		go func() {
+			res, err := http.Get("http://" + listener.Addr().String() + "/")
+			assert.NoError(t, err)
+			responses <- res
+		}()
+		<-started
+
+		// act
+		cancel()
+		time.Sleep(50 * time.Millisecond) // the shutdown is now waiting for the request
+		close(release)
+
+		// assert
+		res := <-responses
+		require.NotNil(t, res)
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "OK", string(body))
+		assert.NoError(t, <-served)
+		assert.NoError(t, dbMock.ExpectationsWereMet())
+		_, err = net.Dial("tcp", listener.Addr().String())
+		assert.Error(t, err, "no connections are accepted after the shutdown")
+	})
+
+	t.Run("cuts the requests still running at the deadline", func(t *testing.T) {
+		// arrange
+		app, dbMock, handler, started, release := newServer(t, 50*time.Millisecond)
+		defer close(release)
+		dbMock.ExpectClose()
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		served := make(chan error, 1)
+		go func() { served <- app.Serve(ctx, listener, handler) }()
+		go http.Get("http://" + listener.Addr().String() + "/")
+		<-started
+
+		// act
+		cancel()
+
+		// assert
+		assert.ErrorIs(t, <-served, context.DeadlineExceeded)
+		assert.NoError(t, dbMock.ExpectationsWereMet())
+	})
+}
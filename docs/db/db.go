@@ -0,0 +1,31 @@
+// Package db embeds the SQL migrations, so the api can tell whether the
+// database schema is up to date without the files next to the binary.
+package db
+
+import (
+	"embed"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+//go:embed migrations
+var migrations embed.FS
+
+// MigrationNames returns the file names of the migrations sorted as cmd/migrate
+// applies them, the same names it records in schema_migrations.
+func MigrationNames() []string {
+	entries, err := fs.ReadDir(migrations, "migrations")
+	if err != nil {
+		// the embedded directory can always be read
+		panic(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".sql") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
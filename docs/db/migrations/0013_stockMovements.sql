@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id                       INT NOT NULL AUTO_INCREMENT,
+    product_batch_id         INT NOT NULL,
+    movement_type            ENUM('inbound', 'outbound', 'adjustment', 'transfer') NOT NULL,
+    quantity                 INT NOT NULL,
+    resulting_quantity       INT NOT NULL,
+    related_product_batch_id INT DEFAULT NULL,
+    reason                   VARCHAR(255) NOT NULL DEFAULT '',
+    created_at               DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_stock_movements_batch (product_batch_id, created_at),
+    FOREIGN KEY (product_batch_id) REFERENCES product_batches(id),
+    FOREIGN KEY (related_product_batch_id) REFERENCES product_batches(id)
+);
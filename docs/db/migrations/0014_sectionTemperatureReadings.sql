@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS section_temperature_readings (
+    id          INT NOT NULL AUTO_INCREMENT,
+    section_id  INT NOT NULL,
+    temperature DECIMAL(5,2) NOT NULL,
+    recorded_at DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_section_temperature_readings_section (section_id, recorded_at),
+    FOREIGN KEY (section_id) REFERENCES sections(id) ON DELETE CASCADE
+);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS alerts (
+    id               INT NOT NULL AUTO_INCREMENT,
+    section_id       INT NOT NULL,
+    product_batch_id INT DEFAULT NULL,
+    alert_type       ENUM('section_minimum_temperature', 'product_recommended_temperature') NOT NULL,
+    status           ENUM('open', 'acknowledged', 'resolved') NOT NULL DEFAULT 'open',
+    temperature      DECIMAL(5,2) NOT NULL,
+    threshold        DECIMAL(5,2) NOT NULL,
+    message          VARCHAR(255) NOT NULL,
+    created_at       DATETIME NOT NULL,
+    acknowledged_at  DATETIME DEFAULT NULL,
+    resolved_at      DATETIME DEFAULT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_alerts_section_status (section_id, status),
+    FOREIGN KEY (section_id) REFERENCES sections(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_batch_id) REFERENCES product_batches(id) ON DELETE CASCADE
+);
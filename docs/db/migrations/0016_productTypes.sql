@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS product_types (
+    id          INT NOT NULL AUTO_INCREMENT,
+    description VARCHAR(255) NOT NULL UNIQUE,
+    PRIMARY KEY (id)
+);
+
+-- Products and sections already reference product types by id, so every id in use
+-- gets a placeholder type before the foreign keys are added
+INSERT IGNORE INTO product_types (id, description)
+    SELECT product_type_id, CONCAT('Product type ', product_type_id) FROM products
+    UNION
+    SELECT product_type_id, CONCAT('Product type ', product_type_id) FROM sections;
+
+ALTER TABLE products
+    ADD CONSTRAINT fk_products_product_type
+        FOREIGN KEY (product_type_id) REFERENCES product_types(id);
+
+ALTER TABLE sections
+    ADD CONSTRAINT fk_sections_product_type
+        FOREIGN KEY (product_type_id) REFERENCES product_types(id);
@@ -0,0 +1,9 @@
+-- Existing orders start the lifecycle as pending
+ALTER TABLE purchase_orders
+    ADD COLUMN status       ENUM('pending', 'confirmed', 'picked', 'shipped', 'delivered', 'cancelled') NOT NULL DEFAULT 'pending',
+    ADD COLUMN confirmed_at DATETIME DEFAULT NULL,
+    ADD COLUMN picked_at    DATETIME DEFAULT NULL,
+    ADD COLUMN shipped_at   DATETIME DEFAULT NULL,
+    ADD COLUMN delivered_at DATETIME DEFAULT NULL,
+    ADD COLUMN cancelled_at DATETIME DEFAULT NULL,
+    ADD INDEX idx_purchase_orders_status (status);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS purchase_order_lines (
+    id                INT NOT NULL AUTO_INCREMENT,
+    purchase_order_id INT NOT NULL,
+    product_id        INT NOT NULL,
+    quantity          INT NOT NULL,
+    unit_price        DECIMAL(12,2) NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+-- Every existing order becomes a single line of one unit of the product of its record
+INSERT INTO purchase_order_lines (purchase_order_id, product_id, quantity, unit_price)
+    SELECT po.id, pr.product_id, 1, pr.sale_price
+    FROM purchase_orders po
+    JOIN product_records pr ON pr.id = po.product_record_id;
+
+ALTER TABLE purchase_orders
+    DROP FOREIGN KEY fk_purchase_orders_product,
+    DROP COLUMN product_record_id;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS purchase_order_allocations (
+    id                     INT NOT NULL AUTO_INCREMENT,
+    purchase_order_line_id INT NOT NULL,
+    product_batch_id       INT NOT NULL,
+    quantity               INT NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (purchase_order_line_id) REFERENCES purchase_order_lines(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_batch_id) REFERENCES product_batches(id)
+);
+
+-- Confirming an order looks for the batches of a product by due date
+CREATE INDEX idx_product_batches_product_due_date ON product_batches (product_id, due_date);
@@ -0,0 +1,7 @@
+ALTER TABLE inbound_orders
+    ADD COLUMN quantity INT NOT NULL DEFAULT 0;
+
+-- Orders received before this column existed brought in the initial quantity of their batch
+UPDATE inbound_orders io
+    JOIN product_batches pb ON pb.id = io.product_batch_id
+    SET io.quantity = pb.initial_quantity;
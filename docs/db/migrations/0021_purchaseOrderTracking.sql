@@ -0,0 +1,37 @@
+ALTER TABLE buyers
+    ADD COLUMN delivery_locality_id VARCHAR(20) DEFAULT NULL,
+    ADD CONSTRAINT fk_buyers_delivery_locality
+        FOREIGN KEY (delivery_locality_id) REFERENCES localities(id)
+        ON UPDATE CASCADE;
+
+-- Orders created before the tracking code was unique may share one: the oldest
+-- keeps it and the others get their id appended, so the constraint below can be added
+UPDATE purchase_orders po
+JOIN (
+    SELECT tracking_code, MIN(id) AS first_id
+    FROM purchase_orders
+    GROUP BY tracking_code
+    HAVING COUNT(*) > 1
+) duplicated ON duplicated.tracking_code = po.tracking_code
+SET po.tracking_code = CONCAT(po.tracking_code, '-', po.id)
+WHERE po.id <> duplicated.first_id;
+
+-- The tracking code identifies the order in the tracking timeline
+ALTER TABLE purchase_orders
+    ADD COLUMN carry_id INT DEFAULT NULL,
+    ADD CONSTRAINT fk_purchase_orders_carry FOREIGN KEY (carry_id) REFERENCES carries(id),
+    ADD CONSTRAINT uq_purchase_orders_tracking_code UNIQUE (tracking_code);
+
+CREATE TABLE IF NOT EXISTS tracking_events (
+    id                INT NOT NULL AUTO_INCREMENT,
+    purchase_order_id INT NOT NULL,
+    carry_id          INT NOT NULL,
+    event_type        ENUM('picked_up', 'in_transit', 'delivered', 'failed_attempt') NOT NULL,
+    location          VARCHAR(255) NOT NULL DEFAULT '',
+    notes             VARCHAR(255) NOT NULL DEFAULT '',
+    occurred_at       DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_tracking_events_order (purchase_order_id, occurred_at),
+    FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    FOREIGN KEY (carry_id) REFERENCES carries(id)
+);
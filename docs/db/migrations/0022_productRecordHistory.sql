@@ -0,0 +1,2 @@
+-- Price history and effective price lookups walk the records of a product by date
+CREATE INDEX idx_product_records_product_date ON product_records (product_id, last_update_date);
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS countries (
+    id           INT NOT NULL AUTO_INCREMENT,
+    country_name VARCHAR(100) NOT NULL UNIQUE,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS provinces (
+    id            INT NOT NULL AUTO_INCREMENT,
+    province_name VARCHAR(100) NOT NULL,
+    country_id    INT NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_provinces_country_name (country_id, province_name),
+    FOREIGN KEY (country_id) REFERENCES countries(id)
+);
+
+-- Every distinct name becomes a single row. Surrounding whitespace is ignored and,
+-- as the collation is case insensitive, INSERT IGNORE keeps one spelling of each name
+INSERT IGNORE INTO countries (country_name)
+    SELECT DISTINCT TRIM(country_name) FROM localities;
+
+INSERT IGNORE INTO provinces (province_name, country_id)
+    SELECT DISTINCT TRIM(l.province_name), c.id
+    FROM localities l
+    JOIN countries c ON c.country_name = TRIM(l.country_name);
+
+ALTER TABLE localities
+    ADD COLUMN province_id INT DEFAULT NULL;
+
+UPDATE localities l
+    JOIN countries c ON c.country_name = TRIM(l.country_name)
+    JOIN provinces p ON p.country_id = c.id AND p.province_name = TRIM(l.province_name)
+SET l.province_id = p.id;
+
+ALTER TABLE localities
+    MODIFY province_id INT NOT NULL,
+    ADD CONSTRAINT fk_localities_province FOREIGN KEY (province_id) REFERENCES provinces(id),
+    DROP COLUMN province_name,
+    DROP COLUMN country_name;
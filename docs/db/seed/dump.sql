@@ -9,19 +9,34 @@ INSERT IGNORE INTO warehouses (warehouse_code, address, telephone, minimun_capac
     ("DHK2", 'Monroe 1234', '47470001', '15', '10'),
     ("CBA3", 'Cordoba 91011', '3516000789', '25', '4');
 
-INSERT IGNORE INTO localities (id, locality_name, province_name, country_name) VALUES
-    ('6700', 'Lujan', 'Buenos Aires', 'Argentina'),
-    ('1001', 'CABA', 'CABA', 'Argentina'),
-    ('2000', 'Rosario', 'Santa Fe', 'Argentina'),
-    ('5000', 'Córdoba', 'Córdoba', 'Argentina'),
-    ('10115', 'Berlin', 'Berlin', 'Alemania'),
-    ('28001', 'Madrid', 'Madrid', 'España'),
-    ('11000', 'Montevideo', 'Montevideo', 'Uruguay');
-
-INSERT IGNORE INTO buyers (card_number_id, first_name, last_name) VALUES
-    (12345678, 'Juan', 'Pérez'),
-    (23456789, 'Ana', 'Gómez'),
-    (34567890, 'Luis', 'Martínez');
+INSERT IGNORE INTO countries (id, country_name) VALUES
+    (1, 'Argentina'),
+    (2, 'Alemania'),
+    (3, 'España'),
+    (4, 'Uruguay');
+
+INSERT IGNORE INTO provinces (id, province_name, country_id) VALUES
+    (1, 'Buenos Aires', 1),
+    (2, 'CABA', 1),
+    (3, 'Santa Fe', 1),
+    (4, 'Córdoba', 1),
+    (5, 'Berlin', 2),
+    (6, 'Madrid', 3),
+    (7, 'Montevideo', 4);
+
+INSERT IGNORE INTO localities (id, locality_name, province_id) VALUES
+    ('6700', 'Lujan', 1),
+    ('1001', 'CABA', 2),
+    ('2000', 'Rosario', 3),
+    ('5000', 'Córdoba', 4),
+    ('10115', 'Berlin', 5),
+    ('28001', 'Madrid', 6),
+    ('11000', 'Montevideo', 7);
+
+INSERT IGNORE INTO buyers (card_number_id, first_name, last_name, delivery_locality_id) VALUES
+    (12345678, 'Juan', 'Pérez', '1001'),
+    (23456789, 'Ana', 'Gómez', '2000'),
+    (34567890, 'Luis', 'Martínez', NULL);
 
 INSERT IGNORE INTO employees (card_number_id, first_name, last_name, warehouse_id) VALUES
     ('10101010', 'Ramon', 'Diaz', 1),
@@ -48,6 +63,12 @@ INSERT IGNORE INTO carries (cid, company_name, address, telephone, locality_id)
      ("CID2445", 'Panaderia El Sol', 'Av. Colon 1000', '3511112222', '5000'),
      ("CID3326", 'Bäckerei Berlin', 'Unter den Linden 77', '+4930123456', '10115');
 
+INSERT IGNORE INTO product_types (id, description) VALUES
+     (1, 'Carnes'),
+     (2, 'Pescados'),
+     (3, 'Lácteos'),
+     (4, 'Congelados');
+
 INSERT IGNORE INTO products (description, expiration_rate, freezing_rate, height, length, width, netweight, product_code, recommended_freezing_temperature, product_type_id, seller_id) VALUES
      ('Pechuga de pollo', 4.50, 0.75, 3.0, 12.0, 8.0, 1.20, 'POL-0001', -18.0, 1, 1),
      ('Salmón', 6.00, 0.60, 2.5, 18.0, 14.0, 0.35, 'SAL-0001', -20.0, 2,  2),
@@ -80,11 +101,16 @@ INSERT IGNORE INTO product_batches (batch_number, current_quantity, current_temp
     (114, 450, 4.2, '2025-08-22', 500, '2025-07-22', 9, 2.0, 1, 3),
     (115, 800, -19.5, '2026-02-01', 800, '2025-07-20', 11, -22.0, 2, 2);
 
-INSERT IGNORE INTO purchase_orders (order_number, order_date, tracking_code, buyer_id, product_record_id) VALUES
-        ('ORD-001', '2024-06-15 12:34:56', 'TRACK-111AAA', 1, 1),
-        ('ORD-002', '2024-06-16 15:10:35', 'TRACK-222BBB', 2, 2);
+INSERT IGNORE INTO purchase_orders (order_number, order_date, tracking_code, buyer_id) VALUES
+        ('ORD-001', '2024-06-15 12:34:56', 'TRACK-111AAA', 1),
+        ('ORD-002', '2024-06-16 15:10:35', 'TRACK-222BBB', 2);
+
+INSERT IGNORE INTO purchase_order_lines (id, purchase_order_id, product_id, quantity, unit_price) VALUES
+        (1, 1, 1, 2, 11.50),
+        (2, 1, 3, 1, 8.99),
+        (3, 2, 2, 5, 20.00);
 
-INSERT IGNORE INTO inbound_orders (order_number, order_date, employee_id, warehouse_id, product_batch_id) VALUES
-   ('INB-1001', '2024-06-01 09:00:00', 1, 1, 1),
-   ('INB-1002', '2024-06-02 10:30:00', 2, 2, 2),
-   ('INB-1003', '2024-06-03 11:00:00', 3, 3, 3);
\ No newline at end of file
+INSERT IGNORE INTO inbound_orders (order_number, order_date, employee_id, warehouse_id, product_batch_id, quantity) VALUES
+   ('INB-1001', '2024-06-01 09:00:00', 1, 1, 1, 500),
+   ('INB-1002', '2024-06-02 10:30:00', 2, 2, 2, 1000),
+   ('INB-1003', '2024-06-03 11:00:00', 3, 3, 3, 400);
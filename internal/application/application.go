@@ -1,31 +1,36 @@
 package application
 
 import (
-	"database/sql"
 	"net/http"
 
+	"github.com/aaguero_meli/W17-G6-Bootcamp/docs/db"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/config"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/handler"
-	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
 	"github.com/go-chi/chi/v5"
 )
 
-func HealthRouter() chi.Router {
-	// here we cand add the storage (if any) repository service and handler
-	//mount the endpoints and return to mount them in the Run()
+// HealthRouter creates a new router with the health endpoints: the plain
+// healthcheck, the liveness probe and the readiness probe reporting version.
+func HealthRouter(repos Repositories, version string) chi.Router {
+	healthService := service.NewHealthService(repos.Health, db.MigrationNames(), version)
+	healthHandler := handler.NewHealthHandler(healthService)
+
 	router := chi.NewRouter()
 	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
 	})
+	router.Get("/live", healthHandler.Live())
+	router.Get("/ready", healthHandler.Ready())
 
 	return router
 }
 
 // SellerRouter creates a new router for seller-related endpoints
-func SellerRouter(db *sql.DB) chi.Router {
+func SellerRouter(repos Repositories) chi.Router {
 	router := chi.NewRouter()
-	sellerRepository := repository.NewSellerRepository(db)
+	sellerRepository := repos.Seller
 	sellerService := service.NewSellerService(sellerRepository)
 	sellerHandler := handler.NewSellerHandler(sellerService)
 	router.Get("/", sellerHandler.GetAll())
@@ -36,22 +41,63 @@ func SellerRouter(db *sql.DB) chi.Router {
 	return router
 }
 
-func LocalityRouter(db *sql.DB) chi.Router {
+func LocalityRouter(repos Repositories) chi.Router {
 	router := chi.NewRouter()
 
-	localityRepository := repository.NewLocalityRepository(db)
+	localityRepository := repos.Locality
 	localityServer := service.NewLocalityService(localityRepository)
 	localityHandler := handler.NewLocalityHandler(localityServer)
 
 	router.Post("/", localityHandler.Create())
+	router.Get("/", localityHandler.GetAll())
 	router.Get("/{id}", localityHandler.GetByID())
+	router.Patch("/{id}", localityHandler.Update())
+	router.Delete("/{id}", localityHandler.Delete())
 	router.Get("/reportSellers", localityHandler.GetSellerReport())
 	router.Get("/reportCarries", localityHandler.GetReportByLocalityId())
 	return router
 }
 
-func WarehouseRouter(db *sql.DB) chi.Router {
-	warehouseRepository := repository.NewWarehouseRepositoryDb(db)
+// CountryRouter creates and returns a chi.Router configured with CRUD endpoints
+// for countries and the seller and carry reports rolled up by country.
+func CountryRouter(repos Repositories) chi.Router {
+	router := chi.NewRouter()
+
+	countryService := service.NewCountryServiceDefault(repos.Country)
+	countryHandler := handler.NewCountryHandler(countryService)
+
+	router.Post("/", countryHandler.Create())
+	router.Get("/", countryHandler.GetAll())
+	router.Get("/reportSellers", countryHandler.GetSellerReport())
+	router.Get("/reportCarries", countryHandler.GetCarryReport())
+	router.Get("/{id}", countryHandler.GetByID())
+	router.Patch("/{id}", countryHandler.Update())
+	router.Delete("/{id}", countryHandler.Delete())
+	return router
+}
+
+// ProvinceRouter creates and returns a chi.Router configured with CRUD endpoints
+// for provinces and the seller and carry reports rolled up by province.
+func ProvinceRouter(repos Repositories) chi.Router {
+	router := chi.NewRouter()
+
+	provinceService := service.NewProvinceServiceDefault(repos.Province)
+	provinceHandler := handler.NewProvinceHandler(provinceService)
+
+	router.Post("/", provinceHandler.Create())
+	router.Get("/", provinceHandler.GetAll())
+	router.Get("/reportSellers", provinceHandler.GetSellerReport())
+	router.Get("/reportCarries", provinceHandler.GetCarryReport())
+	router.Get("/{id}", provinceHandler.GetByID())
+	router.Patch("/{id}", provinceHandler.Update())
+	router.Delete("/{id}", provinceHandler.Delete())
+	return router
+}
+
+// WarehouseRouter creates a new router for warehouse-related endpoints, with the
+// dashboard when features enables it.
+func WarehouseRouter(repos Repositories, features config.Features) chi.Router {
+	warehouseRepository := repos.Warehouse
 	warehouseService := service.NewWarehouseService(warehouseRepository)
 	warehouseHandler := handler.NewWarehouseHandler(warehouseService)
 
@@ -62,33 +108,69 @@ func WarehouseRouter(db *sql.DB) chi.Router {
 	router.Get("/{id}", warehouseHandler.GetById())
 	router.Patch("/{id}", warehouseHandler.Update())
 	router.Delete("/{id}", warehouseHandler.Delete())
+	if features.WarehouseDashboard {
+		dashboardService := service.NewWarehouseDashboardServiceDefault(service.WarehouseDashboardRepositories{
+			Warehouse:                 repos.Warehouse,
+			Section:                   repos.Section,
+			Employee:                  repos.Employee,
+			ProductType:               repos.ProductType,
+			ProductBatch:              repos.ProductBatch,
+			InboundOrder:              repos.InboundOrder,
+			SectionTemperatureReading: repos.SectionTemperatureReading,
+			Alert:                     repos.Alert,
+		})
+		dashboardHandler := handler.NewWarehouseDashboardHandler(dashboardService)
+		router.Get("/{id}/dashboard", dashboardHandler.GetDashboard())
+	}
 
 	return router
 }
 
 // ProductRouter creates and returns a chi.Router configured
 // with CRUD endpoints for products.
-func ProductRouter(db *sql.DB) chi.Router {
+func ProductRouter(repos Repositories) chi.Router {
 	router := chi.NewRouter()
 
-	productRepository := repository.NewProductRepositoryDB(db)
+	productRepository := repos.Product
 	productService := service.NewProductServiceDefault(productRepository)
 	productHandler := handler.NewProductHandler(productService)
 
+	productRecordService := service.NewProductRecordServiceDefault(repos.ProductRecord)
+	productRecordHandler := handler.NewProductRecordHandler(productRecordService)
+
 	router.Post("/", productHandler.Create())
 	router.Get("/", productHandler.GetAll())
 	router.Get("/{id}", productHandler.GetByID())
+	router.Get("/{id}/records", productRecordHandler.GetByProductID())
+	router.Get("/{id}/price", productRecordHandler.GetEffectivePrice())
 	router.Get("/reportRecords", productHandler.GetRecordsPerProduct())
+	router.Get("/reportMargins", productHandler.GetMarginReport())
 	router.Patch("/{id}", productHandler.Update())
 	router.Delete("/{id}", productHandler.Delete())
 	return router
 }
 
+// ProductTypeRouter creates and returns a chi.Router configured
+// with CRUD endpoints for the product type catalog.
+func ProductTypeRouter(repos Repositories) chi.Router {
+	router := chi.NewRouter()
+
+	productTypeService := service.NewProductTypeServiceDefault(repos.ProductType)
+	productTypeHandler := handler.NewProductTypeHandler(productTypeService)
+
+	router.Post("/", productTypeHandler.Create())
+	router.Get("/", productTypeHandler.GetAll())
+	router.Get("/{id}", productTypeHandler.GetByID())
+	router.Patch("/{id}", productTypeHandler.Update())
+	router.Delete("/{id}", productTypeHandler.Delete())
+	return router
+}
+
 // ProductRecordRouter creates and returns a chi.Router configured for product_records.
-func ProductRecordRouter(db *sql.DB) chi.Router {
+func ProductRecordRouter(repos Repositories) chi.Router {
 	router := chi.NewRouter()
 
-	productRecordRepository := repository.NewProductRecordRepositoryDB(db)
+	productRecordRepository := repos.ProductRecord
 	productRecordService := service.NewProductRecordServiceDefault(productRecordRepository)
 	productRecordHandler := handler.NewProductRecordHandler(productRecordService)
 
@@ -97,10 +179,10 @@ func ProductRecordRouter(db *sql.DB) chi.Router {
 }
 
 // BuyersRouter creates and returns the router of buyer
-func BuyersRouter(db *sql.DB) chi.Router {
+func BuyersRouter(repos Repositories) chi.Router {
 	router := chi.NewRouter()
 
-	buyersRepository := repository.NewBuyerRepositoryDB(db)
+	buyersRepository := repos.Buyer
 	buyersService := service.NewBuyerServiceDefault(buyersRepository)
 	buyersHandler := handler.NewBuyerHandler(buyersService)
 
@@ -113,11 +195,11 @@ func BuyersRouter(db *sql.DB) chi.Router {
 	return router
 }
 
-func EmployeeRouter(db *sql.DB) chi.Router {
+func EmployeeRouter(repos Repositories) chi.Router {
 	router := chi.NewRouter()
 
-	employeeRepository := repository.NewEmployeeRepository(db)
-	inboundOrderRepository := repository.NewInboundOrderRepository(db)
+	employeeRepository := repos.Employee
+	inboundOrderRepository := repos.InboundOrder
 	employeeService := service.NewEmployeeService(employeeRepository, inboundOrderRepository)
 	employeeHandler := handler.NewEmployeeHandler(employeeService)
 
@@ -130,11 +212,15 @@ func EmployeeRouter(db *sql.DB) chi.Router {
 	return router
 }
 
-func SectionRouter(db *sql.DB) chi.Router {
-	sectionRepository := repository.NewSectionRepositoryDB(db)
-	sectionService := service.NewSectionServiceDefault(sectionRepository)
+func SectionRouter(repos Repositories) chi.Router {
+	sectionRepository := repos.Section
+	sectionService := service.NewSectionServiceDefault(sectionRepository, repos.Alert)
 	sectionHandler := handler.NewSectionHandler(sectionService)
 
+	readingRepository := repos.SectionTemperatureReading
+	readingService := service.NewSectionTemperatureReadingServiceDefault(readingRepository, repos.Alert)
+	readingHandler := handler.NewSectionTemperatureReadingHandler(readingService)
+
 	router := chi.NewRouter()
 
 	router.Get("/", sectionHandler.GetAll())
@@ -143,51 +229,87 @@ func SectionRouter(db *sql.DB) chi.Router {
 	router.Post("/", sectionHandler.Create())
 	router.Patch("/{id}", sectionHandler.Update())
 	router.Get("/reportProducts", sectionHandler.GetProductsReport())
+	router.Get("/reportExpiringBatches", sectionHandler.GetExpiringBatchesReport())
+	router.Get("/reportCapacity", sectionHandler.GetCapacityReport())
+	router.Post("/{id}/readings", readingHandler.CreateBulk())
+	router.Get("/{id}/readings", readingHandler.GetSeries())
 	return router
 }
 
 // PurchaseOrderRouter creates and returns the router of PurchaseOrder
-func PurchaseOrderRouter(db *sql.DB) chi.Router {
-	purchaseOrderRepository := repository.NewPurchaseOrderRepositoryDB(db)
+func PurchaseOrderRouter(repos Repositories) chi.Router {
+	purchaseOrderRepository := repos.PurchaseOrder
 	purchaseOrderService := service.NewPurchaseOrderDefault(purchaseOrderRepository)
 	purchaseOrderHandler := handler.NewPurchaseOrderHandler(purchaseOrderService)
 
+	trackingEventService := service.NewTrackingEventDefault(repos.TrackingEvent)
+	trackingEventHandler := handler.NewTrackingEventHandler(trackingEventService)
+
 	router := chi.NewRouter()
 
 	router.Post("/", purchaseOrderHandler.Create())
+	router.Get("/", purchaseOrderHandler.GetAll())
+	router.Get("/{id}", purchaseOrderHandler.GetByID())
+	router.Post("/{id}/transitions", purchaseOrderHandler.Transition())
+	router.Post("/{id}/carry", purchaseOrderHandler.AssignCarry())
+	router.Post("/{id}/tracking", trackingEventHandler.Create())
+	router.Get("/tracking/{trackingCode}", trackingEventHandler.GetTimeline())
 	return router
 }
 
-func CarryRouter(db *sql.DB) chi.Router {
-	carryRepository := repository.NewCarryRepositoryDb(db)
+func CarryRouter(repos Repositories) chi.Router {
+	carryRepository := repos.Carry
 	carryService := service.NewCarryService(carryRepository)
 	carryHandler := handler.NewCarryHandler(carryService)
 
 	router := chi.NewRouter()
 	router.Post("/", carryHandler.Create())
+	router.Get("/", carryHandler.GetAll())
+	router.Get("/{id}", carryHandler.GetByID())
+	router.Patch("/{id}", carryHandler.Update())
+	router.Delete("/{id}", carryHandler.Delete())
 
 	return router
 }
 
-func InboundOrderRouter(db *sql.DB) chi.Router {
+func InboundOrderRouter(repos Repositories) chi.Router {
 	router := chi.NewRouter()
 
-	inboundOrderRepository := repository.NewInboundOrderRepository(db)
-	employeeRepository := repository.NewEmployeeRepository(db)
-	warehouseRepository := repository.NewWarehouseRepositoryDb(db)
-	service := service.NewInboundOrderService(inboundOrderRepository, employeeRepository, warehouseRepository)
+	inboundOrderRepository := repos.InboundOrder
+	service := service.NewInboundOrderService(inboundOrderRepository)
 	handler := handler.NewInboundOrderHandler(service)
 
 	router.Post("/", handler.Create())
 	return router
 }
 
-func ProductBatchRouter(db *sql.DB) chi.Router {
-	productBatchRepository := repository.NewProductBatchRepositoryDB(db)
+func ProductBatchRouter(repos Repositories) chi.Router {
+	productBatchRepository := repos.ProductBatch
 	productBatchService := service.NewProductBatchServiceDefault(productBatchRepository)
 	productBatchHandler := handler.NewProductBatchHandler(productBatchService)
 
+	stockMovementRepository := repos.StockMovement
+	stockMovementService := service.NewStockMovementServiceDefault(stockMovementRepository)
+	stockMovementHandler := handler.NewStockMovementHandler(stockMovementService)
+
 	router := chi.NewRouter()
+	router.Get("/", productBatchHandler.GetAll())
 	router.Post("/", productBatchHandler.Create())
+	router.Get("/{id}", productBatchHandler.GetByID())
+	router.Patch("/{id}", productBatchHandler.Update())
+	router.Delete("/{id}", productBatchHandler.Delete())
+	router.Get("/{id}/movements", stockMovementHandler.GetByProductBatchID())
+	router.Post("/{id}/movements", stockMovementHandler.Create())
+	return router
+}
+
+// AlertRouter creates and returns the router of temperature alerts
+func AlertRouter(repos Repositories) chi.Router {
+	alertService := service.NewAlertServiceDefault(repos.Alert)
+	alertHandler := handler.NewAlertHandler(alertService)
+
+	router := chi.NewRouter()
+	router.Get("/", alertHandler.GetAll())
+	router.Post("/{id}/ack", alertHandler.Acknowledge())
 	return router
 }
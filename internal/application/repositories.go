@@ -0,0 +1,90 @@
+package application
+
+import (
+	"database/sql"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+)
+
+// Repositories groups one implementation of every repository interface,
+// so the routers can be wired against MySQL or the in-memory store alike.
+type Repositories struct {
+	Product       repository.ProductRepository
+	ProductType   repository.ProductTypeRepository
+	ProductRecord repository.ProductRecordRepository
+	Seller        repository.SellerRepository
+	Country       repository.CountryRepository
+	Province      repository.ProvinceRepository
+	Locality      repository.LocalityRepository
+	Warehouse     repository.WarehouseRepository
+	Buyer         repository.BuyerRepository
+	Employee      repository.EmployeeRepository
+	Section       repository.SectionRepository
+	Carry         repository.CarryRepository
+	InboundOrder  repository.InboundOrderRepository
+	PurchaseOrder repository.PurchaseOrderRepository
+	ProductBatch  repository.ProductBatchRepository
+	StockMovement repository.StockMovementRepository
+
+	SectionTemperatureReading repository.SectionTemperatureReadingRepository
+	Alert                     repository.AlertRepository
+	TrackingEvent             repository.TrackingEventRepository
+
+	// Health is nil with the in-memory store, which has no database to check
+	Health repository.HealthRepository
+}
+
+// NewRepositoriesDB builds the MySQL backed repositories on top of the given connection.
+func NewRepositoriesDB(db *sql.DB) Repositories {
+	return Repositories{
+		Product:       repository.NewProductRepositoryDB(db),
+		ProductType:   repository.NewProductTypeRepositoryDB(db),
+		ProductRecord: repository.NewProductRecordRepositoryDB(db),
+		Seller:        repository.NewSellerRepository(db),
+		Country:       repository.NewCountryRepositoryDB(db),
+		Province:      repository.NewProvinceRepositoryDB(db),
+		Locality:      repository.NewLocalityRepository(db),
+		Warehouse:     repository.NewWarehouseRepositoryDb(db),
+		Buyer:         repository.NewBuyerRepositoryDB(db),
+		Employee:      repository.NewEmployeeRepository(db),
+		Section:       repository.NewSectionRepositoryDB(db),
+		Carry:         repository.NewCarryRepositoryDb(db),
+		InboundOrder:  repository.NewInboundOrderRepository(db),
+		PurchaseOrder: repository.NewPurchaseOrderRepositoryDB(db),
+		ProductBatch:  repository.NewProductBatchRepositoryDB(db),
+		StockMovement: repository.NewStockMovementRepositoryDB(db),
+
+		SectionTemperatureReading: repository.NewSectionTemperatureReadingRepositoryDB(db),
+		Alert:                     repository.NewAlertRepositoryDB(db),
+		TrackingEvent:             repository.NewTrackingEventRepositoryDB(db),
+
+		Health: repository.NewHealthRepositoryDB(db),
+	}
+}
+
+// NewRepositoriesMemory builds the in-memory repositories, all sharing the given store
+// so that foreign keys between tables are enforced.
+func NewRepositoriesMemory(store *repository.MemoryStore) Repositories {
+	return Repositories{
+		Product:       repository.NewProductRepositoryMemory(store),
+		ProductType:   repository.NewProductTypeRepositoryMemory(store),
+		ProductRecord: repository.NewProductRecordRepositoryMemory(store),
+		Seller:        repository.NewSellerRepositoryMemory(store),
+		Country:       repository.NewCountryRepositoryMemory(store),
+		Province:      repository.NewProvinceRepositoryMemory(store),
+		Locality:      repository.NewLocalityRepositoryMemory(store),
+		Warehouse:     repository.NewWarehouseRepositoryMemory(store),
+		Buyer:         repository.NewBuyerRepositoryMemory(store),
+		Employee:      repository.NewEmployeeRepositoryMemory(store),
+		Section:       repository.NewSectionRepositoryMemory(store),
+		Carry:         repository.NewCarryRepositoryMemory(store),
+		InboundOrder:  repository.NewInboundOrderRepositoryMemory(store),
+		PurchaseOrder: repository.NewPurchaseOrderRepositoryMemory(store),
+		ProductBatch:  repository.NewProductBatchRepositoryMemory(store),
+		StockMovement: repository.NewStockMovementRepositoryMemory(store),
+
+		SectionTemperatureReading: repository.NewSectionTemperatureReadingRepositoryMemory(store),
+		Alert:                     repository.NewAlertRepositoryMemory(store),
+		TrackingEvent:             repository.NewTrackingEventRepositoryMemory(store),
+	}
+}
@@ -0,0 +1,242 @@
+// Package config loads the settings shared by the api, cmd/migrate and cmd/seed.
+// Each setting takes, from lowest to highest precedence, its default, the value
+// in the config file, its env var and its command line flag.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// storage backends of the api
+const (
+	StorageMySQL  = "mysql"
+	StorageMemory = "memory"
+)
+
+// redacted replaces the secrets when the config is printed
+const redacted = "[REDACTED]"
+
+// Config holds every setting of the api
+type Config struct {
+	Server   Server   `json:"server" yaml:"server"`
+	Storage  string   `json:"storage" yaml:"storage"`
+	Database Database `json:"database" yaml:"database"`
+	Log      Log      `json:"log" yaml:"log"`
+	Features Features `json:"features" yaml:"features"`
+}
+
+// Server holds the address of the api and the limits of its http.Server
+type Server struct {
+	Host              string   `json:"host" yaml:"host"`
+	Port              int      `json:"port" yaml:"port"`
+	ReadHeaderTimeout Duration `json:"read_header_timeout" yaml:"read_header_timeout"`
+	ReadTimeout       Duration `json:"read_timeout" yaml:"read_timeout"`
+	WriteTimeout      Duration `json:"write_timeout" yaml:"write_timeout"`
+	IdleTimeout       Duration `json:"idle_timeout" yaml:"idle_timeout"`
+	MaxHeaderBytes    int      `json:"max_header_bytes" yaml:"max_header_bytes"`
+	// ShutdownTimeout is how long a shutdown waits for the requests in flight
+	ShutdownTimeout Duration `json:"shutdown_timeout" yaml:"shutdown_timeout"`
+}
+
+// Database holds the MySQL connection settings and the size of its pool
+type Database struct {
+	Host            string   `json:"host" yaml:"host"`
+	Port            int      `json:"port" yaml:"port"`
+	Name            string   `json:"name" yaml:"name"`
+	User            string   `json:"user" yaml:"user"`
+	Password        string   `json:"password" yaml:"password"`
+	MaxOpenConns    int      `json:"max_open_conns" yaml:"max_open_conns"`
+	MaxIdleConns    int      `json:"max_idle_conns" yaml:"max_idle_conns"`
+	ConnMaxLifetime Duration `json:"conn_max_lifetime" yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime Duration `json:"conn_max_idle_time" yaml:"conn_max_idle_time"`
+}
+
+// Log holds the logger settings
+type Log struct {
+	// Level is debug, info, warn or error
+	Level string `json:"level" yaml:"level"`
+}
+
+// Features toggles optional parts of the api
+type Features struct {
+	// Metrics serves the Prometheus metrics on /metrics
+	Metrics bool `json:"metrics" yaml:"metrics"`
+	// WarehouseDashboard serves GET /warehouses/{id}/dashboard, which reads every
+	// section of the warehouse
+	WarehouseDashboard bool `json:"warehouse_dashboard" yaml:"warehouse_dashboard"`
+}
+
+// Default returns the settings used when nothing overrides them
+func Default() Config {
+	return Config{
+		Server: Server{
+			Port:              8080,
+			ReadHeaderTimeout: Duration(5 * time.Second),
+			ReadTimeout:       Duration(15 * time.Second),
+			WriteTimeout:      Duration(30 * time.Second),
+			IdleTimeout:       Duration(60 * time.Second),
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   Duration(20 * time.Second),
+		},
+		Storage: StorageMySQL,
+		Database: Database{
+			Port:            3306,
+			Name:            "fresh",
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: Duration(5 * time.Minute),
+			ConnMaxIdleTime: Duration(time.Minute),
+		},
+		Log:      Log{Level: "info"},
+		Features: Features{Metrics: true, WarehouseDashboard: true},
+	}
+}
+
+// Addr returns the host:port the api listens on
+func (s Server) Addr() string {
+	return net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+}
+
+// LogLevel returns the parsed log level
+func (l Log) LogLevel() (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(l.Level))
+	return level, err
+}
+
+// MySQLConfig returns the driver configuration of the database
+func (d Database) MySQLConfig() *mysql.Config {
+	cfg := mysql.NewConfig()
+	cfg.User = d.User
+	cfg.Passwd = d.Password
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(d.Host, strconv.Itoa(d.Port))
+	cfg.DBName = d.Name
+	cfg.ParseTime = true
+	cfg.AllowNativePasswords = true
+	cfg.MultiStatements = true
+	cfg.Params = map[string]string{"charset": "utf8mb4"}
+	return cfg
+}
+
+// Validate returns every invalid setting, joined. The database is only
+// checked when the api stores its data in MySQL.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port must be between 1 and 65535, got %d", c.Server.Port))
+	}
+	for _, timeout := range []struct {
+		key   string
+		value Duration
+	}{
+		{"server.read_header_timeout", c.Server.ReadHeaderTimeout},
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+	} {
+		if timeout.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %s", timeout.key, timeout.value))
+		}
+	}
+	if c.Server.MaxHeaderBytes <= 0 {
+		errs = append(errs, fmt.Errorf("server.max_header_bytes must be positive, got %d", c.Server.MaxHeaderBytes))
+	}
+
+	switch c.Storage {
+	case StorageMySQL:
+		if err := c.Database.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	case StorageMemory:
+	default:
+		errs = append(errs, fmt.Errorf("storage must be %q or %q, got %q", StorageMySQL, StorageMemory, c.Storage))
+	}
+
+	if _, err := c.Log.LogLevel(); err != nil {
+		errs = append(errs, fmt.Errorf("log.level must be debug, info, warn or error, got %q", c.Log.Level))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate returns every invalid connection or pool setting, joined
+func (d Database) Validate() error {
+	var errs []error
+	for _, required := range []struct{ key, value string }{
+		{"database.host", d.Host},
+		{"database.name", d.Name},
+		{"database.user", d.User},
+	} {
+		if required.value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", required.key))
+		}
+	}
+	if d.Port < 1 || d.Port > 65535 {
+		errs = append(errs, fmt.Errorf("database.port must be between 1 and 65535, got %d", d.Port))
+	}
+	if d.MaxOpenConns < 0 {
+		errs = append(errs, fmt.Errorf("database.max_open_conns must not be negative, got %d", d.MaxOpenConns))
+	}
+	if d.MaxIdleConns < 0 {
+		errs = append(errs, fmt.Errorf("database.max_idle_conns must not be negative, got %d", d.MaxIdleConns))
+	}
+	if d.MaxOpenConns > 0 && d.MaxIdleConns > d.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("database.max_idle_conns (%d) must not exceed database.max_open_conns (%d)", d.MaxIdleConns, d.MaxOpenConns))
+	}
+	if d.ConnMaxLifetime < 0 {
+		errs = append(errs, fmt.Errorf("database.conn_max_lifetime must not be negative, got %s", d.ConnMaxLifetime))
+	}
+	if d.ConnMaxIdleTime < 0 {
+		errs = append(errs, fmt.Errorf("database.conn_max_idle_time must not be negative, got %s", d.ConnMaxIdleTime))
+	}
+	return errors.Join(errs...)
+}
+
+// Redacted returns a copy of the config safe to print, its secrets replaced
+func (c Config) Redacted() Config {
+	if c.Database.Password != "" {
+		c.Database.Password = redacted
+	}
+	return c
+}
+
+// String returns the redacted config as JSON, to log the effective settings
+func (c Config) String() string {
+	data, err := json.Marshal(c.Redacted())
+	if err != nil {
+		return fmt.Sprintf("invalid config: %v", err)
+	}
+	return string(data)
+}
+
+// Duration is a time.Duration written as "30s" or "1m" in files, env vars and flags
+type Duration time.Duration
+
+// String returns the duration as time.Duration formats it
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// MarshalText formats the duration as "30s"
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses a duration such as "30s" or "1m"
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q, expected a value such as 30s or 1m", text)
+	}
+	*d = Duration(parsed)
+	return nil
+}
@@ -0,0 +1,152 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// envOf returns a getenv reading from env
+func envOf(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	dbEnv := map[string]string{"DB_HOST": "localhost", "DB_USER": "fresh"}
+
+	t.Run("flags override env vars, which override the file", func(t *testing.T) {
+		// arrange
+		file := writeFile(t, "config.yaml", `
+server:
+  port: 9000
+  write_timeout: 45s
+database:
+  host: db.internal
+  user: fresh
+  password: from-file
+  max_open_conns: 50
+log:
+  level: debug
+features:
+  metrics: false
+`)
+		env := map[string]string{"CONFIG_FILE": file, "SERVER_PORT": "9100", "DB_PASS": "s3cret", "DB_MAX_IDLE_CONNS": "20"}
+
+		// act
+		cfg, err := config.Load("server", []string{"-server-port", "9200", "-log-level", "warn"}, envOf(env))
+
+		// assert
+		require.NoError(t, err)
+		expected := config.Default()
+		expected.Server.Port = 9200
+		expected.Server.WriteTimeout = config.Duration(45 * time.Second)
+		expected.Database.Host = "db.internal"
+		expected.Database.User = "fresh"
+		expected.Database.Password = "s3cret"
+		expected.Database.MaxOpenConns = 50
+		expected.Database.MaxIdleConns = 20
+		expected.Log.Level = "warn"
+		expected.Features.Metrics = false
+		assert.Equal(t, expected, cfg)
+		assert.Equal(t, ":9200", cfg.Server.Addr())
+	})
+
+	t.Run("reads a JSON file given with -config", func(t *testing.T) {
+		// arrange
+		file := writeFile(t, "config.json", `{"storage": "memory", "server": {"shutdown_timeout": "1m"}}`)
+
+		// act
+		cfg, err := config.Load("server", []string{"-config", file}, envOf(nil))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, config.StorageMemory, cfg.Storage)
+		assert.Equal(t, config.Duration(time.Minute), cfg.Server.ShutdownTimeout)
+	})
+
+	t.Run("ADDRESS is still read as the port", func(t *testing.T) {
+		// arrange
+		env := map[string]string{"ADDRESS": "8081", "STORAGE": "memory"}
+
+		// act
+		cfg, err := config.Load("server", nil, envOf(env))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, 8081, cfg.Server.Port)
+	})
+
+	t.Run("unknown keys in the file are rejected", func(t *testing.T) {
+		// arrange
+		file := writeFile(t, "config.yaml", "server:\n  adress: 8080\n")
+
+		// act
+		_, err := config.Load("server", []string{"-config", file}, envOf(dbEnv))
+
+		// assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field adress not found")
+	})
+
+	t.Run("values that do not parse are reported with their source", func(t *testing.T) {
+		// arrange
+		env := map[string]string{"SERVER_READ_TIMEOUT": "15", "FEATURE_METRICS": "sometimes"}
+
+		// act
+		_, err := config.Load("server", []string{"-db-port", "mysql"}, envOf(env))
+
+		// assert
+		assert.EqualError(t, err, `env SERVER_READ_TIMEOUT: invalid duration "15", expected a value such as 30s or 1m
+env FEATURE_METRICS: invalid boolean "sometimes", expected true or false
+flag -db-port: invalid integer "mysql"`)
+	})
+
+	t.Run("every invalid setting is reported", func(t *testing.T) {
+		// arrange
+		env := map[string]string{"SERVER_PORT": "70000", "DB_MAX_OPEN_CONNS": "5", "DB_MAX_IDLE_CONNS": "10", "LOG_LEVEL": "verbose"}
+
+		// act
+		_, err := config.Load("server", nil, envOf(env))
+
+		// assert
+		assert.EqualError(t, err, `invalid config: server.port must be between 1 and 65535, got 70000
+database.host is required
+database.user is required
+database.max_idle_conns (10) must not exceed database.max_open_conns (5)
+log.level must be debug, info, warn or error, got "verbose"`)
+	})
+
+	t.Run("the memory storage needs no database", func(t *testing.T) {
+		// act
+		_, err := config.Load("server", []string{"-storage", "memory"}, envOf(nil))
+
+		// assert
+		assert.NoError(t, err)
+	})
+}
+
+func TestConfig_String(t *testing.T) {
+	// arrange
+	cfg := config.Default()
+	cfg.Database.Password = "s3cret"
+
+	// act
+	printed := cfg.String()
+
+	// assert
+	assert.NotContains(t, printed, "s3cret")
+	assert.Contains(t, printed, `"password":"[REDACTED]"`)
+	assert.Contains(t, printed, `"write_timeout":"30s"`)
+	assert.Equal(t, "s3cret", cfg.Database.Password, "the config itself keeps the secret")
+}
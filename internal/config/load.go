@@ -0,0 +1,206 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// setting is one value that env vars and flags can override
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	set   func(cfg *Config, value string) error
+	// legacyEnv is read when env is not set
+	legacyEnv string
+}
+
+// settings lists every overridable value, in the order they are applied
+var settings = []setting{
+	stringSetting("SERVER_HOST", "server-host", "interface the api listens on, all of them when empty",
+		func(c *Config) *string { return &c.Server.Host }),
+	withLegacyEnv(intSetting("SERVER_PORT", "server-port", "port the api listens on",
+		func(c *Config) *int { return &c.Server.Port }), "ADDRESS"),
+	durationSetting("SERVER_READ_HEADER_TIMEOUT", "server-read-header-timeout", "time to read the request headers",
+		func(c *Config) *Duration { return &c.Server.ReadHeaderTimeout }),
+	durationSetting("SERVER_READ_TIMEOUT", "server-read-timeout", "time to read the whole request",
+		func(c *Config) *Duration { return &c.Server.ReadTimeout }),
+	durationSetting("SERVER_WRITE_TIMEOUT", "server-write-timeout", "time to write the response",
+		func(c *Config) *Duration { return &c.Server.WriteTimeout }),
+	durationSetting("SERVER_IDLE_TIMEOUT", "server-idle-timeout", "time a keep-alive connection waits for the next request",
+		func(c *Config) *Duration { return &c.Server.IdleTimeout }),
+	intSetting("SERVER_MAX_HEADER_BYTES", "server-max-header-bytes", "maximum size of the request headers",
+		func(c *Config) *int { return &c.Server.MaxHeaderBytes }),
+	durationSetting("SERVER_SHUTDOWN_TIMEOUT", "server-shutdown-timeout", "time a shutdown waits for the requests in flight",
+		func(c *Config) *Duration { return &c.Server.ShutdownTimeout }),
+	stringSetting("STORAGE", "storage", `"mysql" or "memory"`,
+		func(c *Config) *string { return &c.Storage }),
+	stringSetting("DB_HOST", "db-host", "MySQL host",
+		func(c *Config) *string { return &c.Database.Host }),
+	intSetting("DB_PORT", "db-port", "MySQL port",
+		func(c *Config) *int { return &c.Database.Port }),
+	stringSetting("DB_NAME", "db-name", "MySQL database",
+		func(c *Config) *string { return &c.Database.Name }),
+	stringSetting("DB_USER", "db-user", "MySQL user",
+		func(c *Config) *string { return &c.Database.User }),
+	stringSetting("DB_PASS", "db-pass", "MySQL password",
+		func(c *Config) *string { return &c.Database.Password }),
+	intSetting("DB_MAX_OPEN_CONNS", "db-max-open-conns", "maximum open connections, 0 for unlimited",
+		func(c *Config) *int { return &c.Database.MaxOpenConns }),
+	intSetting("DB_MAX_IDLE_CONNS", "db-max-idle-conns", "maximum idle connections",
+		func(c *Config) *int { return &c.Database.MaxIdleConns }),
+	durationSetting("DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "time a connection is reused, 0 for ever",
+		func(c *Config) *Duration { return &c.Database.ConnMaxLifetime }),
+	durationSetting("DB_CONN_MAX_IDLE_TIME", "db-conn-max-idle-time", "time a connection stays idle, 0 for ever",
+		func(c *Config) *Duration { return &c.Database.ConnMaxIdleTime }),
+	stringSetting("LOG_LEVEL", "log-level", "debug, info, warn or error",
+		func(c *Config) *string { return &c.Log.Level }),
+	boolSetting("FEATURE_METRICS", "feature-metrics", "serve the Prometheus metrics on /metrics",
+		func(c *Config) *bool { return &c.Features.Metrics }),
+	boolSetting("FEATURE_WAREHOUSE_DASHBOARD", "feature-warehouse-dashboard", "serve the warehouse dashboard",
+		func(c *Config) *bool { return &c.Features.WarehouseDashboard }),
+}
+
+// LoadFromOS loads the .env file, if any, into the environment and then loads
+// the config from the environment and args, the command line without the program name.
+func LoadFromOS(name string, args []string) (Config, error) {
+	// the env can also be preloaded in the terminal or by docker
+	_ = godotenv.Load()
+	return Load(name, args, os.Getenv)
+}
+
+// Load returns the defaults overridden by the config file, the env vars read
+// with getenv and the flags in args, in that order, and validates the result.
+// The file is given with -config or CONFIG_FILE and may be YAML or JSON.
+func Load(name string, args []string, getenv func(string) string) (Config, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := fs.String("config", getenv("CONFIG_FILE"), "YAML or JSON config file (env CONFIG_FILE)")
+	flagValues := map[string]string{}
+	for _, s := range settings {
+		fs.Func(s.flag, s.usage+" (env "+s.env+")", func(value string) error {
+			flagValues[s.flag] = value
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+	if fs.NArg() > 0 {
+		return Config{}, fmt.Errorf("unexpected arguments %q", fs.Args())
+	}
+
+	cfg := Default()
+	if *configFile != "" {
+		if err := readFile(*configFile, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+
+	var errs []error
+	for _, s := range settings {
+		env, value := s.env, getenv(s.env)
+		if value == "" && s.legacyEnv != "" {
+			env, value = s.legacyEnv, getenv(s.legacyEnv)
+		}
+		if value == "" {
+			continue
+		}
+		if err := s.set(&cfg, value); err != nil {
+			errs = append(errs, fmt.Errorf("env %s: %w", env, err))
+		}
+	}
+	for _, s := range settings {
+		if value, ok := flagValues[s.flag]; ok {
+			if err := s.set(&cfg, value); err != nil {
+				errs = append(errs, fmt.Errorf("flag -%s: %w", s.flag, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return Config{}, errors.Join(errs...)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid config: %w", err)
+	}
+	return cfg, nil
+}
+
+// readFile decodes the file over cfg, keeping the values it does not set.
+// Unknown keys are rejected so a typo does not go unnoticed.
+func readFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(cfg)
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err = decoder.Decode(cfg); errors.Is(err, io.EOF) {
+			// an empty file sets nothing
+			err = nil
+		}
+	default:
+		return fmt.Errorf("config file %s must be .yaml, .yml or .json", path)
+	}
+	if err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func stringSetting(env, flag, usage string, field func(*Config) *string) setting {
+	return setting{env: env, flag: flag, usage: usage, set: func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}}
+}
+
+func intSetting(env, flag, usage string, field func(*Config) *int) setting {
+	return setting{env: env, flag: flag, usage: usage, set: func(c *Config, value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+func durationSetting(env, flag, usage string, field func(*Config) *Duration) setting {
+	return setting{env: env, flag: flag, usage: usage, set: func(c *Config, value string) error {
+		return field(c).UnmarshalText([]byte(value))
+	}}
+}
+
+func boolSetting(env, flag, usage string, field func(*Config) *bool) setting {
+	return setting{env: env, flag: flag, usage: usage, set: func(c *Config, value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q, expected true or false", value)
+		}
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+func withLegacyEnv(s setting, legacyEnv string) setting {
+	s.legacyEnv = legacyEnv
+	return s
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// AlertHandler handles temperature breach alerts
+type AlertHandler struct {
+	alertService service.AlertService
+}
+
+// NewAlertHandler returns a new AlertHandler
+func NewAlertHandler(alertService service.AlertService) *AlertHandler {
+	return &AlertHandler{alertService: alertService}
+}
+
+// GetAll, returns a page of the temperature alerts, newest first unless sorted otherwise
+// @Summary Get temperature alerts
+// @Description Get a page of the temperature breach alerts, optionally filtered by status and section
+// @Tags alerts
+// @Produce json
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor of the page"
+// @Param sort query string false "Field to sort by, created_at by default"
+// @Param order query string false "asc or desc, desc by default"
+// @Param status query string false "open, acknowledged or resolved"
+// @Param section_id query int false "Section ID"
+// @Success 200 {array} models.Alert
+// @Router /alerts [get]
+func (handler *AlertHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		query := r.URL.Query()
+
+		opts, err := parseQueryOptions(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		// Newest first unless the client chooses the order
+		if opts.Sort == "" {
+			opts.Sort = "created_at"
+		}
+		if query.Get("order") == "" {
+			opts.Desc = true
+		}
+
+		filter := models.AlertFilter{QueryOptions: opts}
+		if status := query.Get("status"); status != "" {
+			switch status {
+			case models.AlertStatusOpen, models.AlertStatusAcknowledged, models.AlertStatusResolved:
+				filter.Status = status
+			default:
+				response.Error(w, http.StatusBadRequest, "Invalid status")
+				return
+			}
+		}
+		if param := query.Get("section_id"); param != "" {
+			id, err := strconv.Atoi(param)
+			if err != nil || id <= 0 {
+				response.Error(w, http.StatusBadRequest, "Invalid section ID")
+				return
+			}
+			filter.SectionID = id
+		}
+
+		page, err := handler.alertService.GetAll(ctx, filter)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		pageJSON(w, page)
+	}
+}
+
+// Acknowledge, marks an open alert as acknowledged
+// @Summary Acknowledge an alert
+// @Description Mark an open alert as seen. It is still resolved automatically once the temperature is back in range.
+// @Tags alerts
+// @Produce json
+// @Param id path int true "Alert ID"
+// @Success 200 {object} models.Alert
+// @Router /alerts/{id}/ack [post]
+func (handler *AlertHandler) Acknowledge() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		alert, err := handler.alertService.Acknowledge(ctx, id)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": alert,
+		})
+	}
+}
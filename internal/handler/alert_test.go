@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+// TestAlertHandler_GetAll tests the default order, pagination and filters of GetAll
+func TestAlertHandler_GetAll(t *testing.T) {
+	tests := []struct {
+		testName       string
+		query          string
+		expectedFilter *models.AlertFilter
+		expectedCode   int
+		expectedBody   string
+	}{
+		{
+			testName: "Success: newest first by default",
+			query:    "",
+			expectedFilter: &models.AlertFilter{
+				QueryOptions: models.QueryOptions{Limit: defaultPageLimit, Sort: "created_at", Desc: true},
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data": [], "meta": {"next_cursor": null, "total": 0}}`,
+		},
+		{
+			testName: "Success: explicit order and filters",
+			query:    "?limit=5&sort=temperature&order=asc&status=open&section_id=2",
+			expectedFilter: &models.AlertFilter{
+				QueryOptions: models.QueryOptions{Limit: 5, Sort: "temperature"},
+				Status:       models.AlertStatusOpen,
+				SectionID:    2,
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			testName:     "Fail: invalid cursor",
+			query:        "?cursor=%21",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid cursor"}`,
+		},
+		{
+			testName:     "Fail: invalid status",
+			query:        "?status=closed",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid status"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			mockService := new(mocks.AlertServiceMock)
+			if tc.expectedFilter != nil {
+				mockService.On("GetAll", testifyMock.Anything, *tc.expectedFilter).
+					Return(models.NewPage([]models.Alert{}, 0, nil), nil)
+			}
+			handler := NewAlertHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/alerts"+tc.query, nil)
+			rr := httptest.NewRecorder()
+			handler.GetAll()(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
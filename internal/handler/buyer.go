@@ -56,19 +56,25 @@ func (h *BuyerHandler) Create() http.HandlerFunc {
 	}
 }
 
+// get a page of buyers, reading limit, cursor, sort, order and the
+// delivery_locality_id filter from the query string
 func (h *BuyerHandler) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		buyerData, err := h.service.GetAll(ctx)
+		opts, err := parseQueryOptions(r, localityFilter("delivery_locality_id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		buyerData, err := h.service.GetAll(ctx, opts)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
 			return
 		}
 
-		response.JSON(w, http.StatusOK, map[string]any{
-			"data": buyerData,
-		})
+		pageJSON(w, buyerData)
 	}
 }
 
@@ -229,7 +229,8 @@ func TestBuyerHandler_GetAll(t *testing.T) {
 
 	// simulates the success response of the method
 	type DataResponseGetAll struct {
-		Data []models.Buyer `json:"data"`
+		Data []models.Buyer  `json:"data"`
+		Meta models.PageMeta `json:"meta"`
 	}
 	t.Run("the service responds with a random error from repository, returns InternalServerError", func(t *testing.T) {
 		// arrange
@@ -240,7 +241,7 @@ func TestBuyerHandler_GetAll(t *testing.T) {
 		rec := httptest.NewRecorder()
 
 		randomErrorFromRepo := errors.New("database is busy")
-		serviceMock.On("GetAll", mock.Anything).Return([]models.Buyer{}, randomErrorFromRepo)
+		serviceMock.On("GetAll", mock.Anything, models.QueryOptions{Limit: 50}).Return(models.Page[models.Buyer]{}, randomErrorFromRepo)
 
 		// act
 		buyerHandler.GetAll().ServeHTTP(rec, req)
@@ -282,10 +283,12 @@ func TestBuyerHandler_GetAll(t *testing.T) {
 			},
 		}
 		ctx := mock.Anything
-		serviceMock.On("GetAll", ctx).Return(expectedBuyers, nil)
+		opts := models.QueryOptions{Limit: 2, Sort: "last_name"}
+		next := models.Cursor{Sort: "last_name", Value: "Esteban", ID: 2}
+		serviceMock.On("GetAll", ctx, opts).Return(models.NewPage(expectedBuyers, 5, &next), nil)
 
 		rec := httptest.NewRecorder()
-		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req := httptest.NewRequest(http.MethodGet, "/?limit=2&sort=last_name", nil)
 
 		// act
 		buyerHandler.GetAll().ServeHTTP(rec, req)
@@ -300,6 +303,9 @@ func TestBuyerHandler_GetAll(t *testing.T) {
 
 		assert.Equal(t, expectedBuyers, actualResponse.Data)
 		assert.Equal(t, 2, len(actualResponse.Data))
+		assert.Equal(t, 5, actualResponse.Meta.Total)
+		require.NotNil(t, actualResponse.Meta.NextCursor)
+		assert.Equal(t, next.Encode(), *actualResponse.Meta.NextCursor)
 		serviceMock.AssertExpectations(t)
 	})
 }
@@ -2,11 +2,16 @@ package handler
 
 import (
 	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
 	"github.com/bootcamp-go/web/response"
-	"net/http"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator"
 )
 
 type CarryHandler struct {
@@ -24,12 +29,27 @@ func NewCarryHandler(sv service.CarryService) *CarryHandler {
 func (h CarryHandler) Create() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var carry models.CarryAttributes
-		err := json.NewDecoder(r.Body).Decode(&carry)
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		err := dec.Decode(&carry)
 		if err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		// delete leading and trailing whitespaces before validating
+		carry.Cid = strings.TrimSpace(carry.Cid)
+		carry.CompanyName = strings.TrimSpace(carry.CompanyName)
+		carry.Address = strings.TrimSpace(carry.Address)
+		carry.Telephone = strings.TrimSpace(carry.Telephone)
+		carry.LocalityId = strings.TrimSpace(carry.LocalityId)
+
+		if err := validator.New().Struct(carry); err != nil {
 			response.Error(w, http.StatusUnprocessableEntity, "Invalid JSON body")
 			return
 		}
-		carryData, err := h.service.Create(carry)
+
+		carryData, err := h.service.Create(r.Context(), carry)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
@@ -40,3 +60,107 @@ func (h CarryHandler) Create() http.HandlerFunc {
 		})
 	}
 }
+
+// GetAll handles the retrieval of a page of carries, optionally filtered by locality_id.
+func (h CarryHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseQueryOptions(r, localityFilter("locality_id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		carries, err := h.service.GetAll(r.Context(), opts)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+		pageJSON(w, carries)
+	}
+}
+
+// GetByID handles the retrieval of the carry identified by the id URL parameter.
+func (h CarryHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		carry, err := h.service.GetByID(r.Context(), id)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": carry,
+		})
+	}
+}
+
+// Update handles the partial update of the carry identified by the id URL parameter.
+func (h CarryHandler) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		var patch models.CarryPatchRequest
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&patch); err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		// a field that is sent must not be blank
+		for _, field := range []*string{patch.Cid, patch.CompanyName, patch.Address, patch.Telephone, patch.LocalityId} {
+			if field != nil {
+				*field = strings.TrimSpace(*field)
+				if *field == "" {
+					response.Error(w, http.StatusUnprocessableEntity, "Invalid JSON body")
+					return
+				}
+			}
+		}
+		if err := validator.New().Struct(patch); err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Invalid JSON body")
+			return
+		}
+
+		carry, err := h.service.Update(r.Context(), id, patch)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": carry,
+		})
+	}
+}
+
+// Delete handles the removal of the carry identified by the id URL parameter.
+// A carry that has had purchase orders responds 409, whether they are still open
+// or already delivered or cancelled, as they keep it in their history.
+func (h CarryHandler) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		if err := h.service.Delete(r.Context(), id); err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+		response.JSON(w, http.StatusNoContent, nil)
+	}
+}
@@ -0,0 +1,141 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/handler"
+	mocks "github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCarryHandler_Create(t *testing.T) {
+	attributes := models.CarryAttributes{Cid: "CID1", CompanyName: "Fast", Address: "Main 1", Telephone: "555", LocalityId: "1001"}
+
+	tests := []struct {
+		testName      string
+		body          string
+		callsService  bool
+		serviceResult models.Carry
+		serviceError  error
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			testName:      "creates the carry and returns it",
+			body:          `{"cid": " CID1 ", "company_name": "Fast", "address": "Main 1", "telephone": "555", "locality_id": "1001"}`,
+			callsService:  true,
+			serviceResult: models.Carry{Id: 1, CarryAttributes: attributes},
+			expectedCode:  http.StatusCreated,
+			expectedBody: `{"data": {"id": 1, "cid": "CID1", "company_name": "Fast", "address": "Main 1",
+				"telephone": "555", "locality_id": "1001"}}`,
+		},
+		{
+			testName:     "missing field returns StatusUnprocessableEntity",
+			body:         `{"cid": "CID1", "company_name": "Fast", "address": "Main 1", "telephone": " ", "locality_id": "1001"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"status": "Unprocessable Entity", "message": "Invalid JSON body"}`,
+		},
+		{
+			testName:     "unknown field returns StatusBadRequest",
+			body:         `{"cid": "CID1", "batch": 1}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid JSON body"}`,
+		},
+		{
+			testName:     "duplicated cid returns StatusConflict",
+			body:         `{"cid": "CID1", "company_name": "Fast", "address": "Main 1", "telephone": "555", "locality_id": "1001"}`,
+			callsService: true,
+			serviceError: httperrors.ConflictError{Message: "the Cid already exists"},
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"status": "Conflict", "message": "the Cid already exists"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.CarryServiceMock)
+			carryHandler := handler.NewCarryHandler(serviceMock)
+			if tc.callsService {
+				serviceMock.On("Create", mock.Anything, attributes).Return(tc.serviceResult, tc.serviceError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+
+			// act
+			carryHandler.Create().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCarryHandler_Delete(t *testing.T) {
+	tests := []struct {
+		testName     string
+		id           string
+		serviceError error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			testName:     "deletes the carry",
+			id:           "1",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			testName:     "carry with open shipments returns StatusConflict",
+			id:           "1",
+			serviceError: httperrors.ConflictError{Message: "the carry is still assigned to open shipments"},
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"status": "Conflict", "message": "the carry is still assigned to open shipments"}`,
+		},
+		{
+			testName:     "invalid id returns StatusBadRequest",
+			id:           "abc",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid ID"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.CarryServiceMock)
+			carryHandler := handler.NewCarryHandler(serviceMock)
+			if tc.id == "1" {
+				serviceMock.On("Delete", mock.Anything, 1).Return(tc.serviceError)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/"+tc.id, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			// act
+			carryHandler.Delete().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectedBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
+)
+
+// CountryHandler handles HTTP requests for country resources.
+// Its handlers are the ones of regionHandler.
+type CountryHandler struct {
+	regionHandler[models.Country, models.CountryAttributes, models.CountryPatchRequest]
+}
+
+// NewCountryHandler constructs a new CountryHandler with the given service.
+func NewCountryHandler(svc service.CountryService) *CountryHandler {
+	return &CountryHandler{regionHandler[models.Country, models.CountryAttributes, models.CountryPatchRequest]{
+		svc: svc,
+		trimAttributes: func(country *models.CountryAttributes) {
+			country.CountryName = strings.TrimSpace(country.CountryName)
+		},
+		trimPatch: func(patch *models.CountryPatchRequest) {
+			if patch.CountryName != nil {
+				*patch.CountryName = strings.TrimSpace(*patch.CountryName)
+			}
+		},
+	}}
+}
@@ -0,0 +1,371 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/handler"
+	mocks "github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCountryHandler_Create(t *testing.T) {
+	tests := []struct {
+		testName       string
+		body           string
+		serviceRequest *models.CountryAttributes
+		serviceResult  models.Country
+		serviceError   error
+		expectedCode   int
+		expectedBody   string
+	}{
+		{
+			testName:       "creates the trimmed country and returns it",
+			body:           `{"country_name": " Uruguay "}`,
+			serviceRequest: &models.CountryAttributes{CountryName: "Uruguay"},
+			serviceResult:  models.Country{ID: 2, CountryAttributes: models.CountryAttributes{CountryName: "Uruguay"}},
+			expectedCode:   http.StatusCreated,
+			expectedBody:   `{"data": {"id": 2, "country_name": "Uruguay"}}`,
+		},
+		{
+			testName:       "duplicated name returns StatusConflict",
+			body:           `{"country_name": "Argentina"}`,
+			serviceRequest: &models.CountryAttributes{CountryName: "Argentina"},
+			serviceError:   httperrors.ConflictError{Message: "Country name already exists."},
+			expectedCode:   http.StatusConflict,
+			expectedBody:   `{"status": "Conflict", "message": "Country name already exists."}`,
+		},
+		{
+			testName:     "blank name returns StatusUnprocessableEntity",
+			body:         `{"country_name": "   "}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"status": "Unprocessable Entity", "message": "Invalid JSON body"}`,
+		},
+		{
+			testName:     "unknown field returns StatusBadRequest",
+			body:         `{"country_name": "Uruguay", "code": "UY"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid JSON body"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.CountryServiceMock)
+			countryHandler := handler.NewCountryHandler(serviceMock)
+			if tc.serviceRequest != nil {
+				serviceMock.On("Create", mock.Anything, *tc.serviceRequest).Return(tc.serviceResult, tc.serviceError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+
+			// act
+			countryHandler.Create().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCountryHandler_GetAll(t *testing.T) {
+	tests := []struct {
+		testName      string
+		query         string
+		serviceOpts   *models.QueryOptions
+		serviceResult models.Page[models.Country]
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			testName:    "returns the page of countries",
+			query:       "?limit=1&sort=country_name",
+			serviceOpts: &models.QueryOptions{Limit: 1, Sort: "country_name"},
+			serviceResult: models.NewPage([]models.Country{
+				{ID: 1, CountryAttributes: models.CountryAttributes{CountryName: "Argentina"}},
+			}, 2, nil),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data": [{"id": 1, "country_name": "Argentina"}], "meta": {"next_cursor": null, "total": 2}}`,
+		},
+		{
+			testName:      "country_id is not a filter of countries",
+			query:         "?country_id=1",
+			serviceOpts:   &models.QueryOptions{Limit: 50},
+			serviceResult: models.NewPage[models.Country](nil, 0, nil),
+			expectedCode:  http.StatusOK,
+			expectedBody:  `{"data": [], "meta": {"next_cursor": null, "total": 0}}`,
+		},
+		{
+			testName:     "invalid limit returns StatusBadRequest",
+			query:        "?limit=0",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid limit"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.CountryServiceMock)
+			countryHandler := handler.NewCountryHandler(serviceMock)
+			if tc.serviceOpts != nil {
+				serviceMock.On("GetAll", mock.Anything, *tc.serviceOpts).Return(tc.serviceResult, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/"+tc.query, nil)
+			rec := httptest.NewRecorder()
+
+			// act
+			countryHandler.GetAll().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCountryHandler_GetByID(t *testing.T) {
+	tests := []struct {
+		testName      string
+		id            string
+		callsService  bool
+		serviceResult models.Country
+		serviceError  error
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			testName:      "returns the country",
+			id:            "1",
+			callsService:  true,
+			serviceResult: models.Country{ID: 1, CountryAttributes: models.CountryAttributes{CountryName: "Argentina"}},
+			expectedCode:  http.StatusOK,
+			expectedBody:  `{"data": {"id": 1, "country_name": "Argentina"}}`,
+		},
+		{
+			testName:     "missing country returns StatusNotFound",
+			id:           "1",
+			callsService: true,
+			serviceError: httperrors.NotFoundError{Message: "Country not found"},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"status": "Not Found", "message": "Country not found"}`,
+		},
+		{
+			testName:     "invalid id returns StatusBadRequest",
+			id:           "abc",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid ID"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.CountryServiceMock)
+			countryHandler := handler.NewCountryHandler(serviceMock)
+			if tc.callsService {
+				serviceMock.On("GetByID", mock.Anything, 1).Return(tc.serviceResult, tc.serviceError)
+			}
+
+			req := addChiURLParam(httptest.NewRequest(http.MethodGet, "/"+tc.id, nil), "id", tc.id)
+			rec := httptest.NewRecorder()
+
+			// act
+			countryHandler.GetByID().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCountryHandler_Update(t *testing.T) {
+	tests := []struct {
+		testName       string
+		id             string
+		body           string
+		serviceRequest *models.CountryPatchRequest
+		serviceResult  models.Country
+		serviceError   error
+		expectedCode   int
+		expectedBody   string
+	}{
+		{
+			testName:       "renames the country with the trimmed name",
+			id:             "1",
+			body:           `{"country_name": " Republica Argentina "}`,
+			serviceRequest: &models.CountryPatchRequest{CountryName: utils.Ptr("Republica Argentina")},
+			serviceResult:  models.Country{ID: 1, CountryAttributes: models.CountryAttributes{CountryName: "Republica Argentina"}},
+			expectedCode:   http.StatusOK,
+			expectedBody:   `{"data": {"id": 1, "country_name": "Republica Argentina"}}`,
+		},
+		{
+			testName:       "missing country returns StatusNotFound",
+			id:             "1",
+			body:           `{"country_name": "Uruguay"}`,
+			serviceRequest: &models.CountryPatchRequest{CountryName: utils.Ptr("Uruguay")},
+			serviceError:   httperrors.NotFoundError{Message: "Country not found"},
+			expectedCode:   http.StatusNotFound,
+			expectedBody:   `{"status": "Not Found", "message": "Country not found"}`,
+		},
+		{
+			testName:     "blank name returns StatusUnprocessableEntity",
+			id:           "1",
+			body:         `{"country_name": " "}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"status": "Unprocessable Entity", "message": "Invalid JSON body"}`,
+		},
+		{
+			testName:     "invalid id returns StatusBadRequest",
+			id:           "0",
+			body:         `{"country_name": "Uruguay"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid ID"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.CountryServiceMock)
+			countryHandler := handler.NewCountryHandler(serviceMock)
+			if tc.serviceRequest != nil {
+				serviceMock.On("Update", mock.Anything, 1, *tc.serviceRequest).Return(tc.serviceResult, tc.serviceError)
+			}
+
+			req := addChiURLParam(httptest.NewRequest(http.MethodPatch, "/"+tc.id, strings.NewReader(tc.body)), "id", tc.id)
+			rec := httptest.NewRecorder()
+
+			// act
+			countryHandler.Update().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCountryHandler_Delete(t *testing.T) {
+	tests := []struct {
+		testName     string
+		id           string
+		callsService bool
+		serviceError error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			testName:     "removes the country",
+			id:           "1",
+			callsService: true,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			testName:     "country still referenced by provinces returns StatusConflict",
+			id:           "1",
+			callsService: true,
+			serviceError: httperrors.ConflictError{Message: "Country is still referenced by provinces."},
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"status": "Conflict", "message": "Country is still referenced by provinces."}`,
+		},
+		{
+			testName:     "invalid id returns StatusBadRequest",
+			id:           "-1",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid ID"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.CountryServiceMock)
+			countryHandler := handler.NewCountryHandler(serviceMock)
+			if tc.callsService {
+				serviceMock.On("Delete", mock.Anything, 1).Return(tc.serviceError)
+			}
+
+			req := addChiURLParam(httptest.NewRequest(http.MethodDelete, "/"+tc.id, nil), "id", tc.id)
+			rec := httptest.NewRecorder()
+
+			// act
+			countryHandler.Delete().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectedBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCountryHandler_GetCarryReport(t *testing.T) {
+	tests := []struct {
+		testName      string
+		query         string
+		serviceID     *int
+		callsService  bool
+		serviceResult []models.RegionCarryReport
+		serviceError  error
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			testName:      "reports the country of the id",
+			query:         "?id=1",
+			serviceID:     utils.Ptr(1),
+			callsService:  true,
+			serviceResult: []models.RegionCarryReport{{ID: 1, Name: "Argentina", CarriesCount: 4}},
+			expectedCode:  http.StatusOK,
+			expectedBody:  `{"data": [{"id": 1, "name": "Argentina", "carries_count": 4}]}`,
+		},
+		{
+			testName:     "non positive id returns StatusBadRequest",
+			query:        "?id=0",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid ID"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.CountryServiceMock)
+			countryHandler := handler.NewCountryHandler(serviceMock)
+			if tc.callsService {
+				serviceMock.On("GetCarryReport", mock.Anything, tc.serviceID).Return(tc.serviceResult, tc.serviceError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/reportCarries"+tc.query, nil)
+			rec := httptest.NewRecorder()
+
+			// act
+			countryHandler.GetCarryReport().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
@@ -21,20 +21,25 @@ func NewEmployeeHandler(sv service.EmployeeService) *EmployeeHandler {
 	return &EmployeeHandler{sv: sv}
 }
 
-// GetAll returns an HTTP handler that retrieves all employees from the service.
-// It responds with a JSON object containing a list of employees or an error message if the operation fails.
+// GetAll returns an HTTP handler that retrieves a page of employees from the service.
+// It reads limit, cursor, sort, order and the warehouse_id filter from the query string,
+// and responds with a JSON object containing the employees and the page meta or an error message if the operation fails.
 func (h *EmployeeHandler) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		data, err := h.sv.GetAll()
+		opts, err := parseQueryOptions(r, idFilter("warehouse_id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		page, err := h.sv.GetAll(r.Context(), opts)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
 			return
 		}
 
-		response.JSON(w, http.StatusOK, map[string]any{
-			"data": data,
-		})
+		pageJSON(w, page)
 	}
 }
 
@@ -50,7 +55,7 @@ func (h *EmployeeHandler) GetById() http.HandlerFunc {
 			return
 		}
 
-		data, err := h.sv.GetByID(id)
+		data, err := h.sv.GetByID(r.Context(), id)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
@@ -86,7 +91,7 @@ func (h *EmployeeHandler) Create() http.HandlerFunc {
 			return
 		}
 
-		data, err := h.sv.Create(employee)
+		data, err := h.sv.Create(r.Context(), employee)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
@@ -129,7 +134,7 @@ func (h *EmployeeHandler) Update() http.HandlerFunc {
 			return
 		}
 
-		data, err := h.sv.Update(id, employee)
+		data, err := h.sv.Update(r.Context(), id, employee)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
@@ -154,7 +159,7 @@ func (h *EmployeeHandler) Delete() http.HandlerFunc {
 			return
 		}
 
-		err = h.sv.Delete(id)
+		err = h.sv.Delete(r.Context(), id)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
@@ -182,7 +187,7 @@ func (h *EmployeeHandler) GetInboundOrderReport() http.HandlerFunc {
 			employeeID = id
 		}
 
-		data, err := h.sv.ReportInboundOrders(employeeID)
+		data, err := h.sv.ReportInboundOrders(r.Context(), employeeID)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
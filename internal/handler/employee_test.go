@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	serviceMocks "github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/service"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
@@ -81,7 +82,7 @@ func TestEmployeeHandler_Create(t *testing.T) {
 		expectedHeaders := http.Header{"Content-Type": []string{"application/json"}}
 
 		mockService := &serviceMocks.MockEmployeeService{}
-		mockService.On("Create", employee).Return(expectedEmployee, nil)
+		mockService.On("Create", mock.Anything, employee).Return(expectedEmployee, nil)
 		h := handler.NewEmployeeHandler(mockService)
 		rr := httptest.NewRecorder()
 		req := createRequestWithBody(http.MethodPost, "/employees", employee)
@@ -131,7 +132,7 @@ func TestEmployeeHandler_Create(t *testing.T) {
 		conflictErr := httperrors.ConflictError{Message: "Card number already exists"}
 
 		mockService := &serviceMocks.MockEmployeeService{}
-		mockService.On("Create", employee).Return(models.Employee{}, conflictErr)
+		mockService.On("Create", mock.Anything, employee).Return(models.Employee{}, conflictErr)
 		h := handler.NewEmployeeHandler(mockService)
 		rr := httptest.NewRecorder()
 		req := createRequestWithBody(http.MethodPost, "/employees", employee)
@@ -186,8 +187,9 @@ func TestEmployeeHandler_Get(t *testing.T) {
 
 		mockService := &serviceMocks.MockEmployeeService{}
 		h := handler.NewEmployeeHandler(mockService)
-		mockService.On("GetAll").Return(expectedEmployees, nil)
-		req := httptest.NewRequest(http.MethodGet, "/employees", nil)
+		opts := models.QueryOptions{Limit: 50, Filters: map[string]string{"warehouse_id": "1"}}
+		mockService.On("GetAll", mock.Anything, opts).Return(models.NewPage(expectedEmployees, 2, nil), nil)
+		req := httptest.NewRequest(http.MethodGet, "/employees?warehouse_id=1", nil)
 		rr := httptest.NewRecorder()
 
 		// act
@@ -200,6 +202,7 @@ func TestEmployeeHandler_Get(t *testing.T) {
 		require.Equal(t, expectedHeaders, rr.Header())
 		require.Contains(t, rr.Body.String(), string(expectedJsonA))
 		require.Contains(t, rr.Body.String(), string(expectedJsonB))
+		require.Contains(t, rr.Body.String(), `"meta":{"next_cursor":null,"total":2}`)
 		mockService.AssertExpectations(t)
 	})
 
@@ -211,7 +214,7 @@ func TestEmployeeHandler_Get(t *testing.T) {
 		require.NoError(t, err)
 		mockService := &serviceMocks.MockEmployeeService{}
 		h := handler.NewEmployeeHandler(mockService)
-		mockService.On("GetByID", 1).Return(expectedEmployee, nil)
+		mockService.On("GetByID", mock.Anything, 1).Return(expectedEmployee, nil)
 		req := createRequestWithContext(http.MethodGet, "/employees/1", "id", "1")
 		rr := httptest.NewRecorder()
 
@@ -234,7 +237,7 @@ func TestEmployeeHandler_Get(t *testing.T) {
 
 		mockService := &serviceMocks.MockEmployeeService{}
 		h := handler.NewEmployeeHandler(mockService)
-		mockService.On("GetByID", 999).Return(models.Employee{}, notFoundError)
+		mockService.On("GetByID", mock.Anything, 999).Return(models.Employee{}, notFoundError)
 		req := createRequestWithContext(http.MethodGet, "/employees/999", "id", "999")
 		rr := httptest.NewRecorder()
 
@@ -264,7 +267,7 @@ func TestEmployeeHandler_Update(t *testing.T) {
 
 		mockService := &serviceMocks.MockEmployeeService{}
 		h := handler.NewEmployeeHandler(mockService)
-		mockService.On("Update", 1, employee).Return(expectedEmployee, nil)
+		mockService.On("Update", mock.Anything, 1, employee).Return(expectedEmployee, nil)
 		req := createRequestWithBodyAndContext(http.MethodPut, "/employees/1", employee, "id", "1")
 		rr := httptest.NewRecorder()
 
@@ -288,7 +291,7 @@ func TestEmployeeHandler_Update(t *testing.T) {
 
 		mockService := &serviceMocks.MockEmployeeService{}
 		h := handler.NewEmployeeHandler(mockService)
-		mockService.On("Update", 999, employee).Return(models.Employee{}, notFoundError)
+		mockService.On("Update", mock.Anything, 999, employee).Return(models.Employee{}, notFoundError)
 		req := createRequestWithBodyAndContext(http.MethodPut, "/employees/999", employee, "id", "999")
 		rr := httptest.NewRecorder()
 
@@ -313,7 +316,7 @@ func TestEmployeeHandler_Delete(t *testing.T) {
 		// arrange
 		mockService := &serviceMocks.MockEmployeeService{}
 		h := handler.NewEmployeeHandler(mockService)
-		mockService.On("Delete", 1).Return(nil)
+		mockService.On("Delete", mock.Anything, 1).Return(nil)
 		req := createRequestWithContext(http.MethodDelete, "/employees/1", "id", "1")
 		rr := httptest.NewRecorder()
 
@@ -334,7 +337,7 @@ func TestEmployeeHandler_Delete(t *testing.T) {
 
 		mockService := &serviceMocks.MockEmployeeService{}
 		h := handler.NewEmployeeHandler(mockService)
-		mockService.On("Delete", 999).Return(notFoundError)
+		mockService.On("Delete", mock.Anything, 999).Return(notFoundError)
 		req := createRequestWithContext(http.MethodDelete, "/employees/999", "id", "999")
 		rr := httptest.NewRecorder()
 
@@ -375,7 +378,7 @@ func TestEmployeeHandler_GetInboundOrderReport(t *testing.T) {
 				InboundOrdersCount: 1,
 			},
 		}
-		mockService.On("ReportInboundOrders", 0).Return(expectedReport, nil)
+		mockService.On("ReportInboundOrders", mock.Anything, 0).Return(expectedReport, nil)
 		h := handler.NewEmployeeHandler(mockService)
 		req := httptest.NewRequest(http.MethodGet, "/employees/report-inbound-orders", nil)
 		rr := httptest.NewRecorder()
@@ -407,7 +410,7 @@ func TestEmployeeHandler_GetInboundOrderReport(t *testing.T) {
 				InboundOrdersCount: 3,
 			},
 		}
-		mockService.On("ReportInboundOrders", 1).Return(expectedReport, nil)
+		mockService.On("ReportInboundOrders", mock.Anything, 1).Return(expectedReport, nil)
 		req := httptest.NewRequest(http.MethodGet, "/employees/report-inbound-orders?id=1", nil)
 		rr := httptest.NewRecorder()
 		h := handler.NewEmployeeHandler(mockService)
@@ -442,7 +445,7 @@ func TestEmployeeHandler_GetInboundOrderReport(t *testing.T) {
 	t.Run("report_service_error: fail - error del service", func(t *testing.T) {
 		notFoundErr := httperrors.NotFoundError{Message: "Employee/s not found"}
 		mockService := &serviceMocks.MockEmployeeService{}
-		mockService.On("ReportInboundOrders", 1).Return([]models.EmployeeWithInboundCount{}, notFoundErr)
+		mockService.On("ReportInboundOrders", mock.Anything, 1).Return([]models.EmployeeWithInboundCount{}, notFoundErr)
 		req := httptest.NewRequest(http.MethodGet, "/employees/report-inbound-orders?id=1", nil)
 		rr := httptest.NewRecorder()
 		h := handler.NewEmployeeHandler(mockService)
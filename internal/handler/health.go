@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
+	"github.com/bootcamp-go/web/response"
+)
+
+// HealthHandler serves the liveness and readiness probes
+type HealthHandler struct {
+	service service.HealthService
+}
+
+// NewHealthHandler creates a new HealthHandler with the given service
+func NewHealthHandler(sv service.HealthService) *HealthHandler {
+	return &HealthHandler{service: sv}
+}
+
+// Live answers 200 while the process is running, without checking its dependencies.
+func (h *HealthHandler) Live() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response.JSON(w, http.StatusOK, h.service.Live(r.Context()))
+	}
+}
+
+// Ready answers 200 when every dependency check is up and 503 otherwise, so
+// the orchestrator stops routing traffic to the instance. The body holds the
+// status of each check.
+func (h *HealthHandler) Ready() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := h.service.Ready(r.Context())
+		statusCode := http.StatusOK
+		if report.Status != models.HealthStatusUp {
+			statusCode = http.StatusServiceUnavailable
+		}
+		response.JSON(w, statusCode, report)
+	}
+}
@@ -0,0 +1,61 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/handler"
+	mocks "github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHealthHandler_Ready(t *testing.T) {
+	tests := []struct {
+		testName     string
+		report       models.HealthReport
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			testName: "every check up returns StatusOK",
+			report: models.HealthReport{Status: models.HealthStatusUp, Version: "v1.2.0", Checks: []models.HealthCheck{
+				{Name: "database", Status: models.HealthStatusUp},
+			}},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status": "up", "version": "v1.2.0", "checks": [{"name": "database", "status": "up"}]}`,
+		},
+		{
+			testName: "schema behind returns StatusServiceUnavailable",
+			report: models.HealthReport{Status: models.HealthStatusDown, Version: "v1.2.0", Checks: []models.HealthCheck{
+				{Name: "database", Status: models.HealthStatusUp},
+				{Name: "migrations", Status: models.HealthStatusDown, Error: "schema is behind", PendingMigrations: []string{"0023_localityHierarchy.sql"}},
+			}},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: `{"status": "down", "version": "v1.2.0", "checks": [{"name": "database", "status": "up"},
+				{"name": "migrations", "status": "down", "error": "schema is behind", "pending_migrations": ["0023_localityHierarchy.sql"]}]}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.HealthServiceMock)
+			healthHandler := handler.NewHealthHandler(serviceMock)
+			serviceMock.On("Ready", mock.Anything).Return(tc.report)
+
+			req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+			rec := httptest.NewRecorder()
+
+			// act
+			healthHandler.Ready().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
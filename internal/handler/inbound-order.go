@@ -5,6 +5,7 @@ import (
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/utils"
 	"github.com/bootcamp-go/web/response"
 	"github.com/go-playground/validator"
 	"net/http"
@@ -18,8 +19,9 @@ func NewInboundOrderHandler(sv service.InboundOrderService) *InboundOrderHandler
 	return &InboundOrderHandler{sv: sv}
 }
 
-// Create returns an HTTP handler that creates a new inbound order.
-// It decodes and validates the request body, then calls the service to persist the inbound order data.
+// Create returns an HTTP handler that receives a new inbound order.
+// It decodes and validates the request body, including the received product batch,
+// then calls the service to persist the inbound order data.
 // The handler responds with the created inbound order as JSON or returns an appropriate error message.
 func (h *InboundOrderHandler) Create() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -35,13 +37,14 @@ func (h *InboundOrderHandler) Create() http.HandlerFunc {
 		}
 
 		validate := validator.New()
+		validate.RegisterValidation("date_format", utils.ValidateDateFormat)
 		err = validate.Struct(req)
 		if err != nil {
 			response.Error(w, http.StatusUnprocessableEntity, "Invalid JSON body")
 			return
 		}
 
-		data, err := h.sv.Create(req)
+		data, err := h.sv.Create(r.Context(), req)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
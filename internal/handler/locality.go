@@ -3,8 +3,10 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
@@ -21,7 +23,7 @@ func NewLocalityHandler(service service.LocalityService) *LocalityHandler {
 }
 
 // This function create a new locality
-// It expects a JSON body with the locality data
+// It expects a JSON body with the locality data, including its province_id
 // Returns the created locality with a 201 status code
 func (h *LocalityHandler) Create() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -33,7 +35,15 @@ func (h *LocalityHandler) Create() http.HandlerFunc {
 			return
 		}
 
-		created, err := h.service.Create(body.Data)
+		// Delete trailing whitespaces before validating
+		body.Data.ID = strings.TrimSpace(body.Data.ID)
+		body.Data.LocalityName = strings.TrimSpace(body.Data.LocalityName)
+		if err := validator.New().Struct(body.Data); err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Invalid locality data")
+			return
+		}
+
+		created, err := h.service.Create(r.Context(), body.Data)
 		if err != nil {
 			status, msg := httperrors.GetErrorData(err)
 			response.Error(w, status, msg)
@@ -46,6 +56,27 @@ func (h *LocalityHandler) Create() http.HandlerFunc {
 	}
 }
 
+// GetAll returns a page of localities as JSON, reading limit, cursor, sort,
+// order and the province_id and country_id filters from the query string
+func (h *LocalityHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseQueryOptions(r, idFilter("province_id"), idFilter("country_id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		page, err := h.service.GetAll(r.Context(), opts)
+		if err != nil {
+			status, msg := httperrors.GetErrorData(err)
+			response.Error(w, status, msg)
+			return
+		}
+
+		pageJSON(w, page)
+	}
+}
+
 // This function retrieves a locality by its ID
 // It expects the ID as a URL parameter
 func (h *LocalityHandler) GetByID() http.HandlerFunc {
@@ -56,7 +87,47 @@ func (h *LocalityHandler) GetByID() http.HandlerFunc {
 			return
 		}
 
-		locality, err := h.service.GetByID(id)
+		locality, err := h.service.GetByID(r.Context(), id)
+		if err != nil {
+			status, msg := httperrors.GetErrorData(err)
+			response.Error(w, status, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": locality,
+		})
+	}
+}
+
+// Update applies a partial update to the locality identified by the id URL parameter
+// Only locality_name and province_id can change
+func (h *LocalityHandler) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		if id == "" {
+			response.Error(w, http.StatusBadRequest, "Missing id")
+			return
+		}
+
+		var patch models.LocalityPatchRequest
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&patch); err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		// Delete trailing whitespaces before validating
+		if patch.LocalityName != nil {
+			*patch.LocalityName = strings.TrimSpace(*patch.LocalityName)
+		}
+		if err := validator.New().Struct(patch); err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Invalid locality data")
+			return
+		}
+
+		locality, err := h.service.Update(r.Context(), id, patch)
 		if err != nil {
 			status, msg := httperrors.GetErrorData(err)
 			response.Error(w, status, msg)
@@ -69,6 +140,26 @@ func (h *LocalityHandler) GetByID() http.HandlerFunc {
 	}
 }
 
+// Delete removes the locality identified by the id URL parameter
+// Returns a 409 status code while sellers, carries or buyers reference it
+func (h *LocalityHandler) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		if id == "" {
+			response.Error(w, http.StatusBadRequest, "Missing id")
+			return
+		}
+
+		if err := h.service.Delete(r.Context(), id); err != nil {
+			status, msg := httperrors.GetErrorData(err)
+			response.Error(w, status, msg)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
 // This function retrieves a report of sellers by locality
 // If an ID is provided, it returns the report for that specific locality
 func (h *LocalityHandler) GetSellerReport() http.HandlerFunc {
@@ -79,7 +170,7 @@ func (h *LocalityHandler) GetSellerReport() http.HandlerFunc {
 			id = &idStr
 		}
 
-		reports, err := h.service.GetSellerReport(id)
+		reports, err := h.service.GetSellerReport(r.Context(), id)
 		if err != nil {
 			status, msg := httperrors.GetErrorData(err)
 			response.Error(w, status, msg)
@@ -96,7 +187,7 @@ func (h *LocalityHandler) GetSellerReport() http.HandlerFunc {
 func (h *LocalityHandler) GetReportByLocalityId() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		localityId := r.URL.Query().Get("id")
-		result, err := h.service.GetReportByLocalityId(localityId)
+		result, err := h.service.GetReportByLocalityId(r.Context(), localityId)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
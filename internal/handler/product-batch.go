@@ -3,12 +3,15 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/utils"
 	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator"
 )
 
@@ -85,4 +88,186 @@ func (handler *ProductBatchHandler) Create() http.HandlerFunc {
 			"data": createdProductBatch,
 		})
 	}
-}
\ No newline at end of file
+}
+
+// GetAll, returns a page of product batches, optionally filtered
+// @Summary Get all product batches
+// @Description Get a page of product batches, filtered by section, product and due date range
+// @Tags product-batches
+// @Produce json
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor of the page"
+// @Param sort query string false "Field to sort by"
+// @Param order query string false "asc or desc"
+// @Param section_id query int false "Section ID"
+// @Param product_id query int false "Product ID"
+// @Param due_date_from query string false "Earliest due date (YYYY-MM-DD)"
+// @Param due_date_to query string false "Latest due date (YYYY-MM-DD)"
+// @Success 200 {array} models.ProductBatch
+// @Router /productBatches [get]
+func (handler *ProductBatchHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		query := r.URL.Query()
+
+		// Parse pagination, sorting and optional filters
+		opts, err := parseQueryOptions(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter := models.ProductBatchFilter{QueryOptions: opts}
+		if param := query.Get("section_id"); param != "" {
+			id, err := strconv.Atoi(param)
+			if err != nil || id <= 0 {
+				response.Error(w, http.StatusBadRequest, "Invalid section ID")
+				return
+			}
+			filter.SectionID = id
+		}
+		if param := query.Get("product_id"); param != "" {
+			id, err := strconv.Atoi(param)
+			if err != nil || id <= 0 {
+				response.Error(w, http.StatusBadRequest, "Invalid product ID")
+				return
+			}
+			filter.ProductID = id
+		}
+		for _, param := range []struct {
+			name   string
+			target *string
+		}{
+			{"due_date_from", &filter.DueDateFrom},
+			{"due_date_to", &filter.DueDateTo},
+		} {
+			value := query.Get(param.name)
+			if value == "" {
+				continue
+			}
+			if _, err := time.Parse(time.DateOnly, value); err != nil {
+				response.Error(w, http.StatusBadRequest, "Invalid "+param.name+", expected YYYY-MM-DD")
+				return
+			}
+			*param.target = value
+		}
+		if filter.DueDateFrom != "" && filter.DueDateTo != "" && filter.DueDateFrom > filter.DueDateTo {
+			response.Error(w, http.StatusBadRequest, "due_date_from must not be after due_date_to")
+			return
+		}
+
+		page, err := handler.productBatchService.GetAll(ctx, filter)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		pageJSON(w, page)
+	}
+}
+
+// GetByID, returns a product batch by its ID
+// @Summary Get a product batch by ID
+// @Description Get a product batch by ID
+// @Tags product-batches
+// @Produce json
+// @Param id path int true "Product batch ID"
+// @Success 200 {object} models.ProductBatch
+// @Router /productBatches/{id} [get]
+func (handler *ProductBatchHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		// Get product batch ID from URL parameter
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		data, err := handler.productBatchService.GetByID(ctx, id)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": data,
+		})
+	}
+}
+
+// Update, updates a product batch in the repository
+// @Summary Update a product batch
+// @Description Partially update a product batch, a change of current_quantity is recorded as an adjustment movement
+// @Tags product-batches
+// @Accept json
+// @Produce json
+// @Param id path int true "Product batch ID"
+// @Param product-batch body models.UpdateProductBatchRequest true "Fields to update"
+// @Success 200 {object} models.ProductBatch
+// @Router /productBatches/{id} [patch]
+func (handler *ProductBatchHandler) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		// Parse request body
+		var req models.UpdateProductBatchRequest
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid body")
+			return
+		}
+
+		// Validate request body
+		validate := validator.New()
+		validate.RegisterValidation("date_format", utils.ValidateDateFormat)
+		if err := validate.Struct(req); err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Invalid JSON body")
+			return
+		}
+
+		updatedProductBatch, err := handler.productBatchService.Update(ctx, id, req)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": updatedProductBatch,
+		})
+	}
+}
+
+// Delete, deletes a product batch from the repository
+// @Summary Delete a product batch
+// @Description Delete a product batch that has no stock movements besides its initial stock
+// @Tags product-batches
+// @Param id path int true "Product batch ID"
+// @Success 204
+// @Router /productBatches/{id} [delete]
+func (handler *ProductBatchHandler) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		if err := handler.productBatchService.Delete(ctx, id); err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
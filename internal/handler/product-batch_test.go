@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+// TestProductBatchHandler_GetAll tests the query filters of GetAll
+func TestProductBatchHandler_GetAll(t *testing.T) {
+	batches := []models.ProductBatch{{ID: 1, ProductBatchAttibutes: models.ProductBatchAttibutes{BatchNumber: 10, DueDate: "2026-01-10", ProductID: 2, SectionID: 3}}}
+	dueDateCursor := models.Cursor{Sort: "due_date", Value: "2026-01-10", ID: 7}
+
+	tests := []struct {
+		testName       string
+		query          string
+		expectedFilter *models.ProductBatchFilter
+		expectedCode   int
+		expectedBody   string
+	}{
+		{
+			testName:       "Success: no filters",
+			query:          "",
+			expectedFilter: &models.ProductBatchFilter{QueryOptions: models.QueryOptions{Limit: defaultPageLimit}},
+			expectedCode:   http.StatusOK,
+			expectedBody: `{
+				"data": [{"id": 1, "batch_number": 10, "current_quantity": 0, "current_temperature": 0, "due_date": "2026-01-10",
+					"initial_quantity": 0, "manufacturing_date": "", "manufacturing_hour": 0, "minimum_temperature": 0,
+					"product_id": 2, "section_id": 3}],
+				"meta": {"next_cursor": null, "total": 1}
+			}`,
+		},
+		{
+			testName: "Success: all filters",
+			query:    "?section_id=3&product_id=2&due_date_from=2026-01-01&due_date_to=2026-01-31",
+			expectedFilter: &models.ProductBatchFilter{
+				QueryOptions: models.QueryOptions{Limit: defaultPageLimit},
+				SectionID:    3,
+				ProductID:    2,
+				DueDateFrom:  "2026-01-01",
+				DueDateTo:    "2026-01-31",
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			testName: "Success: page sorted by due date",
+			query:    "?limit=10&cursor=" + dueDateCursor.Encode() + "&sort=due_date&order=desc",
+			expectedFilter: &models.ProductBatchFilter{
+				QueryOptions: models.QueryOptions{Limit: 10, After: &dueDateCursor, Sort: "due_date", Desc: true},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			testName:     "Fail: invalid order",
+			query:        "?order=up",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid order"}`,
+		},
+		{
+			testName:     "Fail: invalid section ID",
+			query:        "?section_id=abc",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid section ID"}`,
+		},
+		{
+			testName:     "Fail: invalid due date",
+			query:        "?due_date_from=10-01-2026",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid due_date_from, expected YYYY-MM-DD"}`,
+		},
+		{
+			testName:     "Fail: inverted due date range",
+			query:        "?due_date_from=2026-02-01&due_date_to=2026-01-01",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "due_date_from must not be after due_date_to"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			mockService := new(mocks.ProductBatchServiceMock)
+			if tc.expectedFilter != nil {
+				mockService.On("GetAll", testifyMock.Anything, *tc.expectedFilter).Return(models.NewPage(batches, 1, nil), nil)
+			}
+			handler := NewProductBatchHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/productBatches"+tc.query, nil)
+			rr := httptest.NewRecorder()
+			handler.GetAll()(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
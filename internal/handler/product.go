@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
@@ -68,14 +69,23 @@ func (h ProductHandler) Create() http.HandlerFunc {
 	}
 }
 
-// GetAll returns an http.HandlerFunc that fetches all products
-// from the service layer and writes them as JSON.
+// GetAll returns an http.HandlerFunc that fetches a page of products
+// from the service layer and writes it as JSON with its meta.
+// Accepts limit, cursor, sort and order plus the seller_id and
+// product_type_id filters as query parameters.
 func (h ProductHandler) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		// Get all the products from the service layer
-		productData, err := h.svc.GetAll(ctx)
+		// Parse the pagination, sorting and filters
+		opts, err := parseQueryOptions(r, idFilter("seller_id"), idFilter("product_type_id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Get the page of products from the service layer
+		page, err := h.svc.GetAll(ctx, opts)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
@@ -83,9 +93,7 @@ func (h ProductHandler) GetAll() http.HandlerFunc {
 		}
 
 		// Write the JSON Response
-		response.JSON(w, http.StatusOK, map[string]any{
-			"data": productData,
-		})
+		pageJSON(w, page)
 	}
 }
 
@@ -152,6 +160,62 @@ func (h *ProductHandler) GetRecordsPerProduct() http.HandlerFunc {
 	}
 }
 
+// GetMarginReport returns an http.HandlerFunc that handles GET requests
+// for the units sold, revenue, cost and margin of each product, rolled up per seller.
+//
+// Query Parameters:
+//   - from, to (optional): inclusive range of order dates, as YYYY-MM-DD.
+//   - seller_id (optional): when provided, only the products of that seller are reported.
+func (h *ProductHandler) GetMarginReport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		query := r.URL.Query()
+
+		var filter models.MarginReportFilter
+		for _, param := range []struct {
+			name   string
+			target *string
+		}{
+			{"from", &filter.From},
+			{"to", &filter.To},
+		} {
+			value := query.Get(param.name)
+			if value == "" {
+				continue
+			}
+			if _, err := time.Parse(time.DateOnly, value); err != nil {
+				response.Error(w, http.StatusBadRequest, "Invalid "+param.name+", expected YYYY-MM-DD")
+				return
+			}
+			*param.target = value
+		}
+		if filter.From != "" && filter.To != "" && filter.From > filter.To {
+			response.Error(w, http.StatusBadRequest, "from must not be after to")
+			return
+		}
+
+		if sellerIDAsString := query.Get("seller_id"); sellerIDAsString != "" {
+			sellerID, err := strconv.Atoi(sellerIDAsString)
+			if err != nil || sellerID <= 0 {
+				response.Error(w, http.StatusBadRequest, "invalid seller_id")
+				return
+			}
+			filter.SellerID = &sellerID
+		}
+
+		report, err := h.svc.GetMarginReport(ctx, filter)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": report,
+		})
+	}
+}
+
 // Update returns an http.HandlerFunc that parses the id URL parameter,
 // decodes a partial-product JSON payload, validates it, delegates the update
 // to the service layer, and responds with the updated product.
@@ -3,12 +3,14 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
 	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator"
 )
 
@@ -74,3 +76,96 @@ func (h ProductRecordHandler) Create() http.HandlerFunc {
 		})
 	}
 }
+
+// GetByProductID returns an http.HandlerFunc that writes a page of the price history
+// of the product identified by the id URL parameter. The history is ordered by
+// last_update_date unless sort says otherwise, and from and to (YYYY-MM-DD, inclusive)
+// narrow it to a range of dates. It takes no equality filters, the product is
+// already the id URL parameter.
+func (h ProductRecordHandler) GetByProductID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		query := r.URL.Query()
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		// Parse pagination, sorting and the date range
+		opts, err := parseQueryOptions(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if opts.Sort == "" {
+			opts.Sort = "last_update_date"
+		}
+		filter := models.ProductRecordFilter{QueryOptions: opts, ProductID: id}
+		for _, param := range []struct {
+			name   string
+			target *string
+		}{
+			{"from", &filter.DateFrom},
+			{"to", &filter.DateTo},
+		} {
+			value := query.Get(param.name)
+			if value == "" {
+				continue
+			}
+			if _, err := time.Parse(time.DateOnly, value); err != nil {
+				response.Error(w, http.StatusBadRequest, "Invalid "+param.name+", expected YYYY-MM-DD")
+				return
+			}
+			*param.target = value
+		}
+		if filter.DateFrom != "" && filter.DateTo != "" && filter.DateFrom > filter.DateTo {
+			response.Error(w, http.StatusBadRequest, "from must not be after to")
+			return
+		}
+
+		page, err := h.svc.GetAll(ctx, filter)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		pageJSON(w, page)
+	}
+}
+
+// GetEffectivePrice returns an http.HandlerFunc that writes the product record in effect
+// on the date given by the at query parameter (YYYY-MM-DD, today by default)
+// for the product identified by the id URL parameter.
+func (h ProductRecordHandler) GetEffectivePrice() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		at := r.URL.Query().Get("at")
+		if at == "" {
+			at = time.Now().UTC().Format(time.DateOnly)
+		} else if _, err := time.Parse(time.DateOnly, at); err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid at, expected YYYY-MM-DD")
+			return
+		}
+
+		productRecord, err := h.svc.GetEffective(ctx, id, at)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": productRecord,
+		})
+	}
+}
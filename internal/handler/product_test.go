@@ -251,6 +251,8 @@ func TestProductHandler_Create(t *testing.T) {
 // It covers:
 // - Successful retrieval of multiple products
 // - Successful retrieval when no products exist (empty list)
+// - Pagination, sorting and filters parsed from the query string
+// - Error when the limit or the cursor are invalid
 // - Error propagation from the service layer
 func TestProductHandler_GetAll(t *testing.T) {
 	// Define the products used in common by the test cases
@@ -290,14 +292,18 @@ func TestProductHandler_GetAll(t *testing.T) {
 
 	// Each test case is constructed by:
 	// testName            — human‐readable description
-	// serviceData         — the Products slice returned by the mocked service
+	// target              — the request URL with its query string
+	// expectedOpts        — the query options we expect the service to receive, nil if it is not called
+	// serviceData         — the Products page returned by the mocked service
 	// serviceError        — the error returned by the mocked service
 	// expectedCode        — HTTP status code we expect the handler to produce
 	// expectedHeaders     — HTTP headers we expect in the HTTP response
 	// expectedBody        — JSON body (string) we expect in the HTTP response
 	tests := []struct {
 		testName        string
-		serviceData     []models.Product
+		target          string
+		expectedOpts    *models.QueryOptions
+		serviceData     models.Page[models.Product]
 		serviceError    error
 		expectedCode    int
 		expectedHeaders http.Header
@@ -305,7 +311,9 @@ func TestProductHandler_GetAll(t *testing.T) {
 	}{
 		{
 			testName:        "Success: Get all products",
-			serviceData:     []models.Product{product1, product2},
+			target:          "/",
+			expectedOpts:    &models.QueryOptions{Limit: 50},
+			serviceData:     models.NewPage([]models.Product{product1, product2}, 2, nil),
 			serviceError:    nil,
 			expectedCode:    http.StatusOK,
 			expectedHeaders: http.Header{"Content-Type": []string{"application/json"}},
@@ -340,24 +348,139 @@ func TestProductHandler_GetAll(t *testing.T) {
 						"product_type_id": 7,
 						"seller_id": 2
 					}
-				]
+				],
+				"meta": {
+					"next_cursor": null,
+					"total": 2
+				}
+			}`,
+		},
+		{
+			testName: "Success: Get a filtered and sorted page of products",
+			target:   "/?limit=1&sort=product_code&order=desc&seller_id=1&product_type_id=3",
+			expectedOpts: &models.QueryOptions{
+				Limit:   1,
+				Sort:    "product_code",
+				Desc:    true,
+				Filters: map[string]string{"seller_id": "1", "product_type_id": "3"},
+			},
+			serviceData:     models.NewPage([]models.Product{product1}, 2, &models.Cursor{Sort: "product_code", Value: "YOG01", ID: 1}),
+			serviceError:    nil,
+			expectedCode:    http.StatusOK,
+			expectedHeaders: http.Header{"Content-Type": []string{"application/json"}},
+			expectedBody: `
+			{
+				"data": [
+					{
+						"id": 1,
+						"description": "Yogurt helado",
+						"expiration_rate": 7,
+						"freezing_rate": 2,
+						"height": 10.5,
+						"length": 20.0,
+						"width": 15.0,
+						"netweight": 1.2,
+						"product_code": "YOG01",
+						"recommended_freezing_temperature": -5.0,
+						"product_type_id": 3,
+						"seller_id": 1
+					}
+				],
+				"meta": {
+					"next_cursor": "WyJwcm9kdWN0X2NvZGUiLCJzOllPRzAxIiwiaToxIl0",
+					"total": 2
+				}
 			}`,
 		},
+		{
+			testName:        "Error case: Invalid limit",
+			target:          "/?limit=0",
+			expectedCode:    http.StatusBadRequest,
+			expectedHeaders: http.Header{"Content-Type": []string{"application/json"}},
+			expectedBody: `
+				{
+					"status": "Bad Request",
+					"message": "Invalid limit"
+				}
+			`,
+		},
+		{
+			testName:        "Error case: Invalid cursor",
+			target:          "/?cursor=not-a-cursor",
+			expectedCode:    http.StatusBadRequest,
+			expectedHeaders: http.Header{"Content-Type": []string{"application/json"}},
+			expectedBody: `
+				{
+					"status": "Bad Request",
+					"message": "Invalid cursor"
+				}
+			`,
+		},
+		{
+			testName:        "Error case: Invalid seller_id filter",
+			target:          "/?seller_id=abc",
+			expectedCode:    http.StatusBadRequest,
+			expectedHeaders: http.Header{"Content-Type": []string{"application/json"}},
+			expectedBody: `
+				{
+					"status": "Bad Request",
+					"message": "Invalid seller_id"
+				}
+			`,
+		},
+		{
+			testName:        "Error case: Invalid product_type_id filter",
+			target:          "/?product_type_id=-3",
+			expectedCode:    http.StatusBadRequest,
+			expectedHeaders: http.Header{"Content-Type": []string{"application/json"}},
+			expectedBody: `
+				{
+					"status": "Bad Request",
+					"message": "Invalid product_type_id"
+				}
+			`,
+		},
+		{
+			testName:        "Success: ID filters are normalized",
+			target:          "/?seller_id=007",
+			expectedOpts:    &models.QueryOptions{Limit: 50, Filters: map[string]string{"seller_id": "7"}},
+			serviceData:     models.NewPage([]models.Product{}, 0, nil),
+			serviceError:    nil,
+			expectedCode:    http.StatusOK,
+			expectedHeaders: http.Header{"Content-Type": []string{"application/json"}},
+			expectedBody: `
+				{
+					"data": [],
+					"meta": {
+						"next_cursor": null,
+						"total": 0
+					}
+				}
+			`,
+		},
 		{
 			testName:        "Success: Get an empty list if the DB is empty",
-			serviceData:     []models.Product{},
+			target:          "/",
+			expectedOpts:    &models.QueryOptions{Limit: 50},
+			serviceData:     models.NewPage([]models.Product{}, 0, nil),
 			serviceError:    nil,
 			expectedCode:    http.StatusOK,
 			expectedHeaders: http.Header{"Content-Type": []string{"application/json"}},
 			expectedBody: `
 				{
-					"data": []
+					"data": [],
+					"meta": {
+						"next_cursor": null,
+						"total": 0
+					}
 				}
 			`,
 		},
 		{
 			testName:        "Error case: Process an error from the service layer",
-			serviceData:     nil,
+			target:          "/",
+			expectedOpts:    &models.QueryOptions{Limit: 50},
+			serviceData:     models.Page[models.Product]{},
 			serviceError:    errors.New("db error"),
 			expectedCode:    http.StatusInternalServerError,
 			expectedHeaders: http.Header{"Content-Type": []string{"application/json"}},
@@ -373,9 +496,11 @@ func TestProductHandler_GetAll(t *testing.T) {
 		t.Run(tc.testName, func(t *testing.T) {
 			// Arrange
 			serviceMock := &mocks.ProductServiceMock{}
-			serviceMock.On("GetAll", mock.Anything).Return(tc.serviceData, tc.serviceError)
+			if tc.expectedOpts != nil {
+				serviceMock.On("GetAll", mock.Anything, *tc.expectedOpts).Return(tc.serviceData, tc.serviceError)
+			}
 			handler := handler.NewProductHandler(serviceMock)
-			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request := httptest.NewRequest(http.MethodGet, tc.target, nil)
 			request.Header.Set("Content-Type", "application/json")
 			response := httptest.NewRecorder()
 
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator"
+)
+
+// ProductTypeHandler handles HTTP requests for product type resources.
+type ProductTypeHandler struct {
+	svc service.ProductTypeService
+}
+
+// NewProductTypeHandler constructs a new ProductTypeHandler with the given service.
+func NewProductTypeHandler(svc service.ProductTypeService) *ProductTypeHandler {
+	return &ProductTypeHandler{svc: svc}
+}
+
+// Create returns an http.HandlerFunc that decodes and validates a product type
+// and responds with the created product type.
+func (h ProductTypeHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var newProductType models.ProductTypeAttributes
+
+		// Decode JSON Body to a ProductTypeAttributes struct
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&newProductType); err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		// Delete trailing whitespaces before validating
+		newProductType.Description = strings.TrimSpace(newProductType.Description)
+		if err := validator.New().Struct(newProductType); err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Invalid JSON body")
+			return
+		}
+
+		productType, err := h.svc.Create(ctx, newProductType)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": productType,
+		})
+	}
+}
+
+// GetAll returns an http.HandlerFunc that writes a page of product types as JSON,
+// reading limit, cursor, sort and order from the query string.
+func (h ProductTypeHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		opts, err := parseQueryOptions(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		page, err := h.svc.GetAll(ctx, opts)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		pageJSON(w, page)
+	}
+}
+
+// GetByID returns an http.HandlerFunc that parses the product type ID
+// from the URL and writes the product type as JSON.
+func (h ProductTypeHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		productType, err := h.svc.GetByID(ctx, id)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": productType,
+		})
+	}
+}
+
+// Update returns an http.HandlerFunc that applies a partial update
+// to the product type identified by the id URL parameter.
+func (h ProductTypeHandler) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		// Decode JSON Body to a ProductTypePatchRequest struct
+		var patch models.ProductTypePatchRequest
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&patch); err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		// Delete trailing whitespaces before validating
+		if patch.Description != nil {
+			*patch.Description = strings.TrimSpace(*patch.Description)
+		}
+		if err := validator.New().Struct(patch); err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Invalid JSON body")
+			return
+		}
+
+		productType, err := h.svc.Update(ctx, id, patch)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": productType,
+		})
+	}
+}
+
+// Delete returns an http.HandlerFunc that removes the product type identified
+// by the id URL parameter and responds with no content.
+func (h ProductTypeHandler) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		if err := h.svc.Delete(ctx, id); err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
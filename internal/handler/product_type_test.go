@@ -0,0 +1,146 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/handler"
+	mocks "github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Verifies the behavior of the HTTP handler responsible for creating a new product type. It covers:
+// - Successful creation, trimming the description
+// - Error when the description is blank or unknown fields are given
+// - Error propagation from the service layer
+func TestProductTypeHandler_Create(t *testing.T) {
+	attributes := models.ProductTypeAttributes{Description: "Lácteos"}
+
+	tests := []struct {
+		testName       string
+		payload        string
+		isPayloadError bool
+		serviceData    models.ProductType
+		serviceError   error
+		expectedCode   int
+		expectedBody   string
+	}{
+		{
+			testName:     "Success: Create a new product type",
+			payload:      `{"description": "  Lácteos "}`,
+			serviceData:  models.ProductType{ID: 1, ProductTypeAttributes: attributes},
+			expectedCode: http.StatusCreated,
+			expectedBody: `{"data": {"id": 1, "description": "Lácteos"}}`,
+		},
+		{
+			testName:       "Error case: Blank description",
+			payload:        `{"description": "   "}`,
+			isPayloadError: true,
+			expectedCode:   http.StatusUnprocessableEntity,
+			expectedBody:   `{"status": "Unprocessable Entity", "message": "Invalid JSON body"}`,
+		},
+		{
+			testName:       "Error case: Unknown JSON fields",
+			payload:        `{"description": "Lácteos", "code": 1}`,
+			isPayloadError: true,
+			expectedCode:   http.StatusBadRequest,
+			expectedBody:   `{"status": "Bad Request", "message": "Invalid JSON body"}`,
+		},
+		{
+			testName:     "Error case: Duplicated description",
+			payload:      `{"description": "Lácteos"}`,
+			serviceError: httperrors.ConflictError{Message: "Product type description already exists."},
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"status": "Conflict", "message": "Product type description already exists."}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Arrange
+			serviceMock := &mocks.ProductTypeServiceMock{}
+			if !tc.isPayloadError {
+				serviceMock.
+					On("Create", mock.Anything, attributes).
+					Return(tc.serviceData, tc.serviceError)
+			}
+
+			handler := handler.NewProductTypeHandler(serviceMock)
+			request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.payload))
+			response := httptest.NewRecorder()
+
+			// Act
+			handler.Create()(response, request)
+
+			// Assert
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+// Verifies the behavior of the HTTP handler responsible for deleting a product type. It covers:
+// - Successful deletion
+// - Error when the ID is invalid
+// - Error when the product type is still in use
+func TestProductTypeHandler_Delete(t *testing.T) {
+	tests := []struct {
+		testName     string
+		id           string
+		callsService bool
+		serviceError error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			testName:     "Success: Delete a product type",
+			id:           "1",
+			callsService: true,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			testName:     "Error case: Invalid ID",
+			id:           "abc",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid ID"}`,
+		},
+		{
+			testName:     "Error case: Product type still in use",
+			id:           "1",
+			callsService: true,
+			serviceError: httperrors.ConflictError{Message: "Product type is still referenced by products or sections."},
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"status": "Conflict", "message": "Product type is still referenced by products or sections."}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Arrange
+			serviceMock := &mocks.ProductTypeServiceMock{}
+			if tc.callsService {
+				serviceMock.On("Delete", mock.Anything, 1).Return(tc.serviceError)
+			}
+
+			router := chi.NewRouter()
+			router.Delete("/{id}", handler.NewProductTypeHandler(serviceMock).Delete())
+			request := httptest.NewRequest(http.MethodDelete, "/"+tc.id, nil)
+			response := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(response, request)
+
+			// Assert
+			require.Equal(t, tc.expectedCode, response.Code)
+			if tc.expectedBody != "" {
+				require.JSONEq(t, tc.expectedBody, response.Body.String())
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
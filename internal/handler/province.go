@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
+)
+
+// ProvinceHandler handles HTTP requests for province resources.
+// Its handlers are the ones of regionHandler, GetAll also filters by country_id.
+type ProvinceHandler struct {
+	regionHandler[models.Province, models.ProvinceAttributes, models.ProvincePatchRequest]
+}
+
+// NewProvinceHandler constructs a new ProvinceHandler with the given service.
+func NewProvinceHandler(svc service.ProvinceService) *ProvinceHandler {
+	return &ProvinceHandler{regionHandler[models.Province, models.ProvinceAttributes, models.ProvincePatchRequest]{
+		svc:     svc,
+		filters: []queryFilter{idFilter("country_id")},
+		trimAttributes: func(province *models.ProvinceAttributes) {
+			province.ProvinceName = strings.TrimSpace(province.ProvinceName)
+		},
+		trimPatch: func(patch *models.ProvincePatchRequest) {
+			if patch.ProvinceName != nil {
+				*patch.ProvinceName = strings.TrimSpace(*patch.ProvinceName)
+			}
+		},
+	}}
+}
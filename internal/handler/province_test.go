@@ -0,0 +1,212 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/handler"
+	mocks "github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProvinceHandler_Create(t *testing.T) {
+	tests := []struct {
+		testName       string
+		body           string
+		serviceRequest *models.ProvinceAttributes
+		serviceResult  models.Province
+		serviceError   error
+		expectedCode   int
+		expectedBody   string
+	}{
+		{
+			testName:       "creates the province and returns it",
+			body:           `{"province_name": " Santa Fe ", "country_id": 1}`,
+			serviceRequest: &models.ProvinceAttributes{ProvinceName: "Santa Fe", CountryID: 1},
+			serviceResult:  models.Province{ID: 3, ProvinceAttributes: models.ProvinceAttributes{ProvinceName: "Santa Fe", CountryID: 1}},
+			expectedCode:   http.StatusCreated,
+			expectedBody:   `{"data": {"id": 3, "province_name": "Santa Fe", "country_id": 1}}`,
+		},
+		{
+			testName:       "missing country returns StatusConflict",
+			body:           `{"province_name": "Santa Fe", "country_id": 9}`,
+			serviceRequest: &models.ProvinceAttributes{ProvinceName: "Santa Fe", CountryID: 9},
+			serviceError:   httperrors.ConflictError{Message: "Country does not exist"},
+			expectedCode:   http.StatusConflict,
+			expectedBody:   `{"status": "Conflict", "message": "Country does not exist"}`,
+		},
+		{
+			testName:     "missing country_id returns StatusUnprocessableEntity",
+			body:         `{"province_name": "Santa Fe"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"status": "Unprocessable Entity", "message": "Invalid JSON body"}`,
+		},
+		{
+			testName:     "free text country returns StatusBadRequest",
+			body:         `{"province_name": "Santa Fe", "country_name": "Argentina"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid JSON body"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.ProvinceServiceMock)
+			provinceHandler := handler.NewProvinceHandler(serviceMock)
+			if tc.serviceRequest != nil {
+				serviceMock.On("Create", mock.Anything, *tc.serviceRequest).Return(tc.serviceResult, tc.serviceError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+
+			// act
+			provinceHandler.Create().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProvinceHandler_GetSellerReport(t *testing.T) {
+	tests := []struct {
+		testName      string
+		query         string
+		serviceID     *int
+		callsService  bool
+		serviceResult []models.RegionSellerReport
+		serviceError  error
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			testName:      "reports every province without id",
+			callsService:  true,
+			serviceResult: []models.RegionSellerReport{{ID: 1, Name: "Buenos Aires", SellersCount: 2}, {ID: 2, Name: "CABA", SellersCount: 0}},
+			expectedCode:  http.StatusOK,
+			expectedBody: `{"data": [{"id": 1, "name": "Buenos Aires", "sellers_count": 2},
+				{"id": 2, "name": "CABA", "sellers_count": 0}]}`,
+		},
+		{
+			testName:     "unknown province returns StatusNotFound",
+			query:        "?id=9",
+			serviceID:    utils.Ptr(9),
+			callsService: true,
+			serviceError: httperrors.NotFoundError{Message: "Province not found"},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"status": "Not Found", "message": "Province not found"}`,
+		},
+		{
+			testName:     "invalid id returns StatusBadRequest",
+			query:        "?id=abc",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid ID"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.ProvinceServiceMock)
+			provinceHandler := handler.NewProvinceHandler(serviceMock)
+			if tc.callsService {
+				serviceMock.On("GetSellerReport", mock.Anything, tc.serviceID).Return(tc.serviceResult, tc.serviceError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/reportSellers"+tc.query, nil)
+			rec := httptest.NewRecorder()
+
+			// act
+			provinceHandler.GetSellerReport().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProvinceHandler_GetAll(t *testing.T) {
+	t.Run("filters the provinces by country", func(t *testing.T) {
+		// arrange
+		serviceMock := new(mocks.ProvinceServiceMock)
+		provinceHandler := handler.NewProvinceHandler(serviceMock)
+		page := models.NewPage([]models.Province{
+			{ID: 1, ProvinceAttributes: models.ProvinceAttributes{ProvinceName: "Buenos Aires", CountryID: 1}},
+		}, 1, nil)
+		serviceMock.On("GetAll", mock.Anything, models.QueryOptions{Limit: 50, Filters: map[string]string{"country_id": "1"}}).Return(page, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/?country_id=1", nil)
+		rec := httptest.NewRecorder()
+
+		// act
+		provinceHandler.GetAll().ServeHTTP(rec, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"data": [{"id": 1, "province_name": "Buenos Aires", "country_id": 1}],
+			"meta": {"next_cursor": null, "total": 1}}`, rec.Body.String())
+		serviceMock.AssertExpectations(t)
+	})
+}
+
+func TestProvinceHandler_Delete(t *testing.T) {
+	tests := []struct {
+		testName     string
+		serviceError error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			testName:     "removes the province",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			testName:     "province still referenced by localities returns StatusConflict",
+			serviceError: httperrors.ConflictError{Message: "Province is still referenced by localities."},
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"status": "Conflict", "message": "Province is still referenced by localities."}`,
+		},
+		{
+			testName:     "missing province returns StatusNotFound",
+			serviceError: httperrors.NotFoundError{Message: "Province not found"},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"status": "Not Found", "message": "Province not found"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.ProvinceServiceMock)
+			provinceHandler := handler.NewProvinceHandler(serviceMock)
+			serviceMock.On("Delete", mock.Anything, 1).Return(tc.serviceError)
+
+			req := addChiURLParam(httptest.NewRequest(http.MethodDelete, "/1", nil), "id", "1")
+			rec := httptest.NewRecorder()
+
+			// act
+			provinceHandler.Delete().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectedBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
@@ -2,7 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
@@ -10,6 +13,7 @@ import (
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/utils"
 	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator"
 )
 
@@ -62,3 +66,137 @@ func (h *PurchaseOrderHandler) Create() http.HandlerFunc {
 		})
 	}
 }
+
+// GetAll returns a page of purchase orders, optionally filtered by buyer_id, status and carry_id
+func (h *PurchaseOrderHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		opts, err := parseQueryOptions(r,
+			idFilter("buyer_id"),
+			enumFilter("status",
+				models.PurchaseOrderStatusPending,
+				models.PurchaseOrderStatusConfirmed,
+				models.PurchaseOrderStatusPicked,
+				models.PurchaseOrderStatusShipped,
+				models.PurchaseOrderStatusDelivered,
+				models.PurchaseOrderStatusCancelled,
+			),
+			idFilter("carry_id"),
+		)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		page, err := h.service.GetAll(ctx, opts)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		pageJSON(w, page)
+	}
+}
+
+// GetByID returns the purchase order identified by the id URL parameter
+func (h *PurchaseOrderHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		purchaseOrder, err := h.service.GetByID(ctx, id)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": purchaseOrder,
+		})
+	}
+}
+
+// Transition moves the purchase order identified by the id URL parameter
+// to the status given in the body
+func (h *PurchaseOrderHandler) Transition() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		var transition models.PurchaseOrderTransitionRequest
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&transition); err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		transition.Status = strings.TrimSpace(transition.Status)
+		if err := validator.New().Struct(transition); err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Invalid status")
+			return
+		}
+
+		purchaseOrder, err := h.service.Transition(ctx, id, transition.Status)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": purchaseOrder,
+		})
+	}
+}
+
+// AssignCarry hands the purchase order identified by the id URL parameter to the
+// carry given in the body. An empty body lets the carry be picked by the buyer's delivery locality
+func (h *PurchaseOrderHandler) AssignCarry() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		var request models.PurchaseOrderCarryRequest
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+			response.Error(w, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		if err := validator.New().Struct(request); err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Invalid carry_id")
+			return
+		}
+
+		purchaseOrder, err := h.service.AssignCarry(ctx, id, request.CarryId)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": purchaseOrder,
+		})
+	}
+}
@@ -13,7 +13,9 @@ import (
 	mocks "github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/service"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -32,7 +34,7 @@ func TestPurchaseOrderHandler_Create(t *testing.T) {
 			"order_date": "2023-04-04T15:04:05",
 			"tracking_code": "abc123asd",
 			"buyer_id": 1,
-			"product_record_id": 1
+			"lines": [{"product_id": 1, "quantity": 2}]
 		}`) // order_date must have the the format "2025-04-04T15:04:05Z"
 
 		req := httptest.NewRequest(http.MethodPost, "/", invalidBody)
@@ -54,7 +56,7 @@ func TestPurchaseOrderHandler_Create(t *testing.T) {
 			"order_date": "3000-04-04T15:04:05Z",
 			"tracking_code": "abc123asd",
 			"buyer_id": 1,
-			"product_record_id": 1
+			"lines": [{"product_id": 1, "quantity": 2}]
 		}`) // order_date is setted into the future
 
 		req := httptest.NewRequest(http.MethodPost, "/", invalidBody)
@@ -68,6 +70,31 @@ func TestPurchaseOrderHandler_Create(t *testing.T) {
 		serviceMock.AssertNumberOfCalls(t, "Create", 0)
 	})
 
+	t.Run("passing a JSON body without lines or with invalid lines returns StatusUnprocessableEntity", func(t *testing.T) {
+		for _, lines := range []string{`[]`, `[{"product_id": 1, "quantity": 0}]`, `[{"quantity": 2}]`} {
+			serviceMock := mocks.NewPurchaseOrderDefaultMock()
+			purchaseOrderHandler := handler.NewPurchaseOrderHandler(serviceMock)
+
+			invalidBody := strings.NewReader(`{
+				"order_number": "ORD-1",
+				"order_date": "2023-04-04T15:04:05Z",
+				"tracking_code": "abc123asd",
+				"buyer_id": 1,
+				"lines": ` + lines + `
+			}`)
+
+			req := httptest.NewRequest(http.MethodPost, "/", invalidBody)
+			rec := httptest.NewRecorder()
+
+			// act
+			purchaseOrderHandler.Create().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, lines)
+			serviceMock.AssertNumberOfCalls(t, "Create", 0)
+		}
+	})
+
 	t.Run("the service return error ProductRecordID/BuyerID not found, handler returning a NotFound", func(t *testing.T) {
 		// arrange
 		serviceMock := mocks.NewPurchaseOrderDefaultMock()
@@ -75,11 +102,11 @@ func TestPurchaseOrderHandler_Create(t *testing.T) {
 
 		purchaseOrderDate := time.Date(2023, 4, 4, 15, 4, 5, 0, time.UTC)
 		purchaseOrderAtt := models.PurchaseOrderAttributes{
-			OrderNumber:     "ORD-1",
-			OrderDate:       purchaseOrderDate,
-			TrackingCode:    "abc123asd",
-			BuyerId:         1,
-			ProductRecordId: 1,
+			OrderNumber:  "ORD-1",
+			OrderDate:    purchaseOrderDate,
+			TrackingCode: "abc123asd",
+			BuyerId:      1,
+			Lines:        []models.PurchaseOrderLineAttributes{{ProductId: 1, Quantity: 2}},
 		}
 
 		validBody := strings.NewReader(`{
@@ -87,7 +114,7 @@ func TestPurchaseOrderHandler_Create(t *testing.T) {
 			"order_date": "2023-04-04T15:04:05Z",
 			"tracking_code": "abc123asd",
 			"buyer_id": 1,
-			"product_record_id": 1
+			"lines": [{"product_id": 1, "quantity": 2}]
 		}`)
 
 		req := httptest.NewRequest(http.MethodPost, "/", validBody)
@@ -112,16 +139,24 @@ func TestPurchaseOrderHandler_Create(t *testing.T) {
 
 		purchaseOrderDate := time.Date(2023, 4, 4, 15, 4, 5, 0, time.UTC)
 		purchaseOrderAtt := models.PurchaseOrderAttributes{
-			OrderNumber:     "ORD-1",
-			OrderDate:       purchaseOrderDate,
-			TrackingCode:    "abc123asd",
-			BuyerId:         1,
-			ProductRecordId: 1,
+			OrderNumber:  "ORD-1",
+			OrderDate:    purchaseOrderDate,
+			TrackingCode: "abc123asd",
+			BuyerId:      1,
+			Lines:        []models.PurchaseOrderLineAttributes{{ProductId: 1, Quantity: 2}},
 		}
 
 		expectedPurchaseOrder := models.PurchaseOrder{
-			Id:                      1,
-			PurchaseOrderAttributes: purchaseOrderAtt,
+			Id:           1,
+			OrderNumber:  purchaseOrderAtt.OrderNumber,
+			OrderDate:    purchaseOrderAtt.OrderDate,
+			TrackingCode: purchaseOrderAtt.TrackingCode,
+			BuyerId:      purchaseOrderAtt.BuyerId,
+			Lines: []models.PurchaseOrderLine{
+				{Id: 1, PurchaseOrderId: 1, ProductId: 1, Quantity: 2, UnitPrice: 10.5},
+			},
+			Total:  21,
+			Status: models.PurchaseOrderStatusPending,
 		}
 
 		validBody := strings.NewReader(`{
@@ -129,7 +164,7 @@ func TestPurchaseOrderHandler_Create(t *testing.T) {
 			"order_date": "2023-04-04T15:04:05Z",
 			"tracking_code": "abc123asd",
 			"buyer_id": 1,
-			"product_record_id": 1
+			"lines": [{"product_id": 1, "quantity": 2}]
 		}`)
 		req := httptest.NewRequest(http.MethodPost, "/", validBody)
 		rec := httptest.NewRecorder()
@@ -151,3 +186,132 @@ func TestPurchaseOrderHandler_Create(t *testing.T) {
 		serviceMock.AssertExpectations(t)
 	})
 }
+
+func TestPurchaseOrderHandler_Transition(t *testing.T) {
+	tests := []struct {
+		testName      string
+		id            string
+		body          string
+		serviceStatus string
+		serviceResult models.PurchaseOrder
+		serviceError  error
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			testName:      "moves the purchaseOrder and returns it",
+			id:            "1",
+			body:          `{"status": "confirmed"}`,
+			serviceStatus: models.PurchaseOrderStatusConfirmed,
+			serviceResult: models.PurchaseOrder{Id: 1, Status: models.PurchaseOrderStatusConfirmed},
+			expectedCode:  http.StatusOK,
+		},
+		{
+			testName:      "illegal transition returns StatusConflict",
+			id:            "1",
+			body:          `{"status": "delivered"}`,
+			serviceStatus: models.PurchaseOrderStatusDelivered,
+			serviceError:  httperrors.ConflictError{Message: "A pending purchase order cannot be delivered"},
+			expectedCode:  http.StatusConflict,
+			expectedBody:  `{"status": "Conflict", "message": "A pending purchase order cannot be delivered"}`,
+		},
+		{
+			testName:     "unknown status returns StatusUnprocessableEntity",
+			id:           "1",
+			body:         `{"status": "lost"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"status": "Unprocessable Entity", "message": "Invalid status"}`,
+		},
+		{
+			testName:     "invalid id returns StatusBadRequest",
+			id:           "abc",
+			body:         `{"status": "confirmed"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid ID"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := mocks.NewPurchaseOrderDefaultMock()
+			purchaseOrderHandler := handler.NewPurchaseOrderHandler(serviceMock)
+			if tc.serviceStatus != "" {
+				serviceMock.On("Transition", mock.Anything, 1, tc.serviceStatus).Return(tc.serviceResult, tc.serviceError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/"+tc.id+"/transitions", strings.NewReader(tc.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			// act
+			purchaseOrderHandler.Transition().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPurchaseOrderHandler_GetAll(t *testing.T) {
+	tests := []struct {
+		testName     string
+		target       string
+		expectedOpts *models.QueryOptions
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			testName: "filters by buyer, status and carry",
+			target:   "/?buyer_id=3&status=shipped&carry_id=04",
+			expectedOpts: &models.QueryOptions{Limit: 50, Filters: map[string]string{
+				"buyer_id": "3",
+				"status":   "shipped",
+				"carry_id": "4",
+			}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			testName:     "unknown status returns StatusBadRequest",
+			target:       "/?status=lost",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid status"}`,
+		},
+		{
+			testName:     "non numeric buyer_id returns StatusBadRequest",
+			target:       "/?buyer_id=abc",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid buyer_id"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := mocks.NewPurchaseOrderDefaultMock()
+			purchaseOrderHandler := handler.NewPurchaseOrderHandler(serviceMock)
+			if tc.expectedOpts != nil {
+				serviceMock.On("GetAll", mock.Anything, *tc.expectedOpts).Return(models.NewPage([]models.PurchaseOrder{}, 0, nil), nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			rec := httptest.NewRecorder()
+
+			// act
+			purchaseOrderHandler.GetAll().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
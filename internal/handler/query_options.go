@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/bootcamp-go/web/response"
+)
+
+const (
+	// defaultPageLimit is the page size of a listing when limit is not given
+	defaultPageLimit = 50
+	// maxPageLimit is the largest page size a client can ask for
+	maxPageLimit = 500
+)
+
+// queryFilter is an equality filter of a listing. parse checks a value of the
+// query string and returns it in the form the repositories compare, ok is false
+// when it does not match the type of the column.
+type queryFilter struct {
+	name  string
+	parse func(value string) (parsed string, ok bool)
+}
+
+// idFilter accepts the positive integer ID of a related resource. "007" is
+// normalized to "7", as the database would compare it.
+func idFilter(name string) queryFilter {
+	return queryFilter{name: name, parse: func(value string) (string, bool) {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			return "", false
+		}
+		return strconv.Itoa(id), true
+	}}
+}
+
+// localityFilter accepts a locality ID, a code of up to 20 characters.
+func localityFilter(name string) queryFilter {
+	return queryFilter{name: name, parse: func(value string) (string, bool) {
+		return value, len(value) <= 20
+	}}
+}
+
+// enumFilter accepts one of values.
+func enumFilter(name string, values ...string) queryFilter {
+	return queryFilter{name: name, parse: func(value string) (string, bool) {
+		return value, slices.Contains(values, value)
+	}}
+}
+
+// parseQueryOptions reads ?limit=&cursor=&sort=&order= and the given equality
+// filters from the query string. The sort field, and that the cursor was issued
+// for it, are checked by the repository.
+// Returns a BadRequestError describing the first invalid parameter.
+func parseQueryOptions(r *http.Request, filters ...queryFilter) (models.QueryOptions, error) {
+	query := r.URL.Query()
+	opts := models.QueryOptions{Limit: defaultPageLimit, Sort: query.Get("sort")}
+
+	if param := query.Get("limit"); param != "" {
+		limit, err := strconv.Atoi(param)
+		if err != nil || limit <= 0 || limit > maxPageLimit {
+			return models.QueryOptions{}, httperrors.BadRequestError{Message: "Invalid limit"}
+		}
+		opts.Limit = limit
+	}
+
+	if param := query.Get("cursor"); param != "" {
+		cursor, err := models.DecodeCursor(param)
+		if err != nil {
+			return models.QueryOptions{}, httperrors.BadRequestError{Message: "Invalid cursor"}
+		}
+		opts.After = &cursor
+	}
+
+	switch query.Get("order") {
+	case "", "asc":
+	case "desc":
+		opts.Desc = true
+	default:
+		return models.QueryOptions{}, httperrors.BadRequestError{Message: "Invalid order"}
+	}
+
+	for _, filter := range filters {
+		value := query.Get(filter.name)
+		if value == "" {
+			continue
+		}
+		parsed, ok := filter.parse(value)
+		if !ok {
+			return models.QueryOptions{}, httperrors.BadRequestError{Message: "Invalid " + filter.name}
+		}
+		if opts.Filters == nil {
+			opts.Filters = make(map[string]string)
+		}
+		opts.Filters[filter.name] = parsed
+	}
+	return opts, nil
+}
+
+// pageJSON writes a page of a listing with its meta.
+// An empty page is written as an empty array rather than null.
+func pageJSON[T any](w http.ResponseWriter, page models.Page[T]) {
+	if page.Data == nil {
+		page.Data = make([]T, 0)
+	}
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": page.Data,
+		"meta": page.Meta,
+	})
+}
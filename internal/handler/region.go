@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator"
+)
+
+// regionService is the part of CountryService and ProvinceService the region
+// handlers use, over regions T created from A and patched with P.
+type regionService[T, A, P any] interface {
+	Create(ctx context.Context, attributes A) (T, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[T], error)
+	GetByID(ctx context.Context, id int) (T, error)
+	Update(ctx context.Context, id int, patch P) (T, error)
+	Delete(ctx context.Context, id int) error
+	GetSellerReport(ctx context.Context, id *int) ([]models.RegionSellerReport, error)
+	GetCarryReport(ctx context.Context, id *int) ([]models.RegionCarryReport, error)
+}
+
+// regionHandler implements the HTTP handlers shared by CountryHandler and
+// ProvinceHandler.
+type regionHandler[T, A, P any] struct {
+	svc regionService[T, A, P]
+	// filters are the fields GetAll accepts as equality filters.
+	filters []queryFilter
+	// trimAttributes and trimPatch delete the surrounding whitespace of the
+	// names of a request before validating it.
+	trimAttributes func(*A)
+	trimPatch      func(*P)
+}
+
+// Create returns an http.HandlerFunc that decodes and validates a region
+// and responds with the created region.
+func (h regionHandler[T, A, P]) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var attributes A
+		if !decodeRegionBody(w, r, &attributes, h.trimAttributes) {
+			return
+		}
+
+		region, err := h.svc.Create(r.Context(), attributes)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": region,
+		})
+	}
+}
+
+// GetAll returns an http.HandlerFunc that writes a page of regions as JSON,
+// reading limit, cursor, sort, order and the filters from the query string.
+func (h regionHandler[T, A, P]) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseQueryOptions(r, h.filters...)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		page, err := h.svc.GetAll(r.Context(), opts)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		pageJSON(w, page)
+	}
+}
+
+// GetByID returns an http.HandlerFunc that parses the region ID
+// from the URL and writes the region as JSON.
+func (h regionHandler[T, A, P]) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := parseRegionID(r)
+		if !ok {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		region, err := h.svc.GetByID(r.Context(), id)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": region,
+		})
+	}
+}
+
+// Update returns an http.HandlerFunc that applies a partial update
+// to the region identified by the id URL parameter.
+func (h regionHandler[T, A, P]) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := parseRegionID(r)
+		if !ok {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		var patch P
+		if !decodeRegionBody(w, r, &patch, h.trimPatch) {
+			return
+		}
+
+		region, err := h.svc.Update(r.Context(), id, patch)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": region,
+		})
+	}
+}
+
+// Delete returns an http.HandlerFunc that removes the region identified
+// by the id URL parameter and responds with no content.
+func (h regionHandler[T, A, P]) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := parseRegionID(r)
+		if !ok {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		if err := h.svc.Delete(r.Context(), id); err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// GetSellerReport returns an http.HandlerFunc that writes the number of sellers
+// of each region, or only of the region in the optional id query parameter.
+func (h regionHandler[T, A, P]) GetSellerReport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := parseReportID(r)
+		if !ok {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		reports, err := h.svc.GetSellerReport(r.Context(), id)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": reports,
+		})
+	}
+}
+
+// GetCarryReport returns an http.HandlerFunc that writes the number of carries
+// of each region, or only of the region in the optional id query parameter.
+func (h regionHandler[T, A, P]) GetCarryReport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := parseReportID(r)
+		if !ok {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		reports, err := h.svc.GetCarryReport(r.Context(), id)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": reports,
+		})
+	}
+}
+
+// decodeRegionBody decodes the JSON body into dst, rejecting unknown fields,
+// trims it and validates it. On failure it writes the error response and
+// returns false.
+func decodeRegionBody[B any](w http.ResponseWriter, r *http.Request, dst *B, trim func(*B)) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON body")
+		return false
+	}
+
+	// Delete trailing whitespaces before validating
+	trim(dst)
+	if err := validator.New().Struct(*dst); err != nil {
+		response.Error(w, http.StatusUnprocessableEntity, "Invalid JSON body")
+		return false
+	}
+	return true
+}
+
+// parseRegionID reads the id URL parameter, ok is false when it is not a
+// positive integer.
+func parseRegionID(r *http.Request) (id int, ok bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// parseReportID reads the optional id query parameter of the province and
+// country reports. Returns nil when it is omitted and ok false when it is not
+// a positive integer.
+func parseReportID(r *http.Request) (id *int, ok bool) {
+	idAsString := r.URL.Query().Get("id")
+	if idAsString == "" {
+		return nil, true
+	}
+	val, err := strconv.Atoi(idAsString)
+	if err != nil || val <= 0 {
+		return nil, false
+	}
+	return &val, true
+}
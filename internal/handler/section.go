@@ -13,6 +13,9 @@ import (
 	"github.com/go-playground/validator"
 )
 
+// defaultExpiringDays is the window of the expiring batches report when days is not given
+const defaultExpiringDays = 7
+
 // SectionHandler handles section operations
 type SectionHandler struct {
 	sectionService service.SectionService
@@ -23,19 +26,32 @@ func NewSectionHandler(sectionService service.SectionService) *SectionHandler {
 	return &SectionHandler{sectionService: sectionService}
 }
 
-// GetAll, returns all sections
+// GetAll, returns a page of sections
 // @Summary Get all sections
-// @Description Get all sections
+// @Description Get a page of sections, optionally sorted and filtered
 // @Tags sections
 // @Accept json
 // @Produce json
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor of the page"
+// @Param sort query string false "Field to sort by"
+// @Param order query string false "asc or desc"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param product_type_id query int false "Product type ID"
 // @Success 200 {array} models.Section
 // @Router /sections [get]
 func (handler *SectionHandler) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		// Get all sections from the repository
-		data, err := handler.sectionService.GetAll(ctx)
+		// Parse pagination, sorting and filters
+		opts, err := parseQueryOptions(r, idFilter("warehouse_id"), idFilter("product_type_id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Get the page of sections from the repository
+		page, err := handler.sectionService.GetAll(ctx, opts)
 		// Check for errors
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
@@ -43,10 +59,8 @@ func (handler *SectionHandler) GetAll() http.HandlerFunc {
 			return
 		}
 
-		// Return all sections
-		response.JSON(w, http.StatusOK, map[string]any{
-			"data": data,
-		})
+		// Return the page of sections
+		pageJSON(w, page)
 	}
 }
 
@@ -181,7 +195,7 @@ func (handler *SectionHandler) Create() http.HandlerFunc {
 
 // Update, updates a section in the repository
 // @Summary Update a section
-// @Description Update a section
+// @Description Update a section. current_capacity is kept by its product batches, so maximum_capacity cannot go below it.
 // @Tags sections
 // @Accept json
 // @Produce json
@@ -285,3 +299,85 @@ func (handler *SectionHandler) GetProductsReport() http.HandlerFunc {
 		})
 	}
 }
+
+// GetExpiringBatchesReport lists the product batches about to expire.
+// @Summary Get expiring batches report
+// @Description List the batches with stock whose due date falls within the next days days, grouped by section, soonest first.
+// @Tags sections
+// @Produce json
+// @Param days query int false "Days ahead to look (default 7)"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Success 200 {object} map[string][]models.SectionExpiringBatchesReport
+// @Router /sections/reportExpiringBatches [get]
+func (handler *SectionHandler) GetExpiringBatchesReport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		query := r.URL.Query()
+
+		days := defaultExpiringDays
+		if param := query.Get("days"); param != "" {
+			value, err := strconv.Atoi(param)
+			if err != nil || value < 0 {
+				response.Error(w, http.StatusBadRequest, "Invalid days")
+				return
+			}
+			days = value
+		}
+
+		warehouseID := 0
+		if param := query.Get("warehouse_id"); param != "" {
+			id, err := strconv.Atoi(param)
+			if err != nil || id <= 0 {
+				response.Error(w, http.StatusBadRequest, "Invalid warehouse ID")
+				return
+			}
+			warehouseID = id
+		}
+
+		reports, err := handler.sectionService.GetExpiringBatchesReport(ctx, days, warehouseID)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": reports,
+		})
+	}
+}
+
+// GetCapacityReport shows how full the sections are.
+// @Summary Get capacity report
+// @Description Show the utilization of every section grouped by warehouse, flagging sections below their minimum capacity.
+// @Tags sections
+// @Produce json
+// @Param warehouse_id query int false "Warehouse ID"
+// @Success 200 {object} map[string][]models.WarehouseCapacityReport
+// @Router /sections/reportCapacity [get]
+func (handler *SectionHandler) GetCapacityReport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		warehouseID := 0
+		if param := r.URL.Query().Get("warehouse_id"); param != "" {
+			id, err := strconv.Atoi(param)
+			if err != nil || id <= 0 {
+				response.Error(w, http.StatusBadRequest, "Invalid warehouse ID")
+				return
+			}
+			warehouseID = id
+		}
+
+		reports, err := handler.sectionService.GetCapacityReport(ctx, warehouseID)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": reports,
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/utils"
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator"
+)
+
+const (
+	// maxReadingsPerRequest caps the size of a bulk ingestion request
+	maxReadingsPerRequest = 1000
+	// defaultReadingsWindow is the time range of a series when from is not given
+	defaultReadingsWindow = 24 * time.Hour
+)
+
+// SectionTemperatureReadingHandler handles the temperature telemetry of sections
+type SectionTemperatureReadingHandler struct {
+	readingService service.SectionTemperatureReadingService
+}
+
+// NewSectionTemperatureReadingHandler returns a new SectionTemperatureReadingHandler
+func NewSectionTemperatureReadingHandler(readingService service.SectionTemperatureReadingService) *SectionTemperatureReadingHandler {
+	return &SectionTemperatureReadingHandler{readingService: readingService}
+}
+
+// CreateBulk ingests a batch of temperature readings for a section
+// @Summary Ingest temperature readings
+// @Description Accepts a JSON array of readings, or one reading per line with Content-Type application/x-ndjson.
+// @Description Invalid readings are rejected one by one and reported, the valid ones are stored.
+// @Tags sections
+// @Accept json
+// @Produce json
+// @Param id path int true "Section ID"
+// @Param readings body []models.CreateTemperatureReadingRequest true "Readings to ingest"
+// @Success 201 {object} models.TemperatureReadingsIngestion
+// @Router /sections/{id}/readings [post]
+func (handler *SectionTemperatureReadingHandler) CreateBulk() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		requests, err := decodeTemperatureReadings(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid body")
+			return
+		}
+		if len(requests) == 0 {
+			response.Error(w, http.StatusUnprocessableEntity, "No readings in body")
+			return
+		}
+		if len(requests) > maxReadingsPerRequest {
+			response.Error(w, http.StatusUnprocessableEntity, fmt.Sprintf("Too many readings, the limit is %d per request", maxReadingsPerRequest))
+			return
+		}
+
+		// Validate every reading on its own, a bad sample must not drop the whole batch
+		validate := validator.New()
+		validate.RegisterValidation("notfuture", utils.NotFutureDatetime)
+		validate.RegisterTagNameFunc(jsonFieldName)
+
+		accepted := make([]models.CreateTemperatureReadingRequest, 0, len(requests))
+		ingestion := models.TemperatureReadingsIngestion{Rejected: []models.RejectedTemperatureReading{}}
+		for index, request := range requests {
+			if err := validate.Struct(request); err != nil {
+				ingestion.Rejected = append(ingestion.Rejected, models.RejectedTemperatureReading{
+					Index:   index,
+					Message: validationMessage(err),
+				})
+				continue
+			}
+			accepted = append(accepted, request)
+		}
+		if len(accepted) == 0 {
+			response.JSON(w, http.StatusUnprocessableEntity, map[string]any{
+				"data": ingestion,
+			})
+			return
+		}
+
+		created, err := handler.readingService.CreateBulk(ctx, id, accepted)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+		ingestion.Accepted = len(created)
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": ingestion,
+		})
+	}
+}
+
+// GetSeries returns the temperature time series of a section
+// @Summary Get temperature readings of a section
+// @Description Get the readings between from and to (RFC 3339, default the last 24 hours) with min/max/avg.
+// @Description With interval (e.g. 15m, 1h) the readings are aggregated in buckets instead.
+// @Tags sections
+// @Produce json
+// @Param id path int true "Section ID"
+// @Param from query string false "Start of the range (RFC 3339)"
+// @Param to query string false "End of the range (RFC 3339)"
+// @Param interval query string false "Bucket size, at least 1m"
+// @Success 200 {object} models.SectionTemperatureSeries
+// @Router /sections/{id}/readings [get]
+func (handler *SectionTemperatureReadingHandler) GetSeries() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+		query := r.URL.Query()
+
+		to := time.Now()
+		if param := query.Get("to"); param != "" {
+			if to, err = time.Parse(time.RFC3339, param); err != nil {
+				response.Error(w, http.StatusBadRequest, "Invalid to, expected RFC 3339")
+				return
+			}
+		}
+		from := to.Add(-defaultReadingsWindow)
+		if param := query.Get("from"); param != "" {
+			if from, err = time.Parse(time.RFC3339, param); err != nil {
+				response.Error(w, http.StatusBadRequest, "Invalid from, expected RFC 3339")
+				return
+			}
+		}
+		if from.After(to) {
+			response.Error(w, http.StatusBadRequest, "from must not be after to")
+			return
+		}
+
+		var interval time.Duration
+		if param := query.Get("interval"); param != "" {
+			interval, err = time.ParseDuration(param)
+			if err != nil || interval < time.Minute {
+				response.Error(w, http.StatusBadRequest, "Invalid interval, expected a duration of at least 1m")
+				return
+			}
+		}
+
+		series, err := handler.readingService.GetSeries(ctx, id, from, to, interval)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": series,
+		})
+	}
+}
+
+// decodeTemperatureReadings reads a JSON array of readings, or NDJSON
+// when the request says so in its Content-Type.
+func decodeTemperatureReadings(r *http.Request) ([]models.CreateTemperatureReadingRequest, error) {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	contentType := r.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "application/x-ndjson") && !strings.HasPrefix(contentType, "application/ndjson") {
+		var requests []models.CreateTemperatureReadingRequest
+		if err := dec.Decode(&requests); err != nil {
+			return nil, err
+		}
+		return requests, nil
+	}
+
+	var requests []models.CreateTemperatureReadingRequest
+	for {
+		var request models.CreateTemperatureReadingRequest
+		err := dec.Decode(&request)
+		if errors.Is(err, io.EOF) {
+			return requests, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+}
+
+// jsonFieldName makes validation errors use the json name of the field
+func jsonFieldName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+// validationMessage describes the first failed rule of a validation error
+func validationMessage(err error) string {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		return fmt.Sprintf("%s failed the %s validation", validationErrors[0].Field(), validationErrors[0].Tag())
+	}
+	return "Invalid reading"
+}
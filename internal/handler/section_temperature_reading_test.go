@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+// TestSectionTemperatureReadingHandler_CreateBulk tests the JSON and NDJSON ingestion
+func TestSectionTemperatureReadingHandler_CreateBulk(t *testing.T) {
+	tests := []struct {
+		testName      string
+		contentType   string
+		requestBody   string
+		serviceCalls  int
+		serviceOutput []models.SectionTemperatureReading
+		serviceError  error
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			testName:      "Success: JSON array",
+			contentType:   "application/json",
+			requestBody:   `[{"temperature": -18, "recorded_at": "2026-01-01T10:00:00Z"}, {"temperature": 0, "recorded_at": "2026-01-01T10:01:00Z"}]`,
+			serviceCalls:  2,
+			serviceOutput: []models.SectionTemperatureReading{{ID: 1}, {ID: 2}},
+			expectedCode:  http.StatusCreated,
+			expectedBody:  `{"data": {"accepted": 2, "rejected": []}}`,
+		},
+		{
+			testName:    "Success: NDJSON with a rejected reading",
+			contentType: "application/x-ndjson",
+			requestBody: `{"temperature": -18, "recorded_at": "2026-01-01T10:00:00Z"}
+{"temperature": -300, "recorded_at": "2026-01-01T10:01:00Z"}
+{"recorded_at": "2026-01-01T10:02:00Z"}
+`,
+			serviceCalls:  1,
+			serviceOutput: []models.SectionTemperatureReading{{ID: 1}},
+			expectedCode:  http.StatusCreated,
+			expectedBody: `{"data": {"accepted": 1, "rejected": [
+				{"index": 1, "message": "temperature failed the gte validation"},
+				{"index": 2, "message": "temperature failed the required validation"}
+			]}}`,
+		},
+		{
+			testName:     "Fail: every reading rejected",
+			contentType:  "application/json",
+			requestBody:  `[{"temperature": -18, "recorded_at": "2999-01-01T10:00:00Z"}]`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"data": {"accepted": 0, "rejected": [{"index": 0, "message": "recorded_at failed the notfuture validation"}]}}`,
+		},
+		{
+			testName:     "Fail: empty array",
+			contentType:  "application/json",
+			requestBody:  `[]`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"status": "Unprocessable Entity", "message": "No readings in body"}`,
+		},
+		{
+			testName:     "Fail: malformed body",
+			contentType:  "application/json",
+			requestBody:  `{"temperature": -18}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid body"}`,
+		},
+		{
+			testName:     "Fail: section not found",
+			contentType:  "application/json",
+			requestBody:  `[{"temperature": -18, "recorded_at": "2026-01-01T10:00:00Z"}]`,
+			serviceCalls: 1,
+			serviceError: httperrors.NotFoundError{Message: "Section not found"},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"status": "Not Found", "message": "Section not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			mockService := new(mocks.SectionTemperatureReadingServiceMock)
+			if tt.serviceCalls > 0 {
+				mockService.On("CreateBulk", testifyMock.Anything, 1, testifyMock.MatchedBy(func(readings []models.CreateTemperatureReadingRequest) bool {
+					return len(readings) == tt.serviceCalls
+				})).Return(tt.serviceOutput, tt.serviceError)
+			}
+
+			handler := NewSectionTemperatureReadingHandler(mockService)
+			router := chi.NewRouter()
+			router.Post("/api/v1/sections/{id}/readings", handler.CreateBulk())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/sections/1/readings", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", tt.contentType)
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, req)
+
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.JSONEq(t, tt.expectedBody, response.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestSectionTemperatureReadingHandler_GetSeries tests the query parameters of GetSeries
+func TestSectionTemperatureReadingHandler_GetSeries(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(6 * time.Hour)
+
+	tests := []struct {
+		testName     string
+		query        string
+		callsService bool
+		interval     time.Duration
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			testName:     "Success: range and interval",
+			query:        "?from=2026-01-01T00:00:00Z&to=2026-01-01T06:00:00Z&interval=1h",
+			callsService: true,
+			interval:     time.Hour,
+			expectedCode: http.StatusOK,
+		},
+		{
+			testName:     "Fail: inverted range",
+			query:        "?from=2026-01-01T06:00:00Z&to=2026-01-01T00:00:00Z",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "from must not be after to"}`,
+		},
+		{
+			testName:     "Fail: interval too small",
+			query:        "?from=2026-01-01T00:00:00Z&to=2026-01-01T06:00:00Z&interval=10s",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid interval, expected a duration of at least 1m"}`,
+		},
+		{
+			testName:     "Fail: invalid from",
+			query:        "?from=yesterday",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid from, expected RFC 3339"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			mockService := new(mocks.SectionTemperatureReadingServiceMock)
+			if tt.callsService {
+				mockService.On("GetSeries", testifyMock.Anything, 1, from, to, tt.interval).
+					Return(models.SectionTemperatureSeries{SectionID: 1}, nil)
+			}
+
+			handler := NewSectionTemperatureReadingHandler(mockService)
+			router := chi.NewRouter()
+			router.Get("/api/v1/sections/{id}/readings", handler.GetSeries())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/sections/1/readings"+tt.query, nil)
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, req)
+
+			assert.Equal(t, tt.expectedCode, response.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, response.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -152,14 +152,17 @@ func TestSectionHandler_GetAll(t *testing.T) {
 
 	tests := []struct {
 		testName      string
-		serviceOutput []models.Section
+		query         string
+		expectedOpts  models.QueryOptions
+		serviceOutput models.Page[models.Section]
 		serviceError  error
 		expectedCode  int
 		expectedBody  string
 	}{
 		{
 			testName:      "Success: Get All Sections",
-			serviceOutput: []models.Section{sectionResponse},
+			expectedOpts:  models.QueryOptions{Limit: defaultPageLimit},
+			serviceOutput: models.NewPage([]models.Section{sectionResponse}, 1, nil),
 			serviceError:  nil,
 			expectedCode:  http.StatusOK,
 			expectedBody: `{
@@ -169,15 +172,45 @@ func TestSectionHandler_GetAll(t *testing.T) {
                         "current_capacity": 50, "minimum_capacity": 10, "maximum_capacity": 100,
                         "warehouse_id": 1, "product_type_id": 1
                     }
-                ]
+                ],
+                "meta": {"next_cursor": null, "total": 1}
             }`,
 		},
 		{
-			testName:      "Fail: Service returns an error",
-			serviceOutput: nil,
-			serviceError:  errors.New("internal server error"),
-			expectedCode:  http.StatusInternalServerError,
-			expectedBody:  `{"status": "Internal Server Error", "message": "Internal Server Error"}`,
+			testName: "Success: Get a page of the sections of a warehouse",
+			query:    "?limit=1&sort=current_capacity&warehouse_id=1&product_type_id=1",
+			expectedOpts: models.QueryOptions{
+				Limit:   1,
+				Sort:    "current_capacity",
+				Filters: map[string]string{"warehouse_id": "1", "product_type_id": "1"},
+			},
+			serviceOutput: models.NewPage([]models.Section{sectionResponse}, 3, &models.Cursor{Sort: "current_capacity", Value: 50, ID: 1}),
+			expectedCode:  http.StatusOK,
+			expectedBody: `{
+                "data": [
+                    {
+                        "id": 1, "section_number": "SEC-101", "current_temperature": 20, "minimum_temperature": 15,
+                        "current_capacity": 50, "minimum_capacity": 10, "maximum_capacity": 100,
+                        "warehouse_id": 1, "product_type_id": 1
+                    }
+                ],
+                "meta": {"next_cursor": "WyJjdXJyZW50X2NhcGFjaXR5IiwiaTo1MCIsImk6MSJd", "total": 3}
+            }`,
+		},
+		{
+			testName:     "Fail: Service rejects the sort field",
+			query:        "?sort=unknown",
+			expectedOpts: models.QueryOptions{Limit: defaultPageLimit, Sort: "unknown"},
+			serviceError: httperrors.BadRequestError{Message: "Invalid sort field"},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid sort field"}`,
+		},
+		{
+			testName:     "Fail: Service returns an error",
+			expectedOpts: models.QueryOptions{Limit: defaultPageLimit},
+			serviceError: errors.New("internal server error"),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status": "Internal Server Error", "message": "Internal Server Error"}`,
 		},
 	}
 
@@ -185,13 +218,13 @@ func TestSectionHandler_GetAll(t *testing.T) {
 		t.Run(tt.testName, func(t *testing.T) {
 			mockService := new(mocks.SectionServiceMock)
 
-			mockService.On("GetAll", testifyMock.Anything).Return(tt.serviceOutput, tt.serviceError)
+			mockService.On("GetAll", testifyMock.Anything, tt.expectedOpts).Return(tt.serviceOutput, tt.serviceError)
 
 			handler := NewSectionHandler(mockService)
 			router := chi.NewRouter()
 			router.Get("/api/v1/sections", handler.GetAll())
 
-			req := httptest.NewRequest(http.MethodGet, "/api/v1/sections", nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/sections"+tt.query, nil)
 			req.Header.Set("Content-Type", "application/json")
 
 			response := httptest.NewRecorder()
@@ -269,7 +302,7 @@ func TestSectionHandler_GetByID(t *testing.T) {
 		t.Run(tt.testName, func(t *testing.T) {
 			mockService := new(mocks.SectionServiceMock)
 
-			if tt.expectedCode == http.StatusOK || tt.expectedCode == http.StatusNotFound {
+			if tt.expectedCode == http.StatusOK || tt.expectedCode == http.StatusNotFound || tt.expectedCode == http.StatusConflict {
 				mockService.On("GetByID", testifyMock.Anything, tt.inputID).Return(tt.serviceOutput, tt.serviceError)
 			}
 
@@ -374,7 +407,7 @@ func TestSectionHandler_Update(t *testing.T) {
 	updateRequest := models.UpdateSectionRequest{
 		// Use the helper function to get a pointer
 		SectionNumber:   stringPtr("SEC-102"),
-		CurrentCapacity: intPtr(75),
+		MaximumCapacity: intPtr(100),
 	}
 
 	// Expected service response on success
@@ -405,7 +438,7 @@ func TestSectionHandler_Update(t *testing.T) {
 			testName:     "Success: Update Section",
 			inputID:      1,
 			requestURL:   "/api/v1/sections/1",
-			requestBody:  `{"section_number": "SEC-102", "current_capacity": 75}`,
+			requestBody:  `{"section_number": "SEC-102", "maximum_capacity": 100}`,
 			serviceInput: updateRequest,
 			serviceOutput:updatedSectionResponse,
 			serviceError: nil,
@@ -434,17 +467,34 @@ func TestSectionHandler_Update(t *testing.T) {
 			testName:     "Fail: Invalid JSON",
 			inputID:      1,
 			requestURL:   "/api/v1/sections/1",
-			requestBody:  `{"section_number": "SEC-102", "current_capacity": "75"}`,
-			serviceInput: models.UpdateSectionRequest{SectionNumber: stringPtr("SEC-102"), CurrentCapacity: intPtr(75)},
+			requestBody:  `{"section_number": "SEC-102", "maximum_capacity": "75"}`,
 			serviceError: nil,
 			expectedCode: http.StatusBadRequest,
 			expectedBody: `{"status": "Bad Request", "message": "Invalid body"}`,
 		},
+		{
+			testName:     "Fail: current capacity is kept by the product batches",
+			inputID:      1,
+			requestURL:   "/api/v1/sections/1",
+			requestBody:  `{"current_capacity": 75}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid body"}`,
+		},
+		{
+			testName:     "Fail: maximum capacity below the occupancy",
+			inputID:      1,
+			requestURL:   "/api/v1/sections/1",
+			requestBody:  `{"maximum_capacity": 10}`,
+			serviceInput: models.UpdateSectionRequest{MaximumCapacity: intPtr(10)},
+			serviceError: httperrors.ConflictError{Message: "Maximum capacity cannot be below the current occupancy of the section."},
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"status": "Conflict", "message": "Maximum capacity cannot be below the current occupancy of the section."}`,
+		},
         {
             testName:     "Fail: Unprocessable entity",
             inputID:      1,
             requestURL:   "/api/v1/sections/1",
-            requestBody:  `{"current_capacity": -5}`,
+            requestBody:  `{"maximum_capacity": -5}`,
             serviceInput: models.UpdateSectionRequest{}, 
             serviceError: nil,
             expectedCode: http.StatusUnprocessableEntity,
@@ -456,7 +506,7 @@ func TestSectionHandler_Update(t *testing.T) {
 		t.Run(tt.testName, func(t *testing.T) {
 			mockService := new(mocks.SectionServiceMock)
 
-			if tt.expectedCode == http.StatusOK || tt.expectedCode == http.StatusNotFound {
+			if tt.expectedCode == http.StatusOK || tt.expectedCode == http.StatusNotFound || tt.expectedCode == http.StatusConflict {
 				mockService.On("Update", testifyMock.Anything, tt.inputID, tt.serviceInput).Return(tt.serviceOutput, tt.serviceError)
 			}
 
@@ -576,4 +626,152 @@ func TestSectionHandler_GetProductsReport(t *testing.T) {
 			mockService.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+// TestSectionHandler_GetExpiringBatchesReport tests the query parameters of GetExpiringBatchesReport.
+func TestSectionHandler_GetExpiringBatchesReport(t *testing.T) {
+	report := models.SectionExpiringBatchesReport{
+		SectionID:     1,
+		SectionNumber: "SEC-101",
+		WarehouseID:   2,
+		Batches: []models.ExpiringBatch{
+			{ProductBatchID: 3, BatchNumber: 30, ProductID: 4, ProductDescription: "Frozen peas", CurrentQuantity: 12, DueDate: "2026-01-10"},
+		},
+	}
+
+	tests := []struct {
+		testName       string
+		requestURL     string
+		callsService   bool
+		inputDays      int
+		inputWarehouse int
+		expectedCode   int
+		expectedBody   string
+	}{
+		{
+			testName:       "Success: default window for every warehouse",
+			requestURL:     "/api/v1/sections/reportExpiringBatches",
+			callsService:   true,
+			inputDays:      7,
+			inputWarehouse: 0,
+			expectedCode:   http.StatusOK,
+			expectedBody: `{"data": [{"section_id": 1, "section_number": "SEC-101", "warehouse_id": 2, "batches": [
+				{"product_batch_id": 3, "batch_number": 30, "product_id": 4, "product_description": "Frozen peas", "current_quantity": 12, "due_date": "2026-01-10"}
+			]}]}`,
+		},
+		{
+			testName:       "Success: days and warehouse given",
+			requestURL:     "/api/v1/sections/reportExpiringBatches?days=30&warehouse_id=2",
+			callsService:   true,
+			inputDays:      30,
+			inputWarehouse: 2,
+			expectedCode:   http.StatusOK,
+		},
+		{
+			testName:     "Fail: negative days",
+			requestURL:   "/api/v1/sections/reportExpiringBatches?days=-1",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid days"}`,
+		},
+		{
+			testName:     "Fail: invalid warehouse ID",
+			requestURL:   "/api/v1/sections/reportExpiringBatches?warehouse_id=abc",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid warehouse ID"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			mockService := new(mocks.SectionServiceMock)
+			if tt.callsService {
+				mockService.On("GetExpiringBatchesReport", testifyMock.Anything, tt.inputDays, tt.inputWarehouse).
+					Return([]models.SectionExpiringBatchesReport{report}, nil)
+			}
+
+			handler := NewSectionHandler(mockService)
+			router := chi.NewRouter()
+			router.Get("/api/v1/sections/reportExpiringBatches", handler.GetExpiringBatchesReport())
+
+			req := httptest.NewRequest(http.MethodGet, tt.requestURL, nil)
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, req)
+
+			assert.Equal(t, tt.expectedCode, response.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, response.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestSectionHandler_GetCapacityReport tests the query parameters of GetCapacityReport.
+func TestSectionHandler_GetCapacityReport(t *testing.T) {
+	report := models.WarehouseCapacityReport{
+		WarehouseID:     2,
+		CurrentCapacity: 5,
+		MaximumCapacity: 50,
+		Utilization:     10,
+		Sections: []models.SectionCapacityReport{
+			{SectionID: 1, SectionNumber: "SEC-101", CurrentCapacity: 5, MinimumCapacity: 10, MaximumCapacity: 50, Utilization: 10, BelowMinimum: true},
+		},
+	}
+
+	tests := []struct {
+		testName       string
+		requestURL     string
+		callsService   bool
+		inputWarehouse int
+		expectedCode   int
+		expectedBody   string
+	}{
+		{
+			testName:       "Success: every warehouse",
+			requestURL:     "/api/v1/sections/reportCapacity",
+			callsService:   true,
+			inputWarehouse: 0,
+			expectedCode:   http.StatusOK,
+			expectedBody: `{"data": [{"warehouse_id": 2, "current_capacity": 5, "maximum_capacity": 50, "utilization": 10, "sections": [
+				{"section_id": 1, "section_number": "SEC-101", "current_capacity": 5, "minimum_capacity": 10, "maximum_capacity": 50, "utilization": 10, "below_minimum": true}
+			]}]}`,
+		},
+		{
+			testName:       "Success: warehouse given",
+			requestURL:     "/api/v1/sections/reportCapacity?warehouse_id=2",
+			callsService:   true,
+			inputWarehouse: 2,
+			expectedCode:   http.StatusOK,
+		},
+		{
+			testName:     "Fail: invalid warehouse ID",
+			requestURL:   "/api/v1/sections/reportCapacity?warehouse_id=0",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid warehouse ID"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			mockService := new(mocks.SectionServiceMock)
+			if tt.callsService {
+				mockService.On("GetCapacityReport", testifyMock.Anything, tt.inputWarehouse).
+					Return([]models.WarehouseCapacityReport{report}, nil)
+			}
+
+			handler := NewSectionHandler(mockService)
+			router := chi.NewRouter()
+			router.Get("/api/v1/sections/reportCapacity", handler.GetCapacityReport())
+
+			req := httptest.NewRequest(http.MethodGet, tt.requestURL, nil)
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, req)
+
+			assert.Equal(t, tt.expectedCode, response.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, response.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -22,17 +22,20 @@ func NewSellerHandler(sv service.SellerService) *SellerHandler {
 
 func (h *SellerHandler) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseQueryOptions(r, localityFilter("locality_id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
 
-		data, err := h.sv.GetAll()
+		page, err := h.sv.GetAll(r.Context(), opts)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
 			return
 		}
 
-		response.JSON(w, http.StatusOK, map[string]any{
-			"data": data,
-		})
+		pageJSON(w, page)
 	}
 }
 
@@ -43,7 +46,7 @@ func (h *SellerHandler) GetByID() http.HandlerFunc {
 			response.Error(w, http.StatusBadRequest, "Invalid ID")
 			return
 		}
-		seller, err := h.sv.GetByID(id)
+		seller, err := h.sv.GetByID(r.Context(), id)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err) //404 not found
 			response.Error(w, statusCode, msg)
@@ -63,7 +66,7 @@ func (h *SellerHandler) Delete() http.HandlerFunc {
 			response.Error(w, http.StatusBadRequest, "Invalid ID")
 			return
 		}
-		err = h.sv.Delete(id)
+		err = h.sv.Delete(r.Context(), id)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
@@ -79,7 +82,7 @@ func (h *SellerHandler) Create() http.HandlerFunc {
 			response.Error(w, http.StatusUnprocessableEntity, "Invalid JSON body")
 			return
 		}
-		created, err := h.sv.Create(req)
+		created, err := h.sv.Create(r.Context(), req)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
@@ -106,7 +109,7 @@ func (h *SellerHandler) Update() http.HandlerFunc {
 			return
 		}
 
-		updated, err := h.sv.Update(id, &req)
+		updated, err := h.sv.Update(r.Context(), id, &req)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
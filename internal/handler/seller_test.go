@@ -14,6 +14,7 @@ import (
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 )
 
 // TestSellerHandler_GetAll tests the GetAll method of the SellerHandler.
@@ -21,23 +22,42 @@ import (
 func TestSellerHandler_GetAll(t *testing.T) {
 	cases := []struct {
 		name       string
+		query      string
 		setupMock  func(s *mock.SellerServiceDBMock)
 		wantStatus int
 	}{
 		{
 			name: "ok",
 			setupMock: func(s *mock.SellerServiceDBMock) {
-				s.On("GetAll").Return([]models.Seller{
+				opts := models.QueryOptions{Limit: 50}
+				s.On("GetAll", testifyMock.Anything, opts).Return(models.NewPage([]models.Seller{
 					{ID: 1, SellerAttributes: models.SellerAttributes{CID: 1}},
 					{ID: 2, SellerAttributes: models.SellerAttributes{CID: 2}},
-				}, nil)
+				}, 2, nil), nil)
 			},
 			wantStatus: http.StatusOK,
 		},
+		{
+			name:  "ok_filtered_by_locality",
+			query: "?limit=1&sort=cid&order=desc&locality_id=1001",
+			setupMock: func(s *mock.SellerServiceDBMock) {
+				opts := models.QueryOptions{Limit: 1, Sort: "cid", Desc: true, Filters: map[string]string{"locality_id": "1001"}}
+				s.On("GetAll", testifyMock.Anything, opts).Return(models.NewPage([]models.Seller{
+					{ID: 2, SellerAttributes: models.SellerAttributes{CID: 2, LocalityID: "1001"}},
+				}, 2, nil), nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid_limit",
+			query:      "?limit=1000",
+			setupMock:  func(s *mock.SellerServiceDBMock) {},
+			wantStatus: http.StatusBadRequest,
+		},
 		{
 			name: "fail",
 			setupMock: func(s *mock.SellerServiceDBMock) {
-				s.On("GetAll").Return([]models.Seller{}, errors.New("db fail"))
+				s.On("GetAll", testifyMock.Anything, models.QueryOptions{Limit: 50}).Return(models.Page[models.Seller]{}, errors.New("db fail"))
 			},
 			wantStatus: http.StatusInternalServerError, // O el código de error que uses
 		},
@@ -50,7 +70,7 @@ func TestSellerHandler_GetAll(t *testing.T) {
 			handler := handler.NewSellerHandler(service)
 			router := chi.NewRouter()
 			router.Get("/api/v1/sellers", handler.GetAll())
-			req := httptest.NewRequest("GET", "/api/v1/sellers", nil)
+			req := httptest.NewRequest("GET", "/api/v1/sellers"+tc.query, nil)
 			w := httptest.NewRecorder()
 			router.ServeHTTP(w, req)
 			assert.Equal(t, tc.wantStatus, w.Code)
@@ -72,7 +92,7 @@ func TestSellerHandler_GetByID(t *testing.T) {
 			name: "ok",
 			id:   "11",
 			setupMock: func(s *mock.SellerServiceDBMock) {
-				s.On("GetByID", 11).Return(models.Seller{ID: 11, SellerAttributes: models.SellerAttributes{CID: 555}}, nil)
+				s.On("GetByID", testifyMock.Anything, 11).Return(models.Seller{ID: 11, SellerAttributes: models.SellerAttributes{CID: 555}}, nil)
 			},
 			wantStatus: http.StatusOK,
 		},
@@ -80,7 +100,7 @@ func TestSellerHandler_GetByID(t *testing.T) {
 			name: "not found",
 			id:   "987",
 			setupMock: func(s *mock.SellerServiceDBMock) {
-				s.On("GetByID", 987).Return(models.Seller{}, httperrors.NotFoundError{Message: "not found"})
+				s.On("GetByID", testifyMock.Anything, 987).Return(models.Seller{}, httperrors.NotFoundError{Message: "not found"})
 			},
 			wantStatus: http.StatusNotFound,
 		},
@@ -145,7 +165,7 @@ func TestSellerHandler_Create(t *testing.T) {
 			name: "ok",
 			body: func() []byte { b, _ := json.Marshal(attrOK); return b }(),
 			setupMock: func(s *mock.SellerServiceDBMock) {
-				s.On("Create", attrOK).Return(models.Seller{ID: 5, SellerAttributes: attrOK}, nil)
+				s.On("Create", testifyMock.Anything, attrOK).Return(models.Seller{ID: 5, SellerAttributes: attrOK}, nil)
 			},
 			wantStatus: http.StatusCreated,
 		},
@@ -159,7 +179,7 @@ func TestSellerHandler_Create(t *testing.T) {
 			name: "fail (missing Address)",
 			body: func() []byte { b, _ := json.Marshal(attrFail); return b }(),
 			setupMock: func(s *mock.SellerServiceDBMock) {
-				s.On("Create", attrFail).Return(models.Seller{}, httperrors.UnprocessableEntityError{Message: "Invalid seller data"})
+				s.On("Create", testifyMock.Anything, attrFail).Return(models.Seller{}, httperrors.UnprocessableEntityError{Message: "Invalid seller data"})
 			},
 			wantStatus: http.StatusUnprocessableEntity,
 		},
@@ -167,7 +187,7 @@ func TestSellerHandler_Create(t *testing.T) {
 			name: "conflict",
 			body: func() []byte { b, _ := json.Marshal(attrConflict); return b }(),
 			setupMock: func(s *mock.SellerServiceDBMock) {
-				s.On("Create", attrConflict).Return(models.Seller{}, httperrors.ConflictError{Message: "cid exists"})
+				s.On("Create", testifyMock.Anything, attrConflict).Return(models.Seller{}, httperrors.ConflictError{Message: "cid exists"})
 			},
 			wantStatus: http.StatusConflict,
 		},
@@ -212,7 +232,7 @@ func TestSellerHandler_Update(t *testing.T) {
 			id:   "22",
 			body: func() []byte { b, _ := json.Marshal(attr); return b }(),
 			setupMock: func(s *mock.SellerServiceDBMock) {
-				s.On("Update", 22, &attr).Return(models.Seller{ID: 22, SellerAttributes: attr}, nil)
+				s.On("Update", testifyMock.Anything, 22, &attr).Return(models.Seller{ID: 22, SellerAttributes: attr}, nil)
 			},
 			wantStatus: http.StatusOK,
 		},
@@ -254,7 +274,7 @@ func TestSellerHandler_Delete(t *testing.T) {
 			name: "ok",
 			id:   "42",
 			setupMock: func(s *mock.SellerServiceDBMock) {
-				s.On("Delete", 42).Return(nil)
+				s.On("Delete", testifyMock.Anything, 42).Return(nil)
 			},
 			wantStatus: http.StatusOK,
 		},
@@ -262,7 +282,7 @@ func TestSellerHandler_Delete(t *testing.T) {
 			name: "not found",
 			id:   "22",
 			setupMock: func(s *mock.SellerServiceDBMock) {
-				s.On("Delete", 22).Return(httperrors.NotFoundError{Message: "not found"})
+				s.On("Delete", testifyMock.Anything, 22).Return(httperrors.NotFoundError{Message: "not found"})
 			},
 			wantStatus: http.StatusNotFound,
 		},
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator"
+)
+
+// StockMovementHandler handles the stock ledger of product batches
+type StockMovementHandler struct {
+	stockMovementService service.StockMovementService
+}
+
+// NewStockMovementHandler returns a new StockMovementHandler
+func NewStockMovementHandler(stockMovementService service.StockMovementService) *StockMovementHandler {
+	return &StockMovementHandler{stockMovementService: stockMovementService}
+}
+
+// GetByProductBatchID returns the movement history of a product batch
+// @Summary Get the stock movements of a product batch
+// @Description Get every inbound, outbound, adjustment and transfer recorded against a batch, oldest first
+// @Tags product-batches
+// @Produce json
+// @Param id path int true "Product batch ID"
+// @Success 200 {array} models.StockMovement
+// @Router /productBatches/{id}/movements [get]
+func (handler *StockMovementHandler) GetByProductBatchID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		// Get product batch ID from URL parameter
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		movements, err := handler.stockMovementService.GetByProductBatchID(ctx, id)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": movements,
+		})
+	}
+}
+
+// Create records a stock movement against a product batch
+// @Summary Record a stock movement
+// @Description Record an inbound, outbound, adjustment or transfer and update the batch current quantity
+// @Tags product-batches
+// @Accept json
+// @Produce json
+// @Param id path int true "Product batch ID"
+// @Param movement body models.CreateStockMovementRequest true "Movement to record"
+// @Success 201 {array} models.StockMovement
+// @Router /productBatches/{id}/movements [post]
+func (handler *StockMovementHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		// Parse request body
+		var movement models.CreateStockMovementRequest
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&movement); err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid body")
+			return
+		}
+		movement.Reason = strings.TrimSpace(movement.Reason)
+
+		// Validate request body
+		validate := validator.New()
+		if err := validate.Struct(movement); err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Invalid JSON body")
+			return
+		}
+
+		created, err := handler.stockMovementService.Create(ctx, id, movement)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": created,
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/utils"
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator"
+)
+
+// TrackingEventHandler handles the delivery timeline of purchase orders
+type TrackingEventHandler struct {
+	trackingEventService service.TrackingEventService
+}
+
+// NewTrackingEventHandler returns a new TrackingEventHandler
+func NewTrackingEventHandler(trackingEventService service.TrackingEventService) *TrackingEventHandler {
+	return &TrackingEventHandler{trackingEventService: trackingEventService}
+}
+
+// Create appends a tracking event to a purchase order
+// @Summary Record a tracking event
+// @Description Record a picked up, in transit, delivered or failed attempt event reported by the carry of the order. Only shipped orders can be delivered
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Param event body models.CreateTrackingEventRequest true "Event to record"
+// @Success 201 {object} models.TrackingEvent
+// @Router /purchaseOrders/{id}/tracking [post]
+func (handler *TrackingEventHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		// Parse request body
+		var event models.CreateTrackingEventRequest
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&event); err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid body")
+			return
+		}
+		event.EventType = strings.TrimSpace(event.EventType)
+		event.Location = strings.TrimSpace(event.Location)
+		event.Notes = strings.TrimSpace(event.Notes)
+
+		// Validate request body
+		validate := validator.New()
+		_ = validate.RegisterValidation("notfuture", utils.NotFutureDatetime)
+		if err := validate.Struct(event); err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Invalid JSON body")
+			return
+		}
+
+		created, err := handler.trackingEventService.Create(ctx, id, event)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": created,
+		})
+	}
+}
+
+// GetTimeline returns the delivery timeline of a purchase order
+// @Summary Get a tracking timeline
+// @Description Get the order with the tracking code and its tracking events, oldest first
+// @Tags purchase-orders
+// @Produce json
+// @Param trackingCode path string true "Tracking code"
+// @Success 200 {object} models.TrackingTimeline
+// @Router /purchaseOrders/tracking/{trackingCode} [get]
+func (handler *TrackingEventHandler) GetTimeline() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		timeline, err := handler.trackingEventService.GetTimeline(ctx, chi.URLParam(r, "trackingCode"))
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": timeline,
+		})
+	}
+}
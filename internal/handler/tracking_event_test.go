@@ -0,0 +1,125 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/handler"
+	mocks "github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTrackingEventHandler_Create(t *testing.T) {
+	occurredAt := time.Date(2026, 1, 3, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		testName       string
+		id             string
+		body           string
+		serviceRequest *models.CreateTrackingEventRequest
+		serviceResult  models.TrackingEvent
+		serviceError   error
+		expectedCode   int
+		expectedBody   string
+	}{
+		{
+			testName: "records the event and returns it",
+			id:       "1",
+			body:     `{"event_type": "picked_up", "location": " Depot 4 ", "occurred_at": "2026-01-03T08:00:00Z"}`,
+			serviceRequest: &models.CreateTrackingEventRequest{
+				EventType: models.TrackingEventPickedUp, Location: "Depot 4", OccurredAt: &occurredAt,
+			},
+			serviceResult: models.TrackingEvent{Id: 1, PurchaseOrderId: 1, CarryId: 2, EventType: models.TrackingEventPickedUp, Location: "Depot 4", OccurredAt: occurredAt},
+			expectedCode:  http.StatusCreated,
+			expectedBody: `{"data": {"id": 1, "purchase_order_id": 1, "carry_id": 2, "event_type": "picked_up",
+				"location": "Depot 4", "notes": "", "occurred_at": "2026-01-03T08:00:00Z"}}`,
+		},
+		{
+			testName:       "order without carry returns StatusConflict",
+			id:             "1",
+			body:           `{"event_type": "in_transit"}`,
+			serviceRequest: &models.CreateTrackingEventRequest{EventType: models.TrackingEventInTransit},
+			serviceError:   httperrors.ConflictError{Message: "Purchase order has no carry assigned"},
+			expectedCode:   http.StatusConflict,
+			expectedBody:   `{"status": "Conflict", "message": "Purchase order has no carry assigned"}`,
+		},
+		{
+			testName:     "unknown event type returns StatusUnprocessableEntity",
+			id:           "1",
+			body:         `{"event_type": "lost"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"status": "Unprocessable Entity", "message": "Invalid JSON body"}`,
+		},
+		{
+			testName:     "event in the future returns StatusUnprocessableEntity",
+			id:           "1",
+			body:         `{"event_type": "delivered", "occurred_at": "2999-01-01T00:00:00Z"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"status": "Unprocessable Entity", "message": "Invalid JSON body"}`,
+		},
+		{
+			testName:     "invalid id returns StatusBadRequest",
+			id:           "abc",
+			body:         `{"event_type": "delivered"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid ID"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.TrackingEventServiceMock)
+			trackingEventHandler := handler.NewTrackingEventHandler(serviceMock)
+			if tc.serviceRequest != nil {
+				serviceMock.On("Create", mock.Anything, 1, *tc.serviceRequest).Return(tc.serviceResult, tc.serviceError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/"+tc.id+"/tracking", strings.NewReader(tc.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			// act
+			trackingEventHandler.Create().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTrackingEventHandler_GetTimeline(t *testing.T) {
+	t.Run("unknown tracking code returns StatusNotFound", func(t *testing.T) {
+		// arrange
+		serviceMock := new(mocks.TrackingEventServiceMock)
+		trackingEventHandler := handler.NewTrackingEventHandler(serviceMock)
+		serviceMock.On("GetTimeline", mock.Anything, "T9").
+			Return(models.TrackingTimeline{}, httperrors.NotFoundError{Message: "Tracking code not found"})
+
+		req := httptest.NewRequest(http.MethodGet, "/tracking/T9", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("trackingCode", "T9")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rec := httptest.NewRecorder()
+
+		// act
+		trackingEventHandler.GetTimeline().ServeHTTP(rec, req)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.JSONEq(t, `{"status": "Not Found", "message": "Tracking code not found"}`, rec.Body.String())
+		serviceMock.AssertExpectations(t)
+	})
+}
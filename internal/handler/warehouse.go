@@ -32,7 +32,7 @@ func (h WarehouseHandler) Create() http.HandlerFunc {
 			response.Error(w, http.StatusUnprocessableEntity, "Invalid JSON body")
 			return
 		}
-		warehouseData, err := h.service.Create(warehouse)
+		warehouseData, err := h.service.Create(r.Context(), warehouse)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
@@ -44,21 +44,23 @@ func (h WarehouseHandler) Create() http.HandlerFunc {
 	}
 }
 
-// GetAll returns all warehouses.
+// GetAll returns a page of warehouses.
+// Accepts limit, cursor, sort and order as query parameters. There are no
+// filters: a warehouse references no other resource, and its code is unique.
 func (h WarehouseHandler) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		data, err := h.service.GetAll()
+		opts, err := parseQueryOptions(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		page, err := h.service.GetAll(r.Context(), opts)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
 			return
 		}
-		if data == nil {
-			data = make([]models.Warehouse, 0)
-		}
-		response.JSON(w, http.StatusOK, map[string]any{
-			"data": data,
-		})
+		pageJSON(w, page)
 	}
 }
 
@@ -71,7 +73,7 @@ func (h WarehouseHandler) GetById() http.HandlerFunc {
 			return
 		}
 
-		warehouseData, err := h.service.GetByID(id)
+		warehouseData, err := h.service.GetByID(r.Context(), id)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
@@ -100,7 +102,7 @@ func (h WarehouseHandler) Update() http.HandlerFunc {
 			return
 		}
 
-		warehouseData, err := h.service.Update(id, updatedWarehouse)
+		warehouseData, err := h.service.Update(r.Context(), id, updatedWarehouse)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
@@ -121,7 +123,7 @@ func (h WarehouseHandler) Delete() http.HandlerFunc {
 			response.Error(w, http.StatusBadRequest, "Invalid ID format")
 			return
 		}
-		err = h.service.Delete(id)
+		err = h.service.Delete(r.Context(), id)
 		if err != nil {
 			statusCode, msg := httperrors.GetErrorData(err)
 			response.Error(w, statusCode, msg)
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// defaultDashboardDays is how far back the dashboard looks for inbound orders and
+// temperature readings when days is not given
+const defaultDashboardDays = 7
+
+// WarehouseDashboardHandler serves the dashboard of a warehouse
+type WarehouseDashboardHandler struct {
+	service service.WarehouseDashboardService
+}
+
+// NewWarehouseDashboardHandler creates a new WarehouseDashboardHandler with the given service
+func NewWarehouseDashboardHandler(sv service.WarehouseDashboardService) *WarehouseDashboardHandler {
+	return &WarehouseDashboardHandler{service: sv}
+}
+
+// GetDashboard returns the section capacity, staff, stock, inbound orders and
+// temperature figures of the warehouse identified by the id URL parameter.
+//
+// Query Parameters:
+//   - days (optional, default 7): days back to look for inbound orders and temperature readings.
+//   - expiring_days (optional, default 7): days ahead to look for expiring stock.
+func (h WarehouseDashboardHandler) GetDashboard() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || id <= 0 {
+			response.Error(w, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+
+		query := r.URL.Query()
+		days := defaultDashboardDays
+		if param := query.Get("days"); param != "" {
+			value, err := strconv.Atoi(param)
+			if err != nil || value <= 0 {
+				response.Error(w, http.StatusBadRequest, "Invalid days")
+				return
+			}
+			days = value
+		}
+		expiringDays := defaultExpiringDays
+		if param := query.Get("expiring_days"); param != "" {
+			value, err := strconv.Atoi(param)
+			if err != nil || value < 0 {
+				response.Error(w, http.StatusBadRequest, "Invalid expiring_days")
+				return
+			}
+			expiringDays = value
+		}
+
+		dashboard, err := h.service.GetDashboard(r.Context(), id, days, expiringDays)
+		if err != nil {
+			statusCode, msg := httperrors.GetErrorData(err)
+			response.Error(w, statusCode, msg)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": dashboard,
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/handler"
+	mocks "github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWarehouseDashboardHandler_GetDashboard(t *testing.T) {
+	tests := []struct {
+		testName      string
+		id            string
+		query         string
+		callsService  bool
+		serviceDays   int
+		serviceExpiry int
+		serviceError  error
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			testName:      "uses the given days and expiring_days",
+			id:            "1",
+			query:         "?days=30&expiring_days=0",
+			callsService:  true,
+			serviceDays:   30,
+			serviceExpiry: 0,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			testName:      "defaults to seven days",
+			id:            "1",
+			callsService:  true,
+			serviceDays:   7,
+			serviceExpiry: 7,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			testName:      "unknown warehouse returns StatusNotFound",
+			id:            "9",
+			callsService:  true,
+			serviceDays:   7,
+			serviceExpiry: 7,
+			serviceError:  httperrors.NotFoundError{Message: "warehouse not found"},
+			expectedCode:  http.StatusNotFound,
+			expectedBody:  `{"status": "Not Found", "message": "warehouse not found"}`,
+		},
+		{
+			testName:     "invalid id returns StatusBadRequest",
+			id:           "abc",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid ID"}`,
+		},
+		{
+			testName:     "non positive days returns StatusBadRequest",
+			id:           "1",
+			query:        "?days=0",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid days"}`,
+		},
+		{
+			testName:     "negative expiring_days returns StatusBadRequest",
+			id:           "1",
+			query:        "?expiring_days=-1",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status": "Bad Request", "message": "Invalid expiring_days"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			serviceMock := new(mocks.WarehouseDashboardServiceMock)
+			dashboardHandler := handler.NewWarehouseDashboardHandler(serviceMock)
+			if tc.callsService {
+				serviceMock.On("GetDashboard", mock.Anything, mock.AnythingOfType("int"), tc.serviceDays, tc.serviceExpiry).
+					Return(models.WarehouseDashboard{Days: tc.serviceDays, ExpiringDays: tc.serviceExpiry}, tc.serviceError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/"+tc.id+"/dashboard"+tc.query, nil)
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("id", tc.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+			rec := httptest.NewRecorder()
+
+			// act
+			dashboardHandler.GetDashboard().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
 	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -65,7 +66,7 @@ func Test_Create(t *testing.T) {
 
 		// Mock service to return successful creation
 		mockService := new(mocks.WarehouseServiceMock)
-		mockService.On("Create", warehouseInput).
+		mockService.On("Create", mock.Anything, warehouseInput).
 			Return(warehuseUotput, nil)
 		handlerTest := handler.NewWarehouseHandler(mockService)
 		body, err := json.Marshal(warehouseInput)
@@ -134,7 +135,7 @@ func Test_Create(t *testing.T) {
 
 		// Mock service to return conflict error
 		mockService := new(mocks.WarehouseServiceMock)
-		mockService.On("Create", warehouseInput).
+		mockService.On("Create", mock.Anything, warehouseInput).
 			Return(models.Warehouse{}, httperrors.ConflictError{Message: "the WarehouseCode already exists"})
 		handlerTest := handler.NewWarehouseHandler(mockService)
 
@@ -161,7 +162,7 @@ func Test_Create(t *testing.T) {
 // Test_GetAll tests the GetAll method of the WarehouseHandler.
 //
 // This test suite verifies that the warehouse listing endpoint:
-//   - Successfully returns all warehouses when they exist
+//   - Successfully returns a page of warehouses when they exist
 //   - Properly handles service errors during data retrieval
 //   - Returns appropriate HTTP status codes and response format
 //
@@ -195,15 +196,17 @@ func Test_GetAll(t *testing.T) {
 
 		// Mock service to return warehouse collection
 		mockService := new(mocks.WarehouseServiceMock)
-		mockService.On("GetAll").
-			Return([]models.Warehouse{
+		opts := models.QueryOptions{Limit: 2, Sort: "warehouse_code"}
+		next := models.Cursor{Sort: "warehouse_code", Value: warehouseTest2.WarehouseCode, ID: warehouseTest2.Id}
+		mockService.On("GetAll", mock.Anything, opts).
+			Return(models.NewPage([]models.Warehouse{
 				warehouseTest1,
 				warehouseTest2,
-			}, nil)
+			}, 3, &next), nil)
 		handlerTest := handler.NewWarehouseHandler(mockService)
 
-		// Act: execute GET request for all warehouses
-		req := httptest.NewRequest(http.MethodGet, "/warehouses", nil)
+		// Act: execute GET request for the first page of warehouses
+		req := httptest.NewRequest(http.MethodGet, "/warehouses?limit=2&sort=warehouse_code", nil)
 		res := httptest.NewRecorder()
 		handlerTest.GetAll()(res, req)
 
@@ -214,6 +217,10 @@ func Test_GetAll(t *testing.T) {
 				warehouseTest1,
 				warehouseTest2,
 			},
+			"meta": map[string]any{
+				"next_cursor": next.Encode(),
+				"total":       3,
+			},
 		})
 		require.NoError(t, err)
 		require.JSONEq(t, string(expectedBody), res.Body.String())
@@ -228,8 +235,8 @@ func Test_GetAll(t *testing.T) {
 		// Arrange: mock service to return internal server error
 		serviceError := httperrors.InternalServerError{Message: "error obtaining warehouses"}
 		mockService := new(mocks.WarehouseServiceMock)
-		mockService.On("GetAll").
-			Return([]models.Warehouse{}, serviceError)
+		mockService.On("GetAll", mock.Anything, models.QueryOptions{Limit: 50}).
+			Return(models.Page[models.Warehouse]{}, serviceError)
 		handlerTest := handler.NewWarehouseHandler(mockService)
 
 		// Act: execute request that will result in service error
@@ -253,8 +260,8 @@ func Test_GetAll(t *testing.T) {
 	t.Run("There are not warehouses (find_all_empty)", func(t *testing.T) {
 		// Arrange: mock service to return empty slice (no warehouses)
 		mockService := new(mocks.WarehouseServiceMock)
-		mockService.On("GetAll").
-			Return([]models.Warehouse{}, nil)
+		mockService.On("GetAll", mock.Anything, models.QueryOptions{Limit: 50}).
+			Return(models.Page[models.Warehouse]{}, nil)
 		handlerTest := handler.NewWarehouseHandler(mockService)
 
 		// Act: execute GET request when no warehouses exist
@@ -263,7 +270,7 @@ func Test_GetAll(t *testing.T) {
 		handlerTest.GetAll()(res, req)
 
 		// Assert: verify empty array response with 200 OK status
-		expectedBody := `{"data":[]}`
+		expectedBody := `{"data":[],"meta":{"next_cursor":null,"total":0}}`
 		expectedStatus := http.StatusOK
 
 		require.JSONEq(t, expectedBody, res.Body.String())
@@ -304,7 +311,7 @@ func Test_GetById(t *testing.T) {
 	t.Run("on success (find_by_id_existent)", func(t *testing.T) {
 		// Arrange: mock service to return existing warehouse
 		mockService := new(mocks.WarehouseServiceMock)
-		mockService.On("GetByID", warehouseTest.Id).
+		mockService.On("GetByID", mock.Anything, warehouseTest.Id).
 			Return(warehouseTest, nil)
 		handlerTest := handler.NewWarehouseHandler(mockService)
 
@@ -335,7 +342,7 @@ func Test_GetById(t *testing.T) {
 	t.Run("on id not exists (find_by_id_non_existent)", func(t *testing.T) {
 		// Arrange: mock service to return not found error
 		mockService := new(mocks.WarehouseServiceMock)
-		mockService.On("GetByID", 2).
+		mockService.On("GetByID", mock.Anything, 2).
 			Return(models.Warehouse{}, httperrors.NotFoundError{Message: "warehouse not found"})
 
 		handlerTest := handler.NewWarehouseHandler(mockService)
@@ -423,7 +430,7 @@ func Test_Update(t *testing.T) {
 	t.Run("on success (update_ok)", func(t *testing.T) {
 		// Arrange: mock service to return successful update
 		mockService := new(mocks.WarehouseServiceMock)
-		mockService.On("Update", 1, warehouseAttInput).
+		mockService.On("Update", mock.Anything, 1, warehouseAttInput).
 			Return(warehouseOutput, nil)
 		handlerTest := handler.NewWarehouseHandler(mockService)
 		body, err := json.Marshal(warehouseAttInput)
@@ -456,7 +463,7 @@ func Test_Update(t *testing.T) {
 	t.Run("on id not exists (update_non_existent)", func(t *testing.T) {
 		// Arrange: mock service to return not found error
 		mockService := new(mocks.WarehouseServiceMock)
-		mockService.On("Update", 2, warehouseAttInput).
+		mockService.On("Update", mock.Anything, 2, warehouseAttInput).
 			Return(models.Warehouse{}, httperrors.NotFoundError{Message: "warehouse not found"})
 		handlerTest := handler.NewWarehouseHandler(mockService)
 		body, err := json.Marshal(warehouseAttInput)
@@ -548,7 +555,7 @@ func Test_Update(t *testing.T) {
 			t.Run(test.name, func(t *testing.T) {
 				// Arrange: mock service to return specific error
 				mockService := new(mocks.WarehouseServiceMock)
-				mockService.On("Update", 1, warehouseAttInput).
+				mockService.On("Update", mock.Anything, 1, warehouseAttInput).
 					Return(models.Warehouse{}, test.serviceError)
 				handlerTest := handler.NewWarehouseHandler(mockService)
 
@@ -626,7 +633,7 @@ func Test_Delete(t *testing.T) {
 	t.Run("on success (delete_ok)", func(t *testing.T) {
 		// Arrange: mock service to return successful deletion
 		mockService := new(mocks.WarehouseServiceMock)
-		mockService.On("Delete", 1).
+		mockService.On("Delete", mock.Anything, 1).
 			Return(nil)
 		handlerTest := handler.NewWarehouseHandler(mockService)
 
@@ -651,7 +658,7 @@ func Test_Delete(t *testing.T) {
 	t.Run("Id not exists (delete_non_existent)", func(t *testing.T) {
 		// Arrange: mock service to return not found error
 		mockService := new(mocks.WarehouseServiceMock)
-		mockService.On("Delete", 1).
+		mockService.On("Delete", mock.Anything, 1).
 			Return(httperrors.NotFoundError{Message: "warehouse not found"})
 		handlerTest := handler.NewWarehouseHandler(mockService)
 
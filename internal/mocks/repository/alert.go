@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type AlertRepositoryDBMock struct {
+	mock.Mock
+}
+
+func (m *AlertRepositoryDBMock) GetTemperatureTargets(ctx context.Context, sectionID int) (models.SectionTemperatureTargets, error) {
+	args := m.Called(ctx, sectionID)
+	return args.Get(0).(models.SectionTemperatureTargets), args.Error(1)
+}
+
+func (m *AlertRepositoryDBMock) Sync(ctx context.Context, sectionID int, breaches []models.Alert, at time.Time) ([]models.Alert, error) {
+	args := m.Called(ctx, sectionID, breaches, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Alert), args.Error(1)
+}
+
+func (m *AlertRepositoryDBMock) GetAll(ctx context.Context, filter models.AlertFilter) (models.Page[models.Alert], error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return models.Page[models.Alert]{}, args.Error(1)
+	}
+	return args.Get(0).(models.Page[models.Alert]), args.Error(1)
+}
+
+func (m *AlertRepositoryDBMock) Acknowledge(ctx context.Context, id int, at time.Time) (models.Alert, error) {
+	args := m.Called(ctx, id, at)
+	return args.Get(0).(models.Alert), args.Error(1)
+}
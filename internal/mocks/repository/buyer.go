@@ -22,9 +22,9 @@ func (m *BuyerRepositoryDBMock) Create(ctx context.Context, newBuyer models.Buye
 	return args.Get(0).(models.Buyer), args.Error(1)
 }
 
-func (m *BuyerRepositoryDBMock) GetAll(ctx context.Context) ([]models.Buyer, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]models.Buyer), args.Error(1)
+func (m *BuyerRepositoryDBMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Buyer], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.Buyer]), args.Error(1)
 }
 
 func (m *BuyerRepositoryDBMock) GetByID(ctx context.Context, id int) (models.Buyer, error) {
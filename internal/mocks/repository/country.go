@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// CountryRepositoryMock is a mock of CountryRepository
+type CountryRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *CountryRepositoryMock) Create(ctx context.Context, country models.CountryAttributes) (models.Country, error) {
+	args := m.Called(ctx, country)
+	return args.Get(0).(models.Country), args.Error(1)
+}
+
+func (m *CountryRepositoryMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Country], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.Country]), args.Error(1)
+}
+
+func (m *CountryRepositoryMock) GetByID(ctx context.Context, id int) (models.Country, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Country), args.Error(1)
+}
+
+func (m *CountryRepositoryMock) Update(ctx context.Context, id int, country models.Country) (models.Country, error) {
+	args := m.Called(ctx, id, country)
+	return args.Get(0).(models.Country), args.Error(1)
+}
+
+func (m *CountryRepositoryMock) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *CountryRepositoryMock) GetSellerReport(ctx context.Context, id *int) ([]models.RegionSellerReport, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]models.RegionSellerReport), args.Error(1)
+}
+
+func (m *CountryRepositoryMock) GetCarryReport(ctx context.Context, id *int) ([]models.RegionCarryReport, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]models.RegionCarryReport), args.Error(1)
+}
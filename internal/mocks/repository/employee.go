@@ -1,33 +1,35 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/stretchr/testify/mock"
 )
 
 type MockEmployeeRepository struct{ mock.Mock }
 
-func (m *MockEmployeeRepository) Create(e models.Employee) (models.Employee, error) {
-	a := m.Called(e)
+func (m *MockEmployeeRepository) Create(ctx context.Context, e models.Employee) (models.Employee, error) {
+	a := m.Called(ctx, e)
 	return a.Get(0).(models.Employee), a.Error(1)
 }
-func (m *MockEmployeeRepository) GetAll() ([]models.Employee, error) {
-	args := m.Called()
+func (m *MockEmployeeRepository) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Employee], error) {
+	args := m.Called(ctx, opts)
 	v := args.Get(0)
 	if v == nil {
-		return nil, args.Error(1)
+		return models.Page[models.Employee]{}, args.Error(1)
 	}
-	return v.([]models.Employee), args.Error(1)
+	return v.(models.Page[models.Employee]), args.Error(1)
 }
-func (m *MockEmployeeRepository) GetByID(id int) (models.Employee, error) {
-	a := m.Called(id)
+func (m *MockEmployeeRepository) GetByID(ctx context.Context, id int) (models.Employee, error) {
+	a := m.Called(ctx, id)
 	return a.Get(0).(models.Employee), a.Error(1)
 }
-func (m *MockEmployeeRepository) Update(id int, e models.Employee) (models.Employee, error) {
-	a := m.Called(id, e)
+func (m *MockEmployeeRepository) Update(ctx context.Context, id int, e models.Employee) (models.Employee, error) {
+	a := m.Called(ctx, id, e)
 	return a.Get(0).(models.Employee), a.Error(1)
 }
-func (m *MockEmployeeRepository) Delete(id int) error {
-	a := m.Called(id)
+func (m *MockEmployeeRepository) Delete(ctx context.Context, id int) error {
+	a := m.Called(ctx, id)
 	return a.Error(0)
 }
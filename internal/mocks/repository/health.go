@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// HealthRepositoryMock is a mock of HealthRepository
+type HealthRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *HealthRepositoryMock) Ping(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *HealthRepositoryMock) GetAppliedMigrations(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -1,28 +1,31 @@
 package mocks
 
 import (
+	"context"
+	"time"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/stretchr/testify/mock"
 )
 
 type MockInboundOrderRepository struct{ mock.Mock }
 
-func (m *MockInboundOrderRepository) Create(order models.InboundOrder) (models.InboundOrder, error) {
-	args := m.Called(order)
-	return args.Get(0).(models.InboundOrder), args.Error(1)
-}
-
-func (m *MockInboundOrderRepository) GetByOrderNumber(orderNumber string) (models.InboundOrder, error) {
-	args := m.Called(orderNumber)
+func (m *MockInboundOrderRepository) Create(ctx context.Context, attrs models.InboundOrderAttributes) (models.InboundOrder, error) {
+	args := m.Called(ctx, attrs)
 	return args.Get(0).(models.InboundOrder), args.Error(1)
 }
 
-func (m *MockInboundOrderRepository) CountInboundOrdersForEmployees() (map[int]int, error) {
-	args := m.Called()
+func (m *MockInboundOrderRepository) CountInboundOrdersForEmployees(ctx context.Context) (map[int]int, error) {
+	args := m.Called(ctx)
 	return args.Get(0).(map[int]int), args.Error(1)
 }
 
-func (m *MockInboundOrderRepository) CountInboundOrdersForEmployee(employeeID int) (int, error) {
-	args := m.Called(employeeID)
+func (m *MockInboundOrderRepository) CountInboundOrdersForEmployee(ctx context.Context, employeeID int) (int, error) {
+	args := m.Called(ctx, employeeID)
 	return args.Int(0), args.Error(1)
 }
+
+func (m *MockInboundOrderRepository) CountInboundOrdersForWarehouse(ctx context.Context, warehouseID int, since time.Time) (models.InboundOrdersSummary, error) {
+	args := m.Called(ctx, warehouseID, since)
+	return args.Get(0).(models.InboundOrdersSummary), args.Error(1)
+}
@@ -16,9 +16,9 @@ func (m *ProductRepositoryDBMock) Create(ctx context.Context, newProduct models.
 	return args.Get(0).(models.Product), args.Error(1)
 }
 
-func (m *ProductRepositoryDBMock) GetAll(ctx context.Context) ([]models.Product, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]models.Product), args.Error(1)
+func (m *ProductRepositoryDBMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Product], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.Product]), args.Error(1)
 }
 
 func (m *ProductRepositoryDBMock) GetByID(ctx context.Context, id int) (models.Product, error) {
@@ -31,6 +31,11 @@ func (m *ProductRepositoryDBMock) GetRecordsPerProduct(ctx context.Context, id *
 	return args.Get(0).([]models.ProductRecordCount), args.Error(1)
 }
 
+func (m *ProductRepositoryDBMock) GetMargins(ctx context.Context, filter models.MarginReportFilter) ([]models.ProductMargin, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.ProductMargin), args.Error(1)
+}
+
 func (m *ProductRepositoryDBMock) Update(ctx context.Context, id int, updatedProduct models.Product) (models.Product, error) {
 	args := m.Called(ctx, id, updatedProduct)
 	return args.Get(0).(models.Product), args.Error(1)
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// ProductBatchRepositoryMock is a mock of ProductBatchRepository
+type ProductBatchRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ProductBatchRepositoryMock) Create(ctx context.Context, productBatch models.ProductBatchAttibutes) (models.ProductBatch, error) {
+	args := m.Called(ctx, productBatch)
+	return args.Get(0).(models.ProductBatch), args.Error(1)
+}
+
+func (m *ProductBatchRepositoryMock) GetAll(ctx context.Context, filter models.ProductBatchFilter) (models.Page[models.ProductBatch], error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(models.Page[models.ProductBatch]), args.Error(1)
+}
+
+func (m *ProductBatchRepositoryMock) GetByID(ctx context.Context, id int) (models.ProductBatch, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.ProductBatch), args.Error(1)
+}
+
+func (m *ProductBatchRepositoryMock) Update(ctx context.Context, id int, data models.ProductBatchAttibutes) (models.ProductBatch, error) {
+	args := m.Called(ctx, id, data)
+	return args.Get(0).(models.ProductBatch), args.Error(1)
+}
+
+func (m *ProductBatchRepositoryMock) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// ProductTypeRepositoryMock is a mock of ProductTypeRepository
+type ProductTypeRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ProductTypeRepositoryMock) Create(ctx context.Context, productType models.ProductTypeAttributes) (models.ProductType, error) {
+	args := m.Called(ctx, productType)
+	return args.Get(0).(models.ProductType), args.Error(1)
+}
+
+func (m *ProductTypeRepositoryMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.ProductType], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.ProductType]), args.Error(1)
+}
+
+func (m *ProductTypeRepositoryMock) GetByID(ctx context.Context, id int) (models.ProductType, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.ProductType), args.Error(1)
+}
+
+func (m *ProductTypeRepositoryMock) Update(ctx context.Context, id int, productType models.ProductType) (models.ProductType, error) {
+	args := m.Called(ctx, id, productType)
+	return args.Get(0).(models.ProductType), args.Error(1)
+}
+
+func (m *ProductTypeRepositoryMock) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
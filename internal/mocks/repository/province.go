@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// ProvinceRepositoryMock is a mock of ProvinceRepository
+type ProvinceRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ProvinceRepositoryMock) Create(ctx context.Context, province models.ProvinceAttributes) (models.Province, error) {
+	args := m.Called(ctx, province)
+	return args.Get(0).(models.Province), args.Error(1)
+}
+
+func (m *ProvinceRepositoryMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Province], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.Province]), args.Error(1)
+}
+
+func (m *ProvinceRepositoryMock) GetByID(ctx context.Context, id int) (models.Province, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Province), args.Error(1)
+}
+
+func (m *ProvinceRepositoryMock) Update(ctx context.Context, id int, province models.Province) (models.Province, error) {
+	args := m.Called(ctx, id, province)
+	return args.Get(0).(models.Province), args.Error(1)
+}
+
+func (m *ProvinceRepositoryMock) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ProvinceRepositoryMock) GetSellerReport(ctx context.Context, id *int) ([]models.RegionSellerReport, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]models.RegionSellerReport), args.Error(1)
+}
+
+func (m *ProvinceRepositoryMock) GetCarryReport(ctx context.Context, id *int) ([]models.RegionCarryReport, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]models.RegionCarryReport), args.Error(1)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/stretchr/testify/mock"
@@ -23,3 +24,23 @@ func (m *PurchaseOrderRepositoryDBMock) Create(
 	args := m.Called(ctx, newPurchaseOrder)
 	return args.Get(0).(models.PurchaseOrder), args.Error(1)
 }
+
+func (m *PurchaseOrderRepositoryDBMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.PurchaseOrder], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.PurchaseOrder]), args.Error(1)
+}
+
+func (m *PurchaseOrderRepositoryDBMock) GetByID(ctx context.Context, id int) (models.PurchaseOrder, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.PurchaseOrder), args.Error(1)
+}
+
+func (m *PurchaseOrderRepositoryDBMock) Transition(ctx context.Context, id int, status string, at time.Time) (models.PurchaseOrder, error) {
+	args := m.Called(ctx, id, status, at)
+	return args.Get(0).(models.PurchaseOrder), args.Error(1)
+}
+
+func (m *PurchaseOrderRepositoryDBMock) AssignCarry(ctx context.Context, id int, carryID *int) (models.PurchaseOrder, error) {
+	args := m.Called(ctx, id, carryID)
+	return args.Get(0).(models.PurchaseOrder), args.Error(1)
+}
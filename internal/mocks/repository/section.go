@@ -19,12 +19,12 @@ func (m *SectionRepositoryDBMock) Create(ctx context.Context, section models.Sec
     return args.Get(0).(models.Section), args.Error(1)
 }
 
-func (m *SectionRepositoryDBMock) GetAll(ctx context.Context) ([]models.Section, error) {
-    args := m.Called(ctx)
+func (m *SectionRepositoryDBMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Section], error) {
+    args := m.Called(ctx, opts)
     if args.Get(0) == nil {
-        return nil, args.Error(1)
+        return models.Page[models.Section]{}, args.Error(1)
     }
-    return args.Get(0).([]models.Section), args.Error(1)
+    return args.Get(0).(models.Page[models.Section]), args.Error(1)
 }
 
 func (m *SectionRepositoryDBMock) GetByID(ctx context.Context, id int) (models.Section, error) {
@@ -62,4 +62,11 @@ func (m *SectionRepositoryDBMock) GetAllProductsReport(ctx context.Context) ([]m
         return nil, args.Error(1)
     }
     return args.Get(0).([]models.SectionProductsReport), args.Error(1)
-}
\ No newline at end of file
+}
+func (m *SectionRepositoryDBMock) GetExpiringBatchesReport(ctx context.Context, dueDateFrom, dueDateTo string, warehouseID int) ([]models.SectionExpiringBatchesReport, error) {
+    args := m.Called(ctx, dueDateFrom, dueDateTo, warehouseID)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]models.SectionExpiringBatchesReport), args.Error(1)
+}
@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type SectionTemperatureReadingRepositoryDBMock struct {
+	mock.Mock
+}
+
+func (m *SectionTemperatureReadingRepositoryDBMock) CreateBulk(ctx context.Context, sectionID int, readings []models.SectionTemperatureReading) ([]models.SectionTemperatureReading, error) {
+	args := m.Called(ctx, sectionID, readings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SectionTemperatureReading), args.Error(1)
+}
+
+func (m *SectionTemperatureReadingRepositoryDBMock) GetLatestBySectionID(ctx context.Context, sectionID int) (*models.SectionTemperatureReading, error) {
+	args := m.Called(ctx, sectionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SectionTemperatureReading), args.Error(1)
+}
+
+func (m *SectionTemperatureReadingRepositoryDBMock) GetBySectionID(ctx context.Context, sectionID int, from, to time.Time) ([]models.SectionTemperatureReading, error) {
+	args := m.Called(ctx, sectionID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SectionTemperatureReading), args.Error(1)
+}
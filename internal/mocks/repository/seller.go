@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/stretchr/testify/mock"
 )
@@ -9,27 +11,27 @@ type SellerRepositoryDBMock struct {
 	mock.Mock
 }
 
-func (m *SellerRepositoryDBMock) GetByID(id int) (models.Seller, error) {
-	args := m.Called(id)
+func (m *SellerRepositoryDBMock) GetByID(ctx context.Context, id int) (models.Seller, error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).(models.Seller), args.Error(1)
 }
 
-func (m *SellerRepositoryDBMock) GetAll() ([]models.Seller, error) {
-	args := m.Called()
-	return args.Get(0).([]models.Seller), args.Error(1)
+func (m *SellerRepositoryDBMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Seller], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.Seller]), args.Error(1)
 }
 
-func (m *SellerRepositoryDBMock) Create(attr models.SellerAttributes) (models.Seller, error) {
-	args := m.Called(attr)
+func (m *SellerRepositoryDBMock) Create(ctx context.Context, attr models.SellerAttributes) (models.Seller, error) {
+	args := m.Called(ctx, attr)
 	return args.Get(0).(models.Seller), args.Error(1)
 }
 
-func (m *SellerRepositoryDBMock) Delete(id int) error {
-	args := m.Called(id)
+func (m *SellerRepositoryDBMock) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *SellerRepositoryDBMock) Update(id int, attr *models.SellerAttributes) (models.Seller, error) {
-	args := m.Called(id, attr)
+func (m *SellerRepositoryDBMock) Update(ctx context.Context, id int, attr *models.SellerAttributes) (models.Seller, error) {
+	args := m.Called(ctx, id, attr)
 	return args.Get(0).(models.Seller), args.Error(1)
 }
@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type StockMovementRepositoryDBMock struct {
+	mock.Mock
+}
+
+func (m *StockMovementRepositoryDBMock) Create(ctx context.Context, movement models.StockMovement) (models.StockMovement, error) {
+	args := m.Called(ctx, movement)
+	if args.Get(0) == nil {
+		return models.StockMovement{}, args.Error(1)
+	}
+	return args.Get(0).(models.StockMovement), args.Error(1)
+}
+
+func (m *StockMovementRepositoryDBMock) Transfer(ctx context.Context, movement models.StockMovement) ([]models.StockMovement, error) {
+	args := m.Called(ctx, movement)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StockMovement), args.Error(1)
+}
+
+func (m *StockMovementRepositoryDBMock) GetByProductBatchID(ctx context.Context, productBatchID int) ([]models.StockMovement, error) {
+	args := m.Called(ctx, productBatchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StockMovement), args.Error(1)
+}
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// TrackingEventRepositoryMock is a mock of TrackingEventRepository
+type TrackingEventRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *TrackingEventRepositoryMock) Create(ctx context.Context, event models.TrackingEvent) (models.TrackingEvent, error) {
+	args := m.Called(ctx, event)
+	return args.Get(0).(models.TrackingEvent), args.Error(1)
+}
+
+func (m *TrackingEventRepositoryMock) GetTimeline(ctx context.Context, trackingCode string) (models.TrackingTimeline, error) {
+	args := m.Called(ctx, trackingCode)
+	return args.Get(0).(models.TrackingTimeline), args.Error(1)
+}
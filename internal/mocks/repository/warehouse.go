@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/stretchr/testify/mock"
 )
@@ -10,42 +12,42 @@ type WarehouseRepositoryMock struct {
 	mock.Mock
 }
 
-// GetAll retrieves all warehouses from the mock repository.
-// Receives: nothing.
-// Returns: a slice of Warehouse and an error.
-func (w *WarehouseRepositoryMock) GetAll() ([]models.Warehouse, error) {
-	args := w.Called()
-	return args.Get(0).([]models.Warehouse), args.Error(1)
+// GetAll retrieves a page of warehouses from the mock repository.
+// Receives: the query options of the page.
+// Returns: a Page of Warehouse and an error.
+func (w *WarehouseRepositoryMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Warehouse], error) {
+	args := w.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.Warehouse]), args.Error(1)
 }
 
 // Create adds a new warehouse to the mock repository.
 // Receives: WarehouseAttributes with the data for the new warehouse.
 // Returns: the created Warehouse and an error.
-func (w *WarehouseRepositoryMock) Create(warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
-	args := w.Called(warehouseAttributes)
+func (w *WarehouseRepositoryMock) Create(ctx context.Context, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
+	args := w.Called(ctx, warehouseAttributes)
 	return args.Get(0).(models.Warehouse), args.Error(1)
 }
 
 // Update modifies an existing warehouse in the mock repository.
 // Receives: an int (id) identifying the warehouse, and WarehouseAttributes with updated data.
 // Returns: the updated Warehouse and an error.
-func (w *WarehouseRepositoryMock) Update(id int, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
-	args := w.Called(id, warehouseAttributes)
+func (w *WarehouseRepositoryMock) Update(ctx context.Context, id int, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
+	args := w.Called(ctx, id, warehouseAttributes)
 	return args.Get(0).(models.Warehouse), args.Error(1)
 }
 
 // GetByID retrieves a warehouse by its id from the mock repository.
 // Receives: an int (id) identifying the warehouse.
 // Returns: the Warehouse and an error.
-func (w *WarehouseRepositoryMock) GetByID(id int) (models.Warehouse, error) {
-	args := w.Called(id)
+func (w *WarehouseRepositoryMock) GetByID(ctx context.Context, id int) (models.Warehouse, error) {
+	args := w.Called(ctx, id)
 	return args.Get(0).(models.Warehouse), args.Error(1)
 }
 
 // Delete removes a warehouse by its id from the mock repository.
 // Receives: an int (id) identifying the warehouse.
 // Returns: an error if the operation fails.
-func (w *WarehouseRepositoryMock) Delete(id int) error {
-	args := w.Called(id)
+func (w *WarehouseRepositoryMock) Delete(ctx context.Context, id int) error {
+	args := w.Called(ctx, id)
 	return args.Error(0)
 }
@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type AlertServiceMock struct {
+	mock.Mock
+}
+
+func (m *AlertServiceMock) GetAll(ctx context.Context, filter models.AlertFilter) (models.Page[models.Alert], error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return models.Page[models.Alert]{}, args.Error(1)
+	}
+	return args.Get(0).(models.Page[models.Alert]), args.Error(1)
+}
+
+func (m *AlertServiceMock) Acknowledge(ctx context.Context, id int) (models.Alert, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Alert), args.Error(1)
+}
@@ -22,9 +22,9 @@ func (m *BuyerServiceDefaultMock) Create(ctx context.Context, newBuyer models.Bu
 	return args.Get(0).(models.Buyer), args.Error(1)
 }
 
-func (m *BuyerServiceDefaultMock) GetAll(ctx context.Context) ([]models.Buyer, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]models.Buyer), args.Error(1)
+func (m *BuyerServiceDefaultMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Buyer], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.Buyer]), args.Error(1)
 }
 
 func (m *BuyerServiceDefaultMock) GetByID(ctx context.Context, id int) (models.Buyer, error) {
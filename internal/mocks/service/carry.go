@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// CarryServiceMock is a mock of CarryService
+type CarryServiceMock struct {
+	mock.Mock
+}
+
+func (m *CarryServiceMock) Create(ctx context.Context, carryAttributes models.CarryAttributes) (models.Carry, error) {
+	args := m.Called(ctx, carryAttributes)
+	return args.Get(0).(models.Carry), args.Error(1)
+}
+
+func (m *CarryServiceMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Carry], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.Carry]), args.Error(1)
+}
+
+func (m *CarryServiceMock) GetByID(ctx context.Context, id int) (models.Carry, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Carry), args.Error(1)
+}
+
+func (m *CarryServiceMock) Update(ctx context.Context, id int, carryPatch models.CarryPatchRequest) (models.Carry, error) {
+	args := m.Called(ctx, id, carryPatch)
+	return args.Get(0).(models.Carry), args.Error(1)
+}
+
+func (m *CarryServiceMock) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
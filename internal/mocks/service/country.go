@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// CountryServiceMock is a mock of CountryService
+type CountryServiceMock struct {
+	mock.Mock
+}
+
+func (m *CountryServiceMock) Create(ctx context.Context, country models.CountryAttributes) (models.Country, error) {
+	args := m.Called(ctx, country)
+	return args.Get(0).(models.Country), args.Error(1)
+}
+
+func (m *CountryServiceMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Country], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.Country]), args.Error(1)
+}
+
+func (m *CountryServiceMock) GetByID(ctx context.Context, id int) (models.Country, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Country), args.Error(1)
+}
+
+func (m *CountryServiceMock) Update(ctx context.Context, id int, country models.CountryPatchRequest) (models.Country, error) {
+	args := m.Called(ctx, id, country)
+	return args.Get(0).(models.Country), args.Error(1)
+}
+
+func (m *CountryServiceMock) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *CountryServiceMock) GetSellerReport(ctx context.Context, id *int) ([]models.RegionSellerReport, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]models.RegionSellerReport), args.Error(1)
+}
+
+func (m *CountryServiceMock) GetCarryReport(ctx context.Context, id *int) ([]models.RegionCarryReport, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]models.RegionCarryReport), args.Error(1)
+}
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/stretchr/testify/mock"
 )
@@ -10,32 +12,32 @@ type MockEmployeeService struct {
 	mock.Mock
 }
 
-func (m *MockEmployeeService) Create(employee models.EmployeeAttributes) (models.Employee, error) {
-	args := m.Called(employee)
+func (m *MockEmployeeService) Create(ctx context.Context, employee models.EmployeeAttributes) (models.Employee, error) {
+	args := m.Called(ctx, employee)
 	return args.Get(0).(models.Employee), args.Error(1)
 }
 
-func (m *MockEmployeeService) GetAll() ([]models.Employee, error) {
-	args := m.Called()
-	return args.Get(0).([]models.Employee), args.Error(1)
+func (m *MockEmployeeService) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Employee], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.Employee]), args.Error(1)
 }
 
-func (m *MockEmployeeService) GetByID(id int) (models.Employee, error) {
-	args := m.Called(id)
+func (m *MockEmployeeService) GetByID(ctx context.Context, id int) (models.Employee, error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).(models.Employee), args.Error(1)
 }
 
-func (m *MockEmployeeService) Update(id int, employee models.EmployeeAttributes) (models.Employee, error) {
-	args := m.Called(id, employee)
+func (m *MockEmployeeService) Update(ctx context.Context, id int, employee models.EmployeeAttributes) (models.Employee, error) {
+	args := m.Called(ctx, id, employee)
 	return args.Get(0).(models.Employee), args.Error(1)
 }
 
-func (m *MockEmployeeService) Delete(id int) error {
-	args := m.Called(id)
+func (m *MockEmployeeService) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockEmployeeService) ReportInboundOrders(employeeID int) ([]models.EmployeeWithInboundCount, error) {
-	args := m.Called(employeeID)
+func (m *MockEmployeeService) ReportInboundOrders(ctx context.Context, employeeID int) ([]models.EmployeeWithInboundCount, error) {
+	args := m.Called(ctx, employeeID)
 	return args.Get(0).([]models.EmployeeWithInboundCount), args.Error(1)
 }
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// HealthServiceMock is a mock of HealthService
+type HealthServiceMock struct {
+	mock.Mock
+}
+
+func (m *HealthServiceMock) Live(ctx context.Context) models.HealthReport {
+	args := m.Called(ctx)
+	return args.Get(0).(models.HealthReport)
+}
+
+func (m *HealthServiceMock) Ready(ctx context.Context) models.HealthReport {
+	args := m.Called(ctx)
+	return args.Get(0).(models.HealthReport)
+}
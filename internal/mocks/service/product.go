@@ -16,9 +16,9 @@ func (m *ProductServiceMock) Create(ctx context.Context, newProduct models.Produ
 	return args.Get(0).(models.Product), args.Error(1)
 }
 
-func (m *ProductServiceMock) GetAll(ctx context.Context) ([]models.Product, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]models.Product), args.Error(1)
+func (m *ProductServiceMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Product], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.Product]), args.Error(1)
 }
 
 func (m *ProductServiceMock) GetByID(ctx context.Context, id int) (models.Product, error) {
@@ -31,6 +31,11 @@ func (m *ProductServiceMock) GetRecordsPerProduct(ctx context.Context, id *int)
 	return args.Get(0).([]models.ProductRecordCount), args.Error(1)
 }
 
+func (m *ProductServiceMock) GetMarginReport(ctx context.Context, filter models.MarginReportFilter) (models.MarginReport, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(models.MarginReport), args.Error(1)
+}
+
 func (m *ProductServiceMock) Update(ctx context.Context, id int, updatedProduct models.ProductPatchRequest) (models.Product, error) {
 	args := m.Called(ctx, id, updatedProduct)
 	return args.Get(0).(models.Product), args.Error(1)
@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type ProductBatchServiceMock struct {
+	mock.Mock
+}
+
+func (m *ProductBatchServiceMock) Create(ctx context.Context, productBatch models.ProductBatchAttibutes) (models.ProductBatch, error) {
+	args := m.Called(ctx, productBatch)
+	return args.Get(0).(models.ProductBatch), args.Error(1)
+}
+
+func (m *ProductBatchServiceMock) GetAll(ctx context.Context, filter models.ProductBatchFilter) (models.Page[models.ProductBatch], error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return models.Page[models.ProductBatch]{}, args.Error(1)
+	}
+	return args.Get(0).(models.Page[models.ProductBatch]), args.Error(1)
+}
+
+func (m *ProductBatchServiceMock) GetByID(ctx context.Context, id int) (models.ProductBatch, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.ProductBatch), args.Error(1)
+}
+
+func (m *ProductBatchServiceMock) Update(ctx context.Context, id int, data models.UpdateProductBatchRequest) (models.ProductBatch, error) {
+	args := m.Called(ctx, id, data)
+	return args.Get(0).(models.ProductBatch), args.Error(1)
+}
+
+func (m *ProductBatchServiceMock) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
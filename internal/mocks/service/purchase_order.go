@@ -21,3 +21,23 @@ func (m *PurchaseOrderDefaultMock) Create(ctx context.Context, newPurchaseOrder
 	args := m.Called(ctx, newPurchaseOrder)
 	return args.Get(0).(models.PurchaseOrder), args.Error(1)
 }
+
+func (m *PurchaseOrderDefaultMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.PurchaseOrder], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.PurchaseOrder]), args.Error(1)
+}
+
+func (m *PurchaseOrderDefaultMock) GetByID(ctx context.Context, id int) (models.PurchaseOrder, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.PurchaseOrder), args.Error(1)
+}
+
+func (m *PurchaseOrderDefaultMock) Transition(ctx context.Context, id int, status string) (models.PurchaseOrder, error) {
+	args := m.Called(ctx, id, status)
+	return args.Get(0).(models.PurchaseOrder), args.Error(1)
+}
+
+func (m *PurchaseOrderDefaultMock) AssignCarry(ctx context.Context, id int, carryID *int) (models.PurchaseOrder, error) {
+	args := m.Called(ctx, id, carryID)
+	return args.Get(0).(models.PurchaseOrder), args.Error(1)
+}
@@ -15,9 +15,9 @@ func (m *SectionServiceMock) Create(ctx context.Context, section models.Section)
 	args := m.Called(ctx, section)
 	return args.Get(0).(models.Section), args.Error(1)
 }
-func (m *SectionServiceMock) GetAll(ctx context.Context) ([]models.Section, error){
-	args := m.Called(ctx)
-	return args.Get(0).([]models.Section), args.Error(1)
+func (m *SectionServiceMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Section], error){
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.Section]), args.Error(1)
 }
 func (m *SectionServiceMock) GetByID(ctx context.Context, id int) (models.Section, error){
 	args := m.Called(ctx, id)
@@ -38,4 +38,12 @@ func (m *SectionServiceMock) GetProductsReport(ctx context.Context, id int) (mod
 func (m *SectionServiceMock) GetAllProductsReport(ctx context.Context) ([]models.SectionProductsReport, error){
 	args := m.Called(ctx)
 	return args.Get(0).([]models.SectionProductsReport), args.Error(1)
-}
\ No newline at end of file
+}
+func (m *SectionServiceMock) GetExpiringBatchesReport(ctx context.Context, days int, warehouseID int) ([]models.SectionExpiringBatchesReport, error){
+	args := m.Called(ctx, days, warehouseID)
+	return args.Get(0).([]models.SectionExpiringBatchesReport), args.Error(1)
+}
+func (m *SectionServiceMock) GetCapacityReport(ctx context.Context, warehouseID int) ([]models.WarehouseCapacityReport, error){
+	args := m.Called(ctx, warehouseID)
+	return args.Get(0).([]models.WarehouseCapacityReport), args.Error(1)
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type SectionTemperatureReadingServiceMock struct {
+	mock.Mock
+}
+
+func (m *SectionTemperatureReadingServiceMock) CreateBulk(ctx context.Context, sectionID int, readings []models.CreateTemperatureReadingRequest) ([]models.SectionTemperatureReading, error) {
+	args := m.Called(ctx, sectionID, readings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SectionTemperatureReading), args.Error(1)
+}
+
+func (m *SectionTemperatureReadingServiceMock) GetSeries(ctx context.Context, sectionID int, from, to time.Time, interval time.Duration) (models.SectionTemperatureSeries, error) {
+	args := m.Called(ctx, sectionID, from, to, interval)
+	return args.Get(0).(models.SectionTemperatureSeries), args.Error(1)
+}
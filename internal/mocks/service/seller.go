@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/stretchr/testify/mock"
 )
@@ -9,27 +11,27 @@ type SellerServiceDBMock struct {
 	mock.Mock
 }
 
-func (m *SellerServiceDBMock) Create(attr models.SellerAttributes) (models.Seller, error) {
-	args := m.Called(attr)
+func (m *SellerServiceDBMock) Create(ctx context.Context, attr models.SellerAttributes) (models.Seller, error) {
+	args := m.Called(ctx, attr)
 	return args.Get(0).(models.Seller), args.Error(1)
 }
 
-func (m *SellerServiceDBMock) Delete(id int) error {
-	args := m.Called(id)
+func (m *SellerServiceDBMock) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *SellerServiceDBMock) GetAll() ([]models.Seller, error) {
-	args := m.Called()
-	return args.Get(0).([]models.Seller), args.Error(1)
+func (m *SellerServiceDBMock) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Seller], error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(models.Page[models.Seller]), args.Error(1)
 }
 
-func (m *SellerServiceDBMock) GetByID(id int) (models.Seller, error) {
-	args := m.Called(id)
+func (m *SellerServiceDBMock) GetByID(ctx context.Context, id int) (models.Seller, error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).(models.Seller), args.Error(1)
 }
 
-func (m *SellerServiceDBMock) Update(id int, data *models.SellerAttributes) (models.Seller, error) {
-	args := m.Called(id, data)
+func (m *SellerServiceDBMock) Update(ctx context.Context, id int, data *models.SellerAttributes) (models.Seller, error) {
+	args := m.Called(ctx, id, data)
 	return args.Get(0).(models.Seller), args.Error(1)
 }
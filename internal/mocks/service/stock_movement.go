@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type StockMovementServiceMock struct {
+	mock.Mock
+}
+
+func (m *StockMovementServiceMock) Create(ctx context.Context, productBatchID int, movement models.CreateStockMovementRequest) ([]models.StockMovement, error) {
+	args := m.Called(ctx, productBatchID, movement)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StockMovement), args.Error(1)
+}
+
+func (m *StockMovementServiceMock) GetByProductBatchID(ctx context.Context, productBatchID int) ([]models.StockMovement, error) {
+	args := m.Called(ctx, productBatchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StockMovement), args.Error(1)
+}
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// TrackingEventServiceMock is a mock of TrackingEventService
+type TrackingEventServiceMock struct {
+	mock.Mock
+}
+
+func (m *TrackingEventServiceMock) Create(ctx context.Context, purchaseOrderID int, event models.CreateTrackingEventRequest) (models.TrackingEvent, error) {
+	args := m.Called(ctx, purchaseOrderID, event)
+	return args.Get(0).(models.TrackingEvent), args.Error(1)
+}
+
+func (m *TrackingEventServiceMock) GetTimeline(ctx context.Context, trackingCode string) (models.TrackingTimeline, error) {
+	args := m.Called(ctx, trackingCode)
+	return args.Get(0).(models.TrackingTimeline), args.Error(1)
+}
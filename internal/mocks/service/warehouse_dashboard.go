@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// WarehouseDashboardServiceMock is a mock of WarehouseDashboardService
+type WarehouseDashboardServiceMock struct {
+	mock.Mock
+}
+
+func (m *WarehouseDashboardServiceMock) GetDashboard(ctx context.Context, warehouseID, days, expiringDays int) (models.WarehouseDashboard, error) {
+	args := m.Called(ctx, warehouseID, days, expiringDays)
+	return args.Get(0).(models.WarehouseDashboard), args.Error(1)
+}
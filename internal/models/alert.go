@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Alert statuses. An alert starts open, may be acknowledged by an operator
+// and is resolved automatically once the temperature is back in range.
+const (
+	AlertStatusOpen         = "open"
+	AlertStatusAcknowledged = "acknowledged"
+	AlertStatusResolved     = "resolved"
+)
+
+// Alert types, one for each temperature rule
+const (
+	// AlertTypeSectionMinimum is raised when a section is colder than its minimum temperature
+	AlertTypeSectionMinimum = "section_minimum_temperature"
+	// AlertTypeProductRecommended is raised when a section is warmer than the
+	// recommended freezing temperature of a product stored there
+	AlertTypeProductRecommended = "product_recommended_temperature"
+)
+
+// Alert is a temperature breach detected in a section.
+// ProductBatchID is only set for breaches of a product recommended temperature.
+type Alert struct {
+	ID             int        `json:"id"`
+	SectionID      int        `json:"section_id"`
+	ProductBatchID *int       `json:"product_batch_id,omitempty"`
+	AlertType      string     `json:"alert_type"`
+	Status         string     `json:"status"`
+	Temperature    float64    `json:"temperature"`
+	Threshold      float64    `json:"threshold"`
+	Message        string     `json:"message"`
+	CreatedAt      time.Time  `json:"created_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+}
+
+// AlertFilter narrows the alerts returned by GetAll and selects their page.
+// Zero values mean no filter.
+type AlertFilter struct {
+	QueryOptions
+	Status    string
+	SectionID int
+}
+
+// BatchTemperatureTarget is the recommended temperature of the product of a batch.
+type BatchTemperatureTarget struct {
+	ProductBatchID                 int
+	ProductID                      int
+	RecommendedFreezingTemperature float64
+}
+
+// SectionTemperatureTargets holds every threshold a section temperature is checked against.
+type SectionTemperatureTargets struct {
+	SectionID          int
+	MinimumTemperature float64
+	Batches            []BatchTemperatureTarget
+}
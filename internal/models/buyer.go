@@ -6,16 +6,18 @@ type Buyer struct {
 }
 
 type BuyerAttributes struct {
-	CardNumberId int    `json:"card_number_id" validate:"required,gte=10000000,lte=99999999"` // like a dni: 8 digits
-	FirstName    string `json:"first_name" validate:"required,min=1,max=100,alphaunicode"`    // only letters with simbols
-	LastName     string `json:"last_name" validate:"required,min=1,max=100,alphaunicode"`     // only letters with simbols
+	CardNumberId       int     `json:"card_number_id" validate:"required,gte=10000000,lte=99999999"` // like a dni: 8 digits
+	FirstName          string  `json:"first_name" validate:"required,min=1,max=100,alphaunicode"`    // only letters with simbols
+	LastName           string  `json:"last_name" validate:"required,min=1,max=100,alphaunicode"`     // only letters with simbols
+	DeliveryLocalityId *string `json:"delivery_locality_id,omitempty" validate:"omitempty,max=20"`   // where orders are delivered, used to pick a carry
 }
 
 // modeld used for patch methods
 type BuyerPatchRequest struct {
-	CardNumberId *int    `json:"card_number_id" validate:"omitempty,gte=10000000,lte=99999999"` // like a dni: 8 digits
-	FirstName    *string `json:"first_name" validate:"omitempty,min=1,max=100,alphaunicode"`    // only letters with simbols
-	LastName     *string `json:"last_name" validate:"omitempty,min=1,max=100,alphaunicode"`     // only letters with simbols
+	CardNumberId       *int    `json:"card_number_id" validate:"omitempty,gte=10000000,lte=99999999"` // like a dni: 8 digits
+	FirstName          *string `json:"first_name" validate:"omitempty,min=1,max=100,alphaunicode"`    // only letters with simbols
+	LastName           *string `json:"last_name" validate:"omitempty,min=1,max=100,alphaunicode"`     // only letters with simbols
+	DeliveryLocalityId *string `json:"delivery_locality_id" validate:"omitempty,max=20"`
 }
 
 type BuyerWithPurchaseOrdersCount struct {
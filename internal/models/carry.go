@@ -8,11 +8,20 @@ type Carry struct {
 
 // CarryAttributes holds the details of a carry, such as company name, address, telephone, and locality ID.
 type CarryAttributes struct {
-	Cid         string `json:"cid"`
-	CompanyName string `json:"company_name"`
-	Address     string `json:"address"`
-	Telephone   string `json:"telephone"`
-	LocalityId  string `json:"locality_id"`
+	Cid         string `json:"cid" validate:"required,max=20"`
+	CompanyName string `json:"company_name" validate:"required,max=100"`
+	Address     string `json:"address" validate:"required,max=100"`
+	Telephone   string `json:"telephone" validate:"required,max=100"`
+	LocalityId  string `json:"locality_id" validate:"required,max=20"`
+}
+
+// CarryPatchRequest holds the fields of a carry to update, nil fields are left unchanged.
+type CarryPatchRequest struct {
+	Cid         *string `json:"cid" validate:"omitempty,max=20"`
+	CompanyName *string `json:"company_name" validate:"omitempty,max=100"`
+	Address     *string `json:"address" validate:"omitempty,max=100"`
+	Telephone   *string `json:"telephone" validate:"omitempty,max=100"`
+	LocalityId  *string `json:"locality_id" validate:"omitempty,max=20"`
 }
 
 // CarryReport is used for reporting purposes, containing locality information and the count of carries.
@@ -0,0 +1,22 @@
+package models
+
+// health statuses of the api and of each of its checks
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// HealthCheck is the result of checking one dependency of the api
+type HealthCheck struct {
+	Name              string   `json:"name"`
+	Status            string   `json:"status"`
+	Error             string   `json:"error,omitempty"`
+	PendingMigrations []string `json:"pending_migrations,omitempty"`
+}
+
+// HealthReport is the status of the api, down when any of its checks is down
+type HealthReport struct {
+	Status  string        `json:"status"`
+	Version string        `json:"version"`
+	Checks  []HealthCheck `json:"checks,omitempty"`
+}
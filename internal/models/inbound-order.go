@@ -3,17 +3,40 @@ package models
 import "time"
 
 // InboundOrder represents an inbound order in the system.
+// Quantity is the number of units received into the product batch.
 type InboundOrder struct {
-	ID int `json:"id"`
-	InboundOrderAttributes
+	ID             int       `json:"id"`
+	OrderNumber    string    `json:"order_number"`
+	OrderDate      time.Time `json:"order_date"`
+	EmployeeID     int       `json:"employee_id"`
+	WarehouseID    int       `json:"warehouse_id"`
+	ProductBatchID int       `json:"product_batch_id"`
+	Quantity       int       `json:"quantity"`
 }
 
+// InboundOrderAttributes is the body of an inbound order being received.
+// The employee and the section of the batch must belong to the warehouse.
 type InboundOrderAttributes struct {
-	OrderNumber    string    `json:"order_number" validate:"required"`
-	OrderDate      time.Time `json:"order_date"`
-	EmployeeID     int       `json:"employee_id" validate:"required,gt=0"`
-	WarehouseID    int       `json:"warehouse_id" validate:"required,gt=0"`
-	ProductBatchID int       `json:"product_batch_id" validate:"required,gt=0"`
+	OrderNumber  string                      `json:"order_number" validate:"required"`
+	OrderDate    time.Time                   `json:"order_date"`
+	EmployeeID   int                         `json:"employee_id" validate:"required,gt=0"`
+	WarehouseID  int                         `json:"warehouse_id" validate:"required,gt=0"`
+	ProductBatch InboundOrderBatchAttributes `json:"product_batch"`
+}
+
+// InboundOrderBatchAttributes describes the batch received by an inbound order.
+// A new batch_number creates the batch with Quantity units, an existing one
+// adds Quantity to that batch, which must hold the same product in the same section.
+type InboundOrderBatchAttributes struct {
+	BatchNumber        int     `json:"batch_number" validate:"required,min=1"`
+	Quantity           int     `json:"quantity" validate:"required,gt=0"`
+	CurrentTemperature float64 `json:"current_temperature" validate:"required"`
+	DueDate            string  `json:"due_date" validate:"required,date_format"`
+	ManufacturingDate  string  `json:"manufacturing_date" validate:"required,date_format"`
+	ManufacturingHour  int     `json:"manufacturing_hour" validate:"required"`
+	MinimumTemperature float64 `json:"minimum_temperature" validate:"required"`
+	ProductID          int     `json:"product_id" validate:"required,gt=0"`
+	SectionID          int     `json:"section_id" validate:"required,gt=0"`
 }
 
 type EmployeeWithInboundCount struct {
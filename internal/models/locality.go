@@ -1,14 +1,75 @@
 package models
 
+// CountryAttributes represents all the required attributes needed
+// to create a country.
+type CountryAttributes struct {
+	CountryName string `json:"country_name" validate:"required,max=100"`
+}
+
+// CountryPatchRequest holds the optional fields for partial updates.
+type CountryPatchRequest struct {
+	CountryName *string `json:"country_name,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// Country is the top level of the locality hierarchy.
+type Country struct {
+	ID int `json:"id"`
+	CountryAttributes
+}
+
+// ProvinceAttributes represents all the required attributes needed
+// to create a province. Province names are unique within their country.
+type ProvinceAttributes struct {
+	ProvinceName string `json:"province_name" validate:"required,max=100"`
+	CountryID    int    `json:"country_id" validate:"required,gt=0"`
+}
+
+// ProvincePatchRequest holds the optional fields for partial updates.
+type ProvincePatchRequest struct {
+	ProvinceName *string `json:"province_name,omitempty" validate:"omitempty,min=1,max=100"`
+	CountryID    *int    `json:"country_id,omitempty" validate:"omitempty,gt=0"`
+}
+
+// Province groups the localities of a country.
+type Province struct {
+	ID int `json:"id"`
+	ProvinceAttributes
+}
+
+// Locality is identified by its postal code and belongs to a province.
+// ProvinceName, CountryID and CountryName are read from the hierarchy
+// and ignored on writes.
 type Locality struct {
-	ID           string `json:"id"`
-	LocalityName string `json:"locality_name"`
+	ID           string `json:"id" validate:"required,max=20"`
+	LocalityName string `json:"locality_name" validate:"required,max=100"`
+	ProvinceID   int    `json:"province_id" validate:"required,gt=0"`
 	ProvinceName string `json:"province_name"`
+	CountryID    int    `json:"country_id"`
 	CountryName  string `json:"country_name"`
 }
 
+// LocalityPatchRequest holds the optional fields for partial updates.
+type LocalityPatchRequest struct {
+	LocalityName *string `json:"locality_name,omitempty" validate:"omitempty,min=1,max=100"`
+	ProvinceID   *int    `json:"province_id,omitempty" validate:"omitempty,gt=0"`
+}
+
 type SellerReport struct {
 	LocalityID   string `json:"locality_id"`
 	LocalityName string `json:"locality_name"`
 	SellersCount int    `json:"sellers_count"`
 }
+
+// RegionSellerReport counts the sellers of the localities in a province or a country.
+type RegionSellerReport struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	SellersCount int    `json:"sellers_count"`
+}
+
+// RegionCarryReport counts the carries of the localities in a province or a country.
+type RegionCarryReport struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	CarriesCount int    `json:"carries_count"`
+}
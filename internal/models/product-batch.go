@@ -25,4 +25,28 @@ type ProductBatchAttibutes struct {
 // It includes validation tags for go-playground/validator.
 type CreateProductBatchRequest struct {
 	Data ProductBatchAttibutes `json:"data" validate:"required"`
-}
\ No newline at end of file
+}
+// UpdateProductBatchRequest defines the structure for a partial update of a ProductBatch.
+// Only the fields present in the body are changed.
+type UpdateProductBatchRequest struct {
+	BatchNumber        *int     `json:"batch_number,omitempty" validate:"omitempty,min=1"`
+	CurrentQuantity    *int     `json:"current_quantity,omitempty" validate:"omitempty,gte=0"`
+	CurrentTemperature *float64 `json:"current_temperature,omitempty"`
+	DueDate            *string  `json:"due_date,omitempty" validate:"omitempty,date_format"`
+	InitialQuantity    *int     `json:"initial_quantity,omitempty" validate:"omitempty,gte=0"`
+	ManufacturingDate  *string  `json:"manufacturing_date,omitempty" validate:"omitempty,date_format"`
+	ManufacturingHour  *int     `json:"manufacturing_hour,omitempty"`
+	MinimumTemperature *float64 `json:"minimum_temperature,omitempty"`
+	ProductID          *int     `json:"product_id,omitempty" validate:"omitempty,gt=0"`
+	SectionID          *int     `json:"section_id,omitempty" validate:"omitempty,gt=0"`
+}
+
+// ProductBatchFilter narrows the product batches returned by GetAll and selects their page.
+// Zero values mean no filter, due dates are inclusive and use the YYYY-MM-DD format.
+type ProductBatchFilter struct {
+	QueryOptions
+	SectionID   int
+	ProductID   int
+	DueDateFrom string
+	DueDateTo   string
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+// MarginReportFilter narrows the purchase orders a margin report is built from.
+// Dates are inclusive, use the YYYY-MM-DD format and apply to the order date.
+// Zero values mean no filter.
+type MarginReportFilter struct {
+	From     string
+	To       string
+	SellerID *int
+}
+
+// ProductMargin is what a product sold over a period and what it earned.
+// Revenue uses the unit price of each order line and Cost the purchase price
+// in effect on the order date. MarginPercentage is the margin over the revenue.
+type ProductMargin struct {
+	ProductID        int     `json:"product_id"`
+	Description      string  `json:"description"`
+	SellerID         *int    `json:"seller_id"`
+	UnitsSold        int     `json:"units_sold"`
+	Revenue          float64 `json:"revenue"`
+	Cost             float64 `json:"cost"`
+	Margin           float64 `json:"margin"`
+	MarginPercentage float64 `json:"margin_percentage"`
+}
+
+// SellerMargin rolls up the margins of the products of a seller.
+// A nil SellerID groups the products without seller.
+type SellerMargin struct {
+	SellerID         *int    `json:"seller_id"`
+	UnitsSold        int     `json:"units_sold"`
+	Revenue          float64 `json:"revenue"`
+	Cost             float64 `json:"cost"`
+	Margin           float64 `json:"margin"`
+	MarginPercentage float64 `json:"margin_percentage"`
+}
+
+// MarginReport is the profitability of the products sold over a period, per product and per seller.
+type MarginReport struct {
+	From     string          `json:"from,omitempty"`
+	To       string          `json:"to,omitempty"`
+	Products []ProductMargin `json:"products"`
+	Sellers  []SellerMargin  `json:"sellers"`
+}
+
+// NewMarginReport completes the margins of products, which only carry units, revenue
+// and cost, and rolls them up per seller, products without seller last.
+func NewMarginReport(filter MarginReportFilter, products []ProductMargin) MarginReport {
+	report := MarginReport{
+		From:     filter.From,
+		To:       filter.To,
+		Products: make([]ProductMargin, 0, len(products)),
+		Sellers:  make([]SellerMargin, 0),
+	}
+
+	sellers := make(map[int]*SellerMargin)
+	var withoutSeller *SellerMargin
+	for _, product := range products {
+		product.Revenue = roundCents(product.Revenue)
+		product.Cost = roundCents(product.Cost)
+		product.Margin, product.MarginPercentage = marginOf(product.Revenue, product.Cost)
+		report.Products = append(report.Products, product)
+
+		var seller *SellerMargin
+		if product.SellerID == nil {
+			if withoutSeller == nil {
+				withoutSeller = &SellerMargin{}
+			}
+			seller = withoutSeller
+		} else {
+			if sellers[*product.SellerID] == nil {
+				id := *product.SellerID
+				sellers[id] = &SellerMargin{SellerID: &id}
+			}
+			seller = sellers[*product.SellerID]
+		}
+		seller.UnitsSold += product.UnitsSold
+		seller.Revenue += product.Revenue
+		seller.Cost += product.Cost
+	}
+
+	for _, seller := range sellers {
+		report.Sellers = append(report.Sellers, *seller)
+	}
+	sort.Slice(report.Sellers, func(i, j int) bool {
+		return *report.Sellers[i].SellerID < *report.Sellers[j].SellerID
+	})
+	if withoutSeller != nil {
+		report.Sellers = append(report.Sellers, *withoutSeller)
+	}
+	for i := range report.Sellers {
+		seller := &report.Sellers[i]
+		seller.Revenue = roundCents(seller.Revenue)
+		seller.Cost = roundCents(seller.Cost)
+		seller.Margin, seller.MarginPercentage = marginOf(seller.Revenue, seller.Cost)
+	}
+	return report
+}
+
+// marginOf returns the margin of revenue over cost and its percentage of the revenue,
+// which is 0 when nothing was earned
+func marginOf(revenue, cost float64) (float64, float64) {
+	margin := roundCents(revenue - cost)
+	if revenue == 0 {
+		return margin, 0
+	}
+	return margin, roundCents(margin / revenue * 100)
+}
+
+// roundCents rounds an amount to two decimals
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
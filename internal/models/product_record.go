@@ -15,3 +15,12 @@ type ProductRecord struct {
 	ID int `json:"id"`
 	ProductRecordAttributes
 }
+
+// ProductRecordFilter narrows the price history of a product and selects its page.
+// Zero values mean no filter, dates are inclusive and use the YYYY-MM-DD format.
+type ProductRecordFilter struct {
+	QueryOptions
+	ProductID int
+	DateFrom  string
+	DateTo    string
+}
@@ -0,0 +1,19 @@
+package models
+
+// ProductTypeAttributes represents all the required attributes needed
+// to create a product type.
+type ProductTypeAttributes struct {
+	Description string `json:"description" validate:"required,min=1,max=255"`
+}
+
+// ProductTypePatchRequest holds the optional fields for partial updates.
+type ProductTypePatchRequest struct {
+	Description *string `json:"description,omitempty" validate:"omitempty,min=1,max=255"`
+}
+
+// ProductType is an entry of the product type catalog. Products and sections
+// reference it, and a section only stores batches of its own product type.
+type ProductType struct {
+	ID int `json:"id"`
+	ProductTypeAttributes
+}
@@ -1,18 +1,131 @@
 package models
 
 import (
+	"math"
+	"slices"
 	"time"
 )
 
+// Purchase order statuses. An order moves forward one step at a time from
+// pending to delivered and can be cancelled until it is shipped.
+const (
+	PurchaseOrderStatusPending   = "pending"
+	PurchaseOrderStatusConfirmed = "confirmed"
+	PurchaseOrderStatusPicked    = "picked"
+	PurchaseOrderStatusShipped   = "shipped"
+	PurchaseOrderStatusDelivered = "delivered"
+	PurchaseOrderStatusCancelled = "cancelled"
+)
+
+// purchaseOrderTransitions lists the statuses each status can move to
+var purchaseOrderTransitions = map[string][]string{
+	PurchaseOrderStatusPending:   {PurchaseOrderStatusConfirmed, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusConfirmed: {PurchaseOrderStatusPicked, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusPicked:    {PurchaseOrderStatusShipped, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusShipped:   {PurchaseOrderStatusDelivered},
+}
+
+// CanTransitionPurchaseOrder reports whether an order in status from can move to status to
+func CanTransitionPurchaseOrder(from, to string) bool {
+	return slices.Contains(purchaseOrderTransitions[from], to)
+}
+
+// CanAssignPurchaseOrderCarry reports whether an order in status can still change
+// the carry that delivers it, which is until the order is shipped
+func CanAssignPurchaseOrderCarry(status string) bool {
+	switch status {
+	case PurchaseOrderStatusPending, PurchaseOrderStatusConfirmed, PurchaseOrderStatusPicked:
+		return true
+	}
+	return false
+}
+
+// IsPurchaseOrderOpen reports whether an order in status is still to be delivered
+func IsPurchaseOrderOpen(status string) bool {
+	return status != PurchaseOrderStatusDelivered && status != PurchaseOrderStatusCancelled
+}
+
+// IsPurchaseOrderSold reports whether an order in status counts as a sale, which is
+// from the moment its stock is allocated unless it is cancelled
+func IsPurchaseOrderSold(status string) bool {
+	switch status {
+	case PurchaseOrderStatusConfirmed, PurchaseOrderStatusPicked, PurchaseOrderStatusShipped, PurchaseOrderStatusDelivered:
+		return true
+	}
+	return false
+}
+
+// PurchaseOrder is an order placed by a buyer for one or more products.
+// Total is the sum of its lines. CarryId is the carry that delivers it, nil
+// until one is assigned. Each *At field is the time the order reached that
+// status, nil until it does.
 type PurchaseOrder struct {
-	Id int `json:"id"`
-	PurchaseOrderAttributes
+	Id           int                 `json:"id"`
+	OrderNumber  string              `json:"order_number"`
+	OrderDate    time.Time           `json:"order_date"`
+	TrackingCode string              `json:"tracking_code"`
+	BuyerId      int                 `json:"buyer_id"`
+	CarryId      *int                `json:"carry_id,omitempty"`
+	Lines        []PurchaseOrderLine `json:"lines"`
+	Total        float64             `json:"total"`
+	Status       string              `json:"status"`
+	ConfirmedAt  *time.Time          `json:"confirmed_at,omitempty"`
+	PickedAt     *time.Time          `json:"picked_at,omitempty"`
+	ShippedAt    *time.Time          `json:"shipped_at,omitempty"`
+	DeliveredAt  *time.Time          `json:"delivered_at,omitempty"`
+	CancelledAt  *time.Time          `json:"cancelled_at,omitempty"`
 }
 
 type PurchaseOrderAttributes struct {
-	OrderNumber     string    `json:"order_number" validate:"required,max=255"`
-	OrderDate       time.Time `json:"order_date" validate:"required,notfuture"`
-	TrackingCode    string    `json:"tracking_code" validate:"required,max=255,alphanum"`
-	BuyerId         int       `json:"buyer_id" validate:"required,gt=0"`
-	ProductRecordId int       `json:"product_record_id" validate:"required,gt=0"`
+	OrderNumber  string                        `json:"order_number" validate:"required,max=255"`
+	OrderDate    time.Time                     `json:"order_date" validate:"required,notfuture"`
+	TrackingCode string                        `json:"tracking_code" validate:"required,max=255,alphanum"`
+	BuyerId      int                           `json:"buyer_id" validate:"required,gt=0"`
+	Lines        []PurchaseOrderLineAttributes `json:"lines" validate:"required,min=1,dive"`
+}
+
+// PurchaseOrderLine is a product of a purchase order. UnitPrice is the
+// sale price of the product when the order was created. Allocations are
+// the batches its quantity was taken from, set once the order is confirmed.
+type PurchaseOrderLine struct {
+	Id              int                       `json:"id"`
+	PurchaseOrderId int                       `json:"purchase_order_id"`
+	ProductId       int                       `json:"product_id"`
+	Quantity        int                       `json:"quantity"`
+	UnitPrice       float64                   `json:"unit_price"`
+	Allocations     []PurchaseOrderAllocation `json:"allocations,omitempty"`
+}
+
+// PurchaseOrderAllocation is the quantity of a purchase order line taken from a product batch
+type PurchaseOrderAllocation struct {
+	Id                  int `json:"id"`
+	PurchaseOrderLineId int `json:"purchase_order_line_id"`
+	ProductBatchId      int `json:"product_batch_id"`
+	Quantity            int `json:"quantity"`
+}
+
+// PurchaseOrderLineAttributes is a product and quantity requested in a new purchase order
+type PurchaseOrderLineAttributes struct {
+	ProductId int `json:"product_id" validate:"required,gt=0"`
+	Quantity  int `json:"quantity" validate:"required,gt=0"`
+}
+
+// PurchaseOrderTotal returns the amount of the lines, rounded to cents
+func PurchaseOrderTotal(lines []PurchaseOrderLine) float64 {
+	var total float64
+	for _, line := range lines {
+		total += float64(line.Quantity) * line.UnitPrice
+	}
+	return math.Round(total*100) / 100
+}
+
+// PurchaseOrderCarryRequest is the body of a carry assignment. Without CarryId
+// the carry is picked from the delivery locality of the buyer.
+type PurchaseOrderCarryRequest struct {
+	CarryId *int `json:"carry_id" validate:"omitempty,gt=0"`
+}
+
+// PurchaseOrderTransitionRequest is the body of a status change of a purchase order
+type PurchaseOrderTransitionRequest struct {
+	Status string `json:"status" validate:"required,oneof=confirmed picked shipped delivered cancelled"`
 }
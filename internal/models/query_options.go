@@ -0,0 +1,144 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryOptions holds the pagination, sorting and equality filters of a listing.
+// The zero value lists every row ordered by id.
+type QueryOptions struct {
+	// Limit is the maximum number of rows in the page, 0 means no limit.
+	Limit int
+	// After is the last row of the previous page, decoded from the request cursor.
+	// nil starts at the first row.
+	After *Cursor
+	// Sort is the JSON name of the field to order by, empty means id.
+	// Ties are always broken by id so pages never overlap.
+	Sort string
+	// Desc reverses the order.
+	Desc bool
+	// Filters maps the JSON name of a field to the value it must be equal to.
+	Filters map[string]string
+}
+
+// SortField returns the field the listing is ordered by, id when Sort is empty.
+func (opts QueryOptions) SortField() string {
+	if opts.Sort == "" {
+		return "id"
+	}
+	return opts.Sort
+}
+
+// Cursor is the position of a row in a listing sorted by Sort: the value of that
+// field and the id of the row. The next page holds the rows sorted after it, so
+// rows inserted or deleted meanwhile do not shift the pages (keyset pagination).
+type Cursor struct {
+	// Sort is the field the listing was sorted by, a cursor only applies to that order.
+	Sort string
+	// Value is nil for NULL, otherwise an int, float64, string or time.Time.
+	Value any
+	// ID is an int, or a string for listings whose id is a code.
+	ID any
+}
+
+// PageMeta describes a page within the whole listing.
+// NextCursor is null on the last page.
+type PageMeta struct {
+	NextCursor *string `json:"next_cursor"`
+	Total      int     `json:"total"`
+}
+
+// Page is one page of a listing together with its PageMeta.
+type Page[T any] struct {
+	Data []T
+	Meta PageMeta
+}
+
+// NewPage builds the page holding data out of total matching rows.
+// next is the cursor of the following page, nil on the last one.
+func NewPage[T any](data []T, total int, next *Cursor) Page[T] {
+	if data == nil {
+		data = make([]T, 0)
+	}
+	page := Page[T]{Data: data, Meta: PageMeta{Total: total}}
+	if next != nil {
+		cursor := next.Encode()
+		page.Meta.NextCursor = &cursor
+	}
+	return page
+}
+
+// Encode returns the cursor as an opaque URL-safe string.
+func (cursor Cursor) Encode() string {
+	raw, _ := json.Marshal([]string{cursor.Sort, encodeCursorValue(cursor.Value), encodeCursorValue(cursor.ID)})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor returns the cursor encoded by Cursor.Encode.
+func DecodeCursor(encoded string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, errors.New("invalid cursor")
+	}
+	var parts []string
+	if err := json.Unmarshal(raw, &parts); err != nil || len(parts) != 3 || parts[0] == "" {
+		return Cursor{}, errors.New("invalid cursor")
+	}
+	value, err := decodeCursorValue(parts[1])
+	if err != nil {
+		return Cursor{}, err
+	}
+	id, err := decodeCursorValue(parts[2])
+	if err != nil || id == nil {
+		return Cursor{}, errors.New("invalid cursor")
+	}
+	return Cursor{Sort: parts[0], Value: value, ID: id}, nil
+}
+
+// encodeCursorValue prefixes the value with its type, so it decodes back to the same Go type
+func encodeCursorValue(value any) string {
+	switch value := value.(type) {
+	case int:
+		return "i:" + strconv.Itoa(value)
+	case float64:
+		return "f:" + strconv.FormatFloat(value, 'g', -1, 64)
+	case string:
+		return "s:" + value
+	case time.Time:
+		return "t:" + value.Format(time.RFC3339Nano)
+	}
+	return "n:"
+}
+
+// decodeCursorValue reverses encodeCursorValue
+func decodeCursorValue(encoded string) (any, error) {
+	kind, value, ok := strings.Cut(encoded, ":")
+	if !ok {
+		return nil, errors.New("invalid cursor")
+	}
+	var err error
+	var decoded any
+	switch kind {
+	case "i":
+		decoded, err = strconv.Atoi(value)
+	case "f":
+		decoded, err = strconv.ParseFloat(value, 64)
+	case "s":
+		decoded = value
+	case "t":
+		decoded, err = time.Parse(time.RFC3339Nano, value)
+	case "n":
+		return nil, nil
+	default:
+		err = errors.New("unknown type")
+	}
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return decoded, nil
+}
@@ -30,12 +30,12 @@ type CreateSectionRequest struct {
 
 // UpdateSectionRequest defines the structure for updating an existing Section.
 // Fields are pointers to allow for partial updates (omitempty).
+// current_capacity is not part of it: the occupancy is kept by the product batches.
 // It includes validation tags for go-playground/validator.
 type UpdateSectionRequest struct {
     SectionNumber      *string  `json:"section_number,omitempty" validate:"omitempty,min=1"`
     CurrentTemperature *float64 `json:"current_temperature,omitempty"`
     MinimumTemperature *float64 `json:"minimum_temperature,omitempty"`
-    CurrentCapacity    *int     `json:"current_capacity,omitempty" validate:"omitempty,gte=0"`
     MinimumCapacity    *int     `json:"minimum_capacity,omitempty" validate:"omitempty,gte=0"`
     MaximumCapacity    *int     `json:"maximum_capacity,omitempty" validate:"omitempty,gte=0"`
     WarehouseID        *int     `json:"warehouse_id,omitempty" validate:"omitempty,gt=0"`
@@ -48,4 +48,46 @@ type SectionProductsReport struct {
 	SectionID     int    `json:"section_id"`
 	SectionNumber string `json:"section_number"`
 	ProductsCount int    `json:"products_count"`
-}
\ No newline at end of file
+}
+
+// ExpiringBatch is a product batch listed in the expiring batches report.
+type ExpiringBatch struct {
+	ProductBatchID     int    `json:"product_batch_id"`
+	BatchNumber        int    `json:"batch_number"`
+	ProductID          int    `json:"product_id"`
+	ProductDescription string `json:"product_description"`
+	CurrentQuantity    int    `json:"current_quantity"`
+	DueDate            string `json:"due_date"`
+}
+
+// SectionExpiringBatchesReport groups the expiring batches of a section, soonest due date first.
+// It is used for the GET /sections/reportExpiringBatches endpoint response.
+type SectionExpiringBatchesReport struct {
+	SectionID     int             `json:"section_id"`
+	SectionNumber string          `json:"section_number"`
+	WarehouseID   int             `json:"warehouse_id"`
+	Batches       []ExpiringBatch `json:"batches"`
+}
+
+// SectionCapacityReport shows how full a section is.
+// Utilization is the percentage of maximum_capacity in use, and BelowMinimum
+// flags sections holding less than their minimum_capacity.
+type SectionCapacityReport struct {
+	SectionID       int     `json:"section_id"`
+	SectionNumber   string  `json:"section_number"`
+	CurrentCapacity int     `json:"current_capacity"`
+	MinimumCapacity int     `json:"minimum_capacity"`
+	MaximumCapacity int     `json:"maximum_capacity"`
+	Utilization     float64 `json:"utilization"`
+	BelowMinimum    bool    `json:"below_minimum"`
+}
+
+// WarehouseCapacityReport adds up the capacity of the sections of a warehouse.
+// It is used for the GET /sections/reportCapacity endpoint response.
+type WarehouseCapacityReport struct {
+	WarehouseID     int                     `json:"warehouse_id"`
+	CurrentCapacity int                     `json:"current_capacity"`
+	MaximumCapacity int                     `json:"maximum_capacity"`
+	Utilization     float64                 `json:"utilization"`
+	Sections        []SectionCapacityReport `json:"sections"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// SectionTemperatureReading is a temperature sample sent by the sensors of a section.
+type SectionTemperatureReading struct {
+	ID          int       `json:"id"`
+	SectionID   int       `json:"section_id"`
+	Temperature float64   `json:"temperature"`
+	RecordedAt  time.Time `json:"recorded_at"`
+}
+
+// CreateTemperatureReadingRequest is one reading of a bulk ingestion request.
+// Temperature is a pointer so that 0 degrees is not taken as missing.
+type CreateTemperatureReadingRequest struct {
+	Temperature *float64  `json:"temperature" validate:"required,gte=-100,lte=100"`
+	RecordedAt  time.Time `json:"recorded_at" validate:"required,notfuture"`
+}
+
+// RejectedTemperatureReading tells which reading of a bulk request was discarded and why.
+type RejectedTemperatureReading struct {
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+}
+
+// TemperatureReadingsIngestion is the result of a bulk ingestion request.
+type TemperatureReadingsIngestion struct {
+	Accepted int                          `json:"accepted"`
+	Rejected []RejectedTemperatureReading `json:"rejected"`
+}
+
+// TemperatureAggregate summarizes the readings recorded from From onwards.
+// In a bucketed series each aggregate covers one interval.
+type TemperatureAggregate struct {
+	From  time.Time `json:"from"`
+	Count int       `json:"count"`
+	Min   float64   `json:"min"`
+	Max   float64   `json:"max"`
+	Avg   float64   `json:"avg"`
+}
+
+// SectionTemperatureSeries is the time series of a section between From and To.
+// Buckets is set when an interval is requested, Readings otherwise.
+type SectionTemperatureSeries struct {
+	SectionID int                         `json:"section_id"`
+	From      time.Time                   `json:"from"`
+	To        time.Time                   `json:"to"`
+	Summary   TemperatureAggregate        `json:"summary"`
+	Buckets   []TemperatureAggregate      `json:"buckets,omitempty"`
+	Readings  []SectionTemperatureReading `json:"readings,omitempty"`
+}
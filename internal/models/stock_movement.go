@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Movement types accepted by the stock ledger.
+const (
+	StockMovementInbound    = "inbound"
+	StockMovementOutbound   = "outbound"
+	StockMovementAdjustment = "adjustment"
+	StockMovementTransfer   = "transfer"
+)
+
+// StockMovement represents a single change applied to the current_quantity
+// of a product batch. Quantity is signed: negative when stock leaves the batch.
+type StockMovement struct {
+	ID                    int       `json:"id"`
+	ProductBatchID        int       `json:"product_batch_id"`
+	MovementType          string    `json:"movement_type"`
+	Quantity              int       `json:"quantity"`
+	ResultingQuantity     int       `json:"resulting_quantity"`
+	RelatedProductBatchID *int      `json:"related_product_batch_id,omitempty"`
+	Reason                string    `json:"reason"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+// CreateStockMovementRequest defines the structure for recording a movement against a batch.
+// Quantity is always positive except for adjustments, where its sign gives the direction.
+// TargetProductBatchID is only used by transfers.
+type CreateStockMovementRequest struct {
+	MovementType         string `json:"movement_type" validate:"required,oneof=inbound outbound adjustment transfer"`
+	Quantity             int    `json:"quantity" validate:"required"`
+	TargetProductBatchID *int   `json:"target_product_batch_id,omitempty" validate:"omitempty,gt=0"`
+	Reason               string `json:"reason" validate:"max=255"`
+}
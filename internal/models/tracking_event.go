@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Tracking event types reported while a carry delivers a purchase order.
+const (
+	TrackingEventPickedUp      = "picked_up"
+	TrackingEventInTransit     = "in_transit"
+	TrackingEventDelivered     = "delivered"
+	TrackingEventFailedAttempt = "failed_attempt"
+)
+
+// TrackingEvent is a step in the delivery of a purchase order.
+// CarryId is the carry assigned to the order when the event was reported.
+type TrackingEvent struct {
+	Id              int       `json:"id"`
+	PurchaseOrderId int       `json:"purchase_order_id"`
+	CarryId         int       `json:"carry_id"`
+	EventType       string    `json:"event_type"`
+	Location        string    `json:"location"`
+	Notes           string    `json:"notes"`
+	OccurredAt      time.Time `json:"occurred_at"`
+}
+
+// CreateTrackingEventRequest is the body of a new tracking event.
+// Without OccurredAt the event happened when it is received.
+type CreateTrackingEventRequest struct {
+	EventType  string     `json:"event_type" validate:"required,oneof=picked_up in_transit delivered failed_attempt"`
+	Location   string     `json:"location" validate:"max=255"`
+	Notes      string     `json:"notes" validate:"max=255"`
+	OccurredAt *time.Time `json:"occurred_at,omitempty" validate:"omitempty,notfuture"`
+}
+
+// TrackingTimeline is the delivery history of the purchase order with a tracking code,
+// oldest event first.
+type TrackingTimeline struct {
+	TrackingCode    string          `json:"tracking_code"`
+	PurchaseOrderId int             `json:"purchase_order_id"`
+	Status          string          `json:"status"`
+	CarryId         *int            `json:"carry_id"`
+	Events          []TrackingEvent `json:"events"`
+}
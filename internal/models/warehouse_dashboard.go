@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// WarehouseDashboard combines in one response the figures supervisors follow for a warehouse.
+// Inbound orders and temperatures look Days back from now; expiring stock looks ExpiringDays ahead.
+type WarehouseDashboard struct {
+	Warehouse                 Warehouse                    `json:"warehouse"`
+	Days                      int                          `json:"days"`
+	ExpiringDays              int                          `json:"expiring_days"`
+	Sections                  DashboardSections            `json:"sections"`
+	EmployeesCount            int                          `json:"employees_count"`
+	BatchesByProductType      []ProductTypeBatches         `json:"batches_by_product_type"`
+	ExpiringSoon              ExpiringStock                `json:"expiring_soon"`
+	InboundOrders             InboundOrdersSummary         `json:"inbound_orders"`
+	WorstTemperatureDeviation *SectionTemperatureDeviation `json:"worst_temperature_deviation"`
+}
+
+// DashboardSections adds up the capacity of the sections of a warehouse.
+// Utilization is the percentage of the maximum capacity in use.
+type DashboardSections struct {
+	Count             int     `json:"count"`
+	CurrentCapacity   int     `json:"current_capacity"`
+	MaximumCapacity   int     `json:"maximum_capacity"`
+	Utilization       float64 `json:"utilization"`
+	BelowMinimumCount int     `json:"below_minimum_count"`
+}
+
+// ProductTypeBatches counts the batches of a product type and the units they hold.
+type ProductTypeBatches struct {
+	ProductTypeID int    `json:"product_type_id"`
+	Description   string `json:"description"`
+	BatchesCount  int    `json:"batches_count"`
+	Quantity      int    `json:"quantity"`
+}
+
+// ExpiringStock counts the batches with stock due between From and To (inclusive, YYYY-MM-DD).
+type ExpiringStock struct {
+	From         string `json:"from"`
+	To           string `json:"to"`
+	BatchesCount int    `json:"batches_count"`
+	Quantity     int    `json:"quantity"`
+}
+
+// InboundOrdersSummary counts the inbound orders of a warehouse dated from Since onwards
+// and the units they received.
+type InboundOrdersSummary struct {
+	Since       time.Time `json:"since"`
+	OrdersCount int       `json:"orders_count"`
+	Quantity    int       `json:"quantity"`
+}
+
+// SectionTemperatureDeviation is a reading that breached a temperature rule of its section.
+// Deviation is how many degrees the reading was past the threshold of the rule.
+type SectionTemperatureDeviation struct {
+	SectionID     int       `json:"section_id"`
+	SectionNumber string    `json:"section_number"`
+	AlertType     string    `json:"alert_type"`
+	Temperature   float64   `json:"temperature"`
+	Threshold     float64   `json:"threshold"`
+	Deviation     float64   `json:"deviation"`
+	RecordedAt    time.Time `json:"recorded_at"`
+}
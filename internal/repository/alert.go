@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// AlertRepositoryDB implements AlertRepository
+type AlertRepositoryDB struct {
+	db *sql.DB
+}
+
+/*
+NewAlertRepositoryDB constructs an AlertRepositoryDB that uses
+the given *sql.DB for all data operations.
+*/
+func NewAlertRepositoryDB(db *sql.DB) AlertRepository {
+	return &AlertRepositoryDB{
+		db: db,
+	}
+}
+
+// alertKey identifies the condition an alert is about, so a breach that lasts
+// several readings keeps a single active alert.
+type alertKey struct {
+	alertType      string
+	productBatchID int
+}
+
+func newAlertKey(alertType string, productBatchID *int) alertKey {
+	key := alertKey{alertType: alertType}
+	if productBatchID != nil {
+		key.productBatchID = *productBatchID
+	}
+	return key
+}
+
+// alertColumns lists the columns read into a models.Alert
+const alertColumns = `
+            id, section_id, product_batch_id, alert_type, status, temperature, threshold,
+            message, created_at, acknowledged_at, resolved_at`
+
+// scanAlert reads a row selected with alertColumns
+func scanAlert(row interface{ Scan(dest ...any) error }) (models.Alert, error) {
+	var alert models.Alert
+	err := row.Scan(
+		&alert.ID, &alert.SectionID, &alert.ProductBatchID, &alert.AlertType, &alert.Status, &alert.Temperature, &alert.Threshold,
+		&alert.Message, &alert.CreatedAt, &alert.AcknowledgedAt, &alert.ResolvedAt,
+	)
+	return alert, err
+}
+
+// GetTemperatureTargets returns the thresholds that apply to a section.
+// Returns a NotFoundError if the section does not exist.
+func (repository *AlertRepositoryDB) GetTemperatureTargets(ctx context.Context, sectionID int) (models.SectionTemperatureTargets, error) {
+	targets := models.SectionTemperatureTargets{SectionID: sectionID}
+
+	const sectionQuery = `SELECT minimum_temperature FROM sections WHERE id = ?`
+	err := repository.db.QueryRowContext(ctx, sectionQuery, sectionID).Scan(&targets.MinimumTemperature)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.SectionTemperatureTargets{}, httperrors.NotFoundError{Message: "Section not found"}
+		}
+		return models.SectionTemperatureTargets{}, internalError(ctx, "", err)
+	}
+
+	const batchesQuery = `
+        SELECT pb.id, pb.product_id, p.recommended_freezing_temperature
+        FROM product_batches pb
+        JOIN products p ON p.id = pb.product_id
+        WHERE pb.section_id = ? AND pb.current_quantity > 0
+        ORDER BY pb.id
+    `
+	rows, err := repository.db.QueryContext(ctx, batchesQuery, sectionID)
+	if err != nil {
+		return models.SectionTemperatureTargets{}, internalError(ctx, "", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var batch models.BatchTemperatureTarget
+		if err := rows.Scan(&batch.ProductBatchID, &batch.ProductID, &batch.RecommendedFreezingTemperature); err != nil {
+			return models.SectionTemperatureTargets{}, internalError(ctx, "", err)
+		}
+		targets.Batches = append(targets.Batches, batch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.SectionTemperatureTargets{}, internalError(ctx, "", err)
+	}
+	return targets, nil
+}
+
+// Sync opens the breaches that have no active alert and resolves the active alerts
+// that are no longer breached, in a single transaction.
+func (repository *AlertRepositoryDB) Sync(ctx context.Context, sectionID int, breaches []models.Alert, at time.Time) ([]models.Alert, error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	const activeQuery = `
+        SELECT id, alert_type, product_batch_id
+        FROM alerts
+        WHERE section_id = ? AND status IN ('open', 'acknowledged')
+        FOR UPDATE
+    `
+	rows, err := tx.QueryContext(ctx, activeQuery, sectionID)
+	if err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	active := make(map[alertKey]int)
+	for rows.Next() {
+		var id int
+		var alertType string
+		var productBatchID *int
+		if err := rows.Scan(&id, &alertType, &productBatchID); err != nil {
+			rows.Close()
+			return nil, internalError(ctx, "", err)
+		}
+		active[newAlertKey(alertType, productBatchID)] = id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+
+	const insertQuery = `
+        INSERT INTO alerts (
+            section_id, product_batch_id, alert_type, status, temperature, threshold, message, created_at
+        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+    `
+	opened := make([]models.Alert, 0)
+	for _, breach := range breaches {
+		key := newAlertKey(breach.AlertType, breach.ProductBatchID)
+		if _, ok := active[key]; ok {
+			// Still breached, the active alert stays as it is
+			delete(active, key)
+			continue
+		}
+
+		breach.SectionID = sectionID
+		breach.Status = models.AlertStatusOpen
+		breach.CreatedAt = at
+		result, err := tx.ExecContext(ctx, insertQuery,
+			breach.SectionID, breach.ProductBatchID, breach.AlertType, breach.Status,
+			breach.Temperature, breach.Threshold, breach.Message, breach.CreatedAt,
+		)
+		if err != nil {
+			return nil, internalError(ctx, "", err)
+		}
+		lastId, err := result.LastInsertId()
+		if err != nil {
+			return nil, internalError(ctx, "", err)
+		}
+		breach.ID = int(lastId)
+		opened = append(opened, breach)
+	}
+
+	// Whatever is left active is back in range
+	const resolveQuery = `UPDATE alerts SET status = ?, resolved_at = ? WHERE id = ?`
+	for _, id := range active {
+		if _, err := tx.ExecContext(ctx, resolveQuery, models.AlertStatusResolved, at, id); err != nil {
+			return nil, internalError(ctx, "", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	return opened, nil
+}
+
+// alertListColumns are the fields alerts can be sorted by
+var alertListColumns = listColumns{
+	"id":          "id",
+	"section_id":  "section_id",
+	"alert_type":  "alert_type",
+	"status":      "status",
+	"temperature": "temperature",
+	"created_at":  "created_at",
+}
+
+// GetAll returns the page of alerts matching the filter
+func (repository *AlertRepositoryDB) GetAll(ctx context.Context, filter models.AlertFilter) (models.Page[models.Alert], error) {
+	query := listQuery{
+		selectColumns: alertColumns,
+		from:          "alerts",
+		columns:       alertListColumns,
+	}
+	if filter.Status != "" {
+		query.conditions = append(query.conditions, "status = ?")
+		query.args = append(query.args, filter.Status)
+	}
+	if filter.SectionID != 0 {
+		query.conditions = append(query.conditions, "section_id = ?")
+		query.args = append(query.args, filter.SectionID)
+	}
+
+	return queryPage(ctx, repository.db, query, filter.QueryOptions, alertListFields, func(rows *sql.Rows) (models.Alert, error) {
+		return scanAlert(rows)
+	})
+}
+
+// Acknowledge moves an open alert to acknowledged.
+// Returns a NotFoundError if the alert does not exist and a ConflictError if it is not open.
+func (repository *AlertRepositoryDB) Acknowledge(ctx context.Context, id int, at time.Time) (models.Alert, error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Alert{}, internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	query := "SELECT" + alertColumns + "\n        FROM alerts\n        WHERE id = ?\n        FOR UPDATE"
+	alert, err := scanAlert(tx.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Alert{}, httperrors.NotFoundError{Message: "Alert not found"}
+		}
+		return models.Alert{}, internalError(ctx, "", err)
+	}
+	if alert.Status != models.AlertStatusOpen {
+		return models.Alert{}, httperrors.ConflictError{Message: "Only open alerts can be acknowledged."}
+	}
+
+	const updateQuery = `UPDATE alerts SET status = ?, acknowledged_at = ? WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, updateQuery, models.AlertStatusAcknowledged, at, id); err != nil {
+		return models.Alert{}, internalError(ctx, "", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Alert{}, internalError(ctx, "", err)
+	}
+	alert.Status = models.AlertStatusAcknowledged
+	alert.AcknowledgedAt = &at
+	return alert, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// AlertRepositoryMemory implements AlertRepository in memory
+type AlertRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// NewAlertRepositoryMemory constructs an AlertRepositoryMemory backed by the given store.
+func NewAlertRepositoryMemory(store *MemoryStore) AlertRepository {
+	return &AlertRepositoryMemory{store: store}
+}
+
+// GetTemperatureTargets returns the thresholds that apply to a section.
+func (repository *AlertRepositoryMemory) GetTemperatureTargets(ctx context.Context, sectionID int) (models.SectionTemperatureTargets, error) {
+	repository.store.mu.RLock()
+	defer repository.store.mu.RUnlock()
+
+	section, ok := repository.store.sections[sectionID]
+	if !ok {
+		return models.SectionTemperatureTargets{}, httperrors.NotFoundError{Message: "Section not found"}
+	}
+
+	targets := models.SectionTemperatureTargets{SectionID: sectionID, MinimumTemperature: section.MinimumTemperature}
+	for _, batch := range sortedByID(repository.store.productBatches) {
+		if batch.SectionID != sectionID || batch.CurrentQuantity <= 0 {
+			continue
+		}
+		targets.Batches = append(targets.Batches, models.BatchTemperatureTarget{
+			ProductBatchID:                 batch.ID,
+			ProductID:                      batch.ProductID,
+			RecommendedFreezingTemperature: repository.store.products[batch.ProductID].RecommendedFreezingTemperature,
+		})
+	}
+	return targets, nil
+}
+
+// Sync opens the breaches that have no active alert and resolves the active alerts
+// that are no longer breached.
+func (repository *AlertRepositoryMemory) Sync(ctx context.Context, sectionID int, breaches []models.Alert, at time.Time) ([]models.Alert, error) {
+	repository.store.mu.Lock()
+	defer repository.store.mu.Unlock()
+
+	active := make(map[alertKey]int)
+	for _, alert := range repository.store.alerts {
+		if alert.SectionID == sectionID && alert.Status != models.AlertStatusResolved {
+			active[newAlertKey(alert.AlertType, alert.ProductBatchID)] = alert.ID
+		}
+	}
+
+	opened := make([]models.Alert, 0)
+	for _, breach := range breaches {
+		key := newAlertKey(breach.AlertType, breach.ProductBatchID)
+		if _, ok := active[key]; ok {
+			delete(active, key)
+			continue
+		}
+
+		breach.ID = repository.store.nextID("alerts")
+		breach.SectionID = sectionID
+		breach.Status = models.AlertStatusOpen
+		breach.CreatedAt = at
+		breach.ProductBatchID = copyIntPtr(breach.ProductBatchID)
+		repository.store.alerts[breach.ID] = breach
+		opened = append(opened, breach)
+	}
+
+	for _, id := range active {
+		alert := repository.store.alerts[id]
+		resolvedAt := at
+		alert.Status = models.AlertStatusResolved
+		alert.ResolvedAt = &resolvedAt
+		repository.store.alerts[id] = alert
+	}
+	return opened, nil
+}
+
+// alertListFields are the fields alerts can be sorted by
+var alertListFields = listFields[models.Alert]{
+	"id":          func(a models.Alert) any { return a.ID },
+	"section_id":  func(a models.Alert) any { return a.SectionID },
+	"alert_type":  func(a models.Alert) any { return a.AlertType },
+	"status":      func(a models.Alert) any { return a.Status },
+	"temperature": func(a models.Alert) any { return a.Temperature },
+	"created_at":  func(a models.Alert) any { return a.CreatedAt },
+}
+
+// GetAll returns the page of alerts matching the filter
+func (repository *AlertRepositoryMemory) GetAll(ctx context.Context, filter models.AlertFilter) (models.Page[models.Alert], error) {
+	repository.store.mu.RLock()
+	defer repository.store.mu.RUnlock()
+
+	var alerts []models.Alert
+	for _, alert := range sortedByID(repository.store.alerts) {
+		if filter.Status != "" && alert.Status != filter.Status {
+			continue
+		}
+		if filter.SectionID != 0 && alert.SectionID != filter.SectionID {
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+	return pageOf(alerts, filter.QueryOptions, alertListFields)
+}
+
+// Acknowledge moves an open alert to acknowledged.
+func (repository *AlertRepositoryMemory) Acknowledge(ctx context.Context, id int, at time.Time) (models.Alert, error) {
+	repository.store.mu.Lock()
+	defer repository.store.mu.Unlock()
+
+	alert, ok := repository.store.alerts[id]
+	if !ok {
+		return models.Alert{}, httperrors.NotFoundError{Message: "Alert not found"}
+	}
+	if alert.Status != models.AlertStatusOpen {
+		return models.Alert{}, httperrors.ConflictError{Message: "Only open alerts can be acknowledged."}
+	}
+
+	alert.Status = models.AlertStatusAcknowledged
+	alert.AcknowledgedAt = &at
+	repository.store.alerts[id] = alert
+	return alert, nil
+}
@@ -25,8 +25,8 @@ func NewBuyerRepositoryDB(db *sql.DB) *BuyerRepositoryDB {
 func (r *BuyerRepositoryDB) Create(ctx context.Context, newBuyer models.BuyerAttributes) (models.Buyer, error) {
 	query := `
 		INSERT INTO 
-			buyers (card_number_id, first_name, last_name)
-		VALUES (?, ?, ?)
+			buyers (card_number_id, first_name, last_name, delivery_locality_id)
+		VALUES (?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(
@@ -35,12 +35,17 @@ func (r *BuyerRepositoryDB) Create(ctx context.Context, newBuyer models.BuyerAtt
 		newBuyer.CardNumberId,
 		newBuyer.FirstName,
 		newBuyer.LastName,
+		newBuyer.DeliveryLocalityId,
 	)
 	if err != nil {
 		if mysqlErr, ok := err.(*mysql.MySQLError); ok {
-			if mysqlErr.Number == 1062 {
+			switch mysqlErr.Number {
+			case 1062:
 				// cardNumberId is unique
 				return models.Buyer{}, httperrors.ConflictError{Message: "CardNumberId already in use"}
+			case 1452:
+				// FK violation constraint DeliveryLocalityId
+				return models.Buyer{}, httperrors.ConflictError{Message: "DeliveryLocalityId does not exist"}
 			}
 		}
 		return models.Buyer{}, err
@@ -60,49 +65,41 @@ func (r *BuyerRepositoryDB) Create(ctx context.Context, newBuyer models.BuyerAtt
 	return buyer, nil
 }
 
-// GetAll retrieves all Buyers from the database with all the data for each one.
+// buyerListColumns are the fields buyers can be sorted and filtered by
+var buyerListColumns = listColumns{
+	"id":                   "b.id",
+	"card_number_id":       "b.card_number_id",
+	"first_name":           "b.first_name",
+	"last_name":            "b.last_name",
+	"delivery_locality_id": "b.delivery_locality_id",
+}
+
+// GetAll retrieves the page of Buyers described by opts from the database with all the data for each one.
 //
-// Returns a slice of Buyers or an error if the query fails.
-func (r *BuyerRepositoryDB) GetAll(ctx context.Context) ([]models.Buyer, error) {
-	const query = `
-        SELECT 
+// Returns the page of Buyers or an error if the query fails or opts has an unknown sort or filter field.
+func (r *BuyerRepositoryDB) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Buyer], error) {
+	query := listQuery{
+		selectColumns: `
 			b.id,
 			b.first_name, 
 			b.last_name, 
-			b.card_number_id 
-        FROM 
-			buyers b
-    `
-	rows, err := r.db.QueryContext(ctx, query)
-	if err != nil {
-		return nil, err
+			b.card_number_id,
+			b.delivery_locality_id`,
+		from:    "buyers b",
+		columns: buyerListColumns,
 	}
-	defer rows.Close()
-
-	buyers := make([]models.Buyer, 0)
-	for rows.Next() {
 
+	return queryPage(ctx, r.db, query, opts, buyerListFields, func(rows *sql.Rows) (models.Buyer, error) {
 		var buyer models.Buyer
 		err := rows.Scan(
 			&buyer.Id,
 			&buyer.FirstName,
 			&buyer.LastName,
 			&buyer.CardNumberId,
+			&buyer.DeliveryLocalityId,
 		)
-
-		// error at the scan, defer db and return error
-		if err != nil {
-			return nil, err
-		}
-		buyers = append(buyers, buyer)
-	}
-
-	// check if the rows had any error
-	err = rows.Err()
-	if err != nil {
-		return nil, err
-	}
-	return buyers, nil
+		return buyer, err
+	})
 }
 
 // GetByID fetches a Buyer by its ID from the database.
@@ -114,7 +111,8 @@ func (r *BuyerRepositoryDB) GetByID(ctx context.Context, id int) (models.Buyer,
 			b.id,
 			b.first_name, 
 			b.last_name, 
-			b.card_number_id
+			b.card_number_id,
+			b.delivery_locality_id
 		FROM
 			buyers b
 		WHERE
@@ -123,7 +121,7 @@ func (r *BuyerRepositoryDB) GetByID(ctx context.Context, id int) (models.Buyer,
 	row := r.db.QueryRowContext(ctx, query, id)
 
 	var buyer models.Buyer
-	err := row.Scan(&buyer.Id, &buyer.FirstName, &buyer.LastName, &buyer.CardNumberId)
+	err := row.Scan(&buyer.Id, &buyer.FirstName, &buyer.LastName, &buyer.CardNumberId, &buyer.DeliveryLocalityId)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -144,7 +142,8 @@ func (r *BuyerRepositoryDB) Update(ctx context.Context, id int, updatedBuyer mod
         SET 
 			b.card_number_id = ?, 
 			b.first_name = ?, 
-			b.last_name = ?
+			b.last_name = ?,
+			b.delivery_locality_id = ?
         WHERE 
 			b.id = ?
     `
@@ -155,13 +154,18 @@ func (r *BuyerRepositoryDB) Update(ctx context.Context, id int, updatedBuyer mod
 		updatedBuyer.CardNumberId,
 		updatedBuyer.FirstName,
 		updatedBuyer.LastName,
+		updatedBuyer.DeliveryLocalityId,
 		id,
 	)
 	if err != nil {
 		if mysqlErr, ok := err.(*mysql.MySQLError); ok {
-			if mysqlErr.Number == 1062 {
+			switch mysqlErr.Number {
+			case 1062:
 				// cardNumberId is unique
 				return models.Buyer{}, httperrors.ConflictError{Message: "CardNumberId already in use"}
+			case 1452:
+				// FK violation constraint DeliveryLocalityId
+				return models.Buyer{}, httperrors.ConflictError{Message: "DeliveryLocalityId does not exist"}
 			}
 		}
 		return models.Buyer{}, err
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// BuyerRepositoryMemory is an in-memory implementation of BuyerRepository.
+type BuyerRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// Generates a repository backed by the in-memory store
+func NewBuyerRepositoryMemory(store *MemoryStore) *BuyerRepositoryMemory {
+	return &BuyerRepositoryMemory{store: store}
+}
+
+// Create stores a new Buyer if its CardNumberId is not already in use
+// and its DeliveryLocalityId, when set, exists.
+func (r *BuyerRepositoryMemory) Create(ctx context.Context, newBuyer models.BuyerAttributes) (models.Buyer, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.cardNumberTaken(0, newBuyer.CardNumberId) {
+		return models.Buyer{}, httperrors.ConflictError{Message: "CardNumberId already in use"}
+	}
+	if !r.localityExists(newBuyer.DeliveryLocalityId) {
+		return models.Buyer{}, httperrors.ConflictError{Message: "DeliveryLocalityId does not exist"}
+	}
+
+	buyer := models.Buyer{
+		Id:              r.store.nextID("buyers"),
+		BuyerAttributes: newBuyer,
+	}
+	r.store.buyers[buyer.Id] = buyer
+	return buyer, nil
+}
+
+// buyerListFields are the fields buyers can be sorted and filtered by
+var buyerListFields = listFields[models.Buyer]{
+	"id":                   func(b models.Buyer) any { return b.Id },
+	"card_number_id":       func(b models.Buyer) any { return b.CardNumberId },
+	"first_name":           func(b models.Buyer) any { return b.FirstName },
+	"last_name":            func(b models.Buyer) any { return b.LastName },
+	"delivery_locality_id": func(b models.Buyer) any { return b.DeliveryLocalityId },
+}
+
+// GetAll retrieves the page of Buyers described by opts.
+func (r *BuyerRepositoryMemory) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Buyer], error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return pageOf(sortedByID(r.store.buyers), opts, buyerListFields)
+}
+
+// GetByID fetches a Buyer by its ID, returns not found if it does not exist.
+func (r *BuyerRepositoryMemory) GetByID(ctx context.Context, id int) (models.Buyer, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	buyer, ok := r.store.buyers[id]
+	if !ok {
+		return models.Buyer{}, httperrors.NotFoundError{Message: "Buyer not found"}
+	}
+	return buyer, nil
+}
+
+// modifies the Buyer with the given ID using the provided updatedBuyer fields.
+func (r *BuyerRepositoryMemory) Update(ctx context.Context, id int, updatedBuyer models.Buyer) (models.Buyer, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.cardNumberTaken(id, updatedBuyer.CardNumberId) {
+		return models.Buyer{}, httperrors.ConflictError{Message: "CardNumberId already in use"}
+	}
+	if !r.localityExists(updatedBuyer.DeliveryLocalityId) {
+		return models.Buyer{}, httperrors.ConflictError{Message: "DeliveryLocalityId does not exist"}
+	}
+
+	if _, ok := r.store.buyers[id]; ok {
+		stored := updatedBuyer
+		stored.Id = id
+		r.store.buyers[id] = stored
+	}
+	return updatedBuyer, nil
+}
+
+// Deletes a Buyer by its id if it exists and no purchase order references it
+func (r *BuyerRepositoryMemory) Delete(ctx context.Context, id int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.buyers[id]; !ok {
+		return httperrors.NotFoundError{Message: "Buyer not found"}
+	}
+	for _, order := range r.store.purchaseOrders {
+		if order.BuyerId == id {
+			return httperrors.ConflictError{Message: "Buyer still has purchase orders"}
+		}
+	}
+
+	delete(r.store.buyers, id)
+	return nil
+}
+
+// get one or all buyer/s BuyerWithPurchaseOrdersCount if id is setted
+// return not found if the Buyer does not exist
+func (r *BuyerRepositoryMemory) GetWithPurchaseOrdersCount(
+	ctx context.Context, id *int) ([]models.BuyerWithPurchaseOrdersCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := make(map[int]int)
+	for _, order := range r.store.purchaseOrders {
+		counts[order.BuyerId]++
+	}
+
+	var buyers []models.BuyerWithPurchaseOrdersCount
+	for _, buyer := range sortedByID(r.store.buyers) {
+		if id != nil && buyer.Id != *id {
+			continue
+		}
+		buyers = append(buyers, models.BuyerWithPurchaseOrdersCount{
+			Buyer:               buyer,
+			PurchaseOrdersCount: counts[buyer.Id],
+		})
+	}
+
+	if id != nil && len(buyers) == 0 {
+		return nil, httperrors.NotFoundError{Message: "Buyer not found"}
+	}
+	return buyers, nil
+}
+
+// cardNumberTaken reports whether another buyer already uses the card number.
+// The caller must hold the lock.
+func (r *BuyerRepositoryMemory) cardNumberTaken(id int, cardNumberId int) bool {
+	for _, buyer := range r.store.buyers {
+		if buyer.Id != id && buyer.CardNumberId == cardNumberId {
+			return true
+		}
+	}
+	return false
+}
+
+// localityExists reports whether the optional delivery locality exists.
+// The caller must hold the lock.
+func (r *BuyerRepositoryMemory) localityExists(localityId *string) bool {
+	if localityId == nil {
+		return true
+	}
+	_, ok := r.store.localities[*localityId]
+	return ok
+}
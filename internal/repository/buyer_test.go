@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -25,7 +26,7 @@ func TestBuyerRepositoryDB_Create(t *testing.T) {
 		duplicateErr := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
 
 		mock.ExpectExec("INSERT INTO buyers").
-			WithArgs(44728397, "Franco", "Colapinto").
+			WithArgs(44728397, "Franco", "Colapinto", nil).
 			WillReturnError(duplicateErr)
 
 		repo := repository.NewBuyerRepositoryDB(dbMocked)
@@ -53,7 +54,7 @@ func TestBuyerRepositoryDB_Create(t *testing.T) {
 		defer dbMocked.Close()
 
 		mock.ExpectExec("INSERT INTO buyers").
-			WithArgs(44728397, "Franco", "Colapinto").
+			WithArgs(44728397, "Franco", "Colapinto", nil).
 			WillReturnResult(sqlmock.NewResult(10, 1))
 
 		repoDB := repository.NewBuyerRepositoryDB(dbMocked)
@@ -124,7 +125,7 @@ func TestBuyerRepositoryDB_Create(t *testing.T) {
 }
 
 func TestBuyerRepositoryDB_GetAll(t *testing.T) {
-	t.Run("QueryRowCOntext call returns an error as it is", func(t *testing.T) {
+	t.Run("QueryRowCOntext call returns an error as an internal server error", func(t *testing.T) {
 		// arrange
 		dbMocked, mock, err := sqlmock.New()
 		require.NoError(t, err)
@@ -138,11 +139,10 @@ func TestBuyerRepositoryDB_GetAll(t *testing.T) {
 		ctx := context.TODO() // dummy ctx
 
 		// act
-		_, err = repoDB.GetAll(ctx)
+		_, err = repoDB.GetAll(ctx, models.QueryOptions{})
 
 		// assert
-		assert.Error(t, err)
-		assert.ErrorIs(t, err, queryRowError)
+		assert.Equal(t, httperrors.InternalServerError{}, err)
 
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
@@ -154,9 +154,11 @@ func TestBuyerRepositoryDB_GetAll(t *testing.T) {
 		defer dbMocked.Close()
 
 		rowsWithInvalidFields := sqlmock.NewRows(
-			[]string{"id", "first_name", "last_name", "card_number_id"}).
-			AddRow("idNotAnInteger", "Juan", nil, 1234)
+			[]string{"id", "first_name", "last_name", "card_number_id", "delivery_locality_id"}).
+			AddRow("idNotAnInteger", "Juan", nil, 1234, nil)
 
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM buyers b")).
+			WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
 		mock.ExpectQuery("FROM buyers").
 			WillReturnRows(rowsWithInvalidFields)
 
@@ -164,30 +166,32 @@ func TestBuyerRepositoryDB_GetAll(t *testing.T) {
 		ctx := context.TODO() // dummy ctx
 
 		// act
-		_, err = repoDB.GetAll(ctx)
+		_, err = repoDB.GetAll(ctx, models.QueryOptions{})
 
 		// assert
 		assert.Error(t, err)
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("successfully returns 2 rows as a slice of buyers", func(t *testing.T) {
+	t.Run("successfully returns 2 rows as a page of buyers", func(t *testing.T) {
 		// arrange
 		dbMocked, mock, err := sqlmock.New()
 		require.NoError(t, err)
 
 		validRowsFromDB := sqlmock.NewRows(
-			[]string{"id", "first_name", "last_name", "card_number_id"}).
-			AddRow(1, "Juan", "Perez", 34567890).
-			AddRow(2, "Juana", "Gonzalez", 23456789)
+			[]string{"id", "first_name", "last_name", "card_number_id", "delivery_locality_id"}).
+			AddRow(1, "Juan", "Perez", 34567890, nil).
+			AddRow(2, "Juana", "Gonzalez", 23456789, nil)
 
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM buyers b")).
+			WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
 		mock.ExpectQuery("FROM buyers").WillReturnRows(validRowsFromDB)
 
 		repoDB := repository.NewBuyerRepositoryDB(dbMocked)
 		ctx := context.TODO() // dummy context
 
 		// act
-		got, err := repoDB.GetAll(ctx)
+		got, err := repoDB.GetAll(ctx, models.QueryOptions{})
 
 		// assert
 		expectedSecondBuyer := models.Buyer{
@@ -199,14 +203,15 @@ func TestBuyerRepositoryDB_GetAll(t *testing.T) {
 			},
 		}
 		assert.NoError(t, err)
-		assert.Equal(t, 2, len(got))
-		assert.Equal(t, expectedSecondBuyer.CardNumberId, got[1].CardNumberId)
-		assert.Equal(t, expectedSecondBuyer.Id, got[1].Id)
+		assert.Equal(t, 2, len(got.Data))
+		assert.Equal(t, 2, got.Meta.Total)
+		assert.Equal(t, expectedSecondBuyer.CardNumberId, got.Data[1].CardNumberId)
+		assert.Equal(t, expectedSecondBuyer.Id, got.Data[1].Id)
 
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("error after the loop rows.Next() return error as it is", func(t *testing.T) {
+	t.Run("error after the loop rows.Next() return an internal server error", func(t *testing.T) {
 		// arrange
 		dbMocked, mock, err := sqlmock.New()
 		require.NoError(t, err)
@@ -215,21 +220,22 @@ func TestBuyerRepositoryDB_GetAll(t *testing.T) {
 		sqlErrorRowsAfterLoopRows := errors.New("sql error after rows.Next()")
 
 		rowWithErrorReturn := mock.NewRows(
-			[]string{"id", "first_name", "last_name", "card_number_id"}).
-			AddRow(1, "Juan", "Perez", 34567890).
+			[]string{"id", "first_name", "last_name", "card_number_id", "delivery_locality_id"}).
+			AddRow(1, "Juan", "Perez", 34567890, nil).
 			RowError(0, sqlErrorRowsAfterLoopRows) // the first row will return an error
 
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM buyers b")).
+			WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
 		mock.ExpectQuery("FROM buyers").WillReturnRows(rowWithErrorReturn)
 
 		repoDB := repository.NewBuyerRepositoryDB(dbMocked)
 		ctx := context.TODO()
 
 		// act
-		_, err = repoDB.GetAll(ctx)
+		_, err = repoDB.GetAll(ctx, models.QueryOptions{})
 
 		// assert
-		assert.Error(t, err)
-		assert.ErrorIs(t, err, sqlErrorRowsAfterLoopRows)
+		assert.Equal(t, httperrors.InternalServerError{}, err)
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 }
@@ -263,8 +269,8 @@ func TestBuyerRepositoryDB_GetByID(t *testing.T) {
 		defer dbMocked.Close()
 
 		rowWithInvalidFields := mock.NewRows(
-			[]string{"id", "first_name", "last_name", "card_number_Id"}).
-			AddRow(1, nil, nil, 12345678)
+			[]string{"id", "first_name", "last_name", "card_number_Id", "delivery_locality_id"}).
+			AddRow(1, nil, nil, 12345678, nil)
 
 		mock.ExpectQuery("FROM buyers").WillReturnRows(rowWithInvalidFields)
 
@@ -286,7 +292,7 @@ func TestBuyerRepositoryDB_GetByID(t *testing.T) {
 		require.NoError(t, err)
 		defer dbMocked.Close()
 
-		emptyRow := mock.NewRows([]string{"id", "first_name", "last_name", "card_number_Id"})
+		emptyRow := mock.NewRows([]string{"id", "first_name", "last_name", "card_number_Id", "delivery_locality_id"})
 
 		mock.ExpectQuery("FROM buyers").WillReturnRows(emptyRow)
 
@@ -311,8 +317,8 @@ func TestBuyerRepositoryDB_GetByID(t *testing.T) {
 		defer dbMocked.Close()
 
 		validRowsFromDB := mock.NewRows(
-			[]string{"id", "first_name", "last_name", "card_number_id"}).
-			AddRow(1, "Juan", "Perez", 3456789)
+			[]string{"id", "first_name", "last_name", "card_number_id", "delivery_locality_id"}).
+			AddRow(1, "Juan", "Perez", 3456789, nil)
 
 		mock.ExpectQuery("FROM buyers").WillReturnRows(validRowsFromDB)
 
@@ -456,6 +462,7 @@ func TestBuyerRepositoryDB_Update(t *testing.T) {
 				buyerToUpdate.CardNumberId,
 				buyerToUpdate.FirstName,
 				buyerToUpdate.LastName,
+				buyerToUpdate.DeliveryLocalityId,
 				buyerToUpdate.Id).
 			WillReturnResult(sqlResultUpdated)
 
@@ -483,6 +490,7 @@ func TestBuyerRepositoryDB_Update(t *testing.T) {
 				buyerToUpdate.CardNumberId,
 				buyerToUpdate.FirstName,
 				buyerToUpdate.LastName,
+				buyerToUpdate.DeliveryLocalityId,
 				buyerToUpdate.Id).
 			WillReturnError(sqlErrorTimeout)
 
@@ -511,6 +519,7 @@ func TestBuyerRepositoryDB_Update(t *testing.T) {
 				buyerToUpdate.CardNumberId,
 				buyerToUpdate.FirstName,
 				buyerToUpdate.LastName,
+				buyerToUpdate.DeliveryLocalityId,
 				buyerToUpdate.Id).
 			WillReturnError(conflictErr)
 
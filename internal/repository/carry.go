@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
 	"github.com/go-sql-driver/mysql"
@@ -18,7 +20,7 @@ func NewCarryRepositoryDb(db *sql.DB) *CarryRepositoryDB {
 }
 
 // Create inserts a new carry into the database.
-func (p *CarryRepositoryDB) Create(carryAttributes models.CarryAttributes) (models.Carry, error) {
+func (p *CarryRepositoryDB) Create(ctx context.Context, carryAttributes models.CarryAttributes) (models.Carry, error) {
 
 	query := `
 		INSERT INTO carries (
@@ -29,7 +31,7 @@ func (p *CarryRepositoryDB) Create(carryAttributes models.CarryAttributes) (mode
 			locality_id
 		) VALUES (?, ?, ?, ?, ?)
 	`
-	result, err := p.db.Exec(query,
+	result, err := p.db.ExecContext(ctx, query,
 		carryAttributes.Cid,
 		carryAttributes.CompanyName,
 		carryAttributes.Address,
@@ -38,20 +40,11 @@ func (p *CarryRepositoryDB) Create(carryAttributes models.CarryAttributes) (mode
 	)
 
 	if err != nil {
-		var sqlErrors *mysql.MySQLError
-		if errors.As(err, &sqlErrors) {
-			if sqlErrors.Number == 1452 {
-				return models.Carry{}, httperrors.ConflictError{Message: "the LocalityId does not exist"}
-			}
-			if sqlErrors.Number == 1062 {
-				return models.Carry{}, httperrors.ConflictError{Message: "the Cid already exists"}
-			}
-			return models.Carry{}, httperrors.InternalServerError{Message: "error creating carry"}
-		}
+		return models.Carry{}, carryWriteError(ctx, err, "error creating carry")
 	}
 	lastInsertId, err := result.LastInsertId()
 	if err != nil {
-		return models.Carry{}, httperrors.InternalServerError{Message: "error obtaining last insert ID"}
+		return models.Carry{}, internalError(ctx, "error obtaining last insert ID", err)
 	}
 
 	newCarry := models.Carry{
@@ -61,3 +54,158 @@ func (p *CarryRepositoryDB) Create(carryAttributes models.CarryAttributes) (mode
 
 	return newCarry, nil
 }
+
+// carryWriteError maps the errors of inserting or updating a carry,
+// using message for the ones that are not constraint violations.
+func carryWriteError(ctx context.Context, err error, message string) error {
+	var sqlErrors *mysql.MySQLError
+	if errors.As(err, &sqlErrors) {
+		if sqlErrors.Number == 1452 {
+			return httperrors.ConflictError{Message: "the LocalityId does not exist"}
+		}
+		if sqlErrors.Number == 1062 {
+			return httperrors.ConflictError{Message: "the Cid already exists"}
+		}
+	}
+	return internalError(ctx, message, err)
+}
+
+// carryColumns lists the columns read into a models.Carry.
+const carryColumns = `
+			id,
+			cid,
+			company_name,
+			address,
+			telephone,
+			locality_id`
+
+// scanCarry reads a row selected with carryColumns.
+func scanCarry(row interface{ Scan(dest ...any) error }) (models.Carry, error) {
+	var carry models.Carry
+	err := row.Scan(
+		&carry.Id,
+		&carry.Cid,
+		&carry.CompanyName,
+		&carry.Address,
+		&carry.Telephone,
+		&carry.LocalityId,
+	)
+	return carry, err
+}
+
+// carryListColumns are the fields carries can be sorted and filtered by.
+var carryListColumns = listColumns{
+	"id":           "id",
+	"cid":          "cid",
+	"company_name": "company_name",
+	"locality_id":  "locality_id",
+}
+
+// GetAll returns the page of carries described by opts.
+func (p *CarryRepositoryDB) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Carry], error) {
+	query := listQuery{
+		selectColumns: carryColumns,
+		from:          "carries",
+		columns:       carryListColumns,
+	}
+	return queryPage(ctx, p.db, query, opts, carryListFields, func(rows *sql.Rows) (models.Carry, error) {
+		return scanCarry(rows)
+	})
+}
+
+// GetByID returns a carry by its ID.
+func (p *CarryRepositoryDB) GetByID(ctx context.Context, id int) (models.Carry, error) {
+	query := "SELECT" + carryColumns + "\n\t\tFROM carries\n\t\tWHERE id = ?"
+	carry, err := scanCarry(p.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Carry{}, httperrors.NotFoundError{Message: "carry not found"}
+		}
+		return models.Carry{}, internalError(ctx, "error obtaining carry by ID", err)
+	}
+	return carry, nil
+}
+
+// Update replaces the attributes of an existing carry and returns the updated carry.
+func (p *CarryRepositoryDB) Update(ctx context.Context, id int, carryAttributes models.CarryAttributes) (models.Carry, error) {
+	query := `
+		UPDATE carries SET
+			cid = ?,
+			company_name = ?,
+			address = ?,
+			telephone = ?,
+			locality_id = ?
+		WHERE id = ?
+	`
+	_, err := p.db.ExecContext(ctx, query,
+		carryAttributes.Cid,
+		carryAttributes.CompanyName,
+		carryAttributes.Address,
+		carryAttributes.Telephone,
+		carryAttributes.LocalityId,
+		id,
+	)
+	if err != nil {
+		return models.Carry{}, carryWriteError(ctx, err, "error updating carry")
+	}
+	return models.Carry{Id: id, CarryAttributes: carryAttributes}, nil
+}
+
+// Delete removes a carry by its ID. The purchase orders of the carry are read with
+// FOR SHARE so none can be assigned to it until it is gone.
+// Returns a ConflictError if the carry is assigned to orders still to be delivered,
+// or if delivered orders or tracking events keep its history.
+func (p *CarryRepositoryDB) Delete(ctx context.Context, id int) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return internalError(ctx, "error deleting carry", err)
+	}
+	defer tx.Rollback()
+
+	const openQuery = `
+		SELECT COUNT(*)
+		FROM purchase_orders
+		WHERE carry_id = ? AND status NOT IN ('delivered', 'cancelled')
+		FOR SHARE
+	`
+	var open int
+	if err := tx.QueryRowContext(ctx, openQuery, id).Scan(&open); err != nil {
+		return internalError(ctx, "error deleting carry", err)
+	}
+	if open > 0 {
+		return openShipmentsError()
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM carries WHERE id = ?`, id)
+	if err != nil {
+		var sqlErrors *mysql.MySQLError
+		if errors.As(err, &sqlErrors) && sqlErrors.Number == 1451 {
+			return carryHistoryError()
+		}
+		return internalError(ctx, "error deleting carry", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return internalError(ctx, "error checking delete", err)
+	}
+	if rowsAffected == 0 {
+		return httperrors.NotFoundError{Message: "carry not found"}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return internalError(ctx, "error deleting carry", err)
+	}
+	return nil
+}
+
+// openShipmentsError is returned when deleting a carry with purchase orders still to deliver.
+func openShipmentsError() error {
+	return httperrors.ConflictError{Message: "the carry is still assigned to open shipments"}
+}
+
+// carryHistoryError is returned when deleting a carry whose shipments are all
+// closed. Their purchase orders and tracking events keep the carry that
+// delivered them, so only a carry that never had purchase orders can be deleted.
+func carryHistoryError() error {
+	return httperrors.ConflictError{Message: "the carry has delivered or cancelled shipments and is kept in their history; only carries without purchase orders can be deleted"}
+}
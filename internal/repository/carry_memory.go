@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// CarryRepositoryMemory is an in-memory implementation of CarryRepository.
+type CarryRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// NewCarryRepositoryMemory creates a new CarryRepositoryMemory instance.
+func NewCarryRepositoryMemory(store *MemoryStore) CarryRepository {
+	return &CarryRepositoryMemory{store: store}
+}
+
+// Create stores a new carry, checking the locality foreign key and the unique cid.
+func (p *CarryRepositoryMemory) Create(ctx context.Context, carryAttributes models.CarryAttributes) (models.Carry, error) {
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	if _, ok := p.store.localities[carryAttributes.LocalityId]; !ok {
+		return models.Carry{}, httperrors.ConflictError{Message: "the LocalityId does not exist"}
+	}
+	for _, carry := range p.store.carries {
+		if carry.Cid == carryAttributes.Cid {
+			return models.Carry{}, httperrors.ConflictError{Message: "the Cid already exists"}
+		}
+	}
+
+	newCarry := models.Carry{
+		Id:              p.store.nextID("carries"),
+		CarryAttributes: carryAttributes,
+	}
+	p.store.carries[newCarry.Id] = newCarry
+	return newCarry, nil
+}
+
+// carryListFields are the fields carries can be sorted and filtered by.
+var carryListFields = listFields[models.Carry]{
+	"id":           func(c models.Carry) any { return c.Id },
+	"cid":          func(c models.Carry) any { return c.Cid },
+	"company_name": func(c models.Carry) any { return c.CompanyName },
+	"locality_id":  func(c models.Carry) any { return c.LocalityId },
+}
+
+// GetAll returns the page of carries described by opts.
+func (p *CarryRepositoryMemory) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Carry], error) {
+	p.store.mu.RLock()
+	defer p.store.mu.RUnlock()
+
+	return pageOf(sortedByID(p.store.carries), opts, carryListFields)
+}
+
+// GetByID returns a carry by its ID.
+func (p *CarryRepositoryMemory) GetByID(ctx context.Context, id int) (models.Carry, error) {
+	p.store.mu.RLock()
+	defer p.store.mu.RUnlock()
+
+	carry, ok := p.store.carries[id]
+	if !ok {
+		return models.Carry{}, httperrors.NotFoundError{Message: "carry not found"}
+	}
+	return carry, nil
+}
+
+// Update replaces the attributes of a carry, checking the locality foreign key and the unique cid.
+// Like the SQL implementation, updating a missing id is not an error.
+func (p *CarryRepositoryMemory) Update(ctx context.Context, id int, carryAttributes models.CarryAttributes) (models.Carry, error) {
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	if _, ok := p.store.localities[carryAttributes.LocalityId]; !ok {
+		return models.Carry{}, httperrors.ConflictError{Message: "the LocalityId does not exist"}
+	}
+	for _, carry := range p.store.carries {
+		if carry.Cid == carryAttributes.Cid && carry.Id != id {
+			return models.Carry{}, httperrors.ConflictError{Message: "the Cid already exists"}
+		}
+	}
+
+	updatedCarry := models.Carry{Id: id, CarryAttributes: carryAttributes}
+	if _, ok := p.store.carries[id]; ok {
+		p.store.carries[id] = updatedCarry
+	}
+	return updatedCarry, nil
+}
+
+// Delete removes a carry by its ID.
+// Returns a ConflictError if the carry is assigned to orders still to be delivered,
+// or if closed orders or tracking events keep its history.
+func (p *CarryRepositoryMemory) Delete(ctx context.Context, id int) error {
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	if _, ok := p.store.carries[id]; !ok {
+		return httperrors.NotFoundError{Message: "carry not found"}
+	}
+	referenced := false
+	for _, order := range p.store.purchaseOrders {
+		if order.CarryId == nil || *order.CarryId != id {
+			continue
+		}
+		if models.IsPurchaseOrderOpen(order.Status) {
+			return openShipmentsError()
+		}
+		referenced = true
+	}
+	for _, event := range p.store.trackingEvents {
+		if event.CarryId == id {
+			referenced = true
+		}
+	}
+	if referenced {
+		return carryHistoryError()
+	}
+
+	delete(p.store.carries, id)
+	return nil
+}
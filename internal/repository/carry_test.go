@@ -0,0 +1,95 @@
+package repository_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/logging"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCarryRepositoryDB_Delete(t *testing.T) {
+	tests := []struct {
+		testName    string
+		openOrders  int
+		deleteError error
+		deleted     int64
+		expectedErr error
+		expectedLog string
+	}{
+		{
+			testName: "deletes a carry without shipments",
+			deleted:  1,
+		},
+		{
+			testName:    "carry with open shipments returns ConflictError",
+			openOrders:  2,
+			expectedErr: httperrors.ConflictError{Message: "the carry is still assigned to open shipments"},
+		},
+		{
+			testName:    "carry referenced by delivered orders returns ConflictError",
+			deleteError: &mysql.MySQLError{Number: 1451},
+			expectedErr: httperrors.ConflictError{Message: "the carry has delivered or cancelled shipments and is kept in their history; only carries without purchase orders can be deleted"},
+		},
+		{
+			testName:    "database error is logged and returns InternalServerError",
+			deleteError: errors.New("connection reset"),
+			expectedErr: httperrors.InternalServerError{Message: "error deleting carry"},
+			expectedLog: "connection reset",
+		},
+		{
+			testName:    "missing carry returns NotFoundError",
+			deleted:     0,
+			expectedErr: httperrors.NotFoundError{Message: "carry not found"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM purchase_orders WHERE carry_id = \\? AND status NOT IN \\('delivered', 'cancelled'\\) FOR SHARE").
+				WithArgs(3).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tc.openOrders))
+			if tc.openOrders == 0 {
+				exec := mock.ExpectExec("DELETE FROM carries WHERE id = \\?").WithArgs(3)
+				if tc.deleteError != nil {
+					exec.WillReturnError(tc.deleteError)
+				} else {
+					exec.WillReturnResult(sqlmock.NewResult(0, tc.deleted))
+				}
+			}
+			if tc.expectedErr == nil {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			var logs bytes.Buffer
+			ctx := logging.WithLogger(context.Background(), logging.New(&logs, slog.LevelError))
+			repo := repository.NewCarryRepositoryDb(db)
+
+			// act
+			err = repo.Delete(ctx, 3)
+
+			// assert
+			assert.Equal(t, tc.expectedErr, err)
+			if tc.expectedLog != "" {
+				assert.Contains(t, logs.String(), tc.expectedLog)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-sql-driver/mysql"
+)
+
+// CountryRepositoryDB is a SQL implementation of CountryRepository
+// over the countries table.
+// Delete and the reports are the ones of regionDB.
+type CountryRepositoryDB struct {
+	regionDB
+}
+
+// NewCountryRepositoryDB constructs a CountryRepositoryDB that uses
+// the given *sql.DB for all data operations.
+func NewCountryRepositoryDB(db *sql.DB) CountryRepository {
+	return &CountryRepositoryDB{regionDB{
+		db:         db,
+		table:      "countries",
+		nameColumn: "country_name",
+		notFound:   "Country not found",
+		referenced: "Country is still referenced by provinces.",
+	}}
+}
+
+// Create inserts a new country and returns it with its generated ID.
+// Returns a ConflictError if the name is already in use.
+func (r *CountryRepositoryDB) Create(ctx context.Context, countryAttributes models.CountryAttributes) (models.Country, error) {
+	const query = `INSERT INTO countries (country_name) VALUES (?)`
+
+	result, err := r.db.ExecContext(ctx, query, countryAttributes.CountryName)
+	if err != nil {
+		var sqlError *mysql.MySQLError
+		if errors.As(err, &sqlError) && sqlError.Number == 1062 {
+			return models.Country{}, httperrors.ConflictError{Message: "Country name already exists."}
+		}
+		return models.Country{}, internalError(ctx, "", err)
+	}
+
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		return models.Country{}, internalError(ctx, "", err)
+	}
+	return models.Country{ID: int(lastId), CountryAttributes: countryAttributes}, nil
+}
+
+// countryListColumns are the fields countries can be sorted and filtered by.
+var countryListColumns = listColumns{
+	"id":           "id",
+	"country_name": "country_name",
+}
+
+// GetAll returns the page of countries described by opts.
+func (r *CountryRepositoryDB) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Country], error) {
+	query := listQuery{
+		selectColumns: "id, country_name",
+		from:          "countries",
+		columns:       countryListColumns,
+	}
+
+	return queryPage(ctx, r.db, query, opts, countryListFields, func(rows *sql.Rows) (models.Country, error) {
+		var country models.Country
+		err := rows.Scan(&country.ID, &country.CountryName)
+		return country, err
+	})
+}
+
+// GetByID returns the country with the given ID or a NotFoundError.
+func (r *CountryRepositoryDB) GetByID(ctx context.Context, id int) (models.Country, error) {
+	const query = `SELECT id, country_name FROM countries WHERE id = ?`
+
+	var country models.Country
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&country.ID, &country.CountryName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Country{}, httperrors.NotFoundError{Message: "Country not found"}
+		}
+		return models.Country{}, internalError(ctx, "", err)
+	}
+	return country, nil
+}
+
+// Update stores the new attributes of the country.
+// Returns a ConflictError if the name is already in use.
+func (r *CountryRepositoryDB) Update(ctx context.Context, id int, country models.Country) (models.Country, error) {
+	const query = `UPDATE countries SET country_name = ? WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, country.CountryName, id)
+	if err != nil {
+		var sqlError *mysql.MySQLError
+		if errors.As(err, &sqlError) && sqlError.Number == 1062 {
+			return models.Country{}, httperrors.ConflictError{Message: "Country name already exists."}
+		}
+		return models.Country{}, internalError(ctx, "", err)
+	}
+	return country, nil
+}
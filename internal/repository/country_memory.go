@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// CountryRepositoryMemory is an in-memory implementation of CountryRepository.
+// GetAll, GetByID, Delete and the reports are the ones of regionMemory.
+type CountryRepositoryMemory struct {
+	regionMemory[models.Country]
+}
+
+// NewCountryRepositoryMemory constructs a CountryRepositoryMemory backed
+// by the given store.
+func NewCountryRepositoryMemory(store *MemoryStore) CountryRepository {
+	return &CountryRepositoryMemory{regionMemory[models.Country]{
+		store:   store,
+		fields:  countryListFields,
+		regions: func() map[int]models.Country { return store.countries },
+		reportRow: func(country models.Country) regionCount {
+			return regionCount{id: country.ID, name: country.CountryName}
+		},
+		regionOf: func(locality models.Locality) int {
+			return store.provinces[locality.ProvinceID].CountryID
+		},
+		isReferenced: func(id int) bool {
+			for _, province := range store.provinces {
+				if province.CountryID == id {
+					return true
+				}
+			}
+			return false
+		},
+		notFound:   "Country not found",
+		referenced: "Country is still referenced by provinces.",
+	}}
+}
+
+// Create stores a new country and returns it with its generated ID.
+// Returns a ConflictError if the name is already in use.
+func (r *CountryRepositoryMemory) Create(ctx context.Context, countryAttributes models.CountryAttributes) (models.Country, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkConstraints(0, countryAttributes); err != nil {
+		return models.Country{}, err
+	}
+
+	country := models.Country{
+		ID:                r.store.nextID("countries"),
+		CountryAttributes: countryAttributes,
+	}
+	r.store.countries[country.ID] = country
+	return country, nil
+}
+
+// countryListFields are the fields countries can be sorted and filtered by.
+var countryListFields = listFields[models.Country]{
+	"id":           func(c models.Country) any { return c.ID },
+	"country_name": func(c models.Country) any { return c.CountryName },
+}
+
+// Update replaces the stored country.
+// Like the SQL implementation, updating a missing id is not an error.
+func (r *CountryRepositoryMemory) Update(ctx context.Context, id int, country models.Country) (models.Country, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkConstraints(id, country.CountryAttributes); err != nil {
+		return models.Country{}, err
+	}
+
+	if _, ok := r.store.countries[id]; ok {
+		stored := country
+		stored.ID = id
+		r.store.countries[id] = stored
+	}
+	return country, nil
+}
+
+// checkConstraints validates the unique name of the country
+// identified by id (0 for a new country).
+// The caller must hold the lock.
+func (r *CountryRepositoryMemory) checkConstraints(id int, attributes models.CountryAttributes) error {
+	for _, country := range r.store.countries {
+		if country.ID != id && country.CountryName == attributes.CountryName {
+			return httperrors.ConflictError{Message: "Country name already exists."}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/utils"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountryRepositoryDB_Create(t *testing.T) {
+	t.Run("returns the country with its generated id", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("INSERT INTO countries \\(country_name\\) VALUES \\(\\?\\)").
+			WithArgs("Uruguay").
+			WillReturnResult(sqlmock.NewResult(2, 1))
+
+		repo := repository.NewCountryRepositoryDB(db)
+
+		// act
+		got, err := repo.Create(context.Background(), models.CountryAttributes{CountryName: "Uruguay"})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, models.Country{ID: 2, CountryAttributes: models.CountryAttributes{CountryName: "Uruguay"}}, got)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("duplicated name returns ConflictError", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("INSERT INTO countries").
+			WithArgs("Argentina").
+			WillReturnError(&mysql.MySQLError{Number: 1062})
+
+		repo := repository.NewCountryRepositoryDB(db)
+
+		// act
+		_, err = repo.Create(context.Background(), models.CountryAttributes{CountryName: "Argentina"})
+
+		// assert
+		assert.Equal(t, httperrors.ConflictError{Message: "Country name already exists."}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCountryRepositoryDB_GetByID(t *testing.T) {
+	t.Run("returns the country", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT id, country_name FROM countries WHERE id = \\?").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "country_name"}).AddRow(1, "Argentina"))
+
+		repo := repository.NewCountryRepositoryDB(db)
+
+		// act
+		got, err := repo.GetByID(context.Background(), 1)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, models.Country{ID: 1, CountryAttributes: models.CountryAttributes{CountryName: "Argentina"}}, got)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing country returns NotFoundError", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("FROM countries WHERE id = \\?").
+			WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "country_name"}))
+
+		repo := repository.NewCountryRepositoryDB(db)
+
+		// act
+		_, err = repo.GetByID(context.Background(), 9)
+
+		// assert
+		assert.Equal(t, httperrors.NotFoundError{Message: "Country not found"}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCountryRepositoryDB_Update(t *testing.T) {
+	t.Run("duplicated name returns ConflictError", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE countries SET country_name = \\? WHERE id = \\?").
+			WithArgs("Argentina", 2).
+			WillReturnError(&mysql.MySQLError{Number: 1062})
+
+		repo := repository.NewCountryRepositoryDB(db)
+
+		// act
+		_, err = repo.Update(context.Background(), 2, models.Country{ID: 2, CountryAttributes: models.CountryAttributes{CountryName: "Argentina"}})
+
+		// assert
+		assert.Equal(t, httperrors.ConflictError{Message: "Country name already exists."}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCountryRepositoryDB_Delete(t *testing.T) {
+	tests := []struct {
+		testName    string
+		result      func(exec *sqlmock.ExpectedExec)
+		expectedErr error
+	}{
+		{
+			testName: "removes the country",
+			result: func(exec *sqlmock.ExpectedExec) {
+				exec.WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			testName: "missing country returns NotFoundError",
+			result: func(exec *sqlmock.ExpectedExec) {
+				exec.WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: httperrors.NotFoundError{Message: "Country not found"},
+		},
+		{
+			testName: "country still referenced by provinces returns ConflictError",
+			result: func(exec *sqlmock.ExpectedExec) {
+				exec.WillReturnError(&mysql.MySQLError{Number: 1451})
+			},
+			expectedErr: httperrors.ConflictError{Message: "Country is still referenced by provinces."},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tc.result(mock.ExpectExec("DELETE FROM countries WHERE id = \\?").WithArgs(1))
+
+			repo := repository.NewCountryRepositoryDB(db)
+
+			// act
+			err = repo.Delete(context.Background(), 1)
+
+			// assert
+			assert.Equal(t, tc.expectedErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCountryRepositoryDB_GetSellerReport(t *testing.T) {
+	t.Run("counts the sellers of the localities of each province of the country", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT r.id, r.country_name, COUNT\\(t.id\\) FROM countries r " +
+			"LEFT JOIN provinces p ON p.country_id = r.id LEFT JOIN localities l ON l.province_id = p.id " +
+			"LEFT JOIN sellers t ON t.locality_id = l.id WHERE r.id = \\? GROUP BY r.id, r.country_name ORDER BY r.id").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "country_name", "count"}).AddRow(1, "Argentina", 5))
+
+		repo := repository.NewCountryRepositoryDB(db)
+
+		// act
+		got, err := repo.GetSellerReport(context.Background(), utils.Ptr(1))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, []models.RegionSellerReport{{ID: 1, Name: "Argentina", SellersCount: 5}}, got)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing country returns NotFoundError", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("FROM countries r").
+			WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "country_name", "count"}))
+
+		repo := repository.NewCountryRepositoryDB(db)
+
+		// act
+		_, err = repo.GetSellerReport(context.Background(), utils.Ptr(9))
+
+		// assert
+		assert.Equal(t, httperrors.NotFoundError{Message: "Country not found"}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
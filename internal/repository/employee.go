@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
@@ -16,7 +17,7 @@ func NewEmployeeRepository(db *sql.DB) EmployeeRepository {
 	return &EmployeeRepositoryDB{db: db}
 }
 
-func (e *EmployeeRepositoryDB) Create(employee models.Employee) (models.Employee, error) {
+func (e *EmployeeRepositoryDB) Create(ctx context.Context, employee models.Employee) (models.Employee, error) {
 	const query = `
 		INSERT INTO employees (
 			card_number_id,
@@ -25,7 +26,7 @@ func (e *EmployeeRepositoryDB) Create(employee models.Employee) (models.Employee
 			warehouse_id
 		) VALUES (?, ?, ?, ?)
 	`
-	res, err := e.db.Exec(query, employee.CardNumberID, employee.FirstName, employee.LastName, employee.WarehouseID)
+	res, err := e.db.ExecContext(ctx, query, employee.CardNumberID, employee.FirstName, employee.LastName, employee.WarehouseID)
 	if err != nil {
 		return models.Employee{}, err
 	}
@@ -37,34 +38,34 @@ func (e *EmployeeRepositoryDB) Create(employee models.Employee) (models.Employee
 	return employee, nil
 }
 
-func (e *EmployeeRepositoryDB) GetAll() ([]models.Employee, error) {
-	const query = `
-		SELECT
+// employeeListColumns are the fields employees can be sorted and filtered by.
+var employeeListColumns = listColumns{
+	"id":             "id",
+	"card_number_id": "card_number_id",
+	"first_name":     "first_name",
+	"last_name":      "last_name",
+	"warehouse_id":   "warehouse_id",
+}
+
+func (e *EmployeeRepositoryDB) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Employee], error) {
+	query := listQuery{
+		selectColumns: `
 			id,
 			card_number_id,
 			first_name,
 			last_name,
-			warehouse_id
-		FROM employees
-	`
-	rows, err := e.db.Query(query)
-	if err != nil {
-		return nil, err
+			warehouse_id`,
+		from:    "employees",
+		columns: employeeListColumns,
 	}
-	defer rows.Close()
-
-	var employees []models.Employee
-	for rows.Next() {
+	return queryPage(ctx, e.db, query, opts, employeeListFields, func(rows *sql.Rows) (models.Employee, error) {
 		var emp models.Employee
-		if err := rows.Scan(&emp.Id, &emp.CardNumberID, &emp.FirstName, &emp.LastName, &emp.WarehouseID); err != nil {
-			return nil, err
-		}
-		employees = append(employees, emp)
-	}
-	return employees, nil
+		err := rows.Scan(&emp.Id, &emp.CardNumberID, &emp.FirstName, &emp.LastName, &emp.WarehouseID)
+		return emp, err
+	})
 }
 
-func (e *EmployeeRepositoryDB) GetByID(id int) (models.Employee, error) {
+func (e *EmployeeRepositoryDB) GetByID(ctx context.Context, id int) (models.Employee, error) {
 	const query = `
 		SELECT
 			id,
@@ -76,7 +77,7 @@ func (e *EmployeeRepositoryDB) GetByID(id int) (models.Employee, error) {
 		WHERE id = ?
 	`
 	var emp models.Employee
-	err := e.db.QueryRow(query, id).Scan(
+	err := e.db.QueryRowContext(ctx, query, id).Scan(
 		&emp.Id, &emp.CardNumberID, &emp.FirstName, &emp.LastName, &emp.WarehouseID,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -88,7 +89,7 @@ func (e *EmployeeRepositoryDB) GetByID(id int) (models.Employee, error) {
 	return emp, nil
 }
 
-func (e *EmployeeRepositoryDB) Update(id int, employee models.Employee) (models.Employee, error) {
+func (e *EmployeeRepositoryDB) Update(ctx context.Context, id int, employee models.Employee) (models.Employee, error) {
 	const query = `
 		UPDATE employees
 		SET
@@ -98,7 +99,7 @@ func (e *EmployeeRepositoryDB) Update(id int, employee models.Employee) (models.
 			warehouse_id = ?
 		WHERE id = ?
 	`
-	res, err := e.db.Exec(query, employee.CardNumberID, employee.FirstName, employee.LastName, employee.WarehouseID, id)
+	res, err := e.db.ExecContext(ctx, query, employee.CardNumberID, employee.FirstName, employee.LastName, employee.WarehouseID, id)
 	if err != nil {
 		return models.Employee{}, err
 	}
@@ -113,12 +114,12 @@ func (e *EmployeeRepositoryDB) Update(id int, employee models.Employee) (models.
 	return employee, nil
 }
 
-func (e *EmployeeRepositoryDB) Delete(id int) error {
+func (e *EmployeeRepositoryDB) Delete(ctx context.Context, id int) error {
 	const query = `
 		DELETE FROM employees
 		WHERE id = ?
 	`
-	res, err := e.db.Exec(query, id)
+	res, err := e.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
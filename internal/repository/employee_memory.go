@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// EmployeeRepositoryMemory is an in-memory implementation of EmployeeRepository.
+type EmployeeRepositoryMemory struct {
+	store *MemoryStore
+}
+
+func NewEmployeeRepositoryMemory(store *MemoryStore) EmployeeRepository {
+	return &EmployeeRepositoryMemory{store: store}
+}
+
+func (e *EmployeeRepositoryMemory) Create(ctx context.Context, employee models.Employee) (models.Employee, error) {
+	e.store.mu.Lock()
+	defer e.store.mu.Unlock()
+
+	if _, ok := e.store.warehouses[employee.WarehouseID]; !ok {
+		return models.Employee{}, httperrors.ConflictError{Message: "warehouse does not exist"}
+	}
+
+	employee.Id = e.store.nextID("employees")
+	e.store.employees[employee.Id] = employee
+	return employee, nil
+}
+
+// employeeListFields are the fields employees can be sorted and filtered by.
+var employeeListFields = listFields[models.Employee]{
+	"id":             func(e models.Employee) any { return e.Id },
+	"card_number_id": func(e models.Employee) any { return e.CardNumberID },
+	"first_name":     func(e models.Employee) any { return e.FirstName },
+	"last_name":      func(e models.Employee) any { return e.LastName },
+	"warehouse_id":   func(e models.Employee) any { return e.WarehouseID },
+}
+
+func (e *EmployeeRepositoryMemory) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Employee], error) {
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
+
+	return pageOf(sortedByID(e.store.employees), opts, employeeListFields)
+}
+
+func (e *EmployeeRepositoryMemory) GetByID(ctx context.Context, id int) (models.Employee, error) {
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
+
+	emp, ok := e.store.employees[id]
+	if !ok {
+		return models.Employee{}, httperrors.NotFoundError{Message: "employee not found"}
+	}
+	return emp, nil
+}
+
+func (e *EmployeeRepositoryMemory) Update(ctx context.Context, id int, employee models.Employee) (models.Employee, error) {
+	e.store.mu.Lock()
+	defer e.store.mu.Unlock()
+
+	if _, ok := e.store.employees[id]; !ok {
+		return models.Employee{}, httperrors.NotFoundError{Message: "employee not found"}
+	}
+	if _, ok := e.store.warehouses[employee.WarehouseID]; !ok {
+		return models.Employee{}, httperrors.ConflictError{Message: "warehouse does not exist"}
+	}
+
+	employee.Id = id
+	e.store.employees[id] = employee
+	return employee, nil
+}
+
+func (e *EmployeeRepositoryMemory) Delete(ctx context.Context, id int) error {
+	e.store.mu.Lock()
+	defer e.store.mu.Unlock()
+
+	if _, ok := e.store.employees[id]; !ok {
+		return httperrors.NotFoundError{Message: "employee not found"}
+	}
+	for _, order := range e.store.inboundOrders {
+		if order.EmployeeID == id {
+			return httperrors.ConflictError{Message: "employee still has inbound orders"}
+		}
+	}
+
+	delete(e.store.employees, id)
+	return nil
+}
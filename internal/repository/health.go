@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// HealthRepositoryDB is a SQL implementation of HealthRepository
+type HealthRepositoryDB struct {
+	db *sql.DB
+}
+
+// NewHealthRepositoryDB constructs a HealthRepositoryDB that checks the given *sql.DB
+func NewHealthRepositoryDB(db *sql.DB) HealthRepository {
+	return &HealthRepositoryDB{db: db}
+}
+
+// Ping checks a connection to the database can be established.
+func (r *HealthRepositoryDB) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// GetAppliedMigrations returns the file names recorded in schema_migrations.
+// Errors are returned as they are, the health check reports them.
+func (r *HealthRepositoryDB) GetAppliedMigrations(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT filename FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
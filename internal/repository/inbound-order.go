@@ -1,11 +1,14 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
-	_ "time"
+	"time"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-sql-driver/mysql"
 )
 
 type InboundOrderRepositoryDB struct {
@@ -16,80 +19,178 @@ func NewInboundOrderRepository(db *sql.DB) InboundOrderRepository {
 	return &InboundOrderRepositoryDB{db: db}
 }
 
-// Create inserts a new InboundOrder and returns the created record with its ID and OrderDate.
-func (r *InboundOrderRepositoryDB) Create(order models.InboundOrder) (models.InboundOrder, error) {
+// Create receives the inbound order in a single transaction. The employee and the
+// section rows are read with FOR SHARE so they cannot move to another warehouse
+// until the order is stored. A new batch_number creates the batch, an existing one
+// is locked and topped up with an inbound stock movement.
+// Returns a ConflictError if the employee or the section do not belong to the warehouse,
+// the existing batch holds another product or is in another section, or the order number is taken.
+func (r *InboundOrderRepositoryDB) Create(ctx context.Context, attrs models.InboundOrderAttributes) (models.InboundOrder, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.InboundOrder{}, internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	var employeeWarehouseID int
+	err = tx.QueryRowContext(ctx, `SELECT warehouse_id FROM employees WHERE id = ? FOR SHARE`, attrs.EmployeeID).
+		Scan(&employeeWarehouseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.InboundOrder{}, httperrors.ConflictError{Message: "employee does not exist"}
+		}
+		return models.InboundOrder{}, internalError(ctx, "", err)
+	}
+	if employeeWarehouseID != attrs.WarehouseID {
+		return models.InboundOrder{}, httperrors.ConflictError{Message: "employee does not belong to the warehouse"}
+	}
+
+	batch := attrs.ProductBatch
+	var sectionWarehouseID int
+	err = tx.QueryRowContext(ctx, `SELECT warehouse_id FROM sections WHERE id = ? FOR SHARE`, batch.SectionID).
+		Scan(&sectionWarehouseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.InboundOrder{}, httperrors.ConflictError{Message: "section does not exist"}
+		}
+		return models.InboundOrder{}, internalError(ctx, "", err)
+	}
+	if sectionWarehouseID != attrs.WarehouseID {
+		return models.InboundOrder{}, httperrors.ConflictError{Message: "section does not belong to the warehouse"}
+	}
+
+	order := models.InboundOrder{
+		OrderNumber: attrs.OrderNumber,
+		OrderDate:   attrs.OrderDate,
+		EmployeeID:  attrs.EmployeeID,
+		WarehouseID: attrs.WarehouseID,
+		Quantity:    batch.Quantity,
+	}
+
+	var existing lockedProductBatch
+	var existingID int
+	err = tx.QueryRowContext(ctx, `SELECT id, product_id, section_id FROM product_batches WHERE batch_number = ? FOR UPDATE`, batch.BatchNumber).
+		Scan(&existingID, &existing.productID, &existing.sectionID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		created, err := insertProductBatchTx(ctx, tx, models.ProductBatchAttibutes{
+			BatchNumber:        batch.BatchNumber,
+			CurrentQuantity:    batch.Quantity,
+			CurrentTemperature: batch.CurrentTemperature,
+			DueDate:            batch.DueDate,
+			InitialQuantity:    batch.Quantity,
+			ManufacturingDate:  batch.ManufacturingDate,
+			ManufacturingHour:  batch.ManufacturingHour,
+			MinimumTemperature: batch.MinimumTemperature,
+			ProductID:          batch.ProductID,
+			SectionID:          batch.SectionID,
+		})
+		if err != nil {
+			return models.InboundOrder{}, err
+		}
+		order.ProductBatchID = created.ID
+	case err != nil:
+		return models.InboundOrder{}, internalError(ctx, "", err)
+	default:
+		if err := checkInboundTopUp(existing, batch); err != nil {
+			return models.InboundOrder{}, err
+		}
+		_, err := applyStockMovementTx(ctx, tx, models.StockMovement{
+			ProductBatchID: existingID,
+			MovementType:   models.StockMovementInbound,
+			Quantity:       batch.Quantity,
+			Reason:         inboundOrderMovementReason(order),
+		})
+		if err != nil {
+			return models.InboundOrder{}, err
+		}
+		order.ProductBatchID = existingID
+	}
+
 	const query = `
 		INSERT INTO inbound_orders (
 			order_number,
 			order_date,
 			employee_id,
 			warehouse_id,
-			product_batch_id
-		) VALUES (?, ?, ?, ?, ?)
+			product_batch_id,
+			quantity
+		) VALUES (?, ?, ?, ?, ?, ?)
 	`
-
-	res, err := r.db.Exec(query,
+	res, err := tx.ExecContext(ctx, query,
 		order.OrderNumber,
 		order.OrderDate,
 		order.EmployeeID,
 		order.WarehouseID,
 		order.ProductBatchID,
+		order.Quantity,
 	)
 	if err != nil {
-		return models.InboundOrder{}, err
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return models.InboundOrder{}, httperrors.ConflictError{Message: "duplicate order number"}
+		}
+		return models.InboundOrder{}, internalError(ctx, "", err)
 	}
 	id, err := res.LastInsertId()
 	if err != nil {
-		return models.InboundOrder{}, err
+		return models.InboundOrder{}, internalError(ctx, "", err)
 	}
 	order.ID = int(id)
+
+	if err := tx.Commit(); err != nil {
+		return models.InboundOrder{}, internalError(ctx, "", err)
+	}
 	return order, nil
 }
 
-// GetByOrderNumber returns the InboundOrder with that order_number, or a zero-value if not found.
-func (r *InboundOrderRepositoryDB) GetByOrderNumber(orderNumber string) (models.InboundOrder, error) {
-	const query = `
-		SELECT
-			id,
-			order_number,
-			order_date,
-			employee_id,
-			warehouse_id,
-			product_batch_id
-		FROM inbound_orders
-		WHERE order_number = ?
-	`
-	var order models.InboundOrder
-	err := r.db.QueryRow(query, orderNumber).
-		Scan(&order.ID, &order.OrderNumber, &order.OrderDate, &order.EmployeeID, &order.WarehouseID, &order.ProductBatchID)
-	if errors.Is(err, sql.ErrNoRows) {
-		return models.InboundOrder{}, nil
+// checkInboundTopUp verifies an existing batch can receive more units of the order batch
+func checkInboundTopUp(existing lockedProductBatch, batch models.InboundOrderBatchAttributes) error {
+	if existing.productID != batch.ProductID {
+		return httperrors.ConflictError{Message: "product batch holds another product"}
 	}
-	if err != nil {
-		return models.InboundOrder{}, err
+	if existing.sectionID != batch.SectionID {
+		return httperrors.ConflictError{Message: "product batch is stored in another section"}
 	}
-	return order, nil
+	return nil
+}
+
+// inboundOrderMovementReason is the reason of the stock movement made by an order topping up a batch
+func inboundOrderMovementReason(order models.InboundOrder) string {
+	return "Inbound order " + order.OrderNumber
 }
 
 // CountInboundOrdersForEmployee returns the total number of inbound orders
 // associated with a specific employee by their employeeID.
 // Returns the count and any error encountered during the query.
-func (r *InboundOrderRepositoryDB) CountInboundOrdersForEmployee(employeeID int) (int, error) {
+func (r *InboundOrderRepositoryDB) CountInboundOrdersForEmployee(ctx context.Context, employeeID int) (int, error) {
 	const query = `SELECT COUNT(*) FROM inbound_orders WHERE employee_id = ?`
 	var count int
-	err := r.db.QueryRow(query, employeeID).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, employeeID).Scan(&count)
 	return count, err
 }
 
+// CountInboundOrdersForWarehouse counts the inbound orders of a warehouse dated from since
+// onwards and adds up the units they received.
+func (r *InboundOrderRepositoryDB) CountInboundOrdersForWarehouse(ctx context.Context, warehouseID int, since time.Time) (models.InboundOrdersSummary, error) {
+	const query = `SELECT COUNT(*), COALESCE(SUM(quantity), 0) FROM inbound_orders WHERE warehouse_id = ? AND order_date >= ?`
+	summary := models.InboundOrdersSummary{Since: since}
+	err := r.db.QueryRowContext(ctx, query, warehouseID, since).Scan(&summary.OrdersCount, &summary.Quantity)
+	if err != nil {
+		return models.InboundOrdersSummary{}, internalError(ctx, "error counting inbound orders", err)
+	}
+	return summary, nil
+}
+
 // CountInboundOrdersForEmployees retrieves the count of inbound orders for all employees.
 // Returns a map where the key is the employee ID and the value is the inbound order count for that employee.
 // Returns an error if the database query fails.
-func (r *InboundOrderRepositoryDB) CountInboundOrdersForEmployees() (map[int]int, error) {
+func (r *InboundOrderRepositoryDB) CountInboundOrdersForEmployees(ctx context.Context) (map[int]int, error) {
 	result := make(map[int]int)
 
 	query := "SELECT employee_id, COUNT(*) FROM inbound_orders GROUP BY employee_id"
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// InboundOrderRepositoryMemory is an in-memory implementation of InboundOrderRepository.
+type InboundOrderRepositoryMemory struct {
+	store *MemoryStore
+}
+
+func NewInboundOrderRepositoryMemory(store *MemoryStore) InboundOrderRepository {
+	return &InboundOrderRepositoryMemory{store: store}
+}
+
+// Create receives the inbound order: it creates its product batch or adds the
+// quantity to the existing one, and stores the order. Every check runs before
+// anything is written, so a rejected order changes nothing.
+// Returns a ConflictError if the employee or the section do not belong to the warehouse.
+func (r *InboundOrderRepositoryMemory) Create(ctx context.Context, attrs models.InboundOrderAttributes) (models.InboundOrder, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, existing := range r.store.inboundOrders {
+		if existing.OrderNumber == attrs.OrderNumber {
+			return models.InboundOrder{}, httperrors.ConflictError{Message: "duplicate order number"}
+		}
+	}
+	employee, ok := r.store.employees[attrs.EmployeeID]
+	if !ok {
+		return models.InboundOrder{}, httperrors.ConflictError{Message: "employee does not exist"}
+	}
+	if employee.WarehouseID != attrs.WarehouseID {
+		return models.InboundOrder{}, httperrors.ConflictError{Message: "employee does not belong to the warehouse"}
+	}
+	batch := attrs.ProductBatch
+	section, ok := r.store.sections[batch.SectionID]
+	if !ok {
+		return models.InboundOrder{}, httperrors.ConflictError{Message: "section does not exist"}
+	}
+	if section.WarehouseID != attrs.WarehouseID {
+		return models.InboundOrder{}, httperrors.ConflictError{Message: "section does not belong to the warehouse"}
+	}
+
+	order := models.InboundOrder{
+		OrderNumber: attrs.OrderNumber,
+		OrderDate:   attrs.OrderDate,
+		EmployeeID:  attrs.EmployeeID,
+		WarehouseID: attrs.WarehouseID,
+		Quantity:    batch.Quantity,
+	}
+
+	existing, found := r.batchByNumber(batch.BatchNumber)
+	if found {
+		locked := lockedProductBatch{productID: existing.ProductID, sectionID: existing.SectionID}
+		if err := checkInboundTopUp(locked, batch); err != nil {
+			return models.InboundOrder{}, err
+		}
+		movement := models.StockMovement{
+			ProductBatchID: existing.ID,
+			MovementType:   models.StockMovementInbound,
+			Quantity:       batch.Quantity,
+			Reason:         inboundOrderMovementReason(order),
+		}
+		if err := r.store.checkStockMovement(movement); err != nil {
+			return models.InboundOrder{}, err
+		}
+		r.store.applyStockMovement(movement)
+		order.ProductBatchID = existing.ID
+	} else {
+		created, err := r.store.createProductBatch(models.ProductBatchAttibutes{
+			BatchNumber:        batch.BatchNumber,
+			CurrentQuantity:    batch.Quantity,
+			CurrentTemperature: batch.CurrentTemperature,
+			DueDate:            batch.DueDate,
+			InitialQuantity:    batch.Quantity,
+			ManufacturingDate:  batch.ManufacturingDate,
+			ManufacturingHour:  batch.ManufacturingHour,
+			MinimumTemperature: batch.MinimumTemperature,
+			ProductID:          batch.ProductID,
+			SectionID:          batch.SectionID,
+		})
+		if err != nil {
+			return models.InboundOrder{}, err
+		}
+		order.ProductBatchID = created.ID
+	}
+
+	order.ID = r.store.nextID("inbound_orders")
+	r.store.inboundOrders[order.ID] = order
+	return order, nil
+}
+
+// batchByNumber returns the product batch with that batch_number.
+// The caller must hold the lock.
+func (r *InboundOrderRepositoryMemory) batchByNumber(batchNumber int) (models.ProductBatch, bool) {
+	for _, batch := range r.store.productBatches {
+		if batch.BatchNumber == batchNumber {
+			return batch, true
+		}
+	}
+	return models.ProductBatch{}, false
+}
+
+// CountInboundOrdersForEmployee returns the total number of inbound orders
+// associated with a specific employee by their employeeID.
+func (r *InboundOrderRepositoryMemory) CountInboundOrdersForEmployee(ctx context.Context, employeeID int) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, order := range r.store.inboundOrders {
+		if order.EmployeeID == employeeID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CountInboundOrdersForWarehouse counts the inbound orders of a warehouse dated from since
+// onwards and adds up the units they received.
+func (r *InboundOrderRepositoryMemory) CountInboundOrdersForWarehouse(ctx context.Context, warehouseID int, since time.Time) (models.InboundOrdersSummary, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	summary := models.InboundOrdersSummary{Since: since}
+	for _, order := range r.store.inboundOrders {
+		if order.WarehouseID == warehouseID && !order.OrderDate.Before(since) {
+			summary.OrdersCount++
+			summary.Quantity += order.Quantity
+		}
+	}
+	return summary, nil
+}
+
+// CountInboundOrdersForEmployees retrieves the count of inbound orders for all employees,
+// keyed by employee ID.
+func (r *InboundOrderRepositoryMemory) CountInboundOrdersForEmployees(ctx context.Context) (map[int]int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	result := make(map[int]int)
+	for _, order := range r.store.inboundOrders {
+		result[order.EmployeeID]++
+	}
+	return result, nil
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboundOrderRepository_Create(t *testing.T) {
+	orderDate := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	attrs := models.InboundOrderAttributes{
+		OrderNumber: "INB-1",
+		OrderDate:   orderDate,
+		EmployeeID:  4,
+		WarehouseID: 2,
+		ProductBatch: models.InboundOrderBatchAttributes{
+			BatchNumber:        7,
+			Quantity:           15,
+			CurrentTemperature: 4,
+			DueDate:            "2026-03-01",
+			ManufacturingDate:  "2025-12-01",
+			ManufacturingHour:  8,
+			MinimumTemperature: 2,
+			ProductID:          1,
+			SectionID:          3,
+		},
+	}
+
+	employeeQuery := regexp.QuoteMeta(`SELECT warehouse_id FROM employees WHERE id = ? FOR SHARE`)
+	sectionQuery := regexp.QuoteMeta(`SELECT warehouse_id FROM sections WHERE id = ? FOR SHARE`)
+	batchQuery := regexp.QuoteMeta(`SELECT id, product_id, section_id FROM product_batches WHERE batch_number = ? FOR UPDATE`)
+	productTypeQuery := regexp.QuoteMeta(`SELECT p.product_type_id, s.product_type_id`)
+	insertBatchQuery := regexp.QuoteMeta(`INSERT INTO product_batches`)
+	lockQuery := regexp.QuoteMeta(`SELECT current_quantity, product_id, section_id FROM product_batches WHERE id = ? FOR UPDATE`)
+	updateQuery := regexp.QuoteMeta(`UPDATE product_batches SET current_quantity = ? WHERE id = ?`)
+	lockSectionQuery := regexp.QuoteMeta(`SELECT current_capacity, maximum_capacity FROM sections WHERE id = ? FOR UPDATE`)
+	occupySectionQuery := regexp.QuoteMeta(`UPDATE sections SET current_capacity = ? WHERE id = ?`)
+	movementQuery := regexp.QuoteMeta(`INSERT INTO stock_movements`)
+	insertOrderQuery := regexp.QuoteMeta(`INSERT INTO inbound_orders`)
+	warehouseColumns := []string{"warehouse_id"}
+	sectionColumns := []string{"current_capacity", "maximum_capacity"}
+
+	// expectWarehouses expects the employee and the section to be in warehouse 2
+	expectWarehouses := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(employeeQuery).WithArgs(4).
+			WillReturnRows(sqlmock.NewRows(warehouseColumns).AddRow(2))
+		mock.ExpectQuery(sectionQuery).WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(warehouseColumns).AddRow(2))
+	}
+
+	tests := []struct {
+		testName       string
+		mockSetup      func(mock sqlmock.Sqlmock)
+		expectedResult models.InboundOrder
+		expectedError  error
+	}{
+		{
+			testName: "Success: a new batch number creates the batch",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectWarehouses(mock)
+				mock.ExpectQuery(batchQuery).WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "section_id"}))
+				mock.ExpectQuery(productTypeQuery).WithArgs(3, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"product_type_id", "product_type_id"}).AddRow(1, 1))
+				mock.ExpectExec(insertBatchQuery).
+					WithArgs(7, 15, 4.0, "2026-03-01", 15, "2025-12-01", 8, 2.0, 1, 3).
+					WillReturnResult(sqlmock.NewResult(9, 1))
+				mock.ExpectQuery(lockSectionQuery).WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(sectionColumns).AddRow(10, 100))
+				mock.ExpectExec(occupySectionQuery).WithArgs(25, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(movementQuery).
+					WithArgs(9, models.StockMovementInbound, 15, 15, nil, "initial stock", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(insertOrderQuery).WithArgs("INB-1", orderDate, 4, 2, 9, 15).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectCommit()
+			},
+			expectedResult: models.InboundOrder{ID: 5, OrderNumber: "INB-1", OrderDate: orderDate, EmployeeID: 4, WarehouseID: 2, ProductBatchID: 9, Quantity: 15},
+		},
+		{
+			testName: "Success: an existing batch number is topped up",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectWarehouses(mock)
+				mock.ExpectQuery(batchQuery).WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "section_id"}).AddRow(6, 1, 3))
+				mock.ExpectQuery(lockQuery).WithArgs(6).
+					WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "product_id", "section_id"}).AddRow(20, 1, 3))
+				mock.ExpectExec(updateQuery).WithArgs(35, 6).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(lockSectionQuery).WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(sectionColumns).AddRow(20, 100))
+				mock.ExpectExec(occupySectionQuery).WithArgs(35, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(movementQuery).
+					WithArgs(6, models.StockMovementInbound, 15, 35, nil, "Inbound order INB-1", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec(insertOrderQuery).WithArgs("INB-1", orderDate, 4, 2, 6, 15).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectCommit()
+			},
+			expectedResult: models.InboundOrder{ID: 5, OrderNumber: "INB-1", OrderDate: orderDate, EmployeeID: 4, WarehouseID: 2, ProductBatchID: 6, Quantity: 15},
+		},
+		{
+			testName: "Fail: employee of another warehouse",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(employeeQuery).WithArgs(4).
+					WillReturnRows(sqlmock.NewRows(warehouseColumns).AddRow(1))
+				mock.ExpectRollback()
+			},
+			expectedError: httperrors.ConflictError{Message: "employee does not belong to the warehouse"},
+		},
+		{
+			testName: "Fail: section of another warehouse",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(employeeQuery).WithArgs(4).
+					WillReturnRows(sqlmock.NewRows(warehouseColumns).AddRow(2))
+				mock.ExpectQuery(sectionQuery).WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(warehouseColumns).AddRow(1))
+				mock.ExpectRollback()
+			},
+			expectedError: httperrors.ConflictError{Message: "section does not belong to the warehouse"},
+		},
+		{
+			testName: "Fail: existing batch holds another product",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectWarehouses(mock)
+				mock.ExpectQuery(batchQuery).WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "section_id"}).AddRow(6, 2, 3))
+				mock.ExpectRollback()
+			},
+			expectedError: httperrors.ConflictError{Message: "product batch holds another product"},
+		},
+		{
+			testName: "Fail: duplicate order number rolls the batch back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectWarehouses(mock)
+				mock.ExpectQuery(batchQuery).WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "section_id"}).AddRow(6, 1, 3))
+				mock.ExpectQuery(lockQuery).WithArgs(6).
+					WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "product_id", "section_id"}).AddRow(20, 1, 3))
+				mock.ExpectExec(updateQuery).WithArgs(35, 6).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(lockSectionQuery).WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(sectionColumns).AddRow(20, 100))
+				mock.ExpectExec(occupySectionQuery).WithArgs(35, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(movementQuery).WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec(insertOrderQuery).WillReturnError(&mysql.MySQLError{Number: 1062})
+				mock.ExpectRollback()
+			},
+			expectedError: httperrors.ConflictError{Message: "duplicate order number"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			repo := NewInboundOrderRepository(db)
+			result, err := repo.Create(context.Background(), attrs)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
-	"log"
+	"log/slog"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/logging"
 	"github.com/go-sql-driver/mysql"
 )
 
@@ -18,41 +20,74 @@ func NewLocalityRepository(db *sql.DB) LocalityRepository {
 	return &LocalityRepositoryDB{db: db}
 }
 
+// localityColumns and localityFrom select a locality together with the names
+// of its province and country.
+const (
+	localityColumns = "l.id, l.locality_name, l.province_id, p.province_name, p.country_id, c.country_name"
+	localityFrom    = "localities l JOIN provinces p ON p.id = l.province_id JOIN countries c ON c.id = p.country_id"
+)
+
+// localityListColumns are the fields localities can be sorted and filtered by.
+var localityListColumns = listColumns{
+	"id":            "l.id",
+	"locality_name": "l.locality_name",
+	"province_id":   "l.province_id",
+	"country_id":    "p.country_id",
+}
+
+// scanLocality reads a row selected with localityColumns.
+func scanLocality(row interface{ Scan(dest ...any) error }) (models.Locality, error) {
+	var locality models.Locality
+	err := row.Scan(&locality.ID, &locality.LocalityName, &locality.ProvinceID, &locality.ProvinceName, &locality.CountryID, &locality.CountryName)
+	return locality, err
+}
+
+// localityWriteError maps the MySQL errors of an insert or update of a locality.
+func localityWriteError(ctx context.Context, err error) error {
+	var sqlErr *mysql.MySQLError
+	if errors.As(err, &sqlErr) {
+		switch sqlErr.Number {
+		case 1062:
+			return httperrors.ConflictError{Message: "The locality ID already exists"}
+		case 1452:
+			return httperrors.ConflictError{Message: "The province ID does not exist"}
+		}
+	}
+	return internalError(ctx, "Error saving locality", err)
+}
+
 // This function creates a new locality in the database.
-// It returns the created locality or an error if the insertion fails.
-func (r *LocalityRepositoryDB) Create(locality models.Locality) (models.Locality, error) {
+// It returns the created locality with the names of its province and country,
+// or an error if the insertion fails.
+func (r *LocalityRepositoryDB) Create(ctx context.Context, locality models.Locality) (models.Locality, error) {
 	const queryInsertLocality = `
-        INSERT INTO localities (id, locality_name, province_name, country_name)
-        VALUES (?, ?, ?, ?)
+        INSERT INTO localities (id, locality_name, province_id)
+        VALUES (?, ?, ?)
     `
-	_, err := r.db.Exec(queryInsertLocality, locality.ID, locality.LocalityName, locality.ProvinceName, locality.CountryName)
-
+	_, err := r.db.ExecContext(ctx, queryInsertLocality, locality.ID, locality.LocalityName, locality.ProvinceID)
 	if err != nil {
-		var sqlErr *mysql.MySQLError
-		if errors.As(err, &sqlErr) {
-			switch sqlErr.Number {
-			case 1062:
-				return models.Locality{}, httperrors.ConflictError{Message: "The locality ID already exists"}
-			case 1452:
-				return models.Locality{}, httperrors.NotFoundError{Message: "The locality ID does not exist"}
-			}
-			return models.Locality{}, err
-		}
+		return models.Locality{}, localityWriteError(ctx, err)
 	}
-	return locality, nil
+	return r.GetByID(ctx, locality.ID)
+}
+
+// GetAll returns the page of localities described by opts.
+func (r *LocalityRepositoryDB) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Locality], error) {
+	query := listQuery{
+		selectColumns: localityColumns,
+		from:          localityFrom,
+		columns:       localityListColumns,
+	}
+
+	return queryPage(ctx, r.db, query, opts, localityListFields, func(rows *sql.Rows) (models.Locality, error) {
+		return scanLocality(rows)
+	})
 }
 
 // This function retrieves a locality by its ID.
 // It returns an error if the locality is not found or if there is a database error.
-func (r *LocalityRepositoryDB) GetByID(id string) (models.Locality, error) {
-	var locality models.Locality
-	const queryGetByID = `
-		SELECT id, locality_name, province_name, country_name 
-		FROM localities 
-		WHERE id = ?
-	`
-	err := r.db.QueryRow(queryGetByID, id).Scan(&locality.ID, &locality.LocalityName, &locality.ProvinceName, &locality.CountryName)
-
+func (r *LocalityRepositoryDB) GetByID(ctx context.Context, id string) (models.Locality, error) {
+	locality, err := scanLocality(r.db.QueryRowContext(ctx, "SELECT "+localityColumns+" FROM "+localityFrom+" WHERE l.id = ?", id))
 	if err == sql.ErrNoRows {
 		return models.Locality{}, httperrors.NotFoundError{Message: "Locality not found"}
 	}
@@ -62,15 +97,48 @@ func (r *LocalityRepositoryDB) GetByID(id string) (models.Locality, error) {
 	return locality, nil
 }
 
+// Update stores the new name and province of the locality and returns it
+// with the names of its new province and country.
+func (r *LocalityRepositoryDB) Update(ctx context.Context, id string, locality models.Locality) (models.Locality, error) {
+	const queryUpdateLocality = `UPDATE localities SET locality_name = ?, province_id = ? WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, queryUpdateLocality, locality.LocalityName, locality.ProvinceID, id)
+	if err != nil {
+		return models.Locality{}, localityWriteError(ctx, err)
+	}
+	return r.GetByID(ctx, id)
+}
+
+// Delete removes the locality with the given ID.
+// Returns a ConflictError if sellers, carries or buyers still reference it.
+func (r *LocalityRepositoryDB) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM localities WHERE id = ?`, id)
+	if err != nil {
+		var sqlErr *mysql.MySQLError
+		if errors.As(err, &sqlErr) && sqlErr.Number == 1451 {
+			return httperrors.ConflictError{Message: "The locality is still referenced by sellers, carries or buyers"}
+		}
+		return internalError(ctx, "Error deleting locality", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return internalError(ctx, "Error deleting locality", err)
+	} else if count == 0 {
+		return httperrors.NotFoundError{Message: "Locality not found"}
+	}
+	return nil
+}
+
 // This function retrieves a report of sellers by locality.
 // If an ID is provided, it returns the report for that specific locality.
 // If no ID is provided, it returns the report for all localities.
-func (r *LocalityRepositoryDB) GetSellerReport(localityID *string) ([]models.SellerReport, error) {
+func (r *LocalityRepositoryDB) GetSellerReport(ctx context.Context, localityID *string) ([]models.SellerReport, error) {
 	var rows *sql.Rows
 	var err error
 
 	if localityID != nil && *localityID != "" {
-		rows, err = r.db.Query(`
+		rows, err = r.db.QueryContext(ctx, `
             SELECT l.id, l.locality_name, COUNT(s.id)
             FROM localities l
             LEFT JOIN sellers s ON l.id = s.locality_id
@@ -78,7 +146,7 @@ func (r *LocalityRepositoryDB) GetSellerReport(localityID *string) ([]models.Sel
             GROUP BY l.id, l.locality_name
         `, *localityID)
 	} else {
-		rows, err = r.db.Query(`
+		rows, err = r.db.QueryContext(ctx, `
             SELECT l.id, l.locality_name, COUNT(s.id)
             FROM localities l
             LEFT JOIN sellers s ON l.id = s.locality_id
@@ -86,12 +154,12 @@ func (r *LocalityRepositoryDB) GetSellerReport(localityID *string) ([]models.Sel
         `)
 	}
 	if err != nil {
-		return nil, httperrors.InternalServerError{Message: "Error obtaining Report by LocalityId"}
+		return nil, internalError(ctx, "Error obtaining Report by LocalityId", err)
 	}
 
 	defer func() {
 		if err := rows.Close(); err != nil {
-			log.Printf("error closing rows: %v", err)
+			logging.FromContext(ctx).Warn("error closing rows", slog.Any("error", err))
 		}
 	}()
 
@@ -103,7 +171,7 @@ func (r *LocalityRepositoryDB) GetSellerReport(localityID *string) ([]models.Sel
 			&report.LocalityName,
 			&report.SellersCount,
 		); err != nil {
-			return nil, httperrors.InternalServerError{Message: "Error reading Seller data"}
+			return nil, internalError(ctx, "Error reading Seller data", err)
 		}
 		reports = append(reports, report)
 	}
@@ -114,7 +182,7 @@ func (r *LocalityRepositoryDB) GetSellerReport(localityID *string) ([]models.Sel
 }
 
 // GetReportByLocalityId retrieves a report of carries by locality ID.
-func (r *LocalityRepositoryDB) GetReportByLocalityId(localityId string) ([]models.CarryReport, error) {
+func (r *LocalityRepositoryDB) GetReportByLocalityId(ctx context.Context, localityId string) ([]models.CarryReport, error) {
 	var (
 		query string
 		rows  *sql.Rows
@@ -130,7 +198,7 @@ func (r *LocalityRepositoryDB) GetReportByLocalityId(localityId string) ([]model
             RIGHT JOIN localities l ON c.locality_id = l.id
             GROUP BY 1, 2;
 		`
-		rows, err = r.db.Query(query)
+		rows, err = r.db.QueryContext(ctx, query)
 	} else {
 		query = `
 			SELECT
@@ -142,14 +210,14 @@ func (r *LocalityRepositoryDB) GetReportByLocalityId(localityId string) ([]model
 			WHERE l.id = ?
 			GROUP BY 1, 2;
 		`
-		rows, err = r.db.Query(query, localityId)
+		rows, err = r.db.QueryContext(ctx, query, localityId)
 	}
 	if err != nil {
-		return nil, httperrors.InternalServerError{Message: "error obtaining Report by LocalityId"}
+		return nil, internalError(ctx, "error obtaining Report by LocalityId", err)
 	}
 	defer func() {
 		if err := rows.Close(); err != nil {
-			log.Printf("error closing rows: %v", err)
+			logging.FromContext(ctx).Warn("error closing rows", slog.Any("error", err))
 		}
 	}()
 
@@ -161,7 +229,7 @@ func (r *LocalityRepositoryDB) GetReportByLocalityId(localityId string) ([]model
 			&reportCarry.LocalityName,
 			&reportCarry.CarriesCount,
 		); err != nil {
-			return nil, httperrors.InternalServerError{Message: "error reading Report by LocalityId data"}
+			return nil, internalError(ctx, "error reading Report by LocalityId data", err)
 		}
 		reportCarries = append(reportCarries, reportCarry)
 	}
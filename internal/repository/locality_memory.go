@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// LocalityRepositoryMemory is an in-memory implementation of LocalityRepository.
+type LocalityRepositoryMemory struct {
+	store *MemoryStore
+}
+
+func NewLocalityRepositoryMemory(store *MemoryStore) LocalityRepository {
+	return &LocalityRepositoryMemory{store: store}
+}
+
+// This function creates a new locality in the store.
+// It returns a ConflictError if the locality ID is already taken or the province does not exist.
+func (r *LocalityRepositoryMemory) Create(ctx context.Context, locality models.Locality) (models.Locality, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.localities[locality.ID]; ok {
+		return models.Locality{}, httperrors.ConflictError{Message: "The locality ID already exists"}
+	}
+	if _, ok := r.store.provinces[locality.ProvinceID]; !ok {
+		return models.Locality{}, httperrors.ConflictError{Message: "The province ID does not exist"}
+	}
+	r.store.localities[locality.ID] = models.Locality{ID: locality.ID, LocalityName: locality.LocalityName, ProvinceID: locality.ProvinceID}
+	return r.withHierarchy(r.store.localities[locality.ID]), nil
+}
+
+// localityListFields are the fields localities can be sorted and filtered by.
+var localityListFields = listFields[models.Locality]{
+	"id":            func(l models.Locality) any { return l.ID },
+	"locality_name": func(l models.Locality) any { return l.LocalityName },
+	"province_id":   func(l models.Locality) any { return l.ProvinceID },
+	"country_id":    func(l models.Locality) any { return l.CountryID },
+}
+
+// GetAll returns the page of localities described by opts.
+func (r *LocalityRepositoryMemory) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Locality], error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	localities := r.sortedLocalities()
+	for i, locality := range localities {
+		localities[i] = r.withHierarchy(locality)
+	}
+	return pageOf(localities, opts, localityListFields)
+}
+
+// This function retrieves a locality by its ID.
+func (r *LocalityRepositoryMemory) GetByID(ctx context.Context, id string) (models.Locality, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	locality, ok := r.store.localities[id]
+	if !ok {
+		return models.Locality{}, httperrors.NotFoundError{Message: "Locality not found"}
+	}
+	return r.withHierarchy(locality), nil
+}
+
+// Update stores the new name and province of the locality.
+// It returns a ConflictError if the province does not exist.
+func (r *LocalityRepositoryMemory) Update(ctx context.Context, id string, locality models.Locality) (models.Locality, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.provinces[locality.ProvinceID]; !ok {
+		return models.Locality{}, httperrors.ConflictError{Message: "The province ID does not exist"}
+	}
+	if _, ok := r.store.localities[id]; !ok {
+		return models.Locality{}, httperrors.NotFoundError{Message: "Locality not found"}
+	}
+	r.store.localities[id] = models.Locality{ID: id, LocalityName: locality.LocalityName, ProvinceID: locality.ProvinceID}
+	return r.withHierarchy(r.store.localities[id]), nil
+}
+
+// Delete removes the locality with the given ID.
+// It returns a ConflictError if sellers, carries or buyers still reference it.
+func (r *LocalityRepositoryMemory) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.localities[id]; !ok {
+		return httperrors.NotFoundError{Message: "Locality not found"}
+	}
+	inUse := httperrors.ConflictError{Message: "The locality is still referenced by sellers, carries or buyers"}
+	for _, seller := range r.store.sellers {
+		if seller.LocalityID == id {
+			return inUse
+		}
+	}
+	for _, carry := range r.store.carries {
+		if carry.LocalityId == id {
+			return inUse
+		}
+	}
+	for _, buyer := range r.store.buyers {
+		if buyer.DeliveryLocalityId != nil && *buyer.DeliveryLocalityId == id {
+			return inUse
+		}
+	}
+
+	delete(r.store.localities, id)
+	return nil
+}
+
+// This function retrieves a report of sellers by locality.
+// If an ID is provided, it returns the report for that specific locality.
+func (r *LocalityRepositoryMemory) GetSellerReport(ctx context.Context, localityID *string) ([]models.SellerReport, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, seller := range r.store.sellers {
+		counts[seller.LocalityID]++
+	}
+
+	var reports []models.SellerReport
+	for _, locality := range r.sortedLocalities() {
+		if localityID != nil && *localityID != "" && locality.ID != *localityID {
+			continue
+		}
+		reports = append(reports, models.SellerReport{
+			LocalityID:   locality.ID,
+			LocalityName: locality.LocalityName,
+			SellersCount: counts[locality.ID],
+		})
+	}
+	if localityID != nil && len(reports) == 0 {
+		return nil, httperrors.NotFoundError{Message: "Locality not found"}
+	}
+	return reports, nil
+}
+
+// GetReportByLocalityId retrieves a report of carries by locality ID.
+func (r *LocalityRepositoryMemory) GetReportByLocalityId(ctx context.Context, localityId string) ([]models.CarryReport, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, carry := range r.store.carries {
+		counts[carry.LocalityId]++
+	}
+
+	var reportCarries []models.CarryReport
+	for _, locality := range r.sortedLocalities() {
+		if localityId != "" && locality.ID != localityId {
+			continue
+		}
+		reportCarries = append(reportCarries, models.CarryReport{
+			LocalityId:   locality.ID,
+			LocalityName: locality.LocalityName,
+			CarriesCount: counts[locality.ID],
+		})
+	}
+	return reportCarries, nil
+}
+
+// sortedLocalities returns the localities ordered by ID.
+// The caller must hold the lock.
+func (r *LocalityRepositoryMemory) sortedLocalities() []models.Locality {
+	localities := make([]models.Locality, 0, len(r.store.localities))
+	for _, locality := range r.store.localities {
+		localities = append(localities, locality)
+	}
+	sort.Slice(localities, func(i, j int) bool {
+		return localities[i].ID < localities[j].ID
+	})
+	return localities
+}
+
+// withHierarchy fills the province and country fields of a stored locality,
+// the way the SQL implementation joins them.
+// The caller must hold the lock.
+func (r *LocalityRepositoryMemory) withHierarchy(locality models.Locality) models.Locality {
+	province := r.store.provinces[locality.ProvinceID]
+	locality.ProvinceName = province.ProvinceName
+	locality.CountryID = province.CountryID
+	locality.CountryName = r.store.countries[province.CountryID].CountryName
+	return locality
+}
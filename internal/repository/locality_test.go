@@ -0,0 +1,62 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalityRepositoryDB_Create(t *testing.T) {
+	columns := []string{"id", "locality_name", "province_id", "province_name", "country_id", "country_name"}
+
+	t.Run("returns the locality with its province and country", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("INSERT INTO localities \\(id, locality_name, province_id\\)").
+			WithArgs("6700", "Lujan", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("FROM localities l JOIN provinces p ON p.id = l.province_id JOIN countries c ON c.id = p.country_id WHERE l.id = \\?").
+			WithArgs("6700").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("6700", "Lujan", 1, "Buenos Aires", 1, "Argentina"))
+
+		repo := repository.NewLocalityRepository(db)
+
+		// act
+		got, err := repo.Create(context.Background(), models.Locality{ID: "6700", LocalityName: "Lujan", ProvinceID: 1})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, models.Locality{ID: "6700", LocalityName: "Lujan", ProvinceID: 1, ProvinceName: "Buenos Aires", CountryID: 1, CountryName: "Argentina"}, got)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing province returns ConflictError", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("INSERT INTO localities").
+			WithArgs("6700", "Lujan", 9).
+			WillReturnError(&mysql.MySQLError{Number: 1452})
+
+		repo := repository.NewLocalityRepository(db)
+
+		// act
+		_, err = repo.Create(context.Background(), models.Locality{ID: "6700", LocalityName: "Lujan", ProvinceID: 9})
+
+		// assert
+		assert.Equal(t, httperrors.ConflictError{Message: "The province ID does not exist"}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
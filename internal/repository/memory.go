@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+)
+
+// MemoryStore holds every table of the in-memory backend.
+// A single mutex guards all the maps so that cross-table checks
+// (unique keys, foreign keys, ON DELETE rules) always observe a
+// consistent snapshot, the same way a MySQL statement would.
+type MemoryStore struct {
+	mu sync.RWMutex
+
+	sellers                  map[int]models.Seller
+	warehouses               map[int]models.Warehouse
+	sections                 map[int]models.Section
+	productTypes             map[int]models.ProductType
+	products                 map[int]models.Product
+	productRecords           map[int]models.ProductRecord
+	employees                map[int]models.Employee
+	buyers                   map[int]models.Buyer
+	countries                map[int]models.Country
+	provinces                map[int]models.Province
+	localities               map[string]models.Locality
+	carries                  map[int]models.Carry
+	productBatches           map[int]models.ProductBatch
+	inboundOrders            map[int]models.InboundOrder
+	purchaseOrders           map[int]models.PurchaseOrder
+	purchaseOrderLines       map[int]models.PurchaseOrderLine
+	purchaseOrderAllocations map[int]models.PurchaseOrderAllocation
+	stockMovements           map[int]models.StockMovement
+	trackingEvents           map[int]models.TrackingEvent
+	temperatureReadings      map[int]models.SectionTemperatureReading
+	alerts                   map[int]models.Alert
+
+	// lastIDs keeps the AUTO_INCREMENT counter of each table.
+	// Like MySQL, ids are never reused after a delete.
+	lastIDs map[string]int
+}
+
+// NewMemoryStore returns an empty MemoryStore ready to be shared
+// by all the in-memory repositories.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sellers:                  make(map[int]models.Seller),
+		warehouses:               make(map[int]models.Warehouse),
+		sections:                 make(map[int]models.Section),
+		productTypes:             make(map[int]models.ProductType),
+		products:                 make(map[int]models.Product),
+		productRecords:           make(map[int]models.ProductRecord),
+		employees:                make(map[int]models.Employee),
+		buyers:                   make(map[int]models.Buyer),
+		countries:                make(map[int]models.Country),
+		provinces:                make(map[int]models.Province),
+		localities:               make(map[string]models.Locality),
+		carries:                  make(map[int]models.Carry),
+		productBatches:           make(map[int]models.ProductBatch),
+		inboundOrders:            make(map[int]models.InboundOrder),
+		purchaseOrders:           make(map[int]models.PurchaseOrder),
+		purchaseOrderLines:       make(map[int]models.PurchaseOrderLine),
+		purchaseOrderAllocations: make(map[int]models.PurchaseOrderAllocation),
+		stockMovements:           make(map[int]models.StockMovement),
+		trackingEvents:           make(map[int]models.TrackingEvent),
+		temperatureReadings:      make(map[int]models.SectionTemperatureReading),
+		alerts:                   make(map[int]models.Alert),
+		lastIDs:                  make(map[string]int),
+	}
+}
+
+// nextID returns the next AUTO_INCREMENT value for the given table.
+// The caller must hold the write lock.
+func (s *MemoryStore) nextID(table string) int {
+	s.lastIDs[table]++
+	return s.lastIDs[table]
+}
+
+// sortedByID returns the values of the map ordered by their key,
+// so listings are deterministic like a query over the primary key.
+func sortedByID[T any](data map[int]T) []T {
+	ids := make([]int, 0, len(data))
+	for id := range data {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var values []T
+	for _, id := range ids {
+		values = append(values, data[id])
+	}
+	return values
+}
+
+// copyIntPtr returns a new pointer holding the same value, so stored
+// rows never share memory with the caller.
+func copyIntPtr(p *int) *int {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
@@ -0,0 +1,1154 @@
+package repository_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProduct(code string, sellerID *int) models.ProductAttributes {
+	return models.ProductAttributes{
+		Description:                    "Frozen peas",
+		ExpirationRate:                 1,
+		FreezingRate:                   1,
+		Height:                         1,
+		Length:                         1,
+		Width:                          1,
+		NetWeight:                      1,
+		ProductCode:                    code,
+		RecommendedFreezingTemperature: -18,
+		ProductTypeID:                  1,
+		SellerID:                       sellerID,
+	}
+}
+
+// newTestStore returns a store holding the product type 1 used by newTestProduct
+// and the province 1 the test localities belong to.
+func newTestStore(t *testing.T) *repository.MemoryStore {
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	_, err := repository.NewProductTypeRepositoryMemory(store).Create(ctx, models.ProductTypeAttributes{Description: "Frozen"})
+	require.NoError(t, err)
+	country, err := repository.NewCountryRepositoryMemory(store).Create(ctx, models.CountryAttributes{CountryName: "Argentina"})
+	require.NoError(t, err)
+	_, err = repository.NewProvinceRepositoryMemory(store).Create(ctx, models.ProvinceAttributes{ProvinceName: "Buenos Aires", CountryID: country.ID})
+	require.NoError(t, err)
+	return store
+}
+
+func TestMemory_ProductConstraints(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	localities := repository.NewLocalityRepositoryMemory(store)
+	sellers := repository.NewSellerRepositoryMemory(store)
+	products := repository.NewProductRepositoryMemory(store)
+
+	_, err := localities.Create(ctx, models.Locality{ID: "6700", LocalityName: "Lujan", ProvinceID: 1})
+	require.NoError(t, err)
+	seller, err := sellers.Create(ctx, models.SellerAttributes{CID: 1, CompanyName: "Alkemy", Address: "Monroe 860", Telephone: "47470000", LocalityID: "6700"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		testName      string
+		product       models.ProductAttributes
+		expectedError error
+	}{
+		{
+			testName:      "Success: product with seller",
+			product:       newTestProduct("P1", utils.Ptr(seller.ID)),
+			expectedError: nil,
+		},
+		{
+			testName:      "Success: product without seller",
+			product:       newTestProduct("P2", nil),
+			expectedError: nil,
+		},
+		{
+			testName:      "Fail: duplicated product code",
+			product:       newTestProduct("P1", nil),
+			expectedError: httperrors.ConflictError{Message: "A product with the given product code already exists"},
+		},
+		{
+			testName:      "Fail: seller does not exist",
+			product:       newTestProduct("P3", utils.Ptr(99)),
+			expectedError: httperrors.ConflictError{Message: "The given seller id does not exists"},
+		},
+		{
+			testName: "Fail: product type does not exist",
+			product: func() models.ProductAttributes {
+				product := newTestProduct("P4", nil)
+				product.ProductTypeID = 99
+				return product
+			}(),
+			expectedError: httperrors.ConflictError{Message: "The given product type id does not exist"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			_, err := products.Create(ctx, tt.product)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+func TestMemory_ProductPagination(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	localities := repository.NewLocalityRepositoryMemory(store)
+	sellers := repository.NewSellerRepositoryMemory(store)
+	products := repository.NewProductRepositoryMemory(store)
+
+	_, err := localities.Create(ctx, models.Locality{ID: "6700", LocalityName: "Lujan", ProvinceID: 1})
+	require.NoError(t, err)
+	seller, err := sellers.Create(ctx, models.SellerAttributes{CID: 1, CompanyName: "Alkemy", Address: "Monroe 860", Telephone: "47470000", LocalityID: "6700"})
+	require.NoError(t, err)
+	for _, product := range []models.ProductAttributes{
+		newTestProduct("B", utils.Ptr(seller.ID)),
+		newTestProduct("D", nil),
+		newTestProduct("A", utils.Ptr(seller.ID)),
+		newTestProduct("C", nil),
+	} {
+		_, err := products.Create(ctx, product)
+		require.NoError(t, err)
+	}
+
+	codes := func(page models.Page[models.Product]) []string {
+		var codes []string
+		for _, product := range page.Data {
+			codes = append(codes, product.ProductCode)
+		}
+		return codes
+	}
+
+	// Walk every page following the cursors
+	opts := models.QueryOptions{Limit: 3, Sort: "product_code", Desc: true}
+	first, err := products.GetAll(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"D", "C", "B"}, codes(first))
+	assert.Equal(t, 4, first.Meta.Total)
+	require.NotNil(t, first.Meta.NextCursor)
+
+	// A product created before the cursor does not shift the next page
+	_, err = products.Create(ctx, newTestProduct("BB", nil))
+	require.NoError(t, err)
+	after, err := models.DecodeCursor(*first.Meta.NextCursor)
+	require.NoError(t, err)
+	opts.After = &after
+	last, err := products.GetAll(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A"}, codes(last))
+	assert.Equal(t, 5, last.Meta.Total)
+	assert.Nil(t, last.Meta.NextCursor)
+
+	// NULL sellers sort first and ties are broken by id
+	bySeller, err := products.GetAll(ctx, models.QueryOptions{Sort: "seller_id"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"D", "C", "BB", "B", "A"}, codes(bySeller))
+
+	// Walking one row at a time crosses from the NULLs to the values and back
+	walk := func(opts models.QueryOptions) []string {
+		var walked []string
+		for {
+			page, err := products.GetAll(ctx, opts)
+			require.NoError(t, err)
+			walked = append(walked, codes(page)...)
+			if page.Meta.NextCursor == nil {
+				return walked
+			}
+			next, err := models.DecodeCursor(*page.Meta.NextCursor)
+			require.NoError(t, err)
+			opts.After = &next
+		}
+	}
+	assert.Equal(t, []string{"D", "C", "BB", "B", "A"}, walk(models.QueryOptions{Limit: 1, Sort: "seller_id"}))
+	assert.Equal(t, []string{"A", "B", "BB", "C", "D"}, walk(models.QueryOptions{Limit: 1, Sort: "seller_id", Desc: true}))
+	assert.Equal(t, []string{"B", "D", "A", "C", "BB"}, walk(models.QueryOptions{Limit: 2}))
+
+	_, err = products.GetAll(ctx, models.QueryOptions{Sort: "product_code", After: &models.Cursor{Sort: "id", ID: 1}})
+	assert.Equal(t, httperrors.BadRequestError{Message: "Invalid cursor"}, err)
+
+	filtered, err := products.GetAll(ctx, models.QueryOptions{Filters: map[string]string{"seller_id": "1"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"B", "A"}, codes(filtered))
+	assert.Equal(t, 2, filtered.Meta.Total)
+
+	_, err = products.GetAll(ctx, models.QueryOptions{Sort: "height"})
+	assert.Equal(t, httperrors.BadRequestError{Message: "Invalid sort field"}, err)
+	_, err = products.GetAll(ctx, models.QueryOptions{Filters: map[string]string{"height": "1"}})
+	assert.Equal(t, httperrors.BadRequestError{Message: "Invalid filter field"}, err)
+
+	// Invalid fields are rejected even when nothing would be listed, as in SQL
+	empty := repository.NewProductRepositoryMemory(newTestStore(t))
+	_, err = empty.GetAll(ctx, models.QueryOptions{Sort: "height"})
+	assert.Equal(t, httperrors.BadRequestError{Message: "Invalid sort field"}, err)
+	_, err = empty.GetAll(ctx, models.QueryOptions{Filters: map[string]string{"seller_id": "1", "height": "1"}})
+	assert.Equal(t, httperrors.BadRequestError{Message: "Invalid filter field"}, err)
+}
+
+func TestMemory_BuyerLocalityFilter(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	localities := repository.NewLocalityRepositoryMemory(store)
+	buyers := repository.NewBuyerRepositoryMemory(store)
+
+	_, err := localities.Create(ctx, models.Locality{ID: "6700", LocalityName: "Lujan", ProvinceID: 1})
+	require.NoError(t, err)
+	_, err = buyers.Create(ctx, models.BuyerAttributes{CardNumberId: 12345678, FirstName: "Juan", LastName: "Perez"})
+	require.NoError(t, err)
+	delivered, err := buyers.Create(ctx, models.BuyerAttributes{CardNumberId: 22345678, FirstName: "Ana", LastName: "Gomez", DeliveryLocalityId: utils.Ptr("6700")})
+	require.NoError(t, err)
+
+	// A buyer without delivery locality matches no value
+	page, err := buyers.GetAll(ctx, models.QueryOptions{Filters: map[string]string{"delivery_locality_id": "6700"}})
+	require.NoError(t, err)
+	assert.Equal(t, []models.Buyer{delivered}, page.Data)
+	assert.Equal(t, 1, page.Meta.Total)
+
+	page, err = buyers.GetAll(ctx, models.QueryOptions{Filters: map[string]string{"delivery_locality_id": "1900"}})
+	require.NoError(t, err)
+	assert.Empty(t, page.Data)
+}
+
+func TestMemory_SellerDeleteSetsProductSellerToNull(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	localities := repository.NewLocalityRepositoryMemory(store)
+	sellers := repository.NewSellerRepositoryMemory(store)
+	products := repository.NewProductRepositoryMemory(store)
+
+	_, err := localities.Create(ctx, models.Locality{ID: "6700", LocalityName: "Lujan", ProvinceID: 1})
+	require.NoError(t, err)
+	seller, err := sellers.Create(ctx, models.SellerAttributes{CID: 1, CompanyName: "Alkemy", Address: "Monroe 860", Telephone: "47470000", LocalityID: "6700"})
+	require.NoError(t, err)
+	product, err := products.Create(ctx, newTestProduct("P1", utils.Ptr(seller.ID)))
+	require.NoError(t, err)
+
+	err = sellers.Delete(ctx, seller.ID)
+	require.NoError(t, err)
+
+	stored, err := products.GetByID(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.SellerID)
+
+	_, err = sellers.GetByID(ctx, seller.ID)
+	assert.Equal(t, httperrors.NotFoundError{Message: "Seller not found"}, err)
+}
+
+func TestMemory_DeleteRestrictedByForeignKey(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	products := repository.NewProductRepositoryMemory(store)
+	records := repository.NewProductRecordRepositoryMemory(store)
+
+	product, err := products.Create(ctx, newTestProduct("P1", nil))
+	require.NoError(t, err)
+	_, err = records.Create(ctx, models.ProductRecordAttributes{LastUpdateDate: "2025-01-01", PurchasePrice: 10, SalePrice: 12, ProductID: product.ID})
+	require.NoError(t, err)
+
+	err = products.Delete(ctx, product.ID)
+	assert.Equal(t, httperrors.ConflictError{Message: "The product to delete is still referenced by some product records"}, err)
+
+	_, err = records.Create(ctx, models.ProductRecordAttributes{LastUpdateDate: "2025-01-01", PurchasePrice: 10, SalePrice: 12, ProductID: 99})
+	assert.Equal(t, httperrors.ConflictError{Message: "a product with the given id does not exist"}, err)
+}
+
+func TestMemory_IDsAreNotReused(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	buyers := repository.NewBuyerRepositoryMemory(store)
+
+	first, err := buyers.Create(ctx, models.BuyerAttributes{CardNumberId: 12345678, FirstName: "Juan", LastName: "Perez"})
+	require.NoError(t, err)
+	require.NoError(t, buyers.Delete(ctx, first.Id))
+
+	second, err := buyers.Create(ctx, models.BuyerAttributes{CardNumberId: 12345678, FirstName: "Ana", LastName: "Gomez"})
+	require.NoError(t, err)
+	assert.Equal(t, first.Id+1, second.Id)
+
+	_, err = buyers.Create(ctx, models.BuyerAttributes{CardNumberId: 12345678, FirstName: "Luis", LastName: "Martinez"})
+	assert.Equal(t, httperrors.ConflictError{Message: "CardNumberId already in use"}, err)
+}
+
+func TestMemory_StockMovementLedger(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	warehouses := repository.NewWarehouseRepositoryMemory(store)
+	sections := repository.NewSectionRepositoryMemory(store)
+	products := repository.NewProductRepositoryMemory(store)
+	batches := repository.NewProductBatchRepositoryMemory(store)
+	movements := repository.NewStockMovementRepositoryMemory(store)
+
+	warehouse, err := warehouses.Create(ctx, models.WarehouseAttributes{WarehouseCode: "W1"})
+	require.NoError(t, err)
+	section, err := sections.Create(ctx, models.Section{SectionNumber: "S1", WarehouseID: warehouse.Id, ProductTypeID: 1, MaximumCapacity: 100})
+	require.NoError(t, err)
+	product, err := products.Create(ctx, newTestProduct("P1", nil))
+	require.NoError(t, err)
+	other, err := products.Create(ctx, newTestProduct("P2", nil))
+	require.NoError(t, err)
+
+	newBatch := func(number, productID, quantity int) models.ProductBatch {
+		batch, err := batches.Create(ctx, models.ProductBatchAttibutes{
+			BatchNumber:     number,
+			CurrentQuantity: quantity,
+			InitialQuantity: quantity,
+			ProductID:       productID,
+			SectionID:       section.ID,
+		})
+		require.NoError(t, err)
+		return batch
+	}
+	source := newBatch(1, product.ID, 20)
+	target := newBatch(2, product.ID, 0)
+	foreign := newBatch(3, other.ID, 5)
+
+	// Outbound beyond the available stock is rejected
+	_, err = movements.Create(ctx, models.StockMovement{ProductBatchID: source.ID, MovementType: models.StockMovementOutbound, Quantity: -21})
+	assert.Equal(t, httperrors.ConflictError{Message: "Not enough stock in the product batch."}, err)
+
+	created, err := movements.Create(ctx, models.StockMovement{ProductBatchID: source.ID, MovementType: models.StockMovementOutbound, Quantity: -5})
+	require.NoError(t, err)
+	assert.Equal(t, 15, created.ResultingQuantity)
+
+	// Transfers only between batches of the same product
+	_, err = movements.Transfer(ctx, models.StockMovement{ProductBatchID: source.ID, MovementType: models.StockMovementTransfer, Quantity: 1, RelatedProductBatchID: utils.Ptr(foreign.ID)})
+	assert.Equal(t, httperrors.ConflictError{Message: "Stock can only be transferred between batches of the same product."}, err)
+
+	transferred, err := movements.Transfer(ctx, models.StockMovement{ProductBatchID: source.ID, MovementType: models.StockMovementTransfer, Quantity: 10, RelatedProductBatchID: utils.Ptr(target.ID)})
+	require.NoError(t, err)
+	require.Len(t, transferred, 2)
+	assert.Equal(t, 5, transferred[0].ResultingQuantity)
+	assert.Equal(t, 10, transferred[1].ResultingQuantity)
+
+	history, err := movements.GetByProductBatchID(ctx, source.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, []int{20, 15, 5}, []int{history[0].ResultingQuantity, history[1].ResultingQuantity, history[2].ResultingQuantity})
+
+	_, err = movements.GetByProductBatchID(ctx, 99)
+	assert.Equal(t, httperrors.NotFoundError{Message: "Product batch not found"}, err)
+}
+
+func TestMemory_SectionCapacity(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	warehouses := repository.NewWarehouseRepositoryMemory(store)
+	sections := repository.NewSectionRepositoryMemory(store)
+	products := repository.NewProductRepositoryMemory(store)
+	batches := repository.NewProductBatchRepositoryMemory(store)
+	movements := repository.NewStockMovementRepositoryMemory(store)
+
+	warehouse, err := warehouses.Create(ctx, models.WarehouseAttributes{WarehouseCode: "W1"})
+	require.NoError(t, err)
+	small, err := sections.Create(ctx, models.Section{SectionNumber: "S1", WarehouseID: warehouse.Id, ProductTypeID: 1, MaximumCapacity: 30})
+	require.NoError(t, err)
+	large, err := sections.Create(ctx, models.Section{SectionNumber: "S2", WarehouseID: warehouse.Id, ProductTypeID: 1, MaximumCapacity: 100})
+	require.NoError(t, err)
+	product, err := products.Create(ctx, newTestProduct("P1", nil))
+	require.NoError(t, err)
+
+	occupancy := func(sectionID int) int {
+		section, err := sections.GetByID(ctx, sectionID)
+		require.NoError(t, err)
+		return section.CurrentCapacity
+	}
+	attributes := models.ProductBatchAttibutes{BatchNumber: 1, CurrentQuantity: 20, InitialQuantity: 20, ProductID: product.ID, SectionID: small.ID}
+
+	batch, err := batches.Create(ctx, attributes)
+	require.NoError(t, err)
+	assert.Equal(t, 20, occupancy(small.ID))
+
+	// A batch that does not fit is rejected without touching the section
+	_, err = batches.Create(ctx, models.ProductBatchAttibutes{BatchNumber: 2, CurrentQuantity: 11, ProductID: product.ID, SectionID: small.ID})
+	assert.Equal(t, httperrors.ConflictError{Message: "Section does not have enough capacity for the product batch."}, err)
+	assert.Equal(t, 20, occupancy(small.ID))
+
+	// Updating the section keeps the occupancy of its batches, and the maximum cannot go below it
+	patched := small
+	patched.CurrentCapacity = 0
+	patched.MaximumCapacity = 19
+	_, err = sections.Update(ctx, small.ID, patched)
+	assert.Equal(t, httperrors.ConflictError{Message: "Maximum capacity cannot be below the current occupancy of the section."}, err)
+	patched.MaximumCapacity = 30
+	updated, err := sections.Update(ctx, small.ID, patched)
+	require.NoError(t, err)
+	assert.Equal(t, 20, updated.CurrentCapacity)
+	assert.Equal(t, 20, occupancy(small.ID))
+
+	_, err = movements.Create(ctx, models.StockMovement{ProductBatchID: batch.ID, MovementType: models.StockMovementInbound, Quantity: 11})
+	assert.Equal(t, httperrors.ConflictError{Message: "Section does not have enough capacity for the product batch."}, err)
+	_, err = movements.Create(ctx, models.StockMovement{ProductBatchID: batch.ID, MovementType: models.StockMovementOutbound, Quantity: -5})
+	require.NoError(t, err)
+	assert.Equal(t, 15, occupancy(small.ID))
+
+	// Moving the batch releases the previous section
+	attributes.CurrentQuantity = 15
+	attributes.SectionID = large.ID
+	_, err = batches.Update(ctx, batch.ID, attributes)
+	require.NoError(t, err)
+	assert.Equal(t, 0, occupancy(small.ID))
+	assert.Equal(t, 15, occupancy(large.ID))
+
+	// The batch has a ledger, so it cannot be deleted and its stock stays in the section
+	err = batches.Delete(ctx, batch.ID)
+	assert.Equal(t, httperrors.ConflictError{Message: "Product batch has stock movements and cannot be deleted."}, err)
+	history, err := movements.GetByProductBatchID(ctx, batch.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, 15, occupancy(large.ID))
+
+	// A batch whose only movement is its initial stock is deleted with it
+	fresh, err := batches.Create(ctx, models.ProductBatchAttibutes{BatchNumber: 3, CurrentQuantity: 10, InitialQuantity: 10, ProductID: product.ID, SectionID: large.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 25, occupancy(large.ID))
+	require.NoError(t, batches.Delete(ctx, fresh.ID))
+	_, err = batches.GetByID(ctx, fresh.ID)
+	assert.Equal(t, httperrors.NotFoundError{Message: "Product batch not found"}, err)
+	_, err = movements.GetByProductBatchID(ctx, fresh.ID)
+	assert.Equal(t, httperrors.NotFoundError{Message: "Product batch not found"}, err)
+	assert.Equal(t, 15, occupancy(large.ID))
+}
+
+func TestMemory_ProductTypeIntegrity(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	productTypes := repository.NewProductTypeRepositoryMemory(store)
+	warehouses := repository.NewWarehouseRepositoryMemory(store)
+	sections := repository.NewSectionRepositoryMemory(store)
+	products := repository.NewProductRepositoryMemory(store)
+	batches := repository.NewProductBatchRepositoryMemory(store)
+
+	dairy, err := productTypes.Create(ctx, models.ProductTypeAttributes{Description: "Dairy"})
+	require.NoError(t, err)
+	_, err = productTypes.Create(ctx, models.ProductTypeAttributes{Description: "Dairy"})
+	assert.Equal(t, httperrors.ConflictError{Message: "Product type description already exists."}, err)
+
+	warehouse, err := warehouses.Create(ctx, models.WarehouseAttributes{WarehouseCode: "W1"})
+	require.NoError(t, err)
+	_, err = sections.Create(ctx, models.Section{SectionNumber: "S0", WarehouseID: warehouse.Id, ProductTypeID: 99})
+	assert.Equal(t, httperrors.ConflictError{Message: "Product type does not exist."}, err)
+	section, err := sections.Create(ctx, models.Section{SectionNumber: "S1", WarehouseID: warehouse.Id, ProductTypeID: dairy.ID, MaximumCapacity: 100})
+	require.NoError(t, err)
+	frozen, err := products.Create(ctx, newTestProduct("P1", nil))
+	require.NoError(t, err)
+
+	// A frozen product cannot be stored in a dairy section
+	_, err = batches.Create(ctx, models.ProductBatchAttibutes{BatchNumber: 1, CurrentQuantity: 1, ProductID: frozen.ID, SectionID: section.ID})
+	assert.Equal(t, httperrors.ConflictError{Message: "Product type of the product does not match the product type of the section."}, err)
+
+	err = productTypes.Delete(ctx, dairy.ID)
+	assert.Equal(t, httperrors.ConflictError{Message: "Product type is still referenced by products or sections."}, err)
+	require.NoError(t, sections.Delete(ctx, section.ID))
+	require.NoError(t, productTypes.Delete(ctx, dairy.ID))
+}
+
+func TestMemory_ProductTypeChange(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	productTypes := repository.NewProductTypeRepositoryMemory(store)
+	warehouses := repository.NewWarehouseRepositoryMemory(store)
+	sections := repository.NewSectionRepositoryMemory(store)
+	products := repository.NewProductRepositoryMemory(store)
+	batches := repository.NewProductBatchRepositoryMemory(store)
+
+	dairy, err := productTypes.Create(ctx, models.ProductTypeAttributes{Description: "Dairy"})
+	require.NoError(t, err)
+	warehouse, err := warehouses.Create(ctx, models.WarehouseAttributes{WarehouseCode: "W1"})
+	require.NoError(t, err)
+	section, err := sections.Create(ctx, models.Section{SectionNumber: "S1", WarehouseID: warehouse.Id, ProductTypeID: 1, MaximumCapacity: 100})
+	require.NoError(t, err)
+	product, err := products.Create(ctx, newTestProduct("P1", nil))
+	require.NoError(t, err)
+	idle, err := products.Create(ctx, newTestProduct("P2", nil))
+	require.NoError(t, err)
+	_, err = batches.Create(ctx, models.ProductBatchAttibutes{BatchNumber: 1, CurrentQuantity: 5, ProductID: product.ID, SectionID: section.ID})
+	require.NoError(t, err)
+
+	// The stored batch would no longer match its section
+	changed := product
+	changed.ProductTypeID = dairy.ID
+	_, err = products.Update(ctx, product.ID, changed)
+	assert.Equal(t, httperrors.ConflictError{Message: "Product type cannot change while the product has batches in sections of another product type."}, err)
+	changedSection := section
+	changedSection.ProductTypeID = dairy.ID
+	_, err = sections.Update(ctx, section.ID, changedSection)
+	assert.Equal(t, httperrors.ConflictError{Message: "Product type cannot change while the section stores batches of another product type."}, err)
+
+	stored, err := products.GetByID(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.ProductTypeID)
+
+	// Products without batches and other section fields can still change
+	idle.ProductTypeID = dairy.ID
+	_, err = products.Update(ctx, idle.ID, idle)
+	require.NoError(t, err)
+	storedSection, err := sections.GetByID(ctx, section.ID)
+	require.NoError(t, err)
+	storedSection.MaximumCapacity = 200
+	_, err = sections.Update(ctx, section.ID, storedSection)
+	require.NoError(t, err)
+}
+
+func TestMemory_AlertLifecycle(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	warehouses := repository.NewWarehouseRepositoryMemory(store)
+	sections := repository.NewSectionRepositoryMemory(store)
+	alerts := repository.NewAlertRepositoryMemory(store)
+
+	warehouse, err := warehouses.Create(ctx, models.WarehouseAttributes{WarehouseCode: "W1"})
+	require.NoError(t, err)
+	section, err := sections.Create(ctx, models.Section{SectionNumber: "S1", WarehouseID: warehouse.Id, ProductTypeID: 1, MinimumTemperature: -25})
+	require.NoError(t, err)
+
+	breach := models.Alert{AlertType: models.AlertTypeSectionMinimum, Temperature: -30, Threshold: -25, Message: "too cold"}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	opened, err := alerts.Sync(ctx, section.ID, []models.Alert{breach}, start)
+	require.NoError(t, err)
+	require.Len(t, opened, 1)
+	assert.Equal(t, models.AlertStatusOpen, opened[0].Status)
+
+	// The same breach does not open a second alert
+	opened, err = alerts.Sync(ctx, section.ID, []models.Alert{breach}, start.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, opened)
+
+	acknowledged, err := alerts.Acknowledge(ctx, 1, start.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, models.AlertStatusAcknowledged, acknowledged.Status)
+	_, err = alerts.Acknowledge(ctx, 1, start.Add(2*time.Minute))
+	assert.Equal(t, httperrors.ConflictError{Message: "Only open alerts can be acknowledged."}, err)
+
+	// Back in range resolves it
+	_, err = alerts.Sync(ctx, section.ID, nil, start.Add(3*time.Minute))
+	require.NoError(t, err)
+	resolved, err := alerts.GetAll(ctx, models.AlertFilter{Status: models.AlertStatusResolved})
+	require.NoError(t, err)
+	require.Len(t, resolved.Data, 1)
+	assert.Equal(t, start.Add(3*time.Minute), *resolved.Data[0].ResolvedAt)
+
+	_, err = alerts.Acknowledge(ctx, 99, start)
+	assert.Equal(t, httperrors.NotFoundError{Message: "Alert not found"}, err)
+}
+
+func TestMemory_PurchaseOrderLifecycle(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	products := repository.NewProductRepositoryMemory(store)
+	productRecords := repository.NewProductRecordRepositoryMemory(store)
+	buyers := repository.NewBuyerRepositoryMemory(store)
+	purchaseOrders := repository.NewPurchaseOrderRepositoryMemory(store)
+
+	product, err := products.Create(ctx, newTestProduct("P1", nil))
+	require.NoError(t, err)
+	_, err = productRecords.Create(ctx, models.ProductRecordAttributes{LastUpdateDate: "2026-01-05", PurchasePrice: 10, SalePrice: 15.5, ProductID: product.ID})
+	require.NoError(t, err)
+	_, err = productRecords.Create(ctx, models.ProductRecordAttributes{LastUpdateDate: "2026-01-01", PurchasePrice: 10, SalePrice: 12, ProductID: product.ID})
+	require.NoError(t, err)
+	buyer, err := buyers.Create(ctx, models.BuyerAttributes{CardNumberId: 12345678, FirstName: "Ana", LastName: "Diaz"})
+	require.NoError(t, err)
+
+	order, err := purchaseOrders.Create(ctx, models.PurchaseOrderAttributes{
+		OrderNumber: "ORD-1", OrderDate: time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC), TrackingCode: "T1", BuyerId: buyer.Id,
+		Lines: []models.PurchaseOrderLineAttributes{{ProductId: product.ID, Quantity: 3}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.PurchaseOrderStatusPending, order.Status)
+	// Priced with the record in effect on the order date, the most recent rather than the last one created
+	require.Len(t, order.Lines, 1)
+	assert.Equal(t, 15.5, order.Lines[0].UnitPrice)
+	assert.Equal(t, 46.5, order.Total)
+
+	// A product without records has no price and nothing is stored
+	_, err = purchaseOrders.Create(ctx, models.PurchaseOrderAttributes{
+		OrderNumber: "ORD-2", OrderDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), TrackingCode: "T2", BuyerId: buyer.Id,
+		Lines: []models.PurchaseOrderLineAttributes{{ProductId: product.ID, Quantity: 1}, {ProductId: 99, Quantity: 1}},
+	})
+	assert.Equal(t, httperrors.ConflictError{Message: "Product 99 has no price on 2026-01-01"}, err)
+
+	// Steps cannot be skipped
+	_, err = purchaseOrders.Transition(ctx, order.Id, models.PurchaseOrderStatusShipped, time.Now())
+	assert.Equal(t, httperrors.ConflictError{Message: "A pending purchase order cannot be shipped"}, err)
+
+	// Confirming needs stock to allocate
+	warehouse, err := repository.NewWarehouseRepositoryMemory(store).Create(ctx, models.WarehouseAttributes{WarehouseCode: "W1"})
+	require.NoError(t, err)
+	section, err := repository.NewSectionRepositoryMemory(store).Create(ctx, models.Section{SectionNumber: "S1", WarehouseID: warehouse.Id, ProductTypeID: 1, MaximumCapacity: 100})
+	require.NoError(t, err)
+	_, err = repository.NewProductBatchRepositoryMemory(store).Create(ctx, models.ProductBatchAttibutes{
+		BatchNumber: 1, CurrentQuantity: 3, InitialQuantity: 3, DueDate: "2026-02-01", ProductID: product.ID, SectionID: section.ID,
+	})
+	require.NoError(t, err)
+
+	at := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	for _, status := range []string{models.PurchaseOrderStatusConfirmed, models.PurchaseOrderStatusPicked, models.PurchaseOrderStatusShipped} {
+		order, err = purchaseOrders.Transition(ctx, order.Id, status, at)
+		require.NoError(t, err)
+		assert.Equal(t, status, order.Status)
+	}
+	assert.Equal(t, at, *order.ConfirmedAt)
+	assert.Equal(t, at, *order.ShippedAt)
+
+	// A shipped order can no longer be cancelled
+	_, err = purchaseOrders.Transition(ctx, order.Id, models.PurchaseOrderStatusCancelled, at)
+	assert.Equal(t, httperrors.ConflictError{Message: "A shipped purchase order cannot be cancelled"}, err)
+
+	stored, err := purchaseOrders.GetByID(ctx, order.Id)
+	require.NoError(t, err)
+	assert.Equal(t, order, stored)
+
+	shipped, err := purchaseOrders.GetAll(ctx, models.QueryOptions{Filters: map[string]string{"status": "shipped"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, shipped.Meta.Total)
+	assert.Equal(t, order.Lines, shipped.Data[0].Lines)
+
+	// Buyers still count orders, not lines
+	counts, err := buyers.GetWithPurchaseOrdersCount(ctx, &buyer.Id)
+	require.NoError(t, err)
+	assert.Equal(t, 1, counts[0].PurchaseOrdersCount)
+
+	_, err = purchaseOrders.Transition(ctx, 99, models.PurchaseOrderStatusConfirmed, at)
+	assert.Equal(t, httperrors.NotFoundError{Message: "Purchase order not found"}, err)
+}
+
+func TestMemory_PurchaseOrderTracking(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	localities := repository.NewLocalityRepositoryMemory(store)
+	carries := repository.NewCarryRepositoryMemory(store)
+	buyers := repository.NewBuyerRepositoryMemory(store)
+	purchaseOrders := repository.NewPurchaseOrderRepositoryMemory(store)
+	trackingEvents := repository.NewTrackingEventRepositoryMemory(store)
+
+	for _, id := range []string{"1001", "2000"} {
+		_, err := localities.Create(ctx, models.Locality{ID: id, LocalityName: "L" + id, ProvinceID: 1})
+		require.NoError(t, err)
+	}
+	elsewhere, err := carries.Create(ctx, models.CarryAttributes{Cid: "C1", LocalityId: "1001"})
+	require.NoError(t, err)
+	nearby, err := carries.Create(ctx, models.CarryAttributes{Cid: "C2", LocalityId: "2000"})
+	require.NoError(t, err)
+
+	delivery := "2000"
+	buyer, err := buyers.Create(ctx, models.BuyerAttributes{CardNumberId: 12345678, FirstName: "Ana", LastName: "Diaz", DeliveryLocalityId: &delivery})
+	require.NoError(t, err)
+	order, err := purchaseOrders.Create(ctx, models.PurchaseOrderAttributes{
+		OrderNumber: "ORD-1", OrderDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), TrackingCode: "T1", BuyerId: buyer.Id,
+	})
+	require.NoError(t, err)
+
+	// Nothing can be tracked before a carry takes the order
+	_, err = trackingEvents.Create(ctx, models.TrackingEvent{PurchaseOrderId: order.Id, EventType: models.TrackingEventPickedUp})
+	assert.Equal(t, httperrors.ConflictError{Message: "Purchase order has no carry assigned"}, err)
+
+	// Without a carry_id the carry in the delivery locality wins over the lower id
+	order, err = purchaseOrders.AssignCarry(ctx, order.Id, nil)
+	require.NoError(t, err)
+	assert.Equal(t, nearby.Id, *order.CarryId)
+
+	_, err = purchaseOrders.AssignCarry(ctx, order.Id, utils.Ptr(99))
+	assert.Equal(t, httperrors.ConflictError{Message: "Carry does not exist"}, err)
+
+	// A pending order is not out for delivery yet
+	_, err = trackingEvents.Create(ctx, models.TrackingEvent{PurchaseOrderId: order.Id, EventType: models.TrackingEventPickedUp})
+	assert.Equal(t, httperrors.ConflictError{Message: "A pending purchase order cannot receive tracking events"}, err)
+
+	for _, status := range []string{models.PurchaseOrderStatusConfirmed, models.PurchaseOrderStatusPicked} {
+		_, err = purchaseOrders.Transition(ctx, order.Id, status, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+	}
+	order, err = purchaseOrders.AssignCarry(ctx, order.Id, &elsewhere.Id)
+	require.NoError(t, err)
+	assert.Equal(t, elsewhere.Id, *order.CarryId)
+
+	// Events are recorded against the current carry and listed by when they occurred
+	inTransit, err := trackingEvents.Create(ctx, models.TrackingEvent{
+		PurchaseOrderId: order.Id, EventType: models.TrackingEventInTransit, OccurredAt: time.Date(2026, 1, 3, 12, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, elsewhere.Id, inTransit.CarryId)
+	pickedUp, err := trackingEvents.Create(ctx, models.TrackingEvent{
+		PurchaseOrderId: order.Id, EventType: models.TrackingEventPickedUp, OccurredAt: time.Date(2026, 1, 3, 8, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	// A picked order has not left the warehouse, so it cannot be delivered yet
+	_, err = trackingEvents.Create(ctx, models.TrackingEvent{
+		PurchaseOrderId: order.Id, EventType: models.TrackingEventDelivered, OccurredAt: time.Date(2026, 1, 3, 13, 0, 0, 0, time.UTC),
+	})
+	assert.Equal(t, httperrors.ConflictError{Message: "A picked purchase order cannot receive delivered events"}, err)
+
+	_, err = purchaseOrders.Transition(ctx, order.Id, models.PurchaseOrderStatusShipped, time.Date(2026, 1, 3, 13, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	_, err = purchaseOrders.AssignCarry(ctx, order.Id, &nearby.Id)
+	assert.Equal(t, httperrors.ConflictError{Message: "A shipped purchase order cannot change its carry"}, err)
+	delivered, err := trackingEvents.Create(ctx, models.TrackingEvent{
+		PurchaseOrderId: order.Id, EventType: models.TrackingEventDelivered, OccurredAt: time.Date(2026, 1, 4, 9, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	timeline, err := trackingEvents.GetTimeline(ctx, "T1")
+	require.NoError(t, err)
+	assert.Equal(t, models.PurchaseOrderStatusShipped, timeline.Status)
+	assert.Equal(t, elsewhere.Id, *timeline.CarryId)
+	assert.Equal(t, []models.TrackingEvent{pickedUp, inTransit, delivered}, timeline.Events)
+
+	_, err = trackingEvents.GetTimeline(ctx, "T9")
+	assert.Equal(t, httperrors.NotFoundError{Message: "Tracking code not found"}, err)
+}
+
+func TestMemory_PurchaseOrderAllocation(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	warehouses := repository.NewWarehouseRepositoryMemory(store)
+	sections := repository.NewSectionRepositoryMemory(store)
+	products := repository.NewProductRepositoryMemory(store)
+	productRecords := repository.NewProductRecordRepositoryMemory(store)
+	batches := repository.NewProductBatchRepositoryMemory(store)
+	movements := repository.NewStockMovementRepositoryMemory(store)
+	buyers := repository.NewBuyerRepositoryMemory(store)
+	purchaseOrders := repository.NewPurchaseOrderRepositoryMemory(store)
+
+	warehouse, err := warehouses.Create(ctx, models.WarehouseAttributes{WarehouseCode: "W1"})
+	require.NoError(t, err)
+	section, err := sections.Create(ctx, models.Section{SectionNumber: "S1", WarehouseID: warehouse.Id, ProductTypeID: 1, MaximumCapacity: 100})
+	require.NoError(t, err)
+	product, err := products.Create(ctx, newTestProduct("P1", nil))
+	require.NoError(t, err)
+	_, err = productRecords.Create(ctx, models.ProductRecordAttributes{LastUpdateDate: "2026-01-01", PurchasePrice: 1, SalePrice: 2, ProductID: product.ID})
+	require.NoError(t, err)
+	buyer, err := buyers.Create(ctx, models.BuyerAttributes{CardNumberId: 12345678, FirstName: "Ana", LastName: "Diaz"})
+	require.NoError(t, err)
+
+	newBatch := func(number, quantity int, dueDate string) models.ProductBatch {
+		batch, err := batches.Create(ctx, models.ProductBatchAttibutes{
+			BatchNumber:     number,
+			CurrentQuantity: quantity,
+			InitialQuantity: quantity,
+			DueDate:         dueDate,
+			ProductID:       product.ID,
+			SectionID:       section.ID,
+		})
+		require.NoError(t, err)
+		return batch
+	}
+	expired := newBatch(1, 10, "2026-01-09")
+	late := newBatch(2, 10, "2026-03-01")
+	early := newBatch(3, 4, "2026-01-10")
+
+	newOrder := func(number string, quantities ...int) models.PurchaseOrder {
+		attributes := models.PurchaseOrderAttributes{
+			OrderNumber: number, OrderDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), TrackingCode: "T" + number, BuyerId: buyer.Id,
+		}
+		for _, quantity := range quantities {
+			attributes.Lines = append(attributes.Lines, models.PurchaseOrderLineAttributes{ProductId: product.ID, Quantity: quantity})
+		}
+		order, err := purchaseOrders.Create(ctx, attributes)
+		require.NoError(t, err)
+		return order
+	}
+	at := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+
+	// The expired batch does not count, so 15 units are more than the 14 available
+	tooBig := newOrder("ORD-1", 9, 6)
+	_, err = purchaseOrders.Transition(ctx, tooBig.Id, models.PurchaseOrderStatusConfirmed, at)
+	assert.Equal(t, httperrors.ConflictError{Message: "Not enough stock of product 1"}, err)
+	stored, err := purchaseOrders.GetByID(ctx, tooBig.Id)
+	require.NoError(t, err)
+	assert.Equal(t, models.PurchaseOrderStatusPending, stored.Status)
+	history, err := movements.GetByProductBatchID(ctx, early.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+
+	// The batch due first is emptied before the next one, and the second line sees what the first took
+	order := newOrder("ORD-2", 3, 5)
+	order, err = purchaseOrders.Transition(ctx, order.Id, models.PurchaseOrderStatusConfirmed, at)
+	require.NoError(t, err)
+	lines := order.Lines
+	require.Len(t, lines, 2)
+	assert.Equal(t, []models.PurchaseOrderAllocation{
+		{Id: 1, PurchaseOrderLineId: lines[0].Id, ProductBatchId: early.ID, Quantity: 3},
+	}, lines[0].Allocations)
+	assert.Equal(t, []models.PurchaseOrderAllocation{
+		{Id: 2, PurchaseOrderLineId: lines[1].Id, ProductBatchId: early.ID, Quantity: 1},
+		{Id: 3, PurchaseOrderLineId: lines[1].Id, ProductBatchId: late.ID, Quantity: 4},
+	}, lines[1].Allocations)
+
+	stored, err = purchaseOrders.GetByID(ctx, order.Id)
+	require.NoError(t, err)
+	assert.Equal(t, order, stored)
+
+	history, err = movements.GetByProductBatchID(ctx, late.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	outbound := history[slices.IndexFunc(history, func(m models.StockMovement) bool { return m.MovementType == models.StockMovementOutbound })]
+	assert.Equal(t, -4, outbound.Quantity)
+	assert.Equal(t, 6, outbound.ResultingQuantity)
+	assert.Equal(t, "Purchase order ORD-2", outbound.Reason)
+	assert.Equal(t, at, outbound.CreatedAt)
+
+	untouched, err := batches.GetByID(ctx, expired.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 10, untouched.CurrentQuantity)
+
+	// Allocated batches cannot be deleted
+	err = batches.Delete(ctx, late.ID)
+	assert.Equal(t, httperrors.ConflictError{Message: "Product batch has stock movements and cannot be deleted."}, err)
+
+	// Cancelling the confirmed order gives its stock back to the batches and the section
+	cancelledAt := at.Add(time.Hour)
+	order, err = purchaseOrders.Transition(ctx, order.Id, models.PurchaseOrderStatusCancelled, cancelledAt)
+	require.NoError(t, err)
+	for _, line := range order.Lines {
+		assert.Empty(t, line.Allocations)
+	}
+	restored, err := batches.GetByID(ctx, early.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, restored.CurrentQuantity)
+	restored, err = batches.GetByID(ctx, late.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 10, restored.CurrentQuantity)
+	storedSection, err := sections.GetByID(ctx, section.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 24, storedSection.CurrentCapacity)
+
+	history, err = movements.GetByProductBatchID(ctx, late.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	inbound := history[slices.IndexFunc(history, func(m models.StockMovement) bool { return m.Reason == "Purchase order ORD-2 cancelled" })]
+	assert.Equal(t, models.StockMovementInbound, inbound.MovementType)
+	assert.Equal(t, 4, inbound.Quantity)
+	assert.Equal(t, 10, inbound.ResultingQuantity)
+	assert.Equal(t, cancelledAt, inbound.CreatedAt)
+}
+
+func TestMemory_InboundOrderReceiving(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	warehouses := repository.NewWarehouseRepositoryMemory(store)
+	sections := repository.NewSectionRepositoryMemory(store)
+	products := repository.NewProductRepositoryMemory(store)
+	employees := repository.NewEmployeeRepositoryMemory(store)
+	batches := repository.NewProductBatchRepositoryMemory(store)
+	movements := repository.NewStockMovementRepositoryMemory(store)
+	inboundOrders := repository.NewInboundOrderRepositoryMemory(store)
+
+	warehouse, err := warehouses.Create(ctx, models.WarehouseAttributes{WarehouseCode: "W1"})
+	require.NoError(t, err)
+	other, err := warehouses.Create(ctx, models.WarehouseAttributes{WarehouseCode: "W2"})
+	require.NoError(t, err)
+	section, err := sections.Create(ctx, models.Section{SectionNumber: "S1", WarehouseID: warehouse.Id, ProductTypeID: 1, MaximumCapacity: 100})
+	require.NoError(t, err)
+	foreignSection, err := sections.Create(ctx, models.Section{SectionNumber: "S2", WarehouseID: other.Id, ProductTypeID: 1, MaximumCapacity: 100})
+	require.NoError(t, err)
+	product, err := products.Create(ctx, newTestProduct("P1", nil))
+	require.NoError(t, err)
+	employee, err := employees.Create(ctx, models.Employee{EmployeeAttributes: models.EmployeeAttributes{CardNumberID: "12345678", FirstName: "Ana", LastName: "Diaz", WarehouseID: warehouse.Id}})
+	require.NoError(t, err)
+
+	newOrder := func(number string, employeeID, sectionID, quantity int) models.InboundOrderAttributes {
+		return models.InboundOrderAttributes{
+			OrderNumber: number,
+			OrderDate:   time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+			EmployeeID:  employeeID,
+			WarehouseID: warehouse.Id,
+			ProductBatch: models.InboundOrderBatchAttributes{
+				BatchNumber: 7, Quantity: quantity, CurrentTemperature: 4, DueDate: "2026-03-01",
+				ManufacturingDate: "2025-12-01", ManufacturingHour: 8, MinimumTemperature: 2,
+				ProductID: product.ID, SectionID: sectionID,
+			},
+		}
+	}
+
+	// The section must be in the warehouse of the order
+	_, err = inboundOrders.Create(ctx, newOrder("INB-1", employee.Id, foreignSection.ID, 10))
+	assert.Equal(t, httperrors.ConflictError{Message: "section does not belong to the warehouse"}, err)
+
+	// A new batch number creates the batch
+	created, err := inboundOrders.Create(ctx, newOrder("INB-1", employee.Id, section.ID, 10))
+	require.NoError(t, err)
+	assert.Equal(t, 10, created.Quantity)
+	batch, err := batches.GetByID(ctx, created.ProductBatchID)
+	require.NoError(t, err)
+	assert.Equal(t, 10, batch.CurrentQuantity)
+	assert.Equal(t, 10, batch.InitialQuantity)
+
+	// The same batch number tops it up
+	toppedUp, err := inboundOrders.Create(ctx, newOrder("INB-2", employee.Id, section.ID, 15))
+	require.NoError(t, err)
+	assert.Equal(t, created.ProductBatchID, toppedUp.ProductBatchID)
+	batch, err = batches.GetByID(ctx, created.ProductBatchID)
+	require.NoError(t, err)
+	assert.Equal(t, 25, batch.CurrentQuantity)
+	assert.Equal(t, 10, batch.InitialQuantity)
+	history, err := movements.GetByProductBatchID(ctx, batch.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "Inbound order INB-2", history[1].Reason)
+	stored, err := sections.GetByID(ctx, section.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 25, stored.CurrentCapacity)
+
+	// Section capacity is checked before anything is stored
+	_, err = inboundOrders.Create(ctx, newOrder("INB-3", employee.Id, section.ID, 80))
+	assert.Equal(t, httperrors.ConflictError{Message: "Section does not have enough capacity for the product batch."}, err)
+	count, err := inboundOrders.CountInboundOrdersForEmployee(ctx, employee.Id)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	_, err = inboundOrders.Create(ctx, newOrder("INB-2", employee.Id, section.ID, 1))
+	assert.Equal(t, httperrors.ConflictError{Message: "duplicate order number"}, err)
+
+	// Employees only receive orders for their own warehouse
+	outsider, err := employees.Create(ctx, models.Employee{EmployeeAttributes: models.EmployeeAttributes{CardNumberID: "87654321", FirstName: "Luis", LastName: "Gomez", WarehouseID: other.Id}})
+	require.NoError(t, err)
+	_, err = inboundOrders.Create(ctx, newOrder("INB-4", outsider.Id, section.ID, 1))
+	assert.Equal(t, httperrors.ConflictError{Message: "employee does not belong to the warehouse"}, err)
+}
+
+func TestMemory_ProductRecordHistory(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	products := repository.NewProductRepositoryMemory(store)
+	productRecords := repository.NewProductRecordRepositoryMemory(store)
+
+	product, err := products.Create(ctx, newTestProduct("P1", nil))
+	require.NoError(t, err)
+	var records []models.ProductRecord
+	for _, attributes := range []models.ProductRecordAttributes{
+		{LastUpdateDate: "2026-03-01", PurchasePrice: 10, SalePrice: 14, ProductID: product.ID},
+		{LastUpdateDate: "2026-01-01", PurchasePrice: 8, SalePrice: 11, ProductID: product.ID},
+		{LastUpdateDate: "2026-02-01", PurchasePrice: 9, SalePrice: 12, ProductID: product.ID},
+		{LastUpdateDate: "2026-02-01", PurchasePrice: 9, SalePrice: 13, ProductID: product.ID},
+	} {
+		record, err := productRecords.Create(ctx, attributes)
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+
+	// The range is inclusive and the history is ordered by date, ties by id
+	page, err := productRecords.GetAll(ctx, models.ProductRecordFilter{
+		QueryOptions: models.QueryOptions{Sort: "last_update_date"},
+		ProductID:    product.ID,
+		DateFrom:     "2026-01-01",
+		DateTo:       "2026-02-01",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []models.ProductRecord{records[1], records[2], records[3]}, page.Data)
+
+	// A price holds until the next record, and the newest record of a day wins
+	effective, err := productRecords.GetEffective(ctx, product.ID, "2026-02-15")
+	require.NoError(t, err)
+	assert.Equal(t, records[3], effective)
+	effective, err = productRecords.GetEffective(ctx, product.ID, "2026-03-01")
+	require.NoError(t, err)
+	assert.Equal(t, records[0], effective)
+
+	_, err = productRecords.GetEffective(ctx, product.ID, "2025-12-31")
+	assert.Equal(t, httperrors.NotFoundError{Message: "Product 1 has no price on 2025-12-31"}, err)
+	_, err = productRecords.GetAll(ctx, models.ProductRecordFilter{ProductID: 99})
+	assert.Equal(t, httperrors.NotFoundError{Message: "Product not found"}, err)
+}
+
+func TestMemory_ProductMargins(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	sellers := repository.NewSellerRepositoryMemory(store)
+	products := repository.NewProductRepositoryMemory(store)
+	productRecords := repository.NewProductRecordRepositoryMemory(store)
+	buyers := repository.NewBuyerRepositoryMemory(store)
+	purchaseOrders := repository.NewPurchaseOrderRepositoryMemory(store)
+
+	_, err := repository.NewLocalityRepositoryMemory(store).Create(ctx, models.Locality{ID: "1001", LocalityName: "Palermo", ProvinceID: 1})
+	require.NoError(t, err)
+	seller, err := sellers.Create(ctx, models.SellerAttributes{CID: 1, CompanyName: "Frozen Co", LocalityID: "1001"})
+	require.NoError(t, err)
+	product, err := products.Create(ctx, newTestProduct("P1", &seller.ID))
+	require.NoError(t, err)
+	for _, attributes := range []models.ProductRecordAttributes{
+		{LastUpdateDate: "2026-01-01", PurchasePrice: 6, SalePrice: 10, ProductID: product.ID},
+		{LastUpdateDate: "2026-01-10", PurchasePrice: 8, SalePrice: 12, ProductID: product.ID},
+	} {
+		_, err := productRecords.Create(ctx, attributes)
+		require.NoError(t, err)
+	}
+	warehouse, err := repository.NewWarehouseRepositoryMemory(store).Create(ctx, models.WarehouseAttributes{WarehouseCode: "W1"})
+	require.NoError(t, err)
+	section, err := repository.NewSectionRepositoryMemory(store).Create(ctx, models.Section{SectionNumber: "S1", WarehouseID: warehouse.Id, ProductTypeID: 1, MaximumCapacity: 100})
+	require.NoError(t, err)
+	_, err = repository.NewProductBatchRepositoryMemory(store).Create(ctx, models.ProductBatchAttibutes{
+		BatchNumber: 1, CurrentQuantity: 50, InitialQuantity: 50, DueDate: "2026-12-31", ProductID: product.ID, SectionID: section.ID,
+	})
+	require.NoError(t, err)
+	buyer, err := buyers.Create(ctx, models.BuyerAttributes{CardNumberId: 12345678, FirstName: "Ana", LastName: "Diaz"})
+	require.NoError(t, err)
+
+	newOrder := func(number string, day, quantity int, statuses ...string) {
+		order, err := purchaseOrders.Create(ctx, models.PurchaseOrderAttributes{
+			OrderNumber: number, OrderDate: time.Date(2026, 1, day, 15, 0, 0, 0, time.UTC), TrackingCode: "T" + number, BuyerId: buyer.Id,
+			Lines: []models.PurchaseOrderLineAttributes{{ProductId: product.ID, Quantity: quantity}},
+		})
+		require.NoError(t, err)
+		for _, status := range statuses {
+			_, err = purchaseOrders.Transition(ctx, order.Id, status, order.OrderDate)
+			require.NoError(t, err)
+		}
+	}
+	// Each order is priced, and costed, with the record in effect on its day
+	newOrder("ORD-1", 5, 2, models.PurchaseOrderStatusConfirmed)
+	newOrder("ORD-2", 12, 3, models.PurchaseOrderStatusConfirmed, models.PurchaseOrderStatusPicked)
+	// Pending and cancelled orders are not sales
+	newOrder("ORD-3", 12, 4)
+	newOrder("ORD-4", 12, 5, models.PurchaseOrderStatusCancelled)
+
+	margins, err := products.GetMargins(ctx, models.MarginReportFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []models.ProductMargin{{
+		ProductID: product.ID, Description: product.Description, SellerID: &seller.ID,
+		UnitsSold: 5, Revenue: 2*10 + 3*12, Cost: 2*6 + 3*8,
+	}}, margins)
+
+	// The range includes the whole last day
+	margins, err = products.GetMargins(ctx, models.MarginReportFilter{From: "2026-01-06", To: "2026-01-12", SellerID: &seller.ID})
+	require.NoError(t, err)
+	require.Len(t, margins, 1)
+	assert.Equal(t, 3, margins[0].UnitsSold)
+
+	margins, err = products.GetMargins(ctx, models.MarginReportFilter{SellerID: utils.Ptr(99)})
+	require.NoError(t, err)
+	assert.Empty(t, margins)
+}
+
+func TestMemory_CarryManagement(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	localities := repository.NewLocalityRepositoryMemory(store)
+	carries := repository.NewCarryRepositoryMemory(store)
+	buyers := repository.NewBuyerRepositoryMemory(store)
+	purchaseOrders := repository.NewPurchaseOrderRepositoryMemory(store)
+
+	for _, id := range []string{"1001", "2000"} {
+		_, err := localities.Create(ctx, models.Locality{ID: id, LocalityName: "L" + id, ProvinceID: 1})
+		require.NoError(t, err)
+	}
+	first, err := carries.Create(ctx, models.CarryAttributes{Cid: "C1", LocalityId: "1001"})
+	require.NoError(t, err)
+	second, err := carries.Create(ctx, models.CarryAttributes{Cid: "C2", LocalityId: "2000"})
+	require.NoError(t, err)
+
+	page, err := carries.GetAll(ctx, models.QueryOptions{Filters: map[string]string{"locality_id": "2000"}})
+	require.NoError(t, err)
+	assert.Equal(t, []models.Carry{second}, page.Data)
+
+	// The cid stays unique and the locality must exist
+	_, err = carries.Update(ctx, second.Id, models.CarryAttributes{Cid: "C1", LocalityId: "2000"})
+	assert.Equal(t, httperrors.ConflictError{Message: "the Cid already exists"}, err)
+	_, err = carries.Update(ctx, second.Id, models.CarryAttributes{Cid: "C2", LocalityId: "9999"})
+	assert.Equal(t, httperrors.ConflictError{Message: "the LocalityId does not exist"}, err)
+	updated, err := carries.Update(ctx, second.Id, models.CarryAttributes{Cid: "C2", CompanyName: "Fast", LocalityId: "1001"})
+	require.NoError(t, err)
+	got, err := carries.GetByID(ctx, second.Id)
+	require.NoError(t, err)
+	assert.Equal(t, updated, got)
+
+	// A carry cannot be deleted while it still has orders to deliver
+	buyer, err := buyers.Create(ctx, models.BuyerAttributes{CardNumberId: 12345678, FirstName: "Ana", LastName: "Diaz"})
+	require.NoError(t, err)
+	order, err := purchaseOrders.Create(ctx, models.PurchaseOrderAttributes{
+		OrderNumber: "ORD-1", OrderDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), TrackingCode: "T1", BuyerId: buyer.Id,
+	})
+	require.NoError(t, err)
+	_, err = purchaseOrders.AssignCarry(ctx, order.Id, &first.Id)
+	require.NoError(t, err)
+
+	err = carries.Delete(ctx, first.Id)
+	assert.Equal(t, httperrors.ConflictError{Message: "the carry is still assigned to open shipments"}, err)
+
+	_, err = purchaseOrders.Transition(ctx, order.Id, models.PurchaseOrderStatusCancelled, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	err = carries.Delete(ctx, first.Id)
+	assert.Equal(t, httperrors.ConflictError{Message: "the carry has delivered or cancelled shipments and is kept in their history; only carries without purchase orders can be deleted"}, err)
+
+	require.NoError(t, carries.Delete(ctx, second.Id))
+	_, err = carries.GetByID(ctx, second.Id)
+	assert.Equal(t, httperrors.NotFoundError{Message: "carry not found"}, err)
+	assert.Equal(t, httperrors.NotFoundError{Message: "carry not found"}, carries.Delete(ctx, second.Id))
+}
+
+func TestMemory_LocalityHierarchy(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	countries := repository.NewCountryRepositoryMemory(store)
+	provinces := repository.NewProvinceRepositoryMemory(store)
+	localities := repository.NewLocalityRepositoryMemory(store)
+	sellers := repository.NewSellerRepositoryMemory(store)
+	carries := repository.NewCarryRepositoryMemory(store)
+
+	// Province names are unique within their country only
+	uruguay, err := countries.Create(ctx, models.CountryAttributes{CountryName: "Uruguay"})
+	require.NoError(t, err)
+	_, err = provinces.Create(ctx, models.ProvinceAttributes{ProvinceName: "Buenos Aires", CountryID: 1})
+	assert.Equal(t, httperrors.ConflictError{Message: "Province name already exists in the country."}, err)
+	montevideo, err := provinces.Create(ctx, models.ProvinceAttributes{ProvinceName: "Buenos Aires", CountryID: uruguay.ID})
+	require.NoError(t, err)
+	_, err = provinces.Create(ctx, models.ProvinceAttributes{ProvinceName: "Salta", CountryID: 99})
+	assert.Equal(t, httperrors.ConflictError{Message: "Country does not exist"}, err)
+
+	_, err = localities.Create(ctx, models.Locality{ID: "11000", LocalityName: "Montevideo", ProvinceID: 99})
+	assert.Equal(t, httperrors.ConflictError{Message: "The province ID does not exist"}, err)
+	lujan, err := localities.Create(ctx, models.Locality{ID: "6700", LocalityName: "Lujan", ProvinceID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, models.Locality{ID: "6700", LocalityName: "Lujan", ProvinceID: 1, ProvinceName: "Buenos Aires", CountryID: 1, CountryName: "Argentina"}, lujan)
+	_, err = localities.Create(ctx, models.Locality{ID: "11000", LocalityName: "Montevideo", ProvinceID: montevideo.ID})
+	require.NoError(t, err)
+
+	// Renaming a province is seen by its localities
+	_, err = provinces.Update(ctx, montevideo.ID, models.Province{ProvinceAttributes: models.ProvinceAttributes{ProvinceName: "Montevideo", CountryID: uruguay.ID}})
+	require.NoError(t, err)
+	page, err := localities.GetAll(ctx, models.QueryOptions{Filters: map[string]string{"country_id": "2"}})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "Montevideo", page.Data[0].ProvinceName)
+	assert.Equal(t, "Uruguay", page.Data[0].CountryName)
+
+	for i, localityID := range []string{"6700", "6700", "11000"} {
+		_, err := sellers.Create(ctx, models.SellerAttributes{CID: i + 1, CompanyName: "S", LocalityID: localityID})
+		require.NoError(t, err)
+	}
+	_, err = carries.Create(ctx, models.CarryAttributes{Cid: "C1", LocalityId: "11000"})
+	require.NoError(t, err)
+
+	// Reports roll the localities up to their province and country
+	sellersByCountry, err := countries.GetSellerReport(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []models.RegionSellerReport{
+		{ID: 1, Name: "Argentina", SellersCount: 2},
+		{ID: uruguay.ID, Name: "Uruguay", SellersCount: 1},
+	}, sellersByCountry)
+	carriesByProvince, err := provinces.GetCarryReport(ctx, &montevideo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.RegionCarryReport{{ID: montevideo.ID, Name: "Montevideo", CarriesCount: 1}}, carriesByProvince)
+	_, err = provinces.GetSellerReport(ctx, utils.Ptr(99))
+	assert.Equal(t, httperrors.NotFoundError{Message: "Province not found"}, err)
+
+	// Each level is restricted while the one below references it
+	assert.Equal(t, httperrors.ConflictError{Message: "Country is still referenced by provinces."}, countries.Delete(ctx, uruguay.ID))
+	assert.Equal(t, httperrors.ConflictError{Message: "Province is still referenced by localities."}, provinces.Delete(ctx, montevideo.ID))
+	assert.Equal(t, httperrors.ConflictError{Message: "The locality is still referenced by sellers, carries or buyers"}, localities.Delete(ctx, "11000"))
+
+	empty, err := localities.Create(ctx, models.Locality{ID: "11100", LocalityName: "Pocitos", ProvinceID: montevideo.ID})
+	require.NoError(t, err)
+	require.NoError(t, localities.Delete(ctx, empty.ID))
+	_, err = localities.GetByID(ctx, empty.ID)
+	assert.Equal(t, httperrors.NotFoundError{Message: "Locality not found"}, err)
+}
@@ -25,9 +25,33 @@ func NewProductBatchRepositoryDB(db *sql.DB) ProductBatchRepository {
 	}
 }
 
-
-// Create creates a new product batch in the repository
+// Create creates a new product batch in the repository.
+// The initial current_quantity is recorded as an inbound stock movement and added
+// to the occupancy of the section in the same transaction, so the ledger always
+// adds up to the batch quantity. Returns a ConflictError if the section is full
+// or is dedicated to another product type.
 func (repository *ProductBatchRepositoryDB) Create(ctx context.Context, productBatch models.ProductBatchAttibutes) (models.ProductBatch, error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.ProductBatch{}, internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	productCreated, err := insertProductBatchTx(ctx, tx, productBatch)
+	if err != nil {
+		return models.ProductBatch{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.ProductBatch{}, internalError(ctx, "", err)
+	}
+	return productCreated, nil
+}
+
+// insertProductBatchTx stores the batch, adds its current_quantity to the occupancy
+// of the section and records it as the initial stock in the ledger.
+// It must run inside tx so the three writes commit together.
+func insertProductBatchTx(ctx context.Context, tx *sql.Tx, productBatch models.ProductBatchAttibutes) (models.ProductBatch, error) {
 	const query = `
         INSERT INTO product_batches (
             batch_number, current_quantity, current_temperature, due_date,
@@ -35,7 +59,11 @@ func (repository *ProductBatchRepositoryDB) Create(ctx context.Context, productB
             product_id, section_id
         ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
-	result, err := repository.db.ExecContext(ctx, query,
+	if err := checkProductTypeTx(ctx, tx, productBatch.ProductID, productBatch.SectionID); err != nil {
+		return models.ProductBatch{}, err
+	}
+
+	result, err := tx.ExecContext(ctx, query,
 		productBatch.BatchNumber, productBatch.CurrentQuantity, productBatch.CurrentTemperature, productBatch.DueDate,
 		productBatch.InitialQuantity, productBatch.ManufacturingDate, productBatch.ManufacturingHour, productBatch.MinimumTemperature,
 		productBatch.ProductID, productBatch.SectionID,
@@ -51,17 +79,340 @@ func (repository *ProductBatchRepositoryDB) Create(ctx context.Context, productB
 			case 1452:
 				return models.ProductBatch{}, httperrors.ConflictError{Message: "Product or section does not exist."}
 			default:
-				return models.ProductBatch{}, httperrors.InternalServerError{}
+				return models.ProductBatch{}, internalError(ctx, "", err)
 			}
 		}
-		return models.ProductBatch{}, httperrors.InternalServerError{}
+		return models.ProductBatch{}, internalError(ctx, "", err)
 	}
 
 	// Get the last inserted ID
 	lastId, err := result.LastInsertId()
 	if err != nil {
-		return models.ProductBatch{}, httperrors.InternalServerError{}
+		return models.ProductBatch{}, internalError(ctx, "", err)
 	}
 	productCreated := models.ProductBatch{ID: int(lastId), ProductBatchAttibutes: productBatch}
+
+	if err := occupySectionTx(ctx, tx, productBatch.SectionID, productBatch.CurrentQuantity); err != nil {
+		return models.ProductBatch{}, err
+	}
+
+	// Record the initial stock in the ledger
+	_, err = insertStockMovementTx(ctx, tx, models.StockMovement{
+		ProductBatchID:    productCreated.ID,
+		MovementType:      models.StockMovementInbound,
+		Quantity:          productBatch.CurrentQuantity,
+		ResultingQuantity: productBatch.CurrentQuantity,
+		Reason:            initialStockReason,
+	})
+	if err != nil {
+		return models.ProductBatch{}, err
+	}
 	return productCreated, nil
-}
\ No newline at end of file
+}
+
+// initialStockReason is the reason of the movement recording the initial stock of a batch
+const initialStockReason = "initial stock"
+
+// isInitialStockMovement reports whether movement is the initial stock recorded
+// when the batch productBatchID was created.
+func isInitialStockMovement(movement models.StockMovement, productBatchID int) bool {
+	return movement.ProductBatchID == productBatchID && movement.RelatedProductBatchID == nil &&
+		movement.MovementType == models.StockMovementInbound && movement.Reason == initialStockReason
+}
+
+// initialStockMovementTx returns the ID of the initial stock movement of the batch,
+// 0 when it has no movements. Returns a ConflictError if the batch has any other
+// movement, so deleting it would erase the ledger.
+func initialStockMovementTx(ctx context.Context, tx *sql.Tx, productBatchID int) (int, error) {
+	const query = `
+        SELECT id, product_batch_id, movement_type, related_product_batch_id, reason
+        FROM stock_movements
+        WHERE product_batch_id = ? OR related_product_batch_id = ?
+        LIMIT 2
+    `
+	rows, err := tx.QueryContext(ctx, query, productBatchID, productBatchID)
+	if err != nil {
+		return 0, internalError(ctx, "", err)
+	}
+	defer rows.Close()
+
+	var movements []models.StockMovement
+	for rows.Next() {
+		var movement models.StockMovement
+		err := rows.Scan(&movement.ID, &movement.ProductBatchID, &movement.MovementType, &movement.RelatedProductBatchID, &movement.Reason)
+		if err != nil {
+			return 0, internalError(ctx, "", err)
+		}
+		movements = append(movements, movement)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, internalError(ctx, "", err)
+	}
+
+	switch {
+	case len(movements) == 0:
+		return 0, nil
+	case len(movements) == 1 && isInitialStockMovement(movements[0], productBatchID):
+		return movements[0].ID, nil
+	}
+	return 0, httperrors.ConflictError{Message: "Product batch has stock movements and cannot be deleted."}
+}
+
+// productBatchColumns lists the columns read into a models.ProductBatch, dates formatted as YYYY-MM-DD
+const productBatchColumns = `
+            id,
+            batch_number,
+            current_quantity,
+            current_temperature,
+            DATE_FORMAT(due_date, '%Y-%m-%d'),
+            initial_quantity,
+            DATE_FORMAT(manufacturing_date, '%Y-%m-%d'),
+            manufacturing_hour,
+            minimum_temperature,
+            product_id,
+            section_id`
+
+// scanProductBatch reads a row selected with productBatchColumns
+func scanProductBatch(row interface{ Scan(dest ...any) error }) (models.ProductBatch, error) {
+	var productBatch models.ProductBatch
+	err := row.Scan(
+		&productBatch.ID,
+		&productBatch.BatchNumber,
+		&productBatch.CurrentQuantity,
+		&productBatch.CurrentTemperature,
+		&productBatch.DueDate,
+		&productBatch.InitialQuantity,
+		&productBatch.ManufacturingDate,
+		&productBatch.ManufacturingHour,
+		&productBatch.MinimumTemperature,
+		&productBatch.ProductID,
+		&productBatch.SectionID,
+	)
+	return productBatch, err
+}
+
+// productBatchListColumns are the fields product batches can be sorted by
+var productBatchListColumns = listColumns{
+	"id":               "id",
+	"batch_number":     "batch_number",
+	"current_quantity": "current_quantity",
+	"due_date":         "due_date",
+	"product_id":       "product_id",
+	"section_id":       "section_id",
+}
+
+// GetAll returns the page of product batches matching the filter
+func (repository *ProductBatchRepositoryDB) GetAll(ctx context.Context, filter models.ProductBatchFilter) (models.Page[models.ProductBatch], error) {
+	query := listQuery{
+		selectColumns: productBatchColumns,
+		from:          "product_batches",
+		columns:       productBatchListColumns,
+	}
+	if filter.SectionID != 0 {
+		query.conditions = append(query.conditions, "section_id = ?")
+		query.args = append(query.args, filter.SectionID)
+	}
+	if filter.ProductID != 0 {
+		query.conditions = append(query.conditions, "product_id = ?")
+		query.args = append(query.args, filter.ProductID)
+	}
+	if filter.DueDateFrom != "" {
+		query.conditions = append(query.conditions, "due_date >= ?")
+		query.args = append(query.args, filter.DueDateFrom)
+	}
+	if filter.DueDateTo != "" {
+		query.conditions = append(query.conditions, "due_date <= ?")
+		query.args = append(query.args, filter.DueDateTo)
+	}
+
+	return queryPage(ctx, repository.db, query, filter.QueryOptions, productBatchListFields, func(rows *sql.Rows) (models.ProductBatch, error) {
+		return scanProductBatch(rows)
+	})
+}
+
+// GetByID returns a product batch by its ID
+func (repository *ProductBatchRepositoryDB) GetByID(ctx context.Context, id int) (models.ProductBatch, error) {
+	query := "SELECT" + productBatchColumns + "\n        FROM product_batches\n        WHERE id = ?"
+
+	productBatch, err := scanProductBatch(repository.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ProductBatch{}, httperrors.NotFoundError{Message: "Product batch not found"}
+		}
+		return models.ProductBatch{}, internalError(ctx, "", err)
+	}
+	return productBatch, nil
+}
+
+// Update, updates a product batch in the repository.
+// If current_quantity changes, the difference is recorded as an adjustment
+// stock movement in the same transaction. The occupancy of the sections follows
+// the new quantity and section, and a ConflictError is returned if it does not fit
+// or the section is dedicated to another product type.
+func (repository *ProductBatchRepositoryDB) Update(ctx context.Context, id int, data models.ProductBatchAttibutes) (models.ProductBatch, error) {
+	const query = `
+        UPDATE product_batches SET
+            batch_number = ?, current_quantity = ?, current_temperature = ?, due_date = ?,
+            initial_quantity = ?, manufacturing_date = ?, manufacturing_hour = ?, minimum_temperature = ?,
+            product_id = ?, section_id = ?
+        WHERE id = ?
+    `
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.ProductBatch{}, internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	previous, err := lockProductBatchTx(ctx, tx, id)
+	if err != nil {
+		return models.ProductBatch{}, err
+	}
+	previousQuantity := previous.currentQuantity
+
+	if data.ProductID != previous.productID || data.SectionID != previous.sectionID {
+		if err := checkProductTypeTx(ctx, tx, data.ProductID, data.SectionID); err != nil {
+			return models.ProductBatch{}, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, query,
+		data.BatchNumber, data.CurrentQuantity, data.CurrentTemperature, data.DueDate,
+		data.InitialQuantity, data.ManufacturingDate, data.ManufacturingHour, data.MinimumTemperature,
+		data.ProductID, data.SectionID, id,
+	)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) {
+			switch mysqlErr.Number {
+			case 1062:
+				return models.ProductBatch{}, httperrors.ConflictError{Message: "Batch number already exists."}
+			case 1452:
+				return models.ProductBatch{}, httperrors.ConflictError{Message: "Product or section does not exist."}
+			}
+		}
+		return models.ProductBatch{}, internalError(ctx, "", err)
+	}
+
+	if err := moveSectionOccupancyTx(ctx, tx, previous, data); err != nil {
+		return models.ProductBatch{}, err
+	}
+
+	// Keep the ledger in line with the new quantity
+	if data.CurrentQuantity != previousQuantity {
+		_, err = insertStockMovementTx(ctx, tx, models.StockMovement{
+			ProductBatchID:    id,
+			MovementType:      models.StockMovementAdjustment,
+			Quantity:          data.CurrentQuantity - previousQuantity,
+			ResultingQuantity: data.CurrentQuantity,
+			Reason:            "product batch update",
+		})
+		if err != nil {
+			return models.ProductBatch{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.ProductBatch{}, internalError(ctx, "", err)
+	}
+	return models.ProductBatch{ID: id, ProductBatchAttibutes: data}, nil
+}
+
+// Delete, deletes a product batch from the repository, releasing its quantity from the section.
+// Its initial stock movement goes with it in the same transaction. Returns a ConflictError if it
+// has any other stock movement, which is never erased, or if inbound orders or purchase orders
+// still reference it.
+func (repository *ProductBatchRepositoryDB) Delete(ctx context.Context, id int) error {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	batch, err := lockProductBatchTx(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	initialMovementID, err := initialStockMovementTx(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if initialMovementID != 0 {
+		const movementQuery = `DELETE FROM stock_movements WHERE id = ?`
+		if _, err := tx.ExecContext(ctx, movementQuery, initialMovementID); err != nil {
+			return internalError(ctx, "", err)
+		}
+	}
+
+	const query = `DELETE FROM product_batches WHERE id = ?`
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1451 {
+			return httperrors.ConflictError{Message: "Product batch is still referenced by inbound orders, transfers or purchase orders."}
+		}
+		return internalError(ctx, "", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return internalError(ctx, "", err)
+	} else if count == 0 {
+		return httperrors.NotFoundError{Message: "Product batch not found"}
+	}
+
+	if err := occupySectionTx(ctx, tx, batch.sectionID, -batch.currentQuantity); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return internalError(ctx, "", err)
+	}
+	return nil
+}
+
+// moveSectionOccupancyTx updates the occupancy of the sections after a batch
+// changes its quantity or moves from one section to another.
+// Sections are locked in ID order so concurrent moves cannot deadlock.
+func moveSectionOccupancyTx(ctx context.Context, tx *sql.Tx, previous lockedProductBatch, data models.ProductBatchAttibutes) error {
+	if previous.sectionID == data.SectionID {
+		return occupySectionTx(ctx, tx, data.SectionID, data.CurrentQuantity-previous.currentQuantity)
+	}
+
+	changes := []struct{ sectionID, quantity int }{
+		{previous.sectionID, -previous.currentQuantity},
+		{data.SectionID, data.CurrentQuantity},
+	}
+	if changes[1].sectionID < changes[0].sectionID {
+		changes[0], changes[1] = changes[1], changes[0]
+	}
+	for _, change := range changes {
+		if err := occupySectionTx(ctx, tx, change.sectionID, change.quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkProductTypeTx verifies the product is of the product type the section is dedicated to.
+// A missing product or section is left to the foreign keys of the following write.
+func checkProductTypeTx(ctx context.Context, tx *sql.Tx, productID, sectionID int) error {
+	const query = `
+		SELECT p.product_type_id, s.product_type_id
+		FROM products p
+		JOIN sections s ON s.id = ?
+		WHERE p.id = ?`
+
+	var productTypeID, sectionTypeID int
+	err := tx.QueryRowContext(ctx, query, sectionID, productID).Scan(&productTypeID, &sectionTypeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return internalError(ctx, "", err)
+	}
+	if productTypeID != sectionTypeID {
+		return httperrors.ConflictError{Message: "Product type of the product does not match the product type of the section."}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// ProductBatchRepositoryMemory implements ProductBatchRepository in memory
+type ProductBatchRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// NewProductBatchRepositoryMemory constructs a ProductBatchRepositoryMemory
+// backed by the given store.
+func NewProductBatchRepositoryMemory(store *MemoryStore) ProductBatchRepository {
+	return &ProductBatchRepositoryMemory{store: store}
+}
+
+// Create creates a new product batch in the repository, records its initial
+// quantity as an inbound stock movement and adds it to the occupancy of the section.
+// Returns a ConflictError if the section is full.
+func (repository *ProductBatchRepositoryMemory) Create(ctx context.Context, productBatch models.ProductBatchAttibutes) (models.ProductBatch, error) {
+	repository.store.mu.Lock()
+	defer repository.store.mu.Unlock()
+
+	return repository.store.createProductBatch(productBatch)
+}
+
+// createProductBatch stores the batch after checking its constraints and the capacity
+// of its section, adds it to the occupancy of the section and records its initial stock.
+// The caller must hold the write lock.
+func (s *MemoryStore) createProductBatch(productBatch models.ProductBatchAttibutes) (models.ProductBatch, error) {
+	if err := s.checkProductBatchConstraints(0, productBatch); err != nil {
+		return models.ProductBatch{}, err
+	}
+	if err := s.checkSectionOccupancy(productBatch.SectionID, productBatch.CurrentQuantity); err != nil {
+		return models.ProductBatch{}, err
+	}
+
+	productCreated := models.ProductBatch{
+		ID:                    s.nextID("product_batches"),
+		ProductBatchAttibutes: productBatch,
+	}
+	s.productBatches[productCreated.ID] = productCreated
+	s.occupySection(productBatch.SectionID, productBatch.CurrentQuantity)
+
+	// Record the initial stock in the ledger
+	s.insertStockMovement(models.StockMovement{
+		ProductBatchID:    productCreated.ID,
+		MovementType:      models.StockMovementInbound,
+		Quantity:          productBatch.CurrentQuantity,
+		ResultingQuantity: productBatch.CurrentQuantity,
+		Reason:            initialStockReason,
+	})
+	return productCreated, nil
+}
+
+// productBatchListFields are the fields product batches can be sorted by
+var productBatchListFields = listFields[models.ProductBatch]{
+	"id":               func(b models.ProductBatch) any { return b.ID },
+	"batch_number":     func(b models.ProductBatch) any { return b.BatchNumber },
+	"current_quantity": func(b models.ProductBatch) any { return b.CurrentQuantity },
+	"due_date":         func(b models.ProductBatch) any { return b.DueDate },
+	"product_id":       func(b models.ProductBatch) any { return b.ProductID },
+	"section_id":       func(b models.ProductBatch) any { return b.SectionID },
+}
+
+// GetAll returns the page of product batches matching the filter
+func (repository *ProductBatchRepositoryMemory) GetAll(ctx context.Context, filter models.ProductBatchFilter) (models.Page[models.ProductBatch], error) {
+	repository.store.mu.RLock()
+	defer repository.store.mu.RUnlock()
+
+	var productBatches []models.ProductBatch
+	for _, batch := range sortedByID(repository.store.productBatches) {
+		if filter.SectionID != 0 && batch.SectionID != filter.SectionID {
+			continue
+		}
+		if filter.ProductID != 0 && batch.ProductID != filter.ProductID {
+			continue
+		}
+		// Dates are YYYY-MM-DD, so they compare in calendar order as strings
+		if filter.DueDateFrom != "" && batch.DueDate < filter.DueDateFrom {
+			continue
+		}
+		if filter.DueDateTo != "" && batch.DueDate > filter.DueDateTo {
+			continue
+		}
+		productBatches = append(productBatches, batch)
+	}
+	return pageOf(productBatches, filter.QueryOptions, productBatchListFields)
+}
+
+// GetByID returns a product batch by its ID
+func (repository *ProductBatchRepositoryMemory) GetByID(ctx context.Context, id int) (models.ProductBatch, error) {
+	repository.store.mu.RLock()
+	defer repository.store.mu.RUnlock()
+
+	batch, ok := repository.store.productBatches[id]
+	if !ok {
+		return models.ProductBatch{}, httperrors.NotFoundError{Message: "Product batch not found"}
+	}
+	return batch, nil
+}
+
+// Update, updates a product batch in the repository.
+// A change of current_quantity is recorded as an adjustment stock movement, and the
+// occupancy of the sections follows the new quantity and section.
+func (repository *ProductBatchRepositoryMemory) Update(ctx context.Context, id int, data models.ProductBatchAttibutes) (models.ProductBatch, error) {
+	repository.store.mu.Lock()
+	defer repository.store.mu.Unlock()
+
+	previous, ok := repository.store.productBatches[id]
+	if !ok {
+		return models.ProductBatch{}, httperrors.NotFoundError{Message: "Product batch not found"}
+	}
+	if err := repository.store.checkProductBatchConstraints(id, data); err != nil {
+		return models.ProductBatch{}, err
+	}
+	// A batch moving to another section must fit whole there; staying, only the difference counts
+	added := data.CurrentQuantity
+	if data.SectionID == previous.SectionID {
+		added -= previous.CurrentQuantity
+	}
+	if err := repository.store.checkSectionOccupancy(data.SectionID, added); err != nil {
+		return models.ProductBatch{}, err
+	}
+
+	updated := models.ProductBatch{ID: id, ProductBatchAttibutes: data}
+	repository.store.productBatches[id] = updated
+	if data.SectionID != previous.SectionID {
+		repository.store.occupySection(previous.SectionID, -previous.CurrentQuantity)
+	}
+	repository.store.occupySection(data.SectionID, added)
+
+	// Keep the ledger in line with the new quantity
+	if data.CurrentQuantity != previous.CurrentQuantity {
+		repository.store.insertStockMovement(models.StockMovement{
+			ProductBatchID:    id,
+			MovementType:      models.StockMovementAdjustment,
+			Quantity:          data.CurrentQuantity - previous.CurrentQuantity,
+			ResultingQuantity: data.CurrentQuantity,
+			Reason:            "product batch update",
+		})
+	}
+	return updated, nil
+}
+
+// Delete, deletes a product batch from the repository, releasing its quantity from the section.
+// Its initial stock movement goes with it. Returns a ConflictError if it has any other stock
+// movement, which is never erased, or if inbound orders or purchase orders still reference it.
+func (repository *ProductBatchRepositoryMemory) Delete(ctx context.Context, id int) error {
+	repository.store.mu.Lock()
+	defer repository.store.mu.Unlock()
+
+	batch, ok := repository.store.productBatches[id]
+	if !ok {
+		return httperrors.NotFoundError{Message: "Product batch not found"}
+	}
+	var movements []models.StockMovement
+	for _, movement := range repository.store.stockMovements {
+		if movement.ProductBatchID == id || (movement.RelatedProductBatchID != nil && *movement.RelatedProductBatchID == id) {
+			movements = append(movements, movement)
+		}
+	}
+	if len(movements) > 1 || (len(movements) == 1 && !isInitialStockMovement(movements[0], id)) {
+		return httperrors.ConflictError{Message: "Product batch has stock movements and cannot be deleted."}
+	}
+	for _, order := range repository.store.inboundOrders {
+		if order.ProductBatchID == id {
+			return httperrors.ConflictError{Message: "Product batch is still referenced by inbound orders, transfers or purchase orders."}
+		}
+	}
+	for _, allocation := range repository.store.purchaseOrderAllocations {
+		if allocation.ProductBatchId == id {
+			return httperrors.ConflictError{Message: "Product batch is still referenced by inbound orders, transfers or purchase orders."}
+		}
+	}
+
+	// Alerts about the batch go with it, as ON DELETE CASCADE does
+	for alertID, alert := range repository.store.alerts {
+		if alert.ProductBatchID != nil && *alert.ProductBatchID == id {
+			delete(repository.store.alerts, alertID)
+		}
+	}
+	for _, movement := range movements {
+		delete(repository.store.stockMovements, movement.ID)
+	}
+	delete(repository.store.productBatches, id)
+	repository.store.occupySection(batch.SectionID, -batch.CurrentQuantity)
+	return nil
+}
+
+// checkProductBatchConstraints mirrors the unique batch number and the foreign keys of the product_batches table,
+// and checks the section is dedicated to the product type of the product.
+// The caller must hold the lock.
+func (s *MemoryStore) checkProductBatchConstraints(id int, productBatch models.ProductBatchAttibutes) error {
+	for _, batch := range s.productBatches {
+		if batch.ID != id && batch.BatchNumber == productBatch.BatchNumber {
+			return httperrors.ConflictError{Message: "Batch number already exists."}
+		}
+	}
+	product, productExists := s.products[productBatch.ProductID]
+	section, sectionExists := s.sections[productBatch.SectionID]
+	if !productExists || !sectionExists {
+		return httperrors.ConflictError{Message: "Product or section does not exist."}
+	}
+	if product.ProductTypeID != section.ProductTypeID {
+		return httperrors.ConflictError{Message: "Product type of the product does not match the product type of the section."}
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductBatchRepository_Update(t *testing.T) {
+	data := models.ProductBatchAttibutes{
+		BatchNumber:        10,
+		CurrentQuantity:    40,
+		CurrentTemperature: 4,
+		DueDate:            "2026-01-10",
+		InitialQuantity:    50,
+		ManufacturingDate:  "2025-12-01",
+		ManufacturingHour:  8,
+		MinimumTemperature: 2,
+		ProductID:          1,
+		SectionID:          1,
+	}
+
+	lockQuery := regexp.QuoteMeta(`SELECT current_quantity, product_id, section_id FROM product_batches WHERE id = ? FOR UPDATE`)
+	updateQuery := regexp.QuoteMeta(`UPDATE product_batches SET`)
+	lockSectionQuery := regexp.QuoteMeta(`SELECT current_capacity, maximum_capacity FROM sections WHERE id = ? FOR UPDATE`)
+	occupySectionQuery := regexp.QuoteMeta(`UPDATE sections SET current_capacity = ? WHERE id = ?`)
+	movementQuery := regexp.QuoteMeta(`INSERT INTO stock_movements`)
+	productTypeQuery := regexp.QuoteMeta(`SELECT p.product_type_id, s.product_type_id`)
+	batchColumns := []string{"current_quantity", "product_id", "section_id"}
+	sectionColumns := []string{"current_capacity", "maximum_capacity"}
+
+	tests := []struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			testName: "Success: quantity change is recorded as an adjustment",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(50, 1, 1))
+				mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(lockSectionQuery).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(sectionColumns).AddRow(80, 100))
+				mock.ExpectExec(occupySectionQuery).WithArgs(70, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(movementQuery).
+					WithArgs(1, models.StockMovementAdjustment, -10, 40, nil, "product batch update", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(7, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "Success: same quantity does not touch the ledger",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(40, 1, 1))
+				mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(lockSectionQuery).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(sectionColumns).AddRow(80, 100))
+				mock.ExpectExec(occupySectionQuery).WithArgs(80, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "Success: moving to another section releases the previous one",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(40, 1, 2))
+				mock.ExpectQuery(productTypeQuery).WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"product_type_id", "product_type_id"}).AddRow(3, 3))
+				mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(lockSectionQuery).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(sectionColumns).AddRow(10, 100))
+				mock.ExpectExec(occupySectionQuery).WithArgs(50, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(lockSectionQuery).WithArgs(2).
+					WillReturnRows(sqlmock.NewRows(sectionColumns).AddRow(40, 100))
+				mock.ExpectExec(occupySectionQuery).WithArgs(0, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "Fail: section of another product type",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(40, 1, 2))
+				mock.ExpectQuery(productTypeQuery).WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"product_type_id", "product_type_id"}).AddRow(3, 4))
+				mock.ExpectRollback()
+			},
+			expectedError: httperrors.ConflictError{Message: "Product type of the product does not match the product type of the section."},
+		},
+		{
+			testName: "Fail: section over maximum capacity",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(30, 1, 1))
+				mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(lockSectionQuery).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(sectionColumns).AddRow(95, 100))
+				mock.ExpectRollback()
+			},
+			expectedError: httperrors.ConflictError{Message: "Section does not have enough capacity for the product batch."},
+		},
+		{
+			testName: "Fail: batch not found",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(batchColumns))
+				mock.ExpectRollback()
+			},
+			expectedError: httperrors.NotFoundError{Message: "Product batch not found"},
+		},
+		{
+			testName: "Fail: duplicated batch number (1062)",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(50, 1, 1))
+				mock.ExpectExec(updateQuery).WillReturnError(&mysql.MySQLError{Number: 1062})
+				mock.ExpectRollback()
+			},
+			expectedError: httperrors.ConflictError{Message: "Batch number already exists."},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			repo := NewProductBatchRepositoryDB(db)
+			result, err := repo.Update(context.Background(), 1, data)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.ProductBatch{ID: 1, ProductBatchAttibutes: data}, result)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductBatchRepository_Delete(t *testing.T) {
+	lockQuery := regexp.QuoteMeta(`SELECT current_quantity, product_id, section_id FROM product_batches WHERE id = ? FOR UPDATE`)
+	movementsQuery := `SELECT id, product_batch_id, movement_type, related_product_batch_id, reason\s+FROM stock_movements\s+WHERE product_batch_id = \? OR related_product_batch_id = \?`
+	deleteMovementQuery := regexp.QuoteMeta(`DELETE FROM stock_movements WHERE id = ?`)
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM product_batches WHERE id = ?`)
+	movementColumns := []string{"id", "product_batch_id", "movement_type", "related_product_batch_id", "reason"}
+	lockSectionQuery := regexp.QuoteMeta(`SELECT current_capacity, maximum_capacity FROM sections WHERE id = ? FOR UPDATE`)
+	occupySectionQuery := regexp.QuoteMeta(`UPDATE sections SET current_capacity = ? WHERE id = ?`)
+	batchColumns := []string{"current_quantity", "product_id", "section_id"}
+
+	tests := []struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			testName: "Success: batch without movements is deleted",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(15, 1, 2))
+				mock.ExpectQuery(movementsQuery).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows(movementColumns))
+				mock.ExpectExec(deleteQuery).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(lockSectionQuery).WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(40, 100))
+				mock.ExpectExec(occupySectionQuery).WithArgs(25, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "Success: the initial stock movement goes with the batch",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(15, 1, 2))
+				mock.ExpectQuery(movementsQuery).WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows(movementColumns).AddRow(7, 1, models.StockMovementInbound, nil, "initial stock"))
+				mock.ExpectExec(deleteMovementQuery).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(deleteQuery).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(lockSectionQuery).WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(40, 100))
+				mock.ExpectExec(occupySectionQuery).WithArgs(25, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "Fail: batch not found",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows(batchColumns))
+				mock.ExpectRollback()
+			},
+			expectedError: httperrors.NotFoundError{Message: "Product batch not found"},
+		},
+		{
+			testName: "Fail: still referenced (1451)",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(15, 1, 2))
+				mock.ExpectQuery(movementsQuery).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows(movementColumns))
+				mock.ExpectExec(deleteQuery).WithArgs(1).WillReturnError(&mysql.MySQLError{Number: 1451})
+				mock.ExpectRollback()
+			},
+			expectedError: httperrors.ConflictError{Message: "Product batch is still referenced by inbound orders, transfers or purchase orders."},
+		},
+		{
+			testName: "Fail: batch with stock movements keeps its ledger",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(15, 1, 2))
+				mock.ExpectQuery(movementsQuery).WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows(movementColumns).
+						AddRow(7, 1, models.StockMovementInbound, nil, "initial stock").
+						AddRow(9, 1, models.StockMovementOutbound, nil, "Purchase order ORD-1"))
+				mock.ExpectRollback()
+			},
+			expectedError: httperrors.ConflictError{Message: "Product batch has stock movements and cannot be deleted."},
+		},
+		{
+			testName: "Fail: transfer target keeps the ledger of its source",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(15, 1, 2))
+				mock.ExpectQuery(movementsQuery).WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows(movementColumns).AddRow(8, 3, models.StockMovementTransfer, 1, "rebalance"))
+				mock.ExpectRollback()
+			},
+			expectedError: httperrors.ConflictError{Message: "Product batch has stock movements and cannot be deleted."},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			repo := NewProductBatchRepositoryDB(db)
+			err = repo.Delete(context.Background(), 1)
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductBatchRepository_CreateThenDelete(t *testing.T) {
+	// arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	data := models.ProductBatchAttibutes{
+		BatchNumber:     10,
+		CurrentQuantity: 40,
+		InitialQuantity: 40,
+		ProductID:       1,
+		SectionID:       2,
+	}
+	lockSectionQuery := regexp.QuoteMeta(`SELECT current_capacity, maximum_capacity FROM sections WHERE id = ? FOR UPDATE`)
+	occupySectionQuery := regexp.QuoteMeta(`UPDATE sections SET current_capacity = ? WHERE id = ?`)
+	sectionColumns := []string{"current_capacity", "maximum_capacity"}
+
+	// Create records the initial stock as movement 7
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.product_type_id, s.product_type_id`)).WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"product_type_id", "product_type_id"}).AddRow(3, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO product_batches`)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(lockSectionQuery).WithArgs(2).WillReturnRows(sqlmock.NewRows(sectionColumns).AddRow(0, 100))
+	mock.ExpectExec(occupySectionQuery).WithArgs(40, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO stock_movements`)).
+		WithArgs(1, models.StockMovementInbound, 40, 40, nil, "initial stock", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectCommit()
+
+	// Delete finds only that movement and removes it with the batch
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT current_quantity, product_id, section_id FROM product_batches WHERE id = ? FOR UPDATE`)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "product_id", "section_id"}).AddRow(40, 1, 2))
+	mock.ExpectQuery(`FROM stock_movements\s+WHERE product_batch_id = \? OR related_product_batch_id = \?`).WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_batch_id", "movement_type", "related_product_batch_id", "reason"}).
+			AddRow(7, 1, models.StockMovementInbound, nil, "initial stock"))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM stock_movements WHERE id = ?`)).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM product_batches WHERE id = ?`)).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(lockSectionQuery).WithArgs(2).WillReturnRows(sqlmock.NewRows(sectionColumns).AddRow(40, 100))
+	mock.ExpectExec(occupySectionQuery).WithArgs(0, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewProductBatchRepositoryDB(db)
+
+	// act
+	created, err := repo.Create(context.Background(), data)
+	assert.NoError(t, err)
+	err = repo.Delete(context.Background(), created.ID)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
@@ -33,8 +34,8 @@ func NewProductRepositoryDB(db *sql.DB) ProductRepository {
 //     and the same attributes you passed in.
 //   - If a product with the same product_code already exists (MySQL error #1062),
 //     returns httperrors.ConflictError with a message about duplicate product code.
-//   - If the provided seller_id does not exist (MySQL error #1452),
-//     returns httperrors.ConflictError about the missing seller.
+//   - If the provided seller_id or product_type_id does not exist (MySQL error #1452),
+//     returns httperrors.ConflictError about the missing seller or product type.
 //   - Any other database error is returned.
 func (r *ProductRepositoryDB) Create(ctx context.Context, productAttributes models.ProductAttributes) (models.Product, error) {
 	const query = `
@@ -80,18 +81,15 @@ func (r *ProductRepositoryDB) Create(ctx context.Context, productAttributes mode
 						Message: "A product with the given product code already exists",
 					}
 			case 1452:
-				return models.Product{},
-					httperrors.ConflictError{
-						Message: "The given seller id does not exists",
-					}
+				return models.Product{}, productForeignKeyError(sqlError)
 			}
 		}
-		return models.Product{}, httperrors.InternalServerError{}
+		return models.Product{}, internalError(ctx, "", err)
 	}
 
 	lastId, err := result.LastInsertId()
 	if err != nil {
-		return models.Product{}, httperrors.InternalServerError{}
+		return models.Product{}, internalError(ctx, "", err)
 	}
 
 	newProduct := models.Product{
@@ -101,12 +99,25 @@ func (r *ProductRepositoryDB) Create(ctx context.Context, productAttributes mode
 	return newProduct, nil
 }
 
-// GetAll fetches every product record from the database and returns them
-// as a slice of models.Product. If any database error occurs it returns
-// the error.
-func (r *ProductRepositoryDB) GetAll(ctx context.Context) ([]models.Product, error) {
-	const query = `
-		SELECT
+// productListColumns are the fields products can be sorted and filtered by.
+var productListColumns = listColumns{
+	"id":                               "id",
+	"description":                      "description",
+	"expiration_rate":                  "expiration_rate",
+	"freezing_rate":                    "freezing_rate",
+	"netweight":                        "netweight",
+	"product_code":                     "product_code",
+	"recommended_freezing_temperature": "recommended_freezing_temperature",
+	"product_type_id":                  "product_type_id",
+	"seller_id":                        "seller_id",
+}
+
+// GetAll fetches the page of products described by opts together with
+// the total number of products matching its filters.
+// Returns a BadRequestError for unknown sort or filter fields.
+func (r *ProductRepositoryDB) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Product], error) {
+	query := listQuery{
+		selectColumns: `
 			id,
 			description,
 			expiration_rate,
@@ -118,20 +129,14 @@ func (r *ProductRepositoryDB) GetAll(ctx context.Context) ([]models.Product, err
 			product_code,
 			recommended_freezing_temperature,
 			product_type_id,
-			seller_id
-		FROM products 
-	`
-
-	rows, err := r.db.QueryContext(ctx, query)
-	if err != nil {
-		return nil, httperrors.InternalServerError{}
+			seller_id`,
+		from:    "products",
+		columns: productListColumns,
 	}
-	defer rows.Close()
 
-	var products []models.Product
-	for rows.Next() {
+	return queryPage(ctx, r.db, query, opts, productListFields, func(rows *sql.Rows) (models.Product, error) {
 		var product models.Product
-		err = rows.Scan(
+		err := rows.Scan(
 			&product.ID,
 			&product.Description,
 			&product.ExpirationRate,
@@ -145,17 +150,8 @@ func (r *ProductRepositoryDB) GetAll(ctx context.Context) ([]models.Product, err
 			&product.ProductTypeID,
 			&product.SellerID,
 		)
-		if err != nil {
-			return nil, httperrors.InternalServerError{}
-		}
-
-		products = append(products, product)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, httperrors.InternalServerError{}
-	}
-	return products, nil
+		return product, err
+	})
 }
 
 // GetByID retrieves a single product from the database by its unique ID.
@@ -182,7 +178,7 @@ func (r *ProductRepositoryDB) GetByID(ctx context.Context, id int) (models.Produ
 
 	row := r.db.QueryRowContext(ctx, query, id)
 	if err := row.Err(); err != nil {
-		return models.Product{}, httperrors.InternalServerError{}
+		return models.Product{}, internalError(ctx, "", err)
 	}
 
 	var product models.Product
@@ -230,7 +226,7 @@ func (r *ProductRepositoryDB) GetRecordsPerProduct(ctx context.Context, id *int)
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, httperrors.InternalServerError{}
+		return nil, internalError(ctx, "", err)
 	}
 	defer rows.Close()
 
@@ -243,7 +239,7 @@ func (r *ProductRepositoryDB) GetRecordsPerProduct(ctx context.Context, id *int)
 			&productRecordCount.RecordsCount,
 		)
 		if err != nil {
-			return nil, httperrors.InternalServerError{}
+			return nil, internalError(ctx, "", err)
 		}
 		ProductsRecordsCount = append(ProductsRecordsCount, productRecordCount)
 	}
@@ -256,6 +252,76 @@ func (r *ProductRepositoryDB) GetRecordsPerProduct(ctx context.Context, id *int)
 	return ProductsRecordsCount, nil
 }
 
+// GetMargins returns the units sold, revenue and cost of every product sold in the
+// period of the filter, ordered by product ID. Orders count as sold as
+// models.IsPurchaseOrderSold says, and the cost of each line is its quantity times
+// the purchase price of the product record in effect on the order date.
+func (r *ProductRepositoryDB) GetMargins(ctx context.Context, filter models.MarginReportFilter) ([]models.ProductMargin, error) {
+	query := `
+			SELECT
+				p.id,
+				p.description,
+				p.seller_id,
+				SUM(l.quantity) AS units_sold,
+				SUM(l.quantity * l.unit_price) AS revenue,
+				COALESCE(SUM(l.quantity * (
+					SELECT pr.purchase_price
+					FROM product_records pr
+					WHERE pr.product_id = l.product_id AND pr.last_update_date <= DATE(o.order_date)
+					ORDER BY pr.last_update_date DESC, pr.id DESC
+					LIMIT 1
+				)), 0) AS cost
+			FROM products p
+			JOIN purchase_order_lines l ON l.product_id = p.id
+			JOIN purchase_orders o ON o.id = l.purchase_order_id
+			WHERE o.status IN ('confirmed', 'picked', 'shipped', 'delivered')
+		`
+
+	var args []interface{}
+	if filter.From != "" {
+		query += " AND o.order_date >= ?"
+		args = append(args, filter.From)
+	}
+	if filter.To != "" {
+		// the whole last day is included
+		query += " AND o.order_date < DATE_ADD(?, INTERVAL 1 DAY)"
+		args = append(args, filter.To)
+	}
+	if filter.SellerID != nil {
+		query += " AND p.seller_id = ?"
+		args = append(args, *filter.SellerID)
+	}
+	query += " GROUP BY p.id, p.description, p.seller_id ORDER BY p.id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	defer rows.Close()
+
+	var productMargins []models.ProductMargin
+	for rows.Next() {
+		var productMargin models.ProductMargin
+		err := rows.Scan(
+			&productMargin.ProductID,
+			&productMargin.Description,
+			&productMargin.SellerID,
+			&productMargin.UnitsSold,
+			&productMargin.Revenue,
+			&productMargin.Cost,
+		)
+		if err != nil {
+			return nil, internalError(ctx, "", err)
+		}
+		productMargins = append(productMargins, productMargin)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+
+	return productMargins, nil
+}
+
 // Update modifies an existing product record in the database. It applies all
 // fields from updatedProduct to the row identified by id.
 //
@@ -263,10 +329,36 @@ func (r *ProductRepositoryDB) GetRecordsPerProduct(ctx context.Context, id *int)
 //   - On success, returns the updated product.
 //   - If a product with the same product_code already exists (MySQL error #1062),
 //     returns httperrors.ConflictError with a message about duplicate product code.
-//   - If the provided seller_id does not exist (MySQL error #1452),
-//     returns httperrors.ConflictError about the missing seller.
+//   - If the provided seller_id or product_type_id does not exist (MySQL error #1452),
+//     returns httperrors.ConflictError about the missing seller or product type.
+//   - If batches of the product are stored in sections of another product type,
+//     returns httperrors.ConflictError, so a product type change cannot orphan them.
 //   - Any other database error is returned.
 func (r *ProductRepositoryDB) Update(ctx context.Context, id int, updatedProduct models.Product) (models.Product, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Product{}, internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	const mismatchQuery = `
+		SELECT EXISTS(
+			SELECT 1
+			FROM product_batches pb
+			JOIN sections s ON s.id = pb.section_id
+			WHERE pb.product_id = ? AND s.product_type_id <> ?
+		)
+    `
+	var mismatch bool
+	if err := tx.QueryRowContext(ctx, mismatchQuery, id, updatedProduct.ProductTypeID).Scan(&mismatch); err != nil {
+		return models.Product{}, internalError(ctx, "", err)
+	}
+	if mismatch {
+		return models.Product{}, httperrors.ConflictError{
+			Message: "Product type cannot change while the product has batches in sections of another product type.",
+		}
+	}
+
 	const query = `
 		UPDATE products
 		SET
@@ -284,7 +376,7 @@ func (r *ProductRepositoryDB) Update(ctx context.Context, id int, updatedProduct
 		WHERE id = ?
     `
 
-	_, err := r.db.ExecContext(
+	_, err = tx.ExecContext(
 		ctx,
 		query,
 		updatedProduct.Description,
@@ -310,15 +402,15 @@ func (r *ProductRepositoryDB) Update(ctx context.Context, id int, updatedProduct
 						Message: "A product with the given product code already exists",
 					}
 			case 1452:
-				return models.Product{},
-					httperrors.ConflictError{
-						Message: "The given seller id does not exists",
-					}
+				return models.Product{}, productForeignKeyError(sqlError)
 			}
 		}
-		return models.Product{}, httperrors.InternalServerError{}
+		return models.Product{}, internalError(ctx, "", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return models.Product{}, internalError(ctx, "", err)
+	}
 	return updatedProduct, nil
 }
 
@@ -339,14 +431,27 @@ func (r *ProductRepositoryDB) Delete(ctx context.Context, id int) error {
 				Message: "The product to delete is still referenced by some product records",
 			}
 		}
-		return httperrors.InternalServerError{}
+		return internalError(ctx, "", err)
 	}
 
 	count, err := res.RowsAffected()
 	if err != nil {
-		return httperrors.InternalServerError{}
+		return internalError(ctx, "", err)
 	} else if count == 0 {
 		return httperrors.NotFoundError{Message: "Product not found"}
 	}
 	return nil
 }
+
+// productForeignKeyError maps a MySQL error #1452 on products to a ConflictError
+// naming the missing seller or product type, told apart by the constraint in the message.
+func productForeignKeyError(sqlError *mysql.MySQLError) error {
+	if strings.Contains(sqlError.Message, "fk_products_product_type") {
+		return httperrors.ConflictError{
+			Message: "The given product type id does not exist",
+		}
+	}
+	return httperrors.ConflictError{
+		Message: "The given seller id does not exists",
+	}
+}
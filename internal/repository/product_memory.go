@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// ProductRepositoryMemory is an in-memory implementation of ProductRepository.
+// It enforces the same constraints as the products table: unique product_code,
+// a foreign key to product types and a nullable foreign key to sellers.
+type ProductRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// NewProductRepositoryMemory constructs a ProductRepositoryMemory backed
+// by the given store.
+func NewProductRepositoryMemory(store *MemoryStore) ProductRepository {
+	return &ProductRepositoryMemory{store: store}
+}
+
+// Create stores a new product and returns it with its generated ID.
+// Returns a ConflictError if the product code is taken or the seller or product type does not exist.
+func (r *ProductRepositoryMemory) Create(ctx context.Context, productAttributes models.ProductAttributes) (models.Product, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkConstraints(0, productAttributes); err != nil {
+		return models.Product{}, err
+	}
+
+	productAttributes.SellerID = copyIntPtr(productAttributes.SellerID)
+	newProduct := models.Product{
+		ID:                r.store.nextID("products"),
+		ProductAttributes: productAttributes,
+	}
+	r.store.products[newProduct.ID] = newProduct
+	return newProduct, nil
+}
+
+// productListFields are the fields products can be sorted and filtered by.
+var productListFields = listFields[models.Product]{
+	"id":                               func(p models.Product) any { return p.ID },
+	"description":                      func(p models.Product) any { return p.Description },
+	"expiration_rate":                  func(p models.Product) any { return p.ExpirationRate },
+	"freezing_rate":                    func(p models.Product) any { return p.FreezingRate },
+	"netweight":                        func(p models.Product) any { return p.NetWeight },
+	"product_code":                     func(p models.Product) any { return p.ProductCode },
+	"recommended_freezing_temperature": func(p models.Product) any { return p.RecommendedFreezingTemperature },
+	"product_type_id":                  func(p models.Product) any { return p.ProductTypeID },
+	"seller_id":                        func(p models.Product) any { return p.SellerID },
+}
+
+// GetAll returns the page of products described by opts.
+func (r *ProductRepositoryMemory) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Product], error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return pageOf(sortedByID(r.store.products), opts, productListFields)
+}
+
+// GetByID returns the product with the given ID or a NotFoundError.
+func (r *ProductRepositoryMemory) GetByID(ctx context.Context, id int) (models.Product, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	product, ok := r.store.products[id]
+	if !ok {
+		return models.Product{}, httperrors.NotFoundError{Message: "Product not found"}
+	}
+	return product, nil
+}
+
+// GetRecordsPerProduct returns the count of product records for each product,
+// or only for the given product when id is not nil.
+func (r *ProductRepositoryMemory) GetRecordsPerProduct(ctx context.Context, id *int) ([]models.ProductRecordCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := make(map[int]int)
+	for _, record := range r.store.productRecords {
+		counts[record.ProductID]++
+	}
+
+	var productsRecordsCount []models.ProductRecordCount
+	for _, product := range sortedByID(r.store.products) {
+		if id != nil && product.ID != *id {
+			continue
+		}
+		productsRecordsCount = append(productsRecordsCount, models.ProductRecordCount{
+			ProductID:    product.ID,
+			Description:  product.Description,
+			RecordsCount: counts[product.ID],
+		})
+	}
+
+	if id != nil && len(productsRecordsCount) == 0 {
+		return nil, httperrors.NotFoundError{Message: "Product not found"}
+	}
+	return productsRecordsCount, nil
+}
+
+// GetMargins returns the units sold, revenue and cost of every product sold in the
+// period of the filter, ordered by product ID. The cost of each line is its quantity
+// times the purchase price of the product record in effect on the order date.
+func (r *ProductRepositoryMemory) GetMargins(ctx context.Context, filter models.MarginReportFilter) ([]models.ProductMargin, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	margins := make(map[int]*models.ProductMargin)
+	for _, line := range r.store.purchaseOrderLines {
+		order := r.store.purchaseOrders[line.PurchaseOrderId]
+		if !models.IsPurchaseOrderSold(order.Status) {
+			continue
+		}
+		// Dates are YYYY-MM-DD, so they compare in calendar order as strings
+		orderDay := order.OrderDate.Format(time.DateOnly)
+		if filter.From != "" && orderDay < filter.From {
+			continue
+		}
+		if filter.To != "" && orderDay > filter.To {
+			continue
+		}
+		product, ok := r.store.products[line.ProductId]
+		if !ok || (filter.SellerID != nil && (product.SellerID == nil || *product.SellerID != *filter.SellerID)) {
+			continue
+		}
+
+		margin, ok := margins[product.ID]
+		if !ok {
+			margin = &models.ProductMargin{
+				ProductID:   product.ID,
+				Description: product.Description,
+				SellerID:    copyIntPtr(product.SellerID),
+			}
+			margins[product.ID] = margin
+		}
+		margin.UnitsSold += line.Quantity
+		margin.Revenue += float64(line.Quantity) * line.UnitPrice
+		if record, ok := r.store.effectiveProductRecord(product.ID, orderDay); ok {
+			margin.Cost += float64(line.Quantity) * record.PurchasePrice
+		}
+	}
+
+	var productMargins []models.ProductMargin
+	for _, product := range sortedByID(r.store.products) {
+		if margin, ok := margins[product.ID]; ok {
+			productMargins = append(productMargins, *margin)
+		}
+	}
+	return productMargins, nil
+}
+
+// Update replaces the stored product with updatedProduct.
+// Like the SQL implementation, updating a missing id is not an error, and a product type
+// that no longer matches the sections of its batches is a ConflictError.
+func (r *ProductRepositoryMemory) Update(ctx context.Context, id int, updatedProduct models.Product) (models.Product, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkConstraints(id, updatedProduct.ProductAttributes); err != nil {
+		return models.Product{}, err
+	}
+	for _, batch := range r.store.productBatches {
+		if batch.ProductID == id && r.store.sections[batch.SectionID].ProductTypeID != updatedProduct.ProductTypeID {
+			return models.Product{}, httperrors.ConflictError{
+				Message: "Product type cannot change while the product has batches in sections of another product type.",
+			}
+		}
+	}
+
+	if _, ok := r.store.products[id]; ok {
+		stored := updatedProduct
+		stored.ID = id
+		stored.SellerID = copyIntPtr(updatedProduct.SellerID)
+		r.store.products[id] = stored
+	}
+	return updatedProduct, nil
+}
+
+// Delete removes the product with the given ID.
+// Returns a ConflictError if records, batches or purchase order lines still reference it.
+func (r *ProductRepositoryMemory) Delete(ctx context.Context, id int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.products[id]; !ok {
+		return httperrors.NotFoundError{Message: "Product not found"}
+	}
+
+	for _, record := range r.store.productRecords {
+		if record.ProductID == id {
+			return httperrors.ConflictError{
+				Message: "The product to delete is still referenced by some product records",
+			}
+		}
+	}
+	for _, batch := range r.store.productBatches {
+		if batch.ProductID == id {
+			return httperrors.ConflictError{
+				Message: "The product to delete is still referenced by some product records",
+			}
+		}
+	}
+	for _, line := range r.store.purchaseOrderLines {
+		if line.ProductId == id {
+			return httperrors.ConflictError{
+				Message: "The product to delete is still referenced by some product records",
+			}
+		}
+	}
+
+	delete(r.store.products, id)
+	return nil
+}
+
+// checkConstraints validates the unique product_code and the seller and product type
+// foreign keys for the product identified by id (0 for a new product).
+// The caller must hold the lock.
+func (r *ProductRepositoryMemory) checkConstraints(id int, attributes models.ProductAttributes) error {
+	for _, product := range r.store.products {
+		if product.ID != id && product.ProductCode == attributes.ProductCode {
+			return httperrors.ConflictError{
+				Message: "A product with the given product code already exists",
+			}
+		}
+	}
+
+	if _, ok := r.store.productTypes[attributes.ProductTypeID]; !ok {
+		return httperrors.ConflictError{
+			Message: "The given product type id does not exist",
+		}
+	}
+	if attributes.SellerID != nil {
+		if _, ok := r.store.sellers[*attributes.SellerID]; !ok {
+			return httperrors.ConflictError{
+				Message: "The given seller id does not exists",
+			}
+		}
+	}
+	return nil
+}
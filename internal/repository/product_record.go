@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
@@ -68,3 +69,91 @@ func (r *ProductRecordRepositoryDB) Create(ctx context.Context, attributes model
 	}
 	return newProductRecord, nil
 }
+
+// productRecordColumns lists the columns read into a models.ProductRecord, dates formatted as YYYY-MM-DD
+const productRecordColumns = `
+            id,
+            DATE_FORMAT(last_update_date, '%Y-%m-%d'),
+            purchase_price,
+            sale_price,
+            product_id`
+
+// effectiveProductRecordQuery selects the record of a product in effect on a date,
+// the latest one updated on or before it. Ties go to the newest record.
+const effectiveProductRecordQuery = "SELECT" + productRecordColumns + `
+        FROM product_records
+        WHERE product_id = ? AND last_update_date <= ?
+        ORDER BY last_update_date DESC, id DESC
+        LIMIT 1`
+
+// scanProductRecord reads a row selected with productRecordColumns
+func scanProductRecord(row interface{ Scan(dest ...any) error }) (models.ProductRecord, error) {
+	var productRecord models.ProductRecord
+	err := row.Scan(
+		&productRecord.ID,
+		&productRecord.LastUpdateDate,
+		&productRecord.PurchasePrice,
+		&productRecord.SalePrice,
+		&productRecord.ProductID,
+	)
+	return productRecord, err
+}
+
+// productRecordListColumns are the fields product records can be sorted by
+var productRecordListColumns = listColumns{
+	"id":               "id",
+	"last_update_date": "last_update_date",
+	"purchase_price":   "purchase_price",
+	"sale_price":       "sale_price",
+}
+
+// GetAll returns the page of the price history of filter.ProductID.
+// Returns a NotFoundError if the product does not exist.
+func (r *ProductRecordRepositoryDB) GetAll(ctx context.Context, filter models.ProductRecordFilter) (models.Page[models.ProductRecord], error) {
+	const existsQuery = `SELECT EXISTS(SELECT 1 FROM products WHERE id = ?)`
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, existsQuery, filter.ProductID).Scan(&exists); err != nil {
+		return models.Page[models.ProductRecord]{}, internalError(ctx, "", err)
+	}
+	if !exists {
+		return models.Page[models.ProductRecord]{}, httperrors.NotFoundError{Message: "Product not found"}
+	}
+
+	query := listQuery{
+		selectColumns: productRecordColumns,
+		from:          "product_records",
+		columns:       productRecordListColumns,
+		conditions:    []string{"product_id = ?"},
+		args:          []any{filter.ProductID},
+	}
+	if filter.DateFrom != "" {
+		query.conditions = append(query.conditions, "last_update_date >= ?")
+		query.args = append(query.args, filter.DateFrom)
+	}
+	if filter.DateTo != "" {
+		query.conditions = append(query.conditions, "last_update_date <= ?")
+		query.args = append(query.args, filter.DateTo)
+	}
+
+	return queryPage(ctx, r.db, query, filter.QueryOptions, productRecordListFields, func(rows *sql.Rows) (models.ProductRecord, error) {
+		return scanProductRecord(rows)
+	})
+}
+
+// GetEffective returns the record of the product in effect on the date at (YYYY-MM-DD),
+// the latest one updated on or before it. Returns a NotFoundError if there is none.
+func (r *ProductRecordRepositoryDB) GetEffective(ctx context.Context, productID int, at string) (models.ProductRecord, error) {
+	productRecord, err := scanProductRecord(r.db.QueryRowContext(ctx, effectiveProductRecordQuery, productID, at))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ProductRecord{}, httperrors.NotFoundError{Message: noEffectiveRecordMessage(productID, at)}
+		}
+		return models.ProductRecord{}, internalError(ctx, "", err)
+	}
+	return productRecord, nil
+}
+
+// noEffectiveRecordMessage describes a product without a record in effect on the date at
+func noEffectiveRecordMessage(productID int, at string) string {
+	return fmt.Sprintf("Product %d has no price on %s", productID, at)
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// ProductRecordRepositoryMemory is an in-memory implementation of
+// ProductRecordRepository.
+type ProductRecordRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// NewProductRecordRepositoryMemory constructs a ProductRecordRepositoryMemory
+// backed by the given store.
+func NewProductRecordRepositoryMemory(store *MemoryStore) ProductRecordRepository {
+	return &ProductRecordRepositoryMemory{store: store}
+}
+
+// Create stores a new product record and returns it with its generated ID.
+// Returns a ConflictError if the referenced product does not exist.
+func (r *ProductRecordRepositoryMemory) Create(ctx context.Context, attributes models.ProductRecordAttributes) (models.ProductRecord, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.products[attributes.ProductID]; !ok {
+		return models.ProductRecord{},
+			httperrors.ConflictError{Message: "a product with the given id does not exist"}
+	}
+
+	newProductRecord := models.ProductRecord{
+		ID:                      r.store.nextID("product_records"),
+		ProductRecordAttributes: attributes,
+	}
+	r.store.productRecords[newProductRecord.ID] = newProductRecord
+	return newProductRecord, nil
+}
+
+// productRecordListFields are the fields product records can be sorted by
+var productRecordListFields = listFields[models.ProductRecord]{
+	"id":               func(p models.ProductRecord) any { return p.ID },
+	"last_update_date": func(p models.ProductRecord) any { return p.LastUpdateDate },
+	"purchase_price":   func(p models.ProductRecord) any { return p.PurchasePrice },
+	"sale_price":       func(p models.ProductRecord) any { return p.SalePrice },
+}
+
+// GetAll returns the page of the price history of filter.ProductID.
+// Returns a NotFoundError if the product does not exist.
+func (r *ProductRecordRepositoryMemory) GetAll(ctx context.Context, filter models.ProductRecordFilter) (models.Page[models.ProductRecord], error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if _, ok := r.store.products[filter.ProductID]; !ok {
+		return models.Page[models.ProductRecord]{}, httperrors.NotFoundError{Message: "Product not found"}
+	}
+
+	var productRecords []models.ProductRecord
+	for _, record := range sortedByID(r.store.productRecords) {
+		if record.ProductID != filter.ProductID {
+			continue
+		}
+		// Dates are YYYY-MM-DD, so they compare in calendar order as strings
+		if filter.DateFrom != "" && record.LastUpdateDate < filter.DateFrom {
+			continue
+		}
+		if filter.DateTo != "" && record.LastUpdateDate > filter.DateTo {
+			continue
+		}
+		productRecords = append(productRecords, record)
+	}
+	return pageOf(productRecords, filter.QueryOptions, productRecordListFields)
+}
+
+// GetEffective returns the record of the product in effect on the date at (YYYY-MM-DD),
+// the latest one updated on or before it. Returns a NotFoundError if there is none.
+func (r *ProductRecordRepositoryMemory) GetEffective(ctx context.Context, productID int, at string) (models.ProductRecord, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	productRecord, ok := r.store.effectiveProductRecord(productID, at)
+	if !ok {
+		return models.ProductRecord{}, httperrors.NotFoundError{Message: noEffectiveRecordMessage(productID, at)}
+	}
+	return productRecord, nil
+}
+
+// effectiveProductRecord returns the latest record of the product updated on or before at.
+// Ties go to the newest record. The caller must hold the lock.
+func (s *MemoryStore) effectiveProductRecord(productID int, at string) (models.ProductRecord, bool) {
+	var effective models.ProductRecord
+	found := false
+	for _, record := range s.productRecords {
+		// Dates are YYYY-MM-DD, so they order as strings
+		if record.ProductID != productID || record.LastUpdateDate > at {
+			continue
+		}
+		if !found || record.LastUpdateDate > effective.LastUpdateDate ||
+			(record.LastUpdateDate == effective.LastUpdateDate && record.ID > effective.ID) {
+			effective = record
+			found = true
+		}
+	}
+	return effective, found
+}
@@ -0,0 +1,122 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductRecordRepositoryDB_GetAll(t *testing.T) {
+	columns := []string{"id", "last_update_date", "purchase_price", "sale_price", "product_id"}
+
+	t.Run("returns the records of the product within the date range", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM products WHERE id = \\?\\)").
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM product_records WHERE product_id = \\? AND last_update_date >= \\? AND last_update_date <= \\?").
+			WithArgs(4, "2024-01-01", "2024-06-30").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectQuery("FROM product_records WHERE product_id = \\? AND last_update_date >= \\? AND last_update_date <= \\? ORDER BY last_update_date DESC, id DESC").
+			WithArgs(4, "2024-01-01", "2024-06-30", 51).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(7, "2024-05-01", 10, 14, 4).
+				AddRow(3, "2024-02-01", 9, 12, 4))
+
+		repo := repository.NewProductRecordRepositoryDB(db)
+
+		// act
+		got, err := repo.GetAll(context.Background(), models.ProductRecordFilter{
+			QueryOptions: models.QueryOptions{Limit: 50, Sort: "last_update_date", Desc: true},
+			ProductID:    4,
+			DateFrom:     "2024-01-01",
+			DateTo:       "2024-06-30",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, 2, got.Meta.Total)
+		assert.Equal(t, []models.ProductRecord{
+			{ID: 7, ProductRecordAttributes: models.ProductRecordAttributes{LastUpdateDate: "2024-05-01", PurchasePrice: 10, SalePrice: 14, ProductID: 4}},
+			{ID: 3, ProductRecordAttributes: models.ProductRecordAttributes{LastUpdateDate: "2024-02-01", PurchasePrice: 9, SalePrice: 12, ProductID: 4}},
+		}, got.Data)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing product return NotFoundError", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT EXISTS").
+			WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		repo := repository.NewProductRecordRepositoryDB(db)
+
+		// act
+		_, err = repo.GetAll(context.Background(), models.ProductRecordFilter{ProductID: 9})
+
+		// assert
+		assert.Equal(t, httperrors.NotFoundError{Message: "Product not found"}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestProductRecordRepositoryDB_GetEffective(t *testing.T) {
+	columns := []string{"id", "last_update_date", "purchase_price", "sale_price", "product_id"}
+	query := "FROM product_records WHERE product_id = \\? AND last_update_date <= \\? ORDER BY last_update_date DESC, id DESC LIMIT 1"
+
+	t.Run("returns the latest record on or before the date", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(query).
+			WithArgs(4, "2024-03-15").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(3, "2024-02-01", 9, 12, 4))
+
+		repo := repository.NewProductRecordRepositoryDB(db)
+
+		// act
+		got, err := repo.GetEffective(context.Background(), 4, "2024-03-15")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, 3, got.ID)
+		assert.Equal(t, 12.0, got.SalePrice)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no record before the date return NotFoundError", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(query).
+			WithArgs(4, "2023-12-31").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		repo := repository.NewProductRecordRepositoryDB(db)
+
+		// act
+		_, err = repo.GetEffective(context.Background(), 4, "2023-12-31")
+
+		// assert
+		assert.Equal(t, httperrors.NotFoundError{Message: "Product 4 has no price on 2023-12-31"}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
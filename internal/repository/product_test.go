@@ -123,6 +123,19 @@ func TestProductRepository_Create(t *testing.T) {
 				Message: "The given seller id does not exists",
 			},
 		},
+		{
+			testName:          "Error case: Non-existent product type ID (1452)",
+			productAttributes: newProductAttributes,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.
+					ExpectExec(query).
+					WillReturnError(&mysql.MySQLError{Number: 1452, Message: "a foreign key constraint fails (CONSTRAINT `fk_products_product_type`)"})
+			},
+			expectedResp: models.Product{},
+			expectedError: httperrors.ConflictError{
+				Message: "The given product type id does not exist",
+			},
+		},
 		{
 			testName:          "Error case: Internal Server Error on other MySQL error",
 			productAttributes: newProductAttributes,
@@ -172,7 +185,9 @@ func TestProductRepository_Create(t *testing.T) {
 }
 
 // Verifies the behavior of the repository layer responsible for fetching all Products. It covers:
-//   - Successful retrieval of all rows and mapping into []Product.
+//   - Successful retrieval of all rows and mapping into a Page of Product.
+//   - Filters, sorting and limit turned into parameterized SQL.
+//   - BadRequestError for an unknown sort field.
 //   - InternalServerError when the SQL query itself fails.
 //   - InternalServerError when scanning a row into the Product struct fails.
 //   - InternalServerError when rows iteration (rows.Err()) fails.
@@ -194,6 +209,10 @@ func TestProductRepository_GetAll(t *testing.T) {
 			seller_id
 		FROM products 
 	`)
+	countQuery := regexp.QuoteMeta("SELECT COUNT(*) FROM products")
+	countRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2)
+	}
 
 	columns := []string{
 		"id",
@@ -248,17 +267,22 @@ func TestProductRepository_GetAll(t *testing.T) {
 	// Each test case is constructed by:
 	//   testName          – a human‐readable description
 	//   mockSetup         – sets up sqlmock expectations and returned results/errors
-	//   expectedResp      – the Products slice we expect GetAll() to return
+	//   opts              – the pagination, sorting and filters passed to GetAll()
+	//   expectedResp      – the Products page we expect GetAll() to return
 	//   expectedError     – the error we expect GetAll() to return
 	tests := []struct {
 		testName      string
+		opts          models.QueryOptions
 		mockSetup     func(mock sqlmock.Sqlmock)
-		expectedResp  []models.Product
+		expectedResp  models.Page[models.Product]
 		expectedError error
 	}{
 		{
 			testName: "Success: Should return all products",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.
+					ExpectQuery(countQuery).
+					WillReturnRows(countRows())
 				rows := sqlmock.
 					NewRows(columns).
 					AddRow(
@@ -293,22 +317,122 @@ func TestProductRepository_GetAll(t *testing.T) {
 					ExpectQuery(query).
 					WillReturnRows(rows)
 			},
-			expectedResp:  products,
+			expectedResp:  models.NewPage(products, 2, nil),
 			expectedError: nil,
 		},
+		{
+			testName: "Success: Should return a filtered and sorted page of products",
+			opts: models.QueryOptions{
+				Limit:   1,
+				Sort:    "product_code",
+				Desc:    true,
+				Filters: map[string]string{"seller_id": "1"},
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.
+					ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM products WHERE seller_id = ?")).
+					WithArgs("1").
+					WillReturnRows(countRows())
+				rows := sqlmock.
+					NewRows(columns).
+					AddRow(
+						products[0].ID,
+						products[0].Description,
+						products[0].ExpirationRate,
+						products[0].FreezingRate,
+						products[0].Height,
+						products[0].Length,
+						products[0].Width,
+						products[0].NetWeight,
+						products[0].ProductCode,
+						products[0].RecommendedFreezingTemperature,
+						products[0].ProductTypeID,
+						products[0].SellerID,
+					).
+					AddRow(
+						products[1].ID,
+						products[1].Description,
+						products[1].ExpirationRate,
+						products[1].FreezingRate,
+						products[1].Height,
+						products[1].Length,
+						products[1].Width,
+						products[1].NetWeight,
+						products[1].ProductCode,
+						products[1].RecommendedFreezingTemperature,
+						products[1].ProductTypeID,
+						products[1].SellerID,
+					)
+				mock.
+					ExpectQuery(query+regexp.QuoteMeta(" WHERE seller_id = ? ORDER BY product_code DESC, id DESC LIMIT ?")).
+					WithArgs("1", 2).
+					WillReturnRows(rows)
+			},
+			expectedResp:  models.NewPage(products[:1], 2, &models.Cursor{Sort: "product_code", Value: products[0].ProductCode, ID: products[0].ID}),
+			expectedError: nil,
+		},
+		{
+			testName: "Success: Should continue after a product without seller",
+			opts: models.QueryOptions{
+				Limit: 1,
+				Sort:  "seller_id",
+				After: &models.Cursor{Sort: "seller_id", Value: nil, ID: 2},
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.
+					ExpectQuery(countQuery).
+					WillReturnRows(countRows())
+				rows := sqlmock.
+					NewRows(columns).
+					AddRow(
+						products[0].ID,
+						products[0].Description,
+						products[0].ExpirationRate,
+						products[0].FreezingRate,
+						products[0].Height,
+						products[0].Length,
+						products[0].Width,
+						products[0].NetWeight,
+						products[0].ProductCode,
+						products[0].RecommendedFreezingTemperature,
+						products[0].ProductTypeID,
+						products[0].SellerID,
+					)
+				// NULLs sort first, so the rest of the NULLs come before every seller
+				mock.
+					ExpectQuery(query+regexp.QuoteMeta(" WHERE ((seller_id IS NULL AND id > ?) OR seller_id IS NOT NULL) ORDER BY seller_id ASC, id ASC LIMIT ?")).
+					WithArgs(2, 2).
+					WillReturnRows(rows)
+			},
+			expectedResp:  models.NewPage(products[:1], 2, nil),
+			expectedError: nil,
+		},
+		{
+			testName:      "Error case: Bad Request on unknown sort field",
+			opts:          models.QueryOptions{Sort: "height"},
+			mockSetup:     func(mock sqlmock.Sqlmock) {},
+			expectedResp:  models.Page[models.Product]{},
+			expectedError: httperrors.BadRequestError{Message: "Invalid sort field"},
+		},
 		{
 			testName: "Error case: Internal Server Error on query error",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.
+					ExpectQuery(countQuery).
+					WillReturnRows(countRows())
 				mock.
 					ExpectQuery(query).
 					WillReturnError(errors.New("db query error"))
 			},
-			expectedResp:  nil,
+			expectedResp:  models.Page[models.Product]{},
 			expectedError: httperrors.InternalServerError{},
 		},
 		{
 			testName: "Error case: Internal Server Error on scan error",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.
+					ExpectQuery(countQuery).
+					WillReturnRows(countRows())
 				rows := sqlmock.
 					NewRows(columns).
 					AddRow(
@@ -330,12 +454,15 @@ func TestProductRepository_GetAll(t *testing.T) {
 					ExpectQuery(query).
 					WillReturnRows(rows)
 			},
-			expectedResp:  nil,
+			expectedResp:  models.Page[models.Product]{},
 			expectedError: httperrors.InternalServerError{},
 		},
 		{
 			testName: "Error case: Internal Server Error on rows error",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.
+					ExpectQuery(countQuery).
+					WillReturnRows(countRows())
 				rows := sqlmock.
 					NewRows(columns).
 					AddRow(
@@ -358,7 +485,7 @@ func TestProductRepository_GetAll(t *testing.T) {
 					ExpectQuery(query).
 					WillReturnRows(rows)
 			},
-			expectedResp:  nil,
+			expectedResp:  models.Page[models.Product]{},
 			expectedError: httperrors.InternalServerError{},
 		},
 	}
@@ -374,7 +501,7 @@ func TestProductRepository_GetAll(t *testing.T) {
 			tc.mockSetup(mock)
 
 			// Act
-			result, err := repo.GetAll(context.Background())
+			result, err := repo.GetAll(context.Background(), tc.opts)
 
 			// Assert
 			require.Equal(t, tc.expectedError, err)
@@ -696,6 +823,71 @@ func TestProductRepository_GetRecordPerProduct(t *testing.T) {
 	}
 }
 
+// Verifies the behavior of the repository layer responsible for the units sold, revenue and cost of products. It covers:
+//   - Successful retrieval over a period of the products of a seller.
+//   - InternalServerError on query error.
+func TestProductRepository_GetMargins(t *testing.T) {
+	// Define the columns and filter used by the test cases
+	columns := []string{"id", "description", "seller_id", "units_sold", "revenue", "cost"}
+	filter := models.MarginReportFilter{From: "2024-01-01", To: "2024-01-31", SellerID: utils.Ptr(2)}
+	query := `FROM products p JOIN purchase_order_lines l ON l.product_id = p.id JOIN purchase_orders o ON o.id = l.purchase_order_id ` +
+		`WHERE o.status IN \('confirmed', 'picked', 'shipped', 'delivered'\) ` +
+		`AND o.order_date >= \? AND o.order_date < DATE_ADD\(\?, INTERVAL 1 DAY\) AND p.seller_id = \? ` +
+		`GROUP BY p.id, p.description, p.seller_id ORDER BY p.id`
+
+	tests := []struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock)
+		expectedResp  []models.ProductMargin
+		expectedError error
+	}{
+		{
+			testName: "Success: Should return the margins of the products of the seller",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.
+					ExpectQuery(query).
+					WithArgs("2024-01-01", "2024-01-31", 2).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "Yogurt helado", 2, 10, 100.0, 75.0).
+						AddRow(4, "Yogurt natural", 2, 5, 60.0, 25.0))
+			},
+			expectedResp: []models.ProductMargin{
+				{ProductID: 1, Description: "Yogurt helado", SellerID: utils.Ptr(2), UnitsSold: 10, Revenue: 100, Cost: 75},
+				{ProductID: 4, Description: "Yogurt natural", SellerID: utils.Ptr(2), UnitsSold: 5, Revenue: 60, Cost: 25},
+			},
+		},
+		{
+			testName: "Error case: Internal Server Error on query error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.
+					ExpectQuery(query).
+					WillReturnError(errors.New("query error"))
+			},
+			expectedError: httperrors.InternalServerError{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Arrange
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := NewProductRepositoryDB(db)
+			tc.mockSetup(mock)
+
+			// Act
+			result, err := repo.GetMargins(context.Background(), filter)
+
+			// Assert
+			require.Equal(t, tc.expectedError, err)
+			require.Equal(t, tc.expectedResp, result)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 // Verifies the behavior of the repository layer responsible for updating an existing Product. It covers:
 //   - Successful update of a Product.
 //   - Conflict when the given product_code is duplicated (MySQL error 1062).
@@ -719,6 +911,12 @@ func TestProductRepository_Update(t *testing.T) {
 			seller_id                           = ?
 		WHERE id = ?
     `)
+	mismatchQuery := `SELECT EXISTS\(\s*SELECT 1\s*FROM product_batches pb\s*JOIN sections s ON s.id = pb.section_id\s*WHERE pb.product_id = \? AND s.product_type_id <> \?\s*\)`
+	expectTypeCheck := func(mock sqlmock.Sqlmock, mismatch bool) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(mismatchQuery).WithArgs(1, 7).
+			WillReturnRows(sqlmock.NewRows([]string{"mismatch"}).AddRow(mismatch))
+	}
 
 	updatedProduct := models.Product{
 		ID: 1,
@@ -757,6 +955,7 @@ func TestProductRepository_Update(t *testing.T) {
 			id:             inputID,
 			updatedProduct: updatedProduct,
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTypeCheck(mock, false)
 				mock.
 					ExpectExec(query).
 					WithArgs(
@@ -774,6 +973,7 @@ func TestProductRepository_Update(t *testing.T) {
 						inputID,
 					).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			expectedResp:  updatedProduct,
 			expectedError: nil,
@@ -783,9 +983,11 @@ func TestProductRepository_Update(t *testing.T) {
 			id:             inputID,
 			updatedProduct: updatedProduct,
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTypeCheck(mock, false)
 				mock.
 					ExpectExec(query).
 					WillReturnError(&mysql.MySQLError{Number: 1062})
+				mock.ExpectRollback()
 			},
 			expectedResp: models.Product{},
 			expectedError: httperrors.ConflictError{
@@ -797,23 +999,40 @@ func TestProductRepository_Update(t *testing.T) {
 			id:             inputID,
 			updatedProduct: updatedProduct,
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTypeCheck(mock, false)
 				mock.
 					ExpectExec(query).
 					WillReturnError(&mysql.MySQLError{Number: 1452})
+				mock.ExpectRollback()
 			},
 			expectedResp: models.Product{},
 			expectedError: httperrors.ConflictError{
 				Message: "The given seller id does not exists",
 			},
 		},
+		{
+			testName:       "Fail: Product type change orphans batches",
+			id:             inputID,
+			updatedProduct: updatedProduct,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTypeCheck(mock, true)
+				mock.ExpectRollback()
+			},
+			expectedResp: models.Product{},
+			expectedError: httperrors.ConflictError{
+				Message: "Product type cannot change while the product has batches in sections of another product type.",
+			},
+		},
 		{
 			testName:       "Fail: Internal Server Error on other error",
 			id:             inputID,
 			updatedProduct: updatedProduct,
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTypeCheck(mock, false)
 				mock.
 					ExpectExec(query).
 					WillReturnError(errors.New("any database error"))
+				mock.ExpectRollback()
 			},
 			expectedResp:  models.Product{},
 			expectedError: httperrors.InternalServerError{},
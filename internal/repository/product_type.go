@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-sql-driver/mysql"
+)
+
+// ProductTypeRepositoryDB is a SQL implementation of ProductTypeRepository
+// over the product_types table.
+type ProductTypeRepositoryDB struct {
+	db *sql.DB
+}
+
+// NewProductTypeRepositoryDB constructs a ProductTypeRepositoryDB that uses
+// the given *sql.DB for all data operations.
+func NewProductTypeRepositoryDB(db *sql.DB) ProductTypeRepository {
+	return &ProductTypeRepositoryDB{db: db}
+}
+
+// Create inserts a new product type and returns it with its generated ID.
+// Returns a ConflictError if the description is already in use.
+func (r *ProductTypeRepositoryDB) Create(ctx context.Context, productTypeAttributes models.ProductTypeAttributes) (models.ProductType, error) {
+	const query = `INSERT INTO product_types (description) VALUES (?)`
+
+	result, err := r.db.ExecContext(ctx, query, productTypeAttributes.Description)
+	if err != nil {
+		var sqlError *mysql.MySQLError
+		if errors.As(err, &sqlError) && sqlError.Number == 1062 {
+			return models.ProductType{}, httperrors.ConflictError{Message: "Product type description already exists."}
+		}
+		return models.ProductType{}, internalError(ctx, "", err)
+	}
+
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		return models.ProductType{}, internalError(ctx, "", err)
+	}
+	return models.ProductType{ID: int(lastId), ProductTypeAttributes: productTypeAttributes}, nil
+}
+
+// productTypeListColumns are the fields product types can be sorted and filtered by.
+var productTypeListColumns = listColumns{
+	"id":          "id",
+	"description": "description",
+}
+
+// GetAll returns the page of product types described by opts.
+func (r *ProductTypeRepositoryDB) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.ProductType], error) {
+	query := listQuery{
+		selectColumns: "id, description",
+		from:          "product_types",
+		columns:       productTypeListColumns,
+	}
+
+	return queryPage(ctx, r.db, query, opts, productTypeListFields, func(rows *sql.Rows) (models.ProductType, error) {
+		var productType models.ProductType
+		err := rows.Scan(&productType.ID, &productType.Description)
+		return productType, err
+	})
+}
+
+// GetByID returns the product type with the given ID or a NotFoundError.
+func (r *ProductTypeRepositoryDB) GetByID(ctx context.Context, id int) (models.ProductType, error) {
+	const query = `SELECT id, description FROM product_types WHERE id = ?`
+
+	var productType models.ProductType
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&productType.ID, &productType.Description)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ProductType{}, httperrors.NotFoundError{Message: "Product type not found"}
+		}
+		return models.ProductType{}, internalError(ctx, "", err)
+	}
+	return productType, nil
+}
+
+// Update stores the new attributes of the product type.
+// Returns a ConflictError if the description is already in use.
+func (r *ProductTypeRepositoryDB) Update(ctx context.Context, id int, productType models.ProductType) (models.ProductType, error) {
+	const query = `UPDATE product_types SET description = ? WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, productType.Description, id)
+	if err != nil {
+		var sqlError *mysql.MySQLError
+		if errors.As(err, &sqlError) && sqlError.Number == 1062 {
+			return models.ProductType{}, httperrors.ConflictError{Message: "Product type description already exists."}
+		}
+		return models.ProductType{}, internalError(ctx, "", err)
+	}
+	return productType, nil
+}
+
+// Delete removes the product type with the given ID.
+// Returns a ConflictError if products or sections still reference it.
+func (r *ProductTypeRepositoryDB) Delete(ctx context.Context, id int) error {
+	const query = `DELETE FROM product_types WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		var sqlError *mysql.MySQLError
+		if errors.As(err, &sqlError) && sqlError.Number == 1451 {
+			return httperrors.ConflictError{Message: "Product type is still referenced by products or sections."}
+		}
+		return internalError(ctx, "", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return internalError(ctx, "", err)
+	} else if count == 0 {
+		return httperrors.NotFoundError{Message: "Product type not found"}
+	}
+	return nil
+}
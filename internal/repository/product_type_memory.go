@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// ProductTypeRepositoryMemory is an in-memory implementation of ProductTypeRepository.
+type ProductTypeRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// NewProductTypeRepositoryMemory constructs a ProductTypeRepositoryMemory backed
+// by the given store.
+func NewProductTypeRepositoryMemory(store *MemoryStore) ProductTypeRepository {
+	return &ProductTypeRepositoryMemory{store: store}
+}
+
+// Create stores a new product type and returns it with its generated ID.
+// Returns a ConflictError if the description is already in use.
+func (r *ProductTypeRepositoryMemory) Create(ctx context.Context, productTypeAttributes models.ProductTypeAttributes) (models.ProductType, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkConstraints(0, productTypeAttributes); err != nil {
+		return models.ProductType{}, err
+	}
+
+	productType := models.ProductType{
+		ID:                    r.store.nextID("product_types"),
+		ProductTypeAttributes: productTypeAttributes,
+	}
+	r.store.productTypes[productType.ID] = productType
+	return productType, nil
+}
+
+// productTypeListFields are the fields product types can be sorted and filtered by.
+var productTypeListFields = listFields[models.ProductType]{
+	"id":          func(p models.ProductType) any { return p.ID },
+	"description": func(p models.ProductType) any { return p.Description },
+}
+
+// GetAll returns the page of product types described by opts.
+func (r *ProductTypeRepositoryMemory) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.ProductType], error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return pageOf(sortedByID(r.store.productTypes), opts, productTypeListFields)
+}
+
+// GetByID returns the product type with the given ID or a NotFoundError.
+func (r *ProductTypeRepositoryMemory) GetByID(ctx context.Context, id int) (models.ProductType, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	productType, ok := r.store.productTypes[id]
+	if !ok {
+		return models.ProductType{}, httperrors.NotFoundError{Message: "Product type not found"}
+	}
+	return productType, nil
+}
+
+// Update replaces the stored product type.
+// Like the SQL implementation, updating a missing id is not an error.
+func (r *ProductTypeRepositoryMemory) Update(ctx context.Context, id int, productType models.ProductType) (models.ProductType, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkConstraints(id, productType.ProductTypeAttributes); err != nil {
+		return models.ProductType{}, err
+	}
+
+	if _, ok := r.store.productTypes[id]; ok {
+		stored := productType
+		stored.ID = id
+		r.store.productTypes[id] = stored
+	}
+	return productType, nil
+}
+
+// Delete removes the product type with the given ID.
+// Returns a ConflictError if products or sections still reference it.
+func (r *ProductTypeRepositoryMemory) Delete(ctx context.Context, id int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.productTypes[id]; !ok {
+		return httperrors.NotFoundError{Message: "Product type not found"}
+	}
+	inUse := httperrors.ConflictError{Message: "Product type is still referenced by products or sections."}
+	for _, product := range r.store.products {
+		if product.ProductTypeID == id {
+			return inUse
+		}
+	}
+	for _, section := range r.store.sections {
+		if section.ProductTypeID == id {
+			return inUse
+		}
+	}
+
+	delete(r.store.productTypes, id)
+	return nil
+}
+
+// checkConstraints validates the unique description of the product type
+// identified by id (0 for a new product type).
+// The caller must hold the lock.
+func (r *ProductTypeRepositoryMemory) checkConstraints(id int, attributes models.ProductTypeAttributes) error {
+	for _, productType := range r.store.productTypes {
+		if productType.ID != id && productType.Description == attributes.Description {
+			return httperrors.ConflictError{Message: "Product type description already exists."}
+		}
+	}
+	return nil
+}
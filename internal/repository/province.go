@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-sql-driver/mysql"
+)
+
+// ProvinceRepositoryDB is a SQL implementation of ProvinceRepository
+// over the provinces table.
+// Delete and the reports are the ones of regionDB.
+type ProvinceRepositoryDB struct {
+	regionDB
+}
+
+// NewProvinceRepositoryDB constructs a ProvinceRepositoryDB that uses
+// the given *sql.DB for all data operations.
+func NewProvinceRepositoryDB(db *sql.DB) ProvinceRepository {
+	return &ProvinceRepositoryDB{regionDB{
+		db:         db,
+		table:      "provinces",
+		nameColumn: "province_name",
+		notFound:   "Province not found",
+		referenced: "Province is still referenced by localities.",
+	}}
+}
+
+// provinceWriteError maps the MySQL errors of an insert or update of a province.
+func provinceWriteError(ctx context.Context, err error) error {
+	var sqlError *mysql.MySQLError
+	if errors.As(err, &sqlError) {
+		switch sqlError.Number {
+		case 1062:
+			return httperrors.ConflictError{Message: "Province name already exists in the country."}
+		case 1452:
+			return httperrors.ConflictError{Message: "Country does not exist"}
+		}
+	}
+	return internalError(ctx, "", err)
+}
+
+// Create inserts a new province and returns it with its generated ID.
+// Returns a ConflictError if the country does not exist or already has a province with that name.
+func (r *ProvinceRepositoryDB) Create(ctx context.Context, provinceAttributes models.ProvinceAttributes) (models.Province, error) {
+	const query = `INSERT INTO provinces (province_name, country_id) VALUES (?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, provinceAttributes.ProvinceName, provinceAttributes.CountryID)
+	if err != nil {
+		return models.Province{}, provinceWriteError(ctx, err)
+	}
+
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		return models.Province{}, internalError(ctx, "", err)
+	}
+	return models.Province{ID: int(lastId), ProvinceAttributes: provinceAttributes}, nil
+}
+
+// provinceListColumns are the fields provinces can be sorted and filtered by.
+var provinceListColumns = listColumns{
+	"id":            "id",
+	"province_name": "province_name",
+	"country_id":    "country_id",
+}
+
+// GetAll returns the page of provinces described by opts.
+func (r *ProvinceRepositoryDB) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Province], error) {
+	query := listQuery{
+		selectColumns: "id, province_name, country_id",
+		from:          "provinces",
+		columns:       provinceListColumns,
+	}
+
+	return queryPage(ctx, r.db, query, opts, provinceListFields, func(rows *sql.Rows) (models.Province, error) {
+		var province models.Province
+		err := rows.Scan(&province.ID, &province.ProvinceName, &province.CountryID)
+		return province, err
+	})
+}
+
+// GetByID returns the province with the given ID or a NotFoundError.
+func (r *ProvinceRepositoryDB) GetByID(ctx context.Context, id int) (models.Province, error) {
+	const query = `SELECT id, province_name, country_id FROM provinces WHERE id = ?`
+
+	var province models.Province
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&province.ID, &province.ProvinceName, &province.CountryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Province{}, httperrors.NotFoundError{Message: "Province not found"}
+		}
+		return models.Province{}, internalError(ctx, "", err)
+	}
+	return province, nil
+}
+
+// Update stores the new attributes of the province.
+// Returns a ConflictError if the country does not exist or already has a province with that name.
+func (r *ProvinceRepositoryDB) Update(ctx context.Context, id int, province models.Province) (models.Province, error) {
+	const query = `UPDATE provinces SET province_name = ?, country_id = ? WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, province.ProvinceName, province.CountryID, id)
+	if err != nil {
+		return models.Province{}, provinceWriteError(ctx, err)
+	}
+	return province, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// ProvinceRepositoryMemory is an in-memory implementation of ProvinceRepository.
+// GetAll, GetByID, Delete and the reports are the ones of regionMemory.
+type ProvinceRepositoryMemory struct {
+	regionMemory[models.Province]
+}
+
+// NewProvinceRepositoryMemory constructs a ProvinceRepositoryMemory backed
+// by the given store.
+func NewProvinceRepositoryMemory(store *MemoryStore) ProvinceRepository {
+	return &ProvinceRepositoryMemory{regionMemory[models.Province]{
+		store:   store,
+		fields:  provinceListFields,
+		regions: func() map[int]models.Province { return store.provinces },
+		reportRow: func(province models.Province) regionCount {
+			return regionCount{id: province.ID, name: province.ProvinceName}
+		},
+		regionOf: func(locality models.Locality) int {
+			return locality.ProvinceID
+		},
+		isReferenced: func(id int) bool {
+			for _, locality := range store.localities {
+				if locality.ProvinceID == id {
+					return true
+				}
+			}
+			return false
+		},
+		notFound:   "Province not found",
+		referenced: "Province is still referenced by localities.",
+	}}
+}
+
+// Create stores a new province and returns it with its generated ID.
+// Returns a ConflictError if the country does not exist or already has a province with that name.
+func (r *ProvinceRepositoryMemory) Create(ctx context.Context, provinceAttributes models.ProvinceAttributes) (models.Province, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkConstraints(0, provinceAttributes); err != nil {
+		return models.Province{}, err
+	}
+
+	province := models.Province{
+		ID:                 r.store.nextID("provinces"),
+		ProvinceAttributes: provinceAttributes,
+	}
+	r.store.provinces[province.ID] = province
+	return province, nil
+}
+
+// provinceListFields are the fields provinces can be sorted and filtered by.
+var provinceListFields = listFields[models.Province]{
+	"id":            func(p models.Province) any { return p.ID },
+	"province_name": func(p models.Province) any { return p.ProvinceName },
+	"country_id":    func(p models.Province) any { return p.CountryID },
+}
+
+// Update replaces the stored province.
+// Like the SQL implementation, updating a missing id is not an error.
+func (r *ProvinceRepositoryMemory) Update(ctx context.Context, id int, province models.Province) (models.Province, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkConstraints(id, province.ProvinceAttributes); err != nil {
+		return models.Province{}, err
+	}
+
+	if _, ok := r.store.provinces[id]; ok {
+		stored := province
+		stored.ID = id
+		r.store.provinces[id] = stored
+	}
+	return province, nil
+}
+
+// checkConstraints validates the country foreign key and the name of the province
+// identified by id (0 for a new province), unique within its country.
+// The caller must hold the lock.
+func (r *ProvinceRepositoryMemory) checkConstraints(id int, attributes models.ProvinceAttributes) error {
+	if _, ok := r.store.countries[attributes.CountryID]; !ok {
+		return httperrors.ConflictError{Message: "Country does not exist"}
+	}
+	for _, province := range r.store.provinces {
+		if province.ID != id && province.CountryID == attributes.CountryID && province.ProvinceName == attributes.ProvinceName {
+			return httperrors.ConflictError{Message: "Province name already exists in the country."}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvinceRepositoryDB_Create(t *testing.T) {
+	tests := []struct {
+		testName    string
+		sqlErr      error
+		expectedErr error
+	}{
+		{
+			testName:    "duplicated name in the country returns ConflictError",
+			sqlErr:      &mysql.MySQLError{Number: 1062},
+			expectedErr: httperrors.ConflictError{Message: "Province name already exists in the country."},
+		},
+		{
+			testName:    "missing country returns ConflictError",
+			sqlErr:      &mysql.MySQLError{Number: 1452},
+			expectedErr: httperrors.ConflictError{Message: "Country does not exist"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec("INSERT INTO provinces \\(province_name, country_id\\) VALUES \\(\\?, \\?\\)").
+				WithArgs("Santa Fe", 1).
+				WillReturnError(tc.sqlErr)
+
+			repo := repository.NewProvinceRepositoryDB(db)
+
+			// act
+			_, err = repo.Create(context.Background(), models.ProvinceAttributes{ProvinceName: "Santa Fe", CountryID: 1})
+
+			// assert
+			assert.Equal(t, tc.expectedErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProvinceRepositoryDB_Delete(t *testing.T) {
+	tests := []struct {
+		testName    string
+		result      func(exec *sqlmock.ExpectedExec)
+		expectedErr error
+	}{
+		{
+			testName: "removes the province",
+			result: func(exec *sqlmock.ExpectedExec) {
+				exec.WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			testName: "missing province returns NotFoundError",
+			result: func(exec *sqlmock.ExpectedExec) {
+				exec.WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: httperrors.NotFoundError{Message: "Province not found"},
+		},
+		{
+			testName: "province still referenced by localities returns ConflictError",
+			result: func(exec *sqlmock.ExpectedExec) {
+				exec.WillReturnError(&mysql.MySQLError{Number: 1451})
+			},
+			expectedErr: httperrors.ConflictError{Message: "Province is still referenced by localities."},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tc.result(mock.ExpectExec("DELETE FROM provinces WHERE id = \\?").WithArgs(1))
+
+			repo := repository.NewProvinceRepositoryDB(db)
+
+			// act
+			err = repo.Delete(context.Background(), 1)
+
+			// assert
+			assert.Equal(t, tc.expectedErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProvinceRepositoryDB_GetCarryReport(t *testing.T) {
+	t.Run("counts the carries of every province", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("FROM provinces r\\s+LEFT JOIN localities l ON l.province_id = r.id\\s+LEFT JOIN carries t ON t.locality_id = l.id\\s+GROUP BY r.id, r.province_name").
+			WithoutArgs().
+			WillReturnRows(sqlmock.NewRows([]string{"id", "province_name", "count"}).
+				AddRow(1, "Buenos Aires", 2).
+				AddRow(2, "CABA", 0))
+
+		repo := repository.NewProvinceRepositoryDB(db)
+
+		// act
+		got, err := repo.GetCarryReport(context.Background(), nil)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, []models.RegionCarryReport{{ID: 1, Name: "Buenos Aires", CarriesCount: 2}, {ID: 2, Name: "CABA", CarriesCount: 0}}, got)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
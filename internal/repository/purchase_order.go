@@ -3,6 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
@@ -18,32 +23,42 @@ func NewPurchaseOrderRepositoryDB(db *sql.DB) PurchaseOrderRepository {
 	return &PurchaseOrderRepositoryDB{db: db}
 }
 
+// Create stores the order and its lines in one transaction. The unit price of
+// each line is the sale price of the product record in effect on the order date.
 func (r *PurchaseOrderRepositoryDB) Create(
 	ctx context.Context,
 	newPurchaseOrder models.PurchaseOrderAttributes) (models.PurchaseOrder, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.PurchaseOrder{}, err
+	}
+	defer tx.Rollback()
+
 	const query = `
 		INSERT INTO purchase_orders
-			(order_number, order_date, tracking_code, buyer_id, product_record_id)
-		VALUES (?, ?, ?, ?, ?)
+			(order_number, order_date, tracking_code, buyer_id)
+		VALUES (?, ?, ?, ?)
 	`
-	result, err := r.db.ExecContext(
+	result, err := tx.ExecContext(
 		ctx,
 		query,
 		newPurchaseOrder.OrderNumber,
 		newPurchaseOrder.OrderDate,
 		newPurchaseOrder.TrackingCode,
 		newPurchaseOrder.BuyerId,
-		newPurchaseOrder.ProductRecordId,
 	)
 	if err != nil {
 		if mysqlErr, ok := err.(*mysql.MySQLError); ok {
-			switch mysqlErr.Number {
-			case 1062:
+			switch {
+			case mysqlErr.Number == 1062 && strings.Contains(mysqlErr.Message, "tracking_code"):
+				// unique key TrackingCode duplicated
+				err = httperrors.ConflictError{Message: "TrackingCode already in use"}
+			case mysqlErr.Number == 1062:
 				// unique key OrderNumber duplicated
 				err = httperrors.ConflictError{Message: "OrderNumber already in use"}
-			case 1452:
-				// FK violation constraint ProductRecordId or BuyerId
-				err = httperrors.ConflictError{Message: "ProductRecordId and/or BuyerId does not exist"}
+			case mysqlErr.Number == 1452:
+				// FK violation constraint BuyerId
+				err = httperrors.ConflictError{Message: "BuyerId does not exist"}
 			}
 		}
 		return models.PurchaseOrder{}, err
@@ -55,9 +70,525 @@ func (r *PurchaseOrderRepositoryDB) Create(
 	}
 
 	purchaseOrder := models.PurchaseOrder{
-		Id:                      int(lastId),
-		PurchaseOrderAttributes: newPurchaseOrder,
+		Id:           int(lastId),
+		OrderNumber:  newPurchaseOrder.OrderNumber,
+		OrderDate:    newPurchaseOrder.OrderDate,
+		TrackingCode: newPurchaseOrder.TrackingCode,
+		BuyerId:      newPurchaseOrder.BuyerId,
+		Status:       models.PurchaseOrderStatusPending,
+	}
+
+	// the price is snapshotted from the record in effect on the day of the order
+	orderDay := newPurchaseOrder.OrderDate.Format(time.DateOnly)
+	const lineQuery = `
+		INSERT INTO purchase_order_lines
+			(purchase_order_id, product_id, quantity, unit_price)
+		VALUES (?, ?, ?, ?)
+	`
+	for _, lineAttributes := range newPurchaseOrder.Lines {
+		line := models.PurchaseOrderLine{
+			PurchaseOrderId: purchaseOrder.Id,
+			ProductId:       lineAttributes.ProductId,
+			Quantity:        lineAttributes.Quantity,
+		}
+		record, err := scanProductRecord(tx.QueryRowContext(ctx, effectiveProductRecordQuery, line.ProductId, orderDay))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// without product records the product has no price, or does not exist
+				return models.PurchaseOrder{}, httperrors.ConflictError{
+					Message: noEffectiveRecordMessage(line.ProductId, orderDay),
+				}
+			}
+			return models.PurchaseOrder{}, internalError(ctx, "", err)
+		}
+		line.UnitPrice = record.SalePrice
+
+		result, err := tx.ExecContext(ctx, lineQuery, line.PurchaseOrderId, line.ProductId, line.Quantity, line.UnitPrice)
+		if err != nil {
+			return models.PurchaseOrder{}, internalError(ctx, "", err)
+		}
+		lineId, err := result.LastInsertId()
+		if err != nil {
+			return models.PurchaseOrder{}, internalError(ctx, "", err)
+		}
+		line.Id = int(lineId)
+		purchaseOrder.Lines = append(purchaseOrder.Lines, line)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return models.PurchaseOrder{}, internalError(ctx, "", err)
+	}
+	purchaseOrder.Total = models.PurchaseOrderTotal(purchaseOrder.Lines)
 	return purchaseOrder, nil
 }
+
+// queryer is the part of *sql.DB and *sql.Tx used to read the lines of orders
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// loadPurchaseOrderLines reads the lines of the orders, with their allocations, and sets their totals
+func loadPurchaseOrderLines(ctx context.Context, db queryer, orders []models.PurchaseOrder) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	index := make(map[int]int, len(orders))
+	placeholders := make([]string, len(orders))
+	args := make([]any, len(orders))
+	for i, order := range orders {
+		index[order.Id] = i
+		placeholders[i] = "?"
+		args[i] = order.Id
+	}
+
+	query := `
+		SELECT id, purchase_order_id, product_id, quantity, unit_price
+		FROM purchase_order_lines
+		WHERE purchase_order_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY id`
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return internalError(ctx, "", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line models.PurchaseOrderLine
+		if err := rows.Scan(&line.Id, &line.PurchaseOrderId, &line.ProductId, &line.Quantity, &line.UnitPrice); err != nil {
+			return internalError(ctx, "", err)
+		}
+		order := &orders[index[line.PurchaseOrderId]]
+		order.Lines = append(order.Lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return internalError(ctx, "", err)
+	}
+
+	for i := range orders {
+		orders[i].Total = models.PurchaseOrderTotal(orders[i].Lines)
+	}
+	return loadPurchaseOrderAllocations(ctx, db, orders)
+}
+
+// loadPurchaseOrderAllocations reads the allocations of the lines of the orders
+func loadPurchaseOrderAllocations(ctx context.Context, db queryer, orders []models.PurchaseOrder) error {
+	lines := make(map[int]*models.PurchaseOrderLine)
+	var placeholders []string
+	var args []any
+	for i := range orders {
+		for j := range orders[i].Lines {
+			line := &orders[i].Lines[j]
+			lines[line.Id] = line
+			placeholders = append(placeholders, "?")
+			args = append(args, line.Id)
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	query := `
+		SELECT id, purchase_order_line_id, product_batch_id, quantity
+		FROM purchase_order_allocations
+		WHERE purchase_order_line_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY id`
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return internalError(ctx, "", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var allocation models.PurchaseOrderAllocation
+		if err := rows.Scan(&allocation.Id, &allocation.PurchaseOrderLineId, &allocation.ProductBatchId, &allocation.Quantity); err != nil {
+			return internalError(ctx, "", err)
+		}
+		line := lines[allocation.PurchaseOrderLineId]
+		line.Allocations = append(line.Allocations, allocation)
+	}
+	if err := rows.Err(); err != nil {
+		return internalError(ctx, "", err)
+	}
+	return nil
+}
+
+// purchaseOrderColumns lists the columns read into a models.PurchaseOrder
+const purchaseOrderColumns = `
+            id, order_number, order_date, tracking_code, buyer_id, status,
+            confirmed_at, picked_at, shipped_at, delivered_at, cancelled_at, carry_id`
+
+// scanPurchaseOrder reads a row selected with purchaseOrderColumns
+func scanPurchaseOrder(row interface{ Scan(dest ...any) error }) (models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	err := row.Scan(
+		&order.Id, &order.OrderNumber, &order.OrderDate, &order.TrackingCode, &order.BuyerId, &order.Status,
+		&order.ConfirmedAt, &order.PickedAt, &order.ShippedAt, &order.DeliveredAt, &order.CancelledAt, &order.CarryId,
+	)
+	return order, err
+}
+
+// purchaseOrderStatusColumns are the timestamp columns set when an order reaches each status
+var purchaseOrderStatusColumns = map[string]string{
+	models.PurchaseOrderStatusConfirmed: "confirmed_at",
+	models.PurchaseOrderStatusPicked:    "picked_at",
+	models.PurchaseOrderStatusShipped:   "shipped_at",
+	models.PurchaseOrderStatusDelivered: "delivered_at",
+	models.PurchaseOrderStatusCancelled: "cancelled_at",
+}
+
+// setPurchaseOrderStatus moves the order to status and records when it happened
+func setPurchaseOrderStatus(order *models.PurchaseOrder, status string, at time.Time) {
+	order.Status = status
+	switch status {
+	case models.PurchaseOrderStatusConfirmed:
+		order.ConfirmedAt = &at
+	case models.PurchaseOrderStatusPicked:
+		order.PickedAt = &at
+	case models.PurchaseOrderStatusShipped:
+		order.ShippedAt = &at
+	case models.PurchaseOrderStatusDelivered:
+		order.DeliveredAt = &at
+	case models.PurchaseOrderStatusCancelled:
+		order.CancelledAt = &at
+	}
+}
+
+// purchaseOrderListColumns are the fields purchase orders can be sorted and filtered by
+var purchaseOrderListColumns = listColumns{
+	"id":           "id",
+	"order_number": "order_number",
+	"order_date":   "order_date",
+	"buyer_id":     "buyer_id",
+	"carry_id":     "carry_id",
+	"status":       "status",
+}
+
+// GetAll returns the page of purchase orders described by opts
+func (r *PurchaseOrderRepositoryDB) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.PurchaseOrder], error) {
+	query := listQuery{
+		selectColumns: purchaseOrderColumns,
+		from:          "purchase_orders",
+		columns:       purchaseOrderListColumns,
+	}
+	page, err := queryPage(ctx, r.db, query, opts, purchaseOrderListFields, func(rows *sql.Rows) (models.PurchaseOrder, error) {
+		return scanPurchaseOrder(rows)
+	})
+	if err != nil {
+		return models.Page[models.PurchaseOrder]{}, err
+	}
+	if err := loadPurchaseOrderLines(ctx, r.db, page.Data); err != nil {
+		return models.Page[models.PurchaseOrder]{}, err
+	}
+	return page, nil
+}
+
+// GetByID returns the purchase order with the given id or a NotFoundError
+func (r *PurchaseOrderRepositoryDB) GetByID(ctx context.Context, id int) (models.PurchaseOrder, error) {
+	query := "SELECT" + purchaseOrderColumns + "\n        FROM purchase_orders\n        WHERE id = ?"
+	order, err := scanPurchaseOrder(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.PurchaseOrder{}, httperrors.NotFoundError{Message: "Purchase order not found"}
+		}
+		return models.PurchaseOrder{}, internalError(ctx, "", err)
+	}
+
+	orders := []models.PurchaseOrder{order}
+	if err := loadPurchaseOrderLines(ctx, r.db, orders); err != nil {
+		return models.PurchaseOrder{}, err
+	}
+	return orders[0], nil
+}
+
+// Transition moves the order to status, locking its row so concurrent
+// transitions of the same order are applied one after the other.
+// Confirming the order allocates the stock of its lines in the same transaction,
+// and cancelling it gives the allocated stock back.
+// Returns a NotFoundError if the order does not exist and a ConflictError
+// if its current status cannot move to status or there is not enough stock.
+func (r *PurchaseOrderRepositoryDB) Transition(ctx context.Context, id int, status string, at time.Time) (models.PurchaseOrder, error) {
+	column, ok := purchaseOrderStatusColumns[status]
+	if !ok {
+		return models.PurchaseOrder{}, httperrors.UnprocessableEntityError{Message: "Invalid status"}
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.PurchaseOrder{}, internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	query := "SELECT" + purchaseOrderColumns + "\n        FROM purchase_orders\n        WHERE id = ?\n        FOR UPDATE"
+	order, err := scanPurchaseOrder(tx.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.PurchaseOrder{}, httperrors.NotFoundError{Message: "Purchase order not found"}
+		}
+		return models.PurchaseOrder{}, internalError(ctx, "", err)
+	}
+	if !models.CanTransitionPurchaseOrder(order.Status, status) {
+		return models.PurchaseOrder{}, httperrors.ConflictError{
+			Message: "A " + order.Status + " purchase order cannot be " + status,
+		}
+	}
+
+	orders := []models.PurchaseOrder{order}
+	if err := loadPurchaseOrderLines(ctx, tx, orders); err != nil {
+		return models.PurchaseOrder{}, err
+	}
+	order = orders[0]
+
+	switch status {
+	case models.PurchaseOrderStatusConfirmed:
+		if err := allocatePurchaseOrderTx(ctx, tx, &order, at); err != nil {
+			return models.PurchaseOrder{}, err
+		}
+	case models.PurchaseOrderStatusCancelled:
+		if err := releasePurchaseOrderTx(ctx, tx, &order, at); err != nil {
+			return models.PurchaseOrder{}, err
+		}
+	}
+
+	updateQuery := "UPDATE purchase_orders SET status = ?, " + column + " = ? WHERE id = ?"
+	if _, err := tx.ExecContext(ctx, updateQuery, status, at, id); err != nil {
+		return models.PurchaseOrder{}, internalError(ctx, "", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.PurchaseOrder{}, internalError(ctx, "", err)
+	}
+	setPurchaseOrderStatus(&order, status, at)
+	return order, nil
+}
+
+// AssignCarry sets the carry that delivers the order, locking its row like Transition.
+// Without carryID it picks the carry in the delivery locality of the buyer, or any
+// carry when there is none there, lowest id first.
+// Returns a NotFoundError if the order does not exist and a ConflictError if it was
+// already shipped, the carry does not exist or there are no carries.
+func (r *PurchaseOrderRepositoryDB) AssignCarry(ctx context.Context, id int, carryID *int) (models.PurchaseOrder, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.PurchaseOrder{}, internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	query := "SELECT" + purchaseOrderColumns + "\n        FROM purchase_orders\n        WHERE id = ?\n        FOR UPDATE"
+	order, err := scanPurchaseOrder(tx.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.PurchaseOrder{}, httperrors.NotFoundError{Message: "Purchase order not found"}
+		}
+		return models.PurchaseOrder{}, internalError(ctx, "", err)
+	}
+	if !models.CanAssignPurchaseOrderCarry(order.Status) {
+		return models.PurchaseOrder{}, httperrors.ConflictError{
+			Message: "A " + order.Status + " purchase order cannot change its carry",
+		}
+	}
+
+	if carryID == nil {
+		// carries in the delivery locality compare as 1 and go first, the rest as 0 or NULL
+		const pickQuery = `
+			SELECT c.id
+			FROM carries c
+			LEFT JOIN buyers b ON b.id = ?
+			ORDER BY c.locality_id = b.delivery_locality_id DESC, c.id
+			LIMIT 1
+		`
+		var picked int
+		if err := tx.QueryRowContext(ctx, pickQuery, order.BuyerId).Scan(&picked); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return models.PurchaseOrder{}, httperrors.ConflictError{Message: "No carry available"}
+			}
+			return models.PurchaseOrder{}, internalError(ctx, "", err)
+		}
+		carryID = &picked
+	}
+
+	const updateQuery = `UPDATE purchase_orders SET carry_id = ? WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, updateQuery, *carryID, id); err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1452 {
+			return models.PurchaseOrder{}, httperrors.ConflictError{Message: "Carry does not exist"}
+		}
+		return models.PurchaseOrder{}, internalError(ctx, "", err)
+	}
+
+	orders := []models.PurchaseOrder{order}
+	if err := loadPurchaseOrderLines(ctx, tx, orders); err != nil {
+		return models.PurchaseOrder{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.PurchaseOrder{}, internalError(ctx, "", err)
+	}
+	order = orders[0]
+	order.CarryId = carryID
+	return order, nil
+}
+
+// availableBatch is a product batch that stock can be allocated from
+type availableBatch struct {
+	id       int
+	quantity int
+}
+
+// allocateFEFO takes quantity from the batches in the order given, each one
+// emptied before moving to the next. Returns false if they do not hold enough stock.
+func allocateFEFO(quantity int, batches []availableBatch) ([]models.PurchaseOrderAllocation, bool) {
+	var allocations []models.PurchaseOrderAllocation
+	for _, batch := range batches {
+		if quantity == 0 {
+			break
+		}
+		taken := min(quantity, batch.quantity)
+		if taken <= 0 {
+			continue
+		}
+		allocations = append(allocations, models.PurchaseOrderAllocation{ProductBatchId: batch.id, Quantity: taken})
+		quantity -= taken
+	}
+	return allocations, quantity == 0
+}
+
+// notEnoughStockError is returned when the batches of a product cannot cover an order line
+func notEnoughStockError(productID int) error {
+	return httperrors.ConflictError{Message: fmt.Sprintf("Not enough stock of product %d", productID)}
+}
+
+// purchaseOrderMovementReason is the reason of the stock movements made for an order
+func purchaseOrderMovementReason(order models.PurchaseOrder) string {
+	return "Purchase order " + order.OrderNumber
+}
+
+// purchaseOrderCancelledReason is the reason of the stock movements giving back the stock of a cancelled order
+func purchaseOrderCancelledReason(order models.PurchaseOrder) string {
+	return purchaseOrderMovementReason(order) + " cancelled"
+}
+
+// sortedAllocations takes the allocations out of the lines of the order, ordered by
+// batch so the batches are locked in the same order by every transaction
+func sortedAllocations(order *models.PurchaseOrder) []models.PurchaseOrderAllocation {
+	var allocations []models.PurchaseOrderAllocation
+	for i := range order.Lines {
+		allocations = append(allocations, order.Lines[i].Allocations...)
+		order.Lines[i].Allocations = nil
+	}
+	sort.Slice(allocations, func(i, j int) bool {
+		if allocations[i].ProductBatchId != allocations[j].ProductBatchId {
+			return allocations[i].ProductBatchId < allocations[j].ProductBatchId
+		}
+		return allocations[i].Id < allocations[j].Id
+	})
+	return allocations
+}
+
+// releasePurchaseOrderTx gives the stock allocated to the order back to its batches,
+// recording an inbound movement per allocation, and deletes the allocations.
+// The batches stay locked until tx ends. Orders never confirmed have nothing to release.
+func releasePurchaseOrderTx(ctx context.Context, tx *sql.Tx, order *models.PurchaseOrder, at time.Time) error {
+	allocations := sortedAllocations(order)
+	if len(allocations) == 0 {
+		return nil
+	}
+
+	for _, allocation := range allocations {
+		_, err := applyStockMovementTx(ctx, tx, models.StockMovement{
+			ProductBatchID: allocation.ProductBatchId,
+			MovementType:   models.StockMovementInbound,
+			Quantity:       allocation.Quantity,
+			Reason:         purchaseOrderCancelledReason(*order),
+			CreatedAt:      at,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	placeholders := make([]string, len(order.Lines))
+	args := make([]any, len(order.Lines))
+	for i, line := range order.Lines {
+		placeholders[i] = "?"
+		args[i] = line.Id
+	}
+	query := "DELETE FROM purchase_order_allocations WHERE purchase_order_line_id IN (" + strings.Join(placeholders, ", ") + ")"
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return internalError(ctx, "", err)
+	}
+	return nil
+}
+
+// allocatePurchaseOrderTx takes the quantity of every line of the order from the
+// batches of its product that have not expired on at, earliest due date first.
+// The batches stay locked until tx ends, and each allocation is stored along
+// with the outbound stock movement of its batch.
+func allocatePurchaseOrderTx(ctx context.Context, tx *sql.Tx, order *models.PurchaseOrder, at time.Time) error {
+	const batchesQuery = `
+		SELECT id, current_quantity
+		FROM product_batches
+		WHERE product_id = ? AND current_quantity > 0 AND due_date >= ?
+		ORDER BY due_date, id
+		FOR UPDATE
+	`
+	const allocationQuery = `
+		INSERT INTO purchase_order_allocations
+			(purchase_order_line_id, product_batch_id, quantity)
+		VALUES (?, ?, ?)
+	`
+	today := at.Format(time.DateOnly)
+
+	for i := range order.Lines {
+		line := &order.Lines[i]
+
+		rows, err := tx.QueryContext(ctx, batchesQuery, line.ProductId, today)
+		if err != nil {
+			return internalError(ctx, "", err)
+		}
+		var batches []availableBatch
+		for rows.Next() {
+			var batch availableBatch
+			if err := rows.Scan(&batch.id, &batch.quantity); err != nil {
+				rows.Close()
+				return internalError(ctx, "", err)
+			}
+			batches = append(batches, batch)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return internalError(ctx, "", err)
+		}
+
+		allocations, ok := allocateFEFO(line.Quantity, batches)
+		if !ok {
+			return notEnoughStockError(line.ProductId)
+		}
+
+		for _, allocation := range allocations {
+			_, err := applyStockMovementTx(ctx, tx, models.StockMovement{
+				ProductBatchID: allocation.ProductBatchId,
+				MovementType:   models.StockMovementOutbound,
+				Quantity:       -allocation.Quantity,
+				Reason:         purchaseOrderMovementReason(*order),
+				CreatedAt:      at,
+			})
+			if err != nil {
+				return err
+			}
+
+			result, err := tx.ExecContext(ctx, allocationQuery, line.Id, allocation.ProductBatchId, allocation.Quantity)
+			if err != nil {
+				return internalError(ctx, "", err)
+			}
+			allocationId, err := result.LastInsertId()
+			if err != nil {
+				return internalError(ctx, "", err)
+			}
+			allocation.Id = int(allocationId)
+			allocation.PurchaseOrderLineId = line.Id
+			line.Allocations = append(line.Allocations, allocation)
+		}
+	}
+	return nil
+}
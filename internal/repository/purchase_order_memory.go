@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// PurchaseOrderRepositoryMemory is an in-memory implementation of PurchaseOrderRepository.
+type PurchaseOrderRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// Generates a repository backed by the in-memory store
+func NewPurchaseOrderRepositoryMemory(store *MemoryStore) PurchaseOrderRepository {
+	return &PurchaseOrderRepositoryMemory{store: store}
+}
+
+// Create stores a new purchase order and its lines checking the unique OrderNumber
+// and TrackingCode and the buyer foreign key. The unit price of each line is the sale price of the
+// product record in effect on the order date.
+func (r *PurchaseOrderRepositoryMemory) Create(
+	ctx context.Context,
+	newPurchaseOrder models.PurchaseOrderAttributes) (models.PurchaseOrder, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, order := range r.store.purchaseOrders {
+		if order.OrderNumber == newPurchaseOrder.OrderNumber {
+			return models.PurchaseOrder{}, httperrors.ConflictError{Message: "OrderNumber already in use"}
+		}
+		if order.TrackingCode == newPurchaseOrder.TrackingCode {
+			return models.PurchaseOrder{}, httperrors.ConflictError{Message: "TrackingCode already in use"}
+		}
+	}
+	if _, ok := r.store.buyers[newPurchaseOrder.BuyerId]; !ok {
+		return models.PurchaseOrder{}, httperrors.ConflictError{Message: "BuyerId does not exist"}
+	}
+
+	// Price every line before storing anything, so a failure leaves no partial order
+	orderDay := newPurchaseOrder.OrderDate.Format(time.DateOnly)
+	prices := make([]float64, len(newPurchaseOrder.Lines))
+	for i, line := range newPurchaseOrder.Lines {
+		record, ok := r.store.effectiveProductRecord(line.ProductId, orderDay)
+		if !ok {
+			return models.PurchaseOrder{}, httperrors.ConflictError{
+				Message: noEffectiveRecordMessage(line.ProductId, orderDay),
+			}
+		}
+		prices[i] = record.SalePrice
+	}
+
+	purchaseOrder := models.PurchaseOrder{
+		Id:           r.store.nextID("purchase_orders"),
+		OrderNumber:  newPurchaseOrder.OrderNumber,
+		OrderDate:    newPurchaseOrder.OrderDate,
+		TrackingCode: newPurchaseOrder.TrackingCode,
+		BuyerId:      newPurchaseOrder.BuyerId,
+		Status:       models.PurchaseOrderStatusPending,
+	}
+	r.store.purchaseOrders[purchaseOrder.Id] = purchaseOrder
+
+	for i, lineAttributes := range newPurchaseOrder.Lines {
+		line := models.PurchaseOrderLine{
+			Id:              r.store.nextID("purchase_order_lines"),
+			PurchaseOrderId: purchaseOrder.Id,
+			ProductId:       lineAttributes.ProductId,
+			Quantity:        lineAttributes.Quantity,
+			UnitPrice:       prices[i],
+		}
+		r.store.purchaseOrderLines[line.Id] = line
+	}
+	return r.withLines(purchaseOrder), nil
+}
+
+// withLines returns the order with its lines and their allocations, ordered by id, and its total.
+// The caller must hold the lock.
+func (r *PurchaseOrderRepositoryMemory) withLines(order models.PurchaseOrder) models.PurchaseOrder {
+	order.Lines = nil
+	for _, line := range sortedByID(r.store.purchaseOrderLines) {
+		if line.PurchaseOrderId == order.Id {
+			for _, allocation := range sortedByID(r.store.purchaseOrderAllocations) {
+				if allocation.PurchaseOrderLineId == line.Id {
+					line.Allocations = append(line.Allocations, allocation)
+				}
+			}
+			order.Lines = append(order.Lines, line)
+		}
+	}
+	order.Total = models.PurchaseOrderTotal(order.Lines)
+	return order
+}
+
+// purchaseOrderListFields are the fields purchase orders can be sorted and filtered by
+var purchaseOrderListFields = listFields[models.PurchaseOrder]{
+	"id":           func(o models.PurchaseOrder) any { return o.Id },
+	"order_number": func(o models.PurchaseOrder) any { return o.OrderNumber },
+	"order_date":   func(o models.PurchaseOrder) any { return o.OrderDate },
+	"buyer_id":     func(o models.PurchaseOrder) any { return o.BuyerId },
+	"carry_id":     func(o models.PurchaseOrder) any { return o.CarryId },
+	"status":       func(o models.PurchaseOrder) any { return o.Status },
+}
+
+// GetAll returns the page of purchase orders described by opts
+func (r *PurchaseOrderRepositoryMemory) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.PurchaseOrder], error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	page, err := pageOf(sortedByID(r.store.purchaseOrders), opts, purchaseOrderListFields)
+	if err != nil {
+		return models.Page[models.PurchaseOrder]{}, err
+	}
+	for i, order := range page.Data {
+		page.Data[i] = r.withLines(order)
+	}
+	return page, nil
+}
+
+// GetByID returns the purchase order with the given id or a NotFoundError
+func (r *PurchaseOrderRepositoryMemory) GetByID(ctx context.Context, id int) (models.PurchaseOrder, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	order, ok := r.store.purchaseOrders[id]
+	if !ok {
+		return models.PurchaseOrder{}, httperrors.NotFoundError{Message: "Purchase order not found"}
+	}
+	return r.withLines(order), nil
+}
+
+// Transition moves the order to status, allocating the stock of its lines when it is
+// confirmed and giving it back when it is cancelled.
+// Returns a ConflictError if its current status cannot move to status or there is not enough stock.
+func (r *PurchaseOrderRepositoryMemory) Transition(ctx context.Context, id int, status string, at time.Time) (models.PurchaseOrder, error) {
+	if _, ok := purchaseOrderStatusColumns[status]; !ok {
+		return models.PurchaseOrder{}, httperrors.UnprocessableEntityError{Message: "Invalid status"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	order, ok := r.store.purchaseOrders[id]
+	if !ok {
+		return models.PurchaseOrder{}, httperrors.NotFoundError{Message: "Purchase order not found"}
+	}
+	if !models.CanTransitionPurchaseOrder(order.Status, status) {
+		return models.PurchaseOrder{}, httperrors.ConflictError{
+			Message: "A " + order.Status + " purchase order cannot be " + status,
+		}
+	}
+
+	switch status {
+	case models.PurchaseOrderStatusConfirmed:
+		if err := r.allocate(order, at); err != nil {
+			return models.PurchaseOrder{}, err
+		}
+	case models.PurchaseOrderStatusCancelled:
+		if err := r.release(order, at); err != nil {
+			return models.PurchaseOrder{}, err
+		}
+	}
+
+	setPurchaseOrderStatus(&order, status, at)
+	r.store.purchaseOrders[id] = order
+	return r.withLines(order), nil
+}
+
+// AssignCarry sets the carry that delivers the order. Without carryID it picks the
+// carry in the delivery locality of the buyer, or any carry when there is none there,
+// lowest id first.
+// Returns a ConflictError if the order was already shipped, the carry does not exist
+// or there are no carries.
+func (r *PurchaseOrderRepositoryMemory) AssignCarry(ctx context.Context, id int, carryID *int) (models.PurchaseOrder, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	order, ok := r.store.purchaseOrders[id]
+	if !ok {
+		return models.PurchaseOrder{}, httperrors.NotFoundError{Message: "Purchase order not found"}
+	}
+	if !models.CanAssignPurchaseOrderCarry(order.Status) {
+		return models.PurchaseOrder{}, httperrors.ConflictError{
+			Message: "A " + order.Status + " purchase order cannot change its carry",
+		}
+	}
+
+	if carryID == nil {
+		picked, ok := r.pickCarry(r.store.buyers[order.BuyerId].DeliveryLocalityId)
+		if !ok {
+			return models.PurchaseOrder{}, httperrors.ConflictError{Message: "No carry available"}
+		}
+		carryID = &picked
+	} else if _, ok := r.store.carries[*carryID]; !ok {
+		return models.PurchaseOrder{}, httperrors.ConflictError{Message: "Carry does not exist"}
+	}
+
+	order.CarryId = copyIntPtr(carryID)
+	r.store.purchaseOrders[id] = order
+	return r.withLines(order), nil
+}
+
+// pickCarry returns the carry with the lowest id in the locality, or the
+// carry with the lowest id when none is there. The caller must hold the lock.
+func (r *PurchaseOrderRepositoryMemory) pickCarry(localityID *string) (int, bool) {
+	carries := sortedByID(r.store.carries)
+	if len(carries) == 0 {
+		return 0, false
+	}
+	for _, carry := range carries {
+		if localityID != nil && carry.LocalityId == *localityID {
+			return carry.Id, true
+		}
+	}
+	return carries[0].Id, true
+}
+
+// allocate takes the quantity of every line of the order from the batches of its
+// product that have not expired on at, earliest due date first. Every line is
+// planned before any stock moves, so an order without enough stock changes nothing.
+// The caller must hold the write lock.
+func (r *PurchaseOrderRepositoryMemory) allocate(order models.PurchaseOrder, at time.Time) error {
+	today := at.Format(time.DateOnly)
+	// remaining tracks what earlier lines of the order left in each batch
+	remaining := make(map[int]int)
+	available := func(batch models.ProductBatch) int {
+		if quantity, seen := remaining[batch.ID]; seen {
+			return quantity
+		}
+		return batch.CurrentQuantity
+	}
+	planned := make(map[int][]models.PurchaseOrderAllocation)
+
+	lines := r.withLines(order).Lines
+	for _, line := range lines {
+		var candidates []models.ProductBatch
+		for _, batch := range r.store.productBatches {
+			if batch.ProductID == line.ProductId && batch.DueDate >= today {
+				candidates = append(candidates, batch)
+			}
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].DueDate != candidates[j].DueDate {
+				return candidates[i].DueDate < candidates[j].DueDate
+			}
+			return candidates[i].ID < candidates[j].ID
+		})
+
+		batches := make([]availableBatch, 0, len(candidates))
+		for _, batch := range candidates {
+			batches = append(batches, availableBatch{id: batch.ID, quantity: available(batch)})
+		}
+
+		allocations, ok := allocateFEFO(line.Quantity, batches)
+		if !ok {
+			return notEnoughStockError(line.ProductId)
+		}
+		for _, allocation := range allocations {
+			batch := r.store.productBatches[allocation.ProductBatchId]
+			remaining[batch.ID] = available(batch) - allocation.Quantity
+		}
+		planned[line.Id] = allocations
+	}
+
+	for _, line := range lines {
+		for _, allocation := range planned[line.Id] {
+			r.store.applyStockMovement(models.StockMovement{
+				ProductBatchID: allocation.ProductBatchId,
+				MovementType:   models.StockMovementOutbound,
+				Quantity:       -allocation.Quantity,
+				Reason:         purchaseOrderMovementReason(order),
+				CreatedAt:      at,
+			})
+			allocation.Id = r.store.nextID("purchase_order_allocations")
+			allocation.PurchaseOrderLineId = line.Id
+			r.store.purchaseOrderAllocations[allocation.Id] = allocation
+		}
+	}
+	return nil
+}
+
+// release gives the stock allocated to the order back to its batches, recording an
+// inbound movement per allocation, and deletes the allocations. Every batch is checked
+// before any stock moves, so a release that does not fit changes nothing.
+// The caller must hold the write lock.
+func (r *PurchaseOrderRepositoryMemory) release(order models.PurchaseOrder, at time.Time) error {
+	withLines := r.withLines(order)
+	allocations := sortedAllocations(&withLines)
+
+	// the capacity a section must take back, summed over its batches
+	sectionQuantities := make(map[int]int)
+	for _, allocation := range allocations {
+		batch, ok := r.store.productBatches[allocation.ProductBatchId]
+		if !ok {
+			return httperrors.ConflictError{Message: "Product batch not found"}
+		}
+		sectionQuantities[batch.SectionID] += allocation.Quantity
+	}
+	for sectionID, quantity := range sectionQuantities {
+		if err := r.store.checkSectionOccupancy(sectionID, quantity); err != nil {
+			return err
+		}
+	}
+
+	for _, allocation := range allocations {
+		r.store.applyStockMovement(models.StockMovement{
+			ProductBatchID: allocation.ProductBatchId,
+			MovementType:   models.StockMovementInbound,
+			Quantity:       allocation.Quantity,
+			Reason:         purchaseOrderCancelledReason(order),
+			CreatedAt:      at,
+		})
+		delete(r.store.purchaseOrderAllocations, allocation.Id)
+	}
+	return nil
+}
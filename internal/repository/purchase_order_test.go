@@ -10,6 +10,7 @@ import (
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/utils"
 	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,6 +18,7 @@ import (
 
 func TestPurchaseOrderRepositoryDB_Create(t *testing.T) {
 	newPurchaseOrderTime := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	recordColumns := []string{"id", "last_update_date", "purchase_price", "sale_price", "product_id"}
 
 	t.Run("successfully creates a purchaseOrder", func(t *testing.T) {
 		// arrange
@@ -25,29 +27,41 @@ func TestPurchaseOrderRepositoryDB_Create(t *testing.T) {
 		defer db.Close()
 
 		newPurchaseOrder := models.PurchaseOrderAttributes{
-			OrderNumber:     "ON-001",
-			OrderDate:       newPurchaseOrderTime,
-			TrackingCode:    "TR-12345",
-			BuyerId:         1,
-			ProductRecordId: 100,
+			OrderNumber:  "ON-001",
+			OrderDate:    newPurchaseOrderTime,
+			TrackingCode: "TR-12345",
+			BuyerId:      1,
+			Lines:        []models.PurchaseOrderLineAttributes{{ProductId: 100, Quantity: 2}},
 		}
 		lastID := int64(22)
 
+		mock.ExpectBegin()
 		mock.ExpectExec("INSERT INTO purchase_orders").
 			WithArgs(
 				newPurchaseOrder.OrderNumber,
 				newPurchaseOrder.OrderDate,
 				newPurchaseOrder.TrackingCode,
-				newPurchaseOrder.BuyerId,
-				newPurchaseOrder.ProductRecordId).
+				newPurchaseOrder.BuyerId).
 			WillReturnResult(sqlmock.NewResult(lastID, 1))
+		mock.ExpectQuery("FROM product_records WHERE product_id = \\? AND last_update_date <= \\? ORDER BY last_update_date DESC, id DESC LIMIT 1").
+			WithArgs(100, "2024-06-10").
+			WillReturnRows(sqlmock.NewRows(recordColumns).AddRow(3, "2024-06-01", 10, 12.5, 100))
+		mock.ExpectExec("INSERT INTO purchase_order_lines").
+			WithArgs(int(lastID), 100, 2, 12.5).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectCommit()
 
 		repo := repository.NewPurchaseOrderRepositoryDB(db)
 		got, err := repo.Create(context.Background(), newPurchaseOrder)
 
 		require.NoError(t, err)
 		assert.Equal(t, int(lastID), got.Id)
-		assert.Equal(t, newPurchaseOrder, got.PurchaseOrderAttributes)
+		assert.Equal(t, newPurchaseOrder.OrderNumber, got.OrderNumber)
+		assert.Equal(t, models.PurchaseOrderStatusPending, got.Status)
+		assert.Equal(t, []models.PurchaseOrderLine{
+			{Id: 7, PurchaseOrderId: int(lastID), ProductId: 100, Quantity: 2, UnitPrice: 12.5},
+		}, got.Lines)
+		assert.Equal(t, 25.0, got.Total)
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
@@ -58,22 +72,23 @@ func TestPurchaseOrderRepositoryDB_Create(t *testing.T) {
 		defer db.Close()
 
 		newPurchaseOrder := models.PurchaseOrderAttributes{
-			OrderNumber:     "DUP-01",
-			OrderDate:       newPurchaseOrderTime,
-			TrackingCode:    "TR-02",
-			BuyerId:         2,
-			ProductRecordId: 33,
+			OrderNumber:  "DUP-01",
+			OrderDate:    newPurchaseOrderTime,
+			TrackingCode: "TR-02",
+			BuyerId:      2,
+			Lines:        []models.PurchaseOrderLineAttributes{{ProductId: 33, Quantity: 2}},
 		}
 		confErr := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
 
+		mock.ExpectBegin()
 		mock.ExpectExec("INSERT INTO purchase_orders").
 			WithArgs(
 				newPurchaseOrder.OrderNumber,
 				newPurchaseOrder.OrderDate,
 				newPurchaseOrder.TrackingCode,
-				newPurchaseOrder.BuyerId,
-				newPurchaseOrder.ProductRecordId).
+				newPurchaseOrder.BuyerId).
 			WillReturnError(confErr)
+		mock.ExpectRollback()
 
 		repo := repository.NewPurchaseOrderRepositoryDB(db)
 		ctx := context.TODO()
@@ -97,22 +112,23 @@ func TestPurchaseOrderRepositoryDB_Create(t *testing.T) {
 		defer db.Close()
 
 		newPurchaseOrder := models.PurchaseOrderAttributes{
-			OrderNumber:     "DUP-01",
-			OrderDate:       newPurchaseOrderTime,
-			TrackingCode:    "TR-02",
-			BuyerId:         2,
-			ProductRecordId: 33,
+			OrderNumber:  "DUP-01",
+			OrderDate:    newPurchaseOrderTime,
+			TrackingCode: "TR-02",
+			BuyerId:      2,
+			Lines:        []models.PurchaseOrderLineAttributes{{ProductId: 33, Quantity: 2}},
 		}
 		confErr := &mysql.MySQLError{Number: 1452, Message: "foreign key constraint fails"}
 
+		mock.ExpectBegin()
 		mock.ExpectExec("INSERT INTO purchase_orders").
 			WithArgs(
 				newPurchaseOrder.OrderNumber,
 				newPurchaseOrder.OrderDate,
 				newPurchaseOrder.TrackingCode,
-				newPurchaseOrder.BuyerId,
-				newPurchaseOrder.ProductRecordId).
+				newPurchaseOrder.BuyerId).
 			WillReturnError(confErr)
+		mock.ExpectRollback()
 
 		repo := repository.NewPurchaseOrderRepositoryDB(db)
 		ctx := context.TODO()
@@ -120,7 +136,7 @@ func TestPurchaseOrderRepositoryDB_Create(t *testing.T) {
 		_, err = repo.Create(ctx, newPurchaseOrder)
 
 		// assert
-		expectedError := &httperrors.ConflictError{Message: "ProductRecordId and/or BuyerId does not exist"}
+		expectedError := &httperrors.ConflictError{Message: "BuyerId does not exist"}
 		require.ErrorAs(t, err, expectedError)
 		assert.Equal(t, err.Error(), expectedError.Error())
 		require.NoError(t, mock.ExpectationsWereMet())
@@ -133,22 +149,23 @@ func TestPurchaseOrderRepositoryDB_Create(t *testing.T) {
 		defer db.Close()
 
 		newPurchaseOrder := models.PurchaseOrderAttributes{
-			OrderNumber:     "DUP-01",
-			OrderDate:       newPurchaseOrderTime,
-			TrackingCode:    "TR-02",
-			BuyerId:         2,
-			ProductRecordId: 33,
+			OrderNumber:  "DUP-01",
+			OrderDate:    newPurchaseOrderTime,
+			TrackingCode: "TR-02",
+			BuyerId:      2,
+			Lines:        []models.PurchaseOrderLineAttributes{{ProductId: 33, Quantity: 2}},
 		}
 		queryRowError := &mysql.MySQLError{Number: 1037, Message: "Out of memory"}
 
+		mock.ExpectBegin()
 		mock.ExpectExec("INSERT INTO purchase_orders").
 			WithArgs(
 				newPurchaseOrder.OrderNumber,
 				newPurchaseOrder.OrderDate,
 				newPurchaseOrder.TrackingCode,
-				newPurchaseOrder.BuyerId,
-				newPurchaseOrder.ProductRecordId).
+				newPurchaseOrder.BuyerId).
 			WillReturnError(queryRowError)
+		mock.ExpectRollback()
 
 		repo := repository.NewPurchaseOrderRepositoryDB(db)
 		ctx := context.TODO()
@@ -170,23 +187,24 @@ func TestPurchaseOrderRepositoryDB_Create(t *testing.T) {
 		defer db.Close()
 
 		newPurchaseOrder := models.PurchaseOrderAttributes{
-			OrderNumber:     "DUP-01",
-			OrderDate:       newPurchaseOrderTime,
-			TrackingCode:    "TR-02",
-			BuyerId:         2,
-			ProductRecordId: 33,
+			OrderNumber:  "DUP-01",
+			OrderDate:    newPurchaseOrderTime,
+			TrackingCode: "TR-02",
+			BuyerId:      2,
+			Lines:        []models.PurchaseOrderLineAttributes{{ProductId: 33, Quantity: 2}},
 		}
 
 		errLastInsertId := errors.New("cannot get last id")
 
+		mock.ExpectBegin()
 		mock.ExpectExec("INSERT INTO purchase_orders").
 			WithArgs(
 				newPurchaseOrder.OrderNumber,
 				newPurchaseOrder.OrderDate,
 				newPurchaseOrder.TrackingCode,
-				newPurchaseOrder.BuyerId,
-				newPurchaseOrder.ProductRecordId).
+				newPurchaseOrder.BuyerId).
 			WillReturnResult(sqlmock.NewErrorResult(errLastInsertId))
+		mock.ExpectRollback()
 
 		repo := repository.NewPurchaseOrderRepositoryDB(db)
 		ctx := context.TODO()
@@ -201,4 +219,484 @@ func TestPurchaseOrderRepositoryDB_Create(t *testing.T) {
 
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
+
+	t.Run("product without product records return ConflictError and rolls back", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		newPurchaseOrder := models.PurchaseOrderAttributes{
+			OrderNumber:  "ON-002",
+			OrderDate:    newPurchaseOrderTime,
+			TrackingCode: "TR-03",
+			BuyerId:      1,
+			Lines:        []models.PurchaseOrderLineAttributes{{ProductId: 5, Quantity: 1}},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO purchase_orders").
+			WillReturnResult(sqlmock.NewResult(23, 1))
+		mock.ExpectQuery("FROM product_records").
+			WithArgs(5, "2024-06-10").
+			WillReturnRows(sqlmock.NewRows(recordColumns))
+		mock.ExpectRollback()
+
+		repo := repository.NewPurchaseOrderRepositoryDB(db)
+
+		// act
+		_, err = repo.Create(context.Background(), newPurchaseOrder)
+
+		// assert
+		assert.Equal(t, httperrors.ConflictError{Message: "Product 5 has no price on 2024-06-10"}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPurchaseOrderRepositoryDB_GetByID(t *testing.T) {
+	columns := []string{
+		"id", "order_number", "order_date", "tracking_code", "buyer_id", "status",
+		"confirmed_at", "picked_at", "shipped_at", "delivered_at", "cancelled_at", "carry_id",
+	}
+	lineColumns := []string{"id", "purchase_order_id", "product_id", "quantity", "unit_price"}
+	allocationColumns := []string{"id", "purchase_order_line_id", "product_batch_id", "quantity"}
+	orderDate := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("returns the purchaseOrder with its status", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT .* FROM purchase_orders WHERE id = \\?").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "ON-001", orderDate, "TR1", 2, "confirmed", orderDate, nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT id, purchase_order_id, product_id, quantity, unit_price FROM purchase_order_lines WHERE purchase_order_id IN \\(\\?\\)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(4, 1, 8, 3, 2.5).AddRow(5, 1, 9, 1, 10))
+		mock.ExpectQuery("SELECT id, purchase_order_line_id, product_batch_id, quantity FROM purchase_order_allocations WHERE purchase_order_line_id IN \\(\\?, \\?\\)").
+			WithArgs(4, 5).
+			WillReturnRows(sqlmock.NewRows(allocationColumns).AddRow(1, 4, 10, 3).AddRow(2, 5, 12, 1))
+
+		repo := repository.NewPurchaseOrderRepositoryDB(db)
+
+		// act
+		got, err := repo.GetByID(context.Background(), 1)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, models.PurchaseOrderStatusConfirmed, got.Status)
+		assert.Equal(t, orderDate, *got.ConfirmedAt)
+		assert.Nil(t, got.PickedAt)
+		assert.Len(t, got.Lines, 2)
+		assert.Equal(t, 17.5, got.Total)
+		assert.Equal(t, []models.PurchaseOrderAllocation{{Id: 1, PurchaseOrderLineId: 4, ProductBatchId: 10, Quantity: 3}}, got.Lines[0].Allocations)
+		assert.Equal(t, []models.PurchaseOrderAllocation{{Id: 2, PurchaseOrderLineId: 5, ProductBatchId: 12, Quantity: 1}}, got.Lines[1].Allocations)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing purchaseOrder return NotFoundError", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT .* FROM purchase_orders WHERE id = \\?").
+			WithArgs(9).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		repo := repository.NewPurchaseOrderRepositoryDB(db)
+
+		// act
+		_, err = repo.GetByID(context.Background(), 9)
+
+		// assert
+		assert.Equal(t, httperrors.NotFoundError{Message: "Purchase order not found"}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPurchaseOrderRepositoryDB_Transition(t *testing.T) {
+	columns := []string{
+		"id", "order_number", "order_date", "tracking_code", "buyer_id", "status",
+		"confirmed_at", "picked_at", "shipped_at", "delivered_at", "cancelled_at", "carry_id",
+	}
+	lineColumns := []string{"id", "purchase_order_id", "product_id", "quantity", "unit_price"}
+	allocationColumns := []string{"id", "purchase_order_line_id", "product_batch_id", "quantity"}
+	batchColumns := []string{"id", "current_quantity"}
+	orderDate := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	at := time.Date(2024, 6, 11, 8, 0, 0, 0, time.UTC)
+
+	t.Run("confirming allocates the earliest due batches first", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM purchase_orders WHERE id = \\? FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "ON-001", orderDate, "TR1", 2, "pending", nil, nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT id, purchase_order_id, product_id, quantity, unit_price FROM purchase_order_lines WHERE purchase_order_id IN \\(\\?\\)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(4, 1, 8, 3, 2.5).AddRow(5, 1, 9, 1, 10))
+		mock.ExpectQuery("SELECT id, purchase_order_line_id, product_batch_id, quantity FROM purchase_order_allocations").
+			WithArgs(4, 5).
+			WillReturnRows(sqlmock.NewRows(allocationColumns))
+		// product 8 takes the whole first batch and one unit of the second
+		mock.ExpectQuery("SELECT id, current_quantity FROM product_batches WHERE product_id = \\? AND current_quantity > 0 AND due_date >= \\? ORDER BY due_date, id FOR UPDATE").
+			WithArgs(8, "2024-06-11").
+			WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(10, 2).AddRow(11, 5))
+		expectOutboundAllocation(mock, at, 4, 10, 2, 2, 1)
+		expectOutboundAllocation(mock, at, 4, 11, 5, 1, 2)
+		mock.ExpectQuery("SELECT id, current_quantity FROM product_batches").
+			WithArgs(9, "2024-06-11").
+			WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(12, 4))
+		expectOutboundAllocation(mock, at, 5, 12, 4, 1, 3)
+		mock.ExpectExec("UPDATE purchase_orders SET status = \\?, confirmed_at = \\? WHERE id = \\?").
+			WithArgs("confirmed", at, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := repository.NewPurchaseOrderRepositoryDB(db)
+
+		// act
+		got, err := repo.Transition(context.Background(), 1, models.PurchaseOrderStatusConfirmed, at)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, models.PurchaseOrderStatusConfirmed, got.Status)
+		assert.Equal(t, at, *got.ConfirmedAt)
+		assert.Equal(t, 17.5, got.Total)
+		assert.Equal(t, []models.PurchaseOrderAllocation{
+			{Id: 1, PurchaseOrderLineId: 4, ProductBatchId: 10, Quantity: 2},
+			{Id: 2, PurchaseOrderLineId: 4, ProductBatchId: 11, Quantity: 1},
+		}, got.Lines[0].Allocations)
+		assert.Equal(t, []models.PurchaseOrderAllocation{
+			{Id: 3, PurchaseOrderLineId: 5, ProductBatchId: 12, Quantity: 1},
+		}, got.Lines[1].Allocations)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("confirming without enough stock return ConflictError and rolls back", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM purchase_orders WHERE id = \\? FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "ON-001", orderDate, "TR1", 2, "pending", nil, nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT id, purchase_order_id, product_id, quantity, unit_price FROM purchase_order_lines").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(4, 1, 8, 3, 2.5))
+		mock.ExpectQuery("SELECT id, purchase_order_line_id, product_batch_id, quantity FROM purchase_order_allocations").
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows(allocationColumns))
+		mock.ExpectQuery("SELECT id, current_quantity FROM product_batches").
+			WithArgs(8, "2024-06-11").
+			WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(10, 2))
+		mock.ExpectRollback()
+
+		repo := repository.NewPurchaseOrderRepositoryDB(db)
+
+		// act
+		_, err = repo.Transition(context.Background(), 1, models.PurchaseOrderStatusConfirmed, at)
+
+		// assert
+		assert.Equal(t, httperrors.ConflictError{Message: "Not enough stock of product 8"}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("moves a confirmed purchaseOrder to picked without allocating", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM purchase_orders WHERE id = \\? FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "ON-001", orderDate, "TR1", 2, "confirmed", orderDate, nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT id, purchase_order_id, product_id, quantity, unit_price FROM purchase_order_lines").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(4, 1, 8, 3, 2.5))
+		mock.ExpectQuery("SELECT id, purchase_order_line_id, product_batch_id, quantity FROM purchase_order_allocations").
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows(allocationColumns).AddRow(1, 4, 10, 3))
+		mock.ExpectExec("UPDATE purchase_orders SET status = \\?, picked_at = \\? WHERE id = \\?").
+			WithArgs("picked", at, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := repository.NewPurchaseOrderRepositoryDB(db)
+
+		// act
+		got, err := repo.Transition(context.Background(), 1, models.PurchaseOrderStatusPicked, at)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, models.PurchaseOrderStatusPicked, got.Status)
+		assert.Equal(t, at, *got.PickedAt)
+		assert.Len(t, got.Lines[0].Allocations, 1)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("cancelling a confirmed purchaseOrder gives its stock back", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM purchase_orders WHERE id = \\? FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "ON-001", orderDate, "TR1", 2, "confirmed", orderDate, nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT id, purchase_order_id, product_id, quantity, unit_price FROM purchase_order_lines").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(4, 1, 8, 3, 2.5).AddRow(5, 1, 9, 1, 10))
+		mock.ExpectQuery("SELECT id, purchase_order_line_id, product_batch_id, quantity FROM purchase_order_allocations").
+			WithArgs(4, 5).
+			WillReturnRows(sqlmock.NewRows(allocationColumns).AddRow(1, 4, 11, 1).AddRow(2, 4, 10, 2).AddRow(3, 5, 12, 1))
+		// the batches are locked in id order, whatever line they were allocated to
+		expectInboundRelease(mock, at, 10, 0, 2)
+		expectInboundRelease(mock, at, 11, 4, 1)
+		expectInboundRelease(mock, at, 12, 3, 1)
+		mock.ExpectExec("DELETE FROM purchase_order_allocations WHERE purchase_order_line_id IN \\(\\?, \\?\\)").
+			WithArgs(4, 5).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec("UPDATE purchase_orders SET status = \\?, cancelled_at = \\? WHERE id = \\?").
+			WithArgs("cancelled", at, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := repository.NewPurchaseOrderRepositoryDB(db)
+
+		// act
+		got, err := repo.Transition(context.Background(), 1, models.PurchaseOrderStatusCancelled, at)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, models.PurchaseOrderStatusCancelled, got.Status)
+		assert.Empty(t, got.Lines[0].Allocations)
+		assert.Empty(t, got.Lines[1].Allocations)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("cancelling a pending purchaseOrder moves no stock", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM purchase_orders WHERE id = \\? FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "ON-001", orderDate, "TR1", 2, "pending", nil, nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT id, purchase_order_id, product_id, quantity, unit_price FROM purchase_order_lines").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(4, 1, 8, 3, 2.5))
+		mock.ExpectQuery("SELECT id, purchase_order_line_id, product_batch_id, quantity FROM purchase_order_allocations").
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows(allocationColumns))
+		mock.ExpectExec("UPDATE purchase_orders SET status = \\?, cancelled_at = \\? WHERE id = \\?").
+			WithArgs("cancelled", at, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := repository.NewPurchaseOrderRepositoryDB(db)
+
+		// act
+		_, err = repo.Transition(context.Background(), 1, models.PurchaseOrderStatusCancelled, at)
+
+		// assert
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("illegal transition return ConflictError without updating", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM purchase_orders WHERE id = \\? FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "ON-001", orderDate, "TR1", 2, "delivered", at, at, at, at, nil, nil))
+		mock.ExpectRollback()
+
+		repo := repository.NewPurchaseOrderRepositoryDB(db)
+
+		// act
+		_, err = repo.Transition(context.Background(), 1, models.PurchaseOrderStatusCancelled, at)
+
+		// assert
+		assert.Equal(t, httperrors.ConflictError{Message: "A delivered purchase order cannot be cancelled"}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing purchaseOrder return NotFoundError", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM purchase_orders WHERE id = \\? FOR UPDATE").
+			WithArgs(9).
+			WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectRollback()
+
+		repo := repository.NewPurchaseOrderRepositoryDB(db)
+
+		// act
+		_, err = repo.Transition(context.Background(), 9, models.PurchaseOrderStatusConfirmed, at)
+
+		// assert
+		assert.Equal(t, httperrors.NotFoundError{Message: "Purchase order not found"}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// expectOutboundAllocation expects quantity units of a batch holding current units,
+// in section 3 holding 50 units, to leave the batch and be allocated to the line
+func expectOutboundAllocation(mock sqlmock.Sqlmock, at time.Time, lineID, batchID, current, quantity, allocationID int) {
+	mock.ExpectQuery("SELECT current_quantity, product_id, section_id FROM product_batches WHERE id = \\? FOR UPDATE").
+		WithArgs(batchID).
+		WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "product_id", "section_id"}).AddRow(current, 8, 3))
+	mock.ExpectExec("UPDATE product_batches SET current_quantity = \\? WHERE id = \\?").
+		WithArgs(current-quantity, batchID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT current_capacity, maximum_capacity FROM sections WHERE id = \\? FOR UPDATE").
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(50, 100))
+	mock.ExpectExec("UPDATE sections SET current_capacity = \\? WHERE id = \\?").
+		WithArgs(50-quantity, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO stock_movements").
+		WithArgs(batchID, models.StockMovementOutbound, -quantity, current-quantity, nil, "Purchase order ON-001", at).
+		WillReturnResult(sqlmock.NewResult(int64(allocationID+100), 1))
+	mock.ExpectExec("INSERT INTO purchase_order_allocations").
+		WithArgs(lineID, batchID, quantity).
+		WillReturnResult(sqlmock.NewResult(int64(allocationID), 1))
+}
+
+// expectInboundRelease expects quantity units of a cancelled order to return to a
+// batch holding current units, in section 3 holding 50 units
+func expectInboundRelease(mock sqlmock.Sqlmock, at time.Time, batchID, current, quantity int) {
+	mock.ExpectQuery("SELECT current_quantity, product_id, section_id FROM product_batches WHERE id = \\? FOR UPDATE").
+		WithArgs(batchID).
+		WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "product_id", "section_id"}).AddRow(current, 8, 3))
+	mock.ExpectExec("UPDATE product_batches SET current_quantity = \\? WHERE id = \\?").
+		WithArgs(current+quantity, batchID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT current_capacity, maximum_capacity FROM sections WHERE id = \\? FOR UPDATE").
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(50, 100))
+	mock.ExpectExec("UPDATE sections SET current_capacity = \\? WHERE id = \\?").
+		WithArgs(50+quantity, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO stock_movements").
+		WithArgs(batchID, models.StockMovementInbound, quantity, current+quantity, nil, "Purchase order ON-001 cancelled", at).
+		WillReturnResult(sqlmock.NewResult(int64(batchID+100), 1))
+}
+
+func TestPurchaseOrderRepositoryDB_AssignCarry(t *testing.T) {
+	columns := []string{
+		"id", "order_number", "order_date", "tracking_code", "buyer_id", "status",
+		"confirmed_at", "picked_at", "shipped_at", "delivered_at", "cancelled_at", "carry_id",
+	}
+	lineColumns := []string{"id", "purchase_order_id", "product_id", "quantity", "unit_price"}
+	orderDate := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("without a carry_id picks the carry of the buyer delivery locality", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM purchase_orders WHERE id = \\? FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "ON-001", orderDate, "TR1", 2, "pending", nil, nil, nil, nil, nil, nil))
+		mock.ExpectQuery("SELECT c.id FROM carries c LEFT JOIN buyers b ON b.id = \\? ORDER BY c.locality_id = b.delivery_locality_id DESC, c.id LIMIT 1").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		mock.ExpectExec("UPDATE purchase_orders SET carry_id = \\? WHERE id = \\?").
+			WithArgs(7, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT id, purchase_order_id, product_id, quantity, unit_price FROM purchase_order_lines").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(lineColumns))
+		mock.ExpectCommit()
+
+		repo := repository.NewPurchaseOrderRepositoryDB(db)
+
+		// act
+		got, err := repo.AssignCarry(context.Background(), 1, nil)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, 7, *got.CarryId)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing carry return ConflictError", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM purchase_orders WHERE id = \\? FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "ON-001", orderDate, "TR1", 2, "picked", orderDate, orderDate, nil, nil, nil, 3))
+		mock.ExpectExec("UPDATE purchase_orders SET carry_id = \\? WHERE id = \\?").
+			WithArgs(9, 1).
+			WillReturnError(&mysql.MySQLError{Number: 1452})
+		mock.ExpectRollback()
+
+		repo := repository.NewPurchaseOrderRepositoryDB(db)
+
+		// act
+		_, err = repo.AssignCarry(context.Background(), 1, utils.Ptr(9))
+
+		// assert
+		assert.Equal(t, httperrors.ConflictError{Message: "Carry does not exist"}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("shipped purchaseOrder return ConflictError without updating", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM purchase_orders WHERE id = \\? FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "ON-001", orderDate, "TR1", 2, "shipped", orderDate, orderDate, orderDate, nil, nil, 3))
+		mock.ExpectRollback()
+
+		repo := repository.NewPurchaseOrderRepositoryDB(db)
+
+		// act
+		_, err = repo.AssignCarry(context.Background(), 1, utils.Ptr(4))
+
+		// assert
+		assert.Equal(t, httperrors.ConflictError{Message: "A shipped purchase order cannot change its carry"}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
 }
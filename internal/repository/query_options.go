@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/logging"
+)
+
+// listColumns maps the JSON fields a listing can be sorted or filtered by
+// to their SQL column. It must include "id", used to break ties.
+type listColumns map[string]string
+
+// listQuery describes a paginated SELECT: the columns read by scan, the FROM
+// clause with its joins, and the conditions every row must meet besides the filters.
+type listQuery struct {
+	selectColumns string
+	from          string
+	columns       listColumns
+	conditions    []string
+	args          []any
+}
+
+// queryPage counts the rows of query matching opts and reads the requested page of them,
+// turning the fields of opts into parameterized SQL. Pages are read by keyset: the rows
+// sorted after opts.After, never with OFFSET, and fields gives the cursor of the next page.
+// Returns a BadRequestError for sort or filter fields the listing does not support.
+func queryPage[T any](ctx context.Context, db *sql.DB, query listQuery, opts models.QueryOptions, fields listFields[T], scan func(*sql.Rows) (T, error)) (models.Page[T], error) {
+	if err := checkQueryOptions(opts, query.columns); err != nil {
+		return models.Page[T]{}, err
+	}
+	conditions := append([]string(nil), query.conditions...)
+	args := append([]any(nil), query.args...)
+
+	// Map iteration order is random, sort the filters so the SQL is stable
+	filterFields := make([]string, 0, len(opts.Filters))
+	for field := range opts.Filters {
+		filterFields = append(filterFields, field)
+	}
+	sort.Strings(filterFields)
+	for _, field := range filterFields {
+		conditions = append(conditions, query.columns[field]+" = ?")
+		args = append(args, opts.Filters[field])
+	}
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM " + query.from + whereClause(conditions)
+	if err := db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return models.Page[T]{}, internalError(ctx, "", err)
+	}
+
+	if opts.After != nil {
+		condition, afterArgs := keysetCondition(opts, query.columns)
+		conditions = append(conditions, condition)
+		args = append(args, afterArgs...)
+	}
+	pageQuery := "SELECT " + query.selectColumns + " FROM " + query.from + whereClause(conditions) + orderByClause(opts, query.columns)
+	if opts.Limit > 0 {
+		// One row more than the page tells whether there is a next one
+		pageQuery += " LIMIT ?"
+		args = append(args, opts.Limit+1)
+	}
+
+	rows, err := db.QueryContext(ctx, pageQuery, args...)
+	if err != nil {
+		return models.Page[T]{}, internalError(ctx, "", err)
+	}
+	defer rows.Close()
+
+	var data []T
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return models.Page[T]{}, internalError(ctx, "", err)
+		}
+		data = append(data, item)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Page[T]{}, internalError(ctx, "", err)
+	}
+	return newPage(data, opts, total, fields), nil
+}
+
+// checkQueryOptions verifies the listing supports the filter and sort fields of opts,
+// and that the cursor was issued for the same sort field.
+func checkQueryOptions[V any](opts models.QueryOptions, fields map[string]V) error {
+	for field := range opts.Filters {
+		if _, ok := fields[field]; !ok {
+			return httperrors.BadRequestError{Message: "Invalid filter field"}
+		}
+	}
+	if _, ok := fields[opts.SortField()]; !ok {
+		return httperrors.BadRequestError{Message: "Invalid sort field"}
+	}
+	if opts.After != nil && opts.After.Sort != opts.SortField() {
+		return httperrors.BadRequestError{Message: "Invalid cursor"}
+	}
+	return nil
+}
+
+// whereClause joins the conditions with AND, empty when there are none.
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// keysetCondition selects the rows sorted after opts.After. MySQL sorts NULLs
+// first, so they come before every value ascending and after them descending.
+func keysetCondition(opts models.QueryOptions, columns listColumns) (string, []any) {
+	after := opts.After
+	idColumn := columns["id"]
+	if after.Sort == "id" {
+		if opts.Desc {
+			return idColumn + " < ?", []any{after.ID}
+		}
+		return idColumn + " > ?", []any{after.ID}
+	}
+
+	column := columns[after.Sort]
+	switch {
+	case after.Value == nil && opts.Desc:
+		return fmt.Sprintf("(%s IS NULL AND %s < ?)", column, idColumn), []any{after.ID}
+	case after.Value == nil:
+		return fmt.Sprintf("((%s IS NULL AND %s > ?) OR %s IS NOT NULL)", column, idColumn, column), []any{after.ID}
+	case opts.Desc:
+		return fmt.Sprintf("(%s < ? OR (%s = ? AND %s < ?) OR %s IS NULL)", column, column, idColumn, column),
+			[]any{after.Value, after.Value, after.ID}
+	default:
+		return fmt.Sprintf("(%s > ? OR (%s = ? AND %s > ?))", column, column, idColumn),
+			[]any{after.Value, after.Value, after.ID}
+	}
+}
+
+// newPage trims the extra row read past the limit and, when there was one,
+// points the next cursor at the last row of the page.
+func newPage[T any](rows []T, opts models.QueryOptions, total int, fields listFields[T]) models.Page[T] {
+	if opts.Limit <= 0 || len(rows) <= opts.Limit {
+		return models.NewPage(rows, total, nil)
+	}
+	rows = rows[:opts.Limit]
+	last := rows[len(rows)-1]
+	next := &models.Cursor{Sort: opts.SortField(), ID: fields["id"](last)}
+	if next.Sort != "id" {
+		next.Value = keyValue(fields[next.Sort](last))
+	}
+	return models.NewPage(rows, total, next)
+}
+
+// internalError logs the cause of a failed query with the request logger, so it
+// can be matched by request ID, and returns the InternalServerError shown to the client.
+func internalError(ctx context.Context, message string, err error) error {
+	logging.FromContext(ctx).Error("database error", slog.String("message", message), slog.Any("error", err))
+	return httperrors.InternalServerError{Message: message}
+}
+
+// orderByClause returns the ORDER BY clause of opts, always ending with the id column.
+// The sort field must have been checked with checkQueryOptions.
+func orderByClause(opts models.QueryOptions, columns listColumns) string {
+	direction := "ASC"
+	if opts.Desc {
+		direction = "DESC"
+	}
+
+	idColumn := columns["id"]
+	if opts.SortField() == "id" {
+		return fmt.Sprintf(" ORDER BY %s %s", idColumn, direction)
+	}
+	return fmt.Sprintf(" ORDER BY %s %s, %s %s", columns[opts.Sort], direction, idColumn, direction)
+}
+
+// listFields gives access to the fields a listing can be sorted or filtered by,
+// mirroring listColumns. It must include "id". The in-memory repositories filter
+// and sort with it, and both implementations read the next page cursor from it.
+type listFields[T any] map[string]func(T) any
+
+// pageOf filters, sorts and slices rows the way queryPage does in SQL.
+// rows must be ordered by id, as sortedByID returns them.
+func pageOf[T any](rows []T, opts models.QueryOptions, fields listFields[T]) (models.Page[T], error) {
+	if err := checkQueryOptions(opts, fields); err != nil {
+		return models.Page[T]{}, err
+	}
+
+	var matching []T
+	for _, row := range rows {
+		matches := true
+		for field, value := range opts.Filters {
+			if fieldString(fields[field](row)) != value {
+				matches = false
+			}
+		}
+		if matches {
+			matching = append(matching, row)
+		}
+	}
+
+	get := fields[opts.SortField()]
+	// Reversing first keeps ties ordered by id in the same direction, as the SQL does
+	if opts.Desc {
+		for i, j := 0, len(matching)-1; i < j; i, j = i+1, j-1 {
+			matching[i], matching[j] = matching[j], matching[i]
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		if opts.Desc {
+			return compareFields(get(matching[j]), get(matching[i])) < 0
+		}
+		return compareFields(get(matching[i]), get(matching[j])) < 0
+	})
+
+	total := len(matching)
+	start := 0
+	if after := opts.After; after != nil {
+		// Skip the rows up to the cursor, the keyset condition of queryPage
+		for start < total {
+			order := compareFields(get(matching[start]), after.Value)
+			if after.Sort == "id" || order == 0 {
+				order = compareFields(fields["id"](matching[start]), after.ID)
+			}
+			if opts.Desc {
+				order = -order
+			}
+			if order > 0 {
+				break
+			}
+			start++
+		}
+	}
+	end := total
+	if opts.Limit > 0 {
+		// One row more than the page, as queryPage reads it
+		end = min(start+opts.Limit+1, total)
+	}
+	return newPage(matching[start:end], opts, total, fields), nil
+}
+
+// fieldString formats a field the way it is compared against a filter value.
+// A nil pointer matches no value, like NULL in SQL.
+func fieldString(value any) string {
+	value = keyValue(value)
+	if value == nil {
+		return "\x00"
+	}
+	return fmt.Sprint(value)
+}
+
+// keyValue returns the value a cursor holds for a field: nil for a NULL pointer
+// and the pointed value otherwise.
+func keyValue(value any) any {
+	switch p := value.(type) {
+	case *int:
+		if p == nil {
+			return nil
+		}
+		return *p
+	case *string:
+		if p == nil {
+			return nil
+		}
+		return *p
+	}
+	return value
+}
+
+// compareFields orders two values of the same field, NULLs first as MySQL does.
+// Values of different types only meet with a cursor of another listing; they are
+// ordered by type so the comparison stays consistent.
+func compareFields(a, b any) int {
+	a, b = keyValue(a), keyValue(b)
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	switch a := a.(type) {
+	case int:
+		if b, ok := b.(int); ok {
+			return cmp.Compare(a, b)
+		}
+	case float64:
+		if b, ok := b.(float64); ok {
+			return cmp.Compare(a, b)
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b)
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Compare(b)
+		}
+	}
+	return strings.Compare(fmt.Sprintf("%T", a), fmt.Sprintf("%T", b))
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/go-sql-driver/mysql"
+)
+
+// regionDB implements the operations shared by CountryRepositoryDB and
+// ProvinceRepositoryDB over the table of their regions.
+type regionDB struct {
+	db         *sql.DB
+	table      string
+	nameColumn string
+	// notFound is the message of a missing region, referenced the one of a
+	// region whose children still reference it.
+	notFound   string
+	referenced string
+}
+
+// Delete removes the region with the given ID.
+// Returns a ConflictError if its children still reference it.
+func (r *regionDB) Delete(ctx context.Context, id int) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = ?`, r.table)
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		var sqlError *mysql.MySQLError
+		if errors.As(err, &sqlError) && sqlError.Number == 1451 {
+			return httperrors.ConflictError{Message: r.referenced}
+		}
+		return internalError(ctx, "", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return internalError(ctx, "", err)
+	} else if count == 0 {
+		return httperrors.NotFoundError{Message: r.notFound}
+	}
+	return nil
+}
+
+// GetSellerReport counts the sellers of the localities of each region,
+// or only of the region id when it is not nil.
+func (r *regionDB) GetSellerReport(ctx context.Context, id *int) ([]models.RegionSellerReport, error) {
+	counts, err := queryRegionCounts(ctx, r.db, r.table, r.nameColumn, "sellers", id, r.notFound)
+	if err != nil {
+		return nil, err
+	}
+	return sellerReports(counts), nil
+}
+
+// GetCarryReport counts the carries of the localities of each region,
+// or only of the region id when it is not nil.
+func (r *regionDB) GetCarryReport(ctx context.Context, id *int) ([]models.RegionCarryReport, error) {
+	counts, err := queryRegionCounts(ctx, r.db, r.table, r.nameColumn, "carries", id, r.notFound)
+	if err != nil {
+		return nil, err
+	}
+	return carryReports(counts), nil
+}
+
+// regionJoins joins each region table with its localities, aliased l.
+var regionJoins = map[string]string{
+	"provinces": `provinces r
+		LEFT JOIN localities l ON l.province_id = r.id`,
+	"countries": `countries r
+		LEFT JOIN provinces p ON p.country_id = r.id
+		LEFT JOIN localities l ON l.province_id = p.id`,
+}
+
+// regionCount is a row of a report grouped by province or country.
+type regionCount struct {
+	id    int
+	name  string
+	count int
+}
+
+// queryRegionCounts counts the rows of counted (sellers or carries) in the localities of each
+// region of regionTable, or only of id when it is not nil.
+// Returns a NotFoundError with notFound if id does not exist.
+func queryRegionCounts(ctx context.Context, db *sql.DB, regionTable, nameColumn, counted string, id *int, notFound string) ([]regionCount, error) {
+	where := ""
+	var args []any
+	if id != nil {
+		where = "WHERE r.id = ?"
+		args = append(args, *id)
+	}
+	query := fmt.Sprintf(`
+		SELECT r.id, r.%s, COUNT(t.id)
+		FROM %s
+		LEFT JOIN %s t ON t.locality_id = l.id
+		%s
+		GROUP BY r.id, r.%s
+		ORDER BY r.id
+	`, nameColumn, regionJoins[regionTable], counted, where, nameColumn)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, internalError(ctx, "Error obtaining report", err)
+	}
+	defer rows.Close()
+
+	var counts []regionCount
+	for rows.Next() {
+		var count regionCount
+		if err := rows.Scan(&count.id, &count.name, &count.count); err != nil {
+			return nil, internalError(ctx, "Error reading report data", err)
+		}
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, internalError(ctx, "Error reading report data", err)
+	}
+	if id != nil && len(counts) == 0 {
+		return nil, httperrors.NotFoundError{Message: notFound}
+	}
+	return counts, nil
+}
+
+// sellerReports turns the counts of a sellers report into its response rows.
+func sellerReports(counts []regionCount) []models.RegionSellerReport {
+	reports := make([]models.RegionSellerReport, 0, len(counts))
+	for _, count := range counts {
+		reports = append(reports, models.RegionSellerReport{ID: count.id, Name: count.name, SellersCount: count.count})
+	}
+	return reports
+}
+
+// carryReports turns the counts of a carries report into its response rows.
+func carryReports(counts []regionCount) []models.RegionCarryReport {
+	reports := make([]models.RegionCarryReport, 0, len(counts))
+	for _, count := range counts {
+		reports = append(reports, models.RegionCarryReport{ID: count.id, Name: count.name, CarriesCount: count.count})
+	}
+	return reports
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// regionMemory implements the operations shared by CountryRepositoryMemory and
+// ProvinceRepositoryMemory over the regions T of a MemoryStore.
+// Its functions read the store, so the caller must hold the lock.
+type regionMemory[T any] struct {
+	store  *MemoryStore
+	fields listFields[T]
+	// regions returns the stored regions by id.
+	regions func() map[int]T
+	// reportRow returns the empty report row of a region.
+	reportRow func(T) regionCount
+	// regionOf returns the region of a locality.
+	regionOf func(models.Locality) int
+	// isReferenced reports whether a child still references the region id.
+	isReferenced func(id int) bool
+	// notFound is the message of a missing region, referenced the one of a
+	// region whose children still reference it.
+	notFound   string
+	referenced string
+}
+
+// GetAll returns the page of regions described by opts.
+func (r *regionMemory[T]) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[T], error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return pageOf(sortedByID(r.regions()), opts, r.fields)
+}
+
+// GetByID returns the region with the given ID or a NotFoundError.
+func (r *regionMemory[T]) GetByID(ctx context.Context, id int) (T, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	region, ok := r.regions()[id]
+	if !ok {
+		var zero T
+		return zero, httperrors.NotFoundError{Message: r.notFound}
+	}
+	return region, nil
+}
+
+// Delete removes the region with the given ID.
+// Returns a ConflictError if its children still reference it.
+func (r *regionMemory[T]) Delete(ctx context.Context, id int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.regions()[id]; !ok {
+		return httperrors.NotFoundError{Message: r.notFound}
+	}
+	if r.isReferenced(id) {
+		return httperrors.ConflictError{Message: r.referenced}
+	}
+
+	delete(r.regions(), id)
+	return nil
+}
+
+// GetSellerReport counts the sellers of the localities of each region,
+// or only of the region id when it is not nil.
+func (r *regionMemory[T]) GetSellerReport(ctx context.Context, id *int) ([]models.RegionSellerReport, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts, err := r.store.regionCountsOf(r.reportRows(), r.store.sellerLocalityIDs(), r.regionOf, id, r.notFound)
+	if err != nil {
+		return nil, err
+	}
+	return sellerReports(counts), nil
+}
+
+// GetCarryReport counts the carries of the localities of each region,
+// or only of the region id when it is not nil.
+func (r *regionMemory[T]) GetCarryReport(ctx context.Context, id *int) ([]models.RegionCarryReport, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts, err := r.store.regionCountsOf(r.reportRows(), r.store.carryLocalityIDs(), r.regionOf, id, r.notFound)
+	if err != nil {
+		return nil, err
+	}
+	return carryReports(counts), nil
+}
+
+// reportRows returns the regions ordered by id as empty report rows.
+// The caller must hold the lock.
+func (r *regionMemory[T]) reportRows() []regionCount {
+	var rows []regionCount
+	for _, region := range sortedByID(r.regions()) {
+		rows = append(rows, r.reportRow(region))
+	}
+	return rows
+}
+
+// sellerLocalityIDs returns the locality of every seller.
+// The caller must hold the lock.
+func (s *MemoryStore) sellerLocalityIDs() []string {
+	ids := make([]string, 0, len(s.sellers))
+	for _, seller := range s.sellers {
+		ids = append(ids, seller.LocalityID)
+	}
+	return ids
+}
+
+// carryLocalityIDs returns the locality of every carry.
+// The caller must hold the lock.
+func (s *MemoryStore) carryLocalityIDs() []string {
+	ids := make([]string, 0, len(s.carries))
+	for _, carry := range s.carries {
+		ids = append(ids, carry.LocalityId)
+	}
+	return ids
+}
+
+// regionCountsOf counts the localityIDs falling in each region, where regionOf gives the
+// region id of a locality, and returns the rows of the regions ordered by id,
+// or only the row of id when it is not nil.
+// Returns a NotFoundError with notFound if id does not exist.
+// The caller must hold the lock.
+func (s *MemoryStore) regionCountsOf(regions []regionCount, localityIDs []string, regionOf func(models.Locality) int, id *int, notFound string) ([]regionCount, error) {
+	perRegion := make(map[int]int)
+	for _, localityID := range localityIDs {
+		if locality, ok := s.localities[localityID]; ok {
+			perRegion[regionOf(locality)]++
+		}
+	}
+
+	var counts []regionCount
+	for _, region := range regions {
+		if id != nil && region.id != *id {
+			continue
+		}
+		region.count = perRegion[region.id]
+		counts = append(counts, region)
+	}
+	if id != nil && len(counts) == 0 {
+		return nil, httperrors.NotFoundError{Message: notFound}
+	}
+	return counts, nil
+}
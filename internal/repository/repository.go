@@ -2,56 +2,107 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 )
 
 type ProductRepository interface {
 	Create(ctx context.Context, product models.ProductAttributes) (models.Product, error)
-	GetAll(ctx context.Context) ([]models.Product, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Product], error)
 	GetByID(ctx context.Context, id int) (models.Product, error)
 	GetRecordsPerProduct(ctx context.Context, id *int) ([]models.ProductRecordCount, error)
+	// GetMargins returns the units sold, revenue and cost of every product sold in the period of the filter.
+	GetMargins(ctx context.Context, filter models.MarginReportFilter) ([]models.ProductMargin, error)
 	Update(ctx context.Context, id int, product models.Product) (models.Product, error)
 	Delete(ctx context.Context, id int) error
 }
 
+// ProductTypeRepository provides access to the product type catalog.
+type ProductTypeRepository interface {
+	Create(ctx context.Context, productType models.ProductTypeAttributes) (models.ProductType, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.ProductType], error)
+	GetByID(ctx context.Context, id int) (models.ProductType, error)
+	Update(ctx context.Context, id int, productType models.ProductType) (models.ProductType, error)
+	// Delete removes the product type. Returns a ConflictError while products or sections reference it.
+	Delete(ctx context.Context, id int) error
+}
+
 type ProductRecordRepository interface {
 	Create(ctx context.Context, productRecord models.ProductRecordAttributes) (models.ProductRecord, error)
+	// GetAll returns the page of the price history of filter.ProductID.
+	// Returns a NotFoundError if the product does not exist.
+	GetAll(ctx context.Context, filter models.ProductRecordFilter) (models.Page[models.ProductRecord], error)
+	// GetEffective returns the record of the product in effect on the date at (YYYY-MM-DD),
+	// the latest one updated on or before it. Returns a NotFoundError if there is none.
+	GetEffective(ctx context.Context, productID int, at string) (models.ProductRecord, error)
 }
 
 type SellerRepository interface {
-	Create(seller models.SellerAttributes) (models.Seller, error)
-	GetAll() ([]models.Seller, error)
-	GetByID(id int) (models.Seller, error)
-	Update(id int, data *models.SellerAttributes) (models.Seller, error)
-	Delete(id int) error
+	Create(ctx context.Context, seller models.SellerAttributes) (models.Seller, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Seller], error)
+	GetByID(ctx context.Context, id int) (models.Seller, error)
+	Update(ctx context.Context, id int, data *models.SellerAttributes) (models.Seller, error)
+	Delete(ctx context.Context, id int) error
+}
+
+// CountryRepository provides access to the top level of the locality hierarchy.
+type CountryRepository interface {
+	Create(ctx context.Context, country models.CountryAttributes) (models.Country, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Country], error)
+	GetByID(ctx context.Context, id int) (models.Country, error)
+	Update(ctx context.Context, id int, country models.Country) (models.Country, error)
+	// Delete removes the country. Returns a ConflictError while provinces reference it.
+	Delete(ctx context.Context, id int) error
+	// GetSellerReport counts the sellers of each country, or only of id when it is not nil.
+	GetSellerReport(ctx context.Context, id *int) ([]models.RegionSellerReport, error)
+	// GetCarryReport counts the carries of each country, or only of id when it is not nil.
+	GetCarryReport(ctx context.Context, id *int) ([]models.RegionCarryReport, error)
+}
+
+// ProvinceRepository provides access to the provinces of the locality hierarchy.
+type ProvinceRepository interface {
+	Create(ctx context.Context, province models.ProvinceAttributes) (models.Province, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Province], error)
+	GetByID(ctx context.Context, id int) (models.Province, error)
+	Update(ctx context.Context, id int, province models.Province) (models.Province, error)
+	// Delete removes the province. Returns a ConflictError while localities reference it.
+	Delete(ctx context.Context, id int) error
+	// GetSellerReport counts the sellers of each province, or only of id when it is not nil.
+	GetSellerReport(ctx context.Context, id *int) ([]models.RegionSellerReport, error)
+	// GetCarryReport counts the carries of each province, or only of id when it is not nil.
+	GetCarryReport(ctx context.Context, id *int) ([]models.RegionCarryReport, error)
 }
 
 type LocalityRepository interface {
-	Create(locality models.Locality) (models.Locality, error)
-	GetByID(id string) (models.Locality, error)
-	GetSellerReport(id *string) ([]models.SellerReport, error)
+	Create(ctx context.Context, locality models.Locality) (models.Locality, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Locality], error)
+	GetByID(ctx context.Context, id string) (models.Locality, error)
+	Update(ctx context.Context, id string, locality models.Locality) (models.Locality, error)
+	// Delete removes the locality. Returns a ConflictError while sellers, carries or buyers reference it.
+	Delete(ctx context.Context, id string) error
+	GetSellerReport(ctx context.Context, id *string) ([]models.SellerReport, error)
 	// GetReportByLocalityId retrieves a report of carries by locality ID.
-	GetReportByLocalityId(localityId string) ([]models.CarryReport, error)
+	GetReportByLocalityId(ctx context.Context, localityId string) ([]models.CarryReport, error)
 }
 
 // WarehouseRepository provides methods for warehouse data access.
 type WarehouseRepository interface {
-	// GetAll returns all warehouses.
-	GetAll() ([]models.Warehouse, error)
+	// GetAll returns the page of warehouses described by opts.
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Warehouse], error)
 	// Create adds a new warehouse.
-	Create(warehouseAtribbutes models.WarehouseAttributes) (models.Warehouse, error)
+	Create(ctx context.Context, warehouseAtribbutes models.WarehouseAttributes) (models.Warehouse, error)
 	// GetByID returns a warehouse by its ID.
-	GetByID(id int) (models.Warehouse, error)
+	GetByID(ctx context.Context, id int) (models.Warehouse, error)
 	// Update modifies a warehouse by its ID.
-	Update(id int, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error)
+	Update(ctx context.Context, id int, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error)
 	// Delete removes a warehouse by its ID.
-	Delete(id int) error
+	Delete(ctx context.Context, id int) error
 }
 
 type BuyerRepository interface {
 	Create(ctx context.Context, newBuyer models.BuyerAttributes) (models.Buyer, error)
-	GetAll(ctx context.Context) ([]models.Buyer, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Buyer], error)
 	GetByID(ctx context.Context, id int) (models.Buyer, error)
 	Update(ctx context.Context, id int, updatedBuyer models.Buyer) (models.Buyer, error)
 	Delete(ctx context.Context, id int) error
@@ -59,40 +110,130 @@ type BuyerRepository interface {
 }
 
 type EmployeeRepository interface {
-	Create(Employee models.Employee) (models.Employee, error)
-	GetAll() ([]models.Employee, error)
-	GetByID(id int) (models.Employee, error)
-	Update(id int, data models.Employee) (models.Employee, error)
-	Delete(id int) error
+	Create(ctx context.Context, Employee models.Employee) (models.Employee, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Employee], error)
+	GetByID(ctx context.Context, id int) (models.Employee, error)
+	Update(ctx context.Context, id int, data models.Employee) (models.Employee, error)
+	Delete(ctx context.Context, id int) error
 }
 
 type SectionRepository interface {
 	Create(ctx context.Context, section models.Section) (models.Section, error)
-	GetAll(ctx context.Context) ([]models.Section, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Section], error)
 	GetByID(ctx context.Context, id int) (models.Section, error)
+	// Update stores every field but current_capacity, which is kept by the product batches,
+	// and returns the section with its current occupancy.
 	Update(ctx context.Context, id int, data models.Section) (models.Section, error)
 	Delete(ctx context.Context, id int) error
 	GetProductsReport(ctx context.Context, id int) (models.SectionProductsReport, error)
 	GetAllProductsReport(ctx context.Context) ([]models.SectionProductsReport, error)
+	// GetExpiringBatchesReport lists the batches with stock whose due date is between
+	// dueDateFrom and dueDateTo (inclusive, YYYY-MM-DD), grouped by section.
+	// A warehouseID of 0 means every warehouse.
+	GetExpiringBatchesReport(ctx context.Context, dueDateFrom, dueDateTo string, warehouseID int) ([]models.SectionExpiringBatchesReport, error)
 }
 
 // CarryRepository provides methods for carry data access.
 type CarryRepository interface {
 	// Create creates a new carry.
-	Create(carryAttributes models.CarryAttributes) (models.Carry, error)
+	Create(ctx context.Context, carryAttributes models.CarryAttributes) (models.Carry, error)
+	// GetAll returns the page of carries described by opts.
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Carry], error)
+	// GetByID returns a carry by ID.
+	GetByID(ctx context.Context, id int) (models.Carry, error)
+	// Update replaces the attributes of a carry by ID.
+	Update(ctx context.Context, id int, carryAttributes models.CarryAttributes) (models.Carry, error)
+	// Delete removes a carry by ID. Returns a ConflictError while purchase orders reference it,
+	// open or not: closed orders and their tracking events keep the carry in their history.
+	Delete(ctx context.Context, id int) error
 }
 
 type InboundOrderRepository interface {
-	Create(order models.InboundOrder) (models.InboundOrder, error)
-	GetByOrderNumber(orderNumber string) (models.InboundOrder, error)
-	CountInboundOrdersForEmployees() (map[int]int, error)
-	CountInboundOrdersForEmployee(employeeID int) (int, error)
+	// Create receives the order in one transaction, creating its product batch or
+	// adding the quantity to the existing one, and stores the order.
+	// Returns a ConflictError if the employee or the section do not belong to the warehouse.
+	Create(ctx context.Context, attrs models.InboundOrderAttributes) (models.InboundOrder, error)
+	CountInboundOrdersForEmployees(ctx context.Context) (map[int]int, error)
+	CountInboundOrdersForEmployee(ctx context.Context, employeeID int) (int, error)
+	// CountInboundOrdersForWarehouse counts the orders of the warehouse dated from since
+	// onwards and the units they received.
+	CountInboundOrdersForWarehouse(ctx context.Context, warehouseID int, since time.Time) (models.InboundOrdersSummary, error)
 }
 
 type PurchaseOrderRepository interface {
 	Create(ctx context.Context, newPurchaseOrder models.PurchaseOrderAttributes) (models.PurchaseOrder, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.PurchaseOrder], error)
+	GetByID(ctx context.Context, id int) (models.PurchaseOrder, error)
+	// Transition moves the order to status, recording at as the time it reached it.
+	// Returns a ConflictError if the current status of the order cannot move to status.
+	Transition(ctx context.Context, id int, status string, at time.Time) (models.PurchaseOrder, error)
+	// AssignCarry sets the carry that delivers the order, picking one from the delivery
+	// locality of the buyer when carryID is nil.
+	// Returns a ConflictError if the order was already shipped.
+	AssignCarry(ctx context.Context, id int, carryID *int) (models.PurchaseOrder, error)
+}
+
+// TrackingEventRepository stores the delivery timeline of purchase orders.
+type TrackingEventRepository interface {
+	// Create appends the event to the timeline of its order, reported by the carry assigned to it.
+	Create(ctx context.Context, event models.TrackingEvent) (models.TrackingEvent, error)
+	// GetTimeline returns the order with the tracking code and its events in chronological order.
+	GetTimeline(ctx context.Context, trackingCode string) (models.TrackingTimeline, error)
 }
 
 type ProductBatchRepository interface {
 	Create(ctx context.Context, productBatch models.ProductBatchAttibutes) (models.ProductBatch, error)
-}
\ No newline at end of file
+	GetAll(ctx context.Context, filter models.ProductBatchFilter) (models.Page[models.ProductBatch], error)
+	GetByID(ctx context.Context, id int) (models.ProductBatch, error)
+	// Update stores the new attributes. A change of current_quantity is recorded
+	// as an adjustment stock movement in the same transaction.
+	Update(ctx context.Context, id int, data models.ProductBatchAttibutes) (models.ProductBatch, error)
+	// Delete removes the batch with its initial stock movement. Returns a ConflictError while it has
+	// any other stock movement, so the ledger is kept.
+	Delete(ctx context.Context, id int) error
+}
+
+// StockMovementRepository provides access to the stock ledger of product batches.
+type StockMovementRepository interface {
+	// Create applies the signed quantity of the movement to the batch current_quantity
+	// and stores the movement, both in the same transaction.
+	Create(ctx context.Context, movement models.StockMovement) (models.StockMovement, error)
+	// Transfer moves movement.Quantity units from movement.ProductBatchID to
+	// movement.RelatedProductBatchID, storing one movement for each batch in the same transaction.
+	Transfer(ctx context.Context, movement models.StockMovement) ([]models.StockMovement, error)
+	// GetByProductBatchID returns the movements of a batch in chronological order.
+	GetByProductBatchID(ctx context.Context, productBatchID int) ([]models.StockMovement, error)
+}
+
+// SectionTemperatureReadingRepository stores the temperature telemetry of sections.
+type SectionTemperatureReadingRepository interface {
+	// CreateBulk stores the readings of a section and sets the section current_temperature
+	// to its most recent reading, all in one transaction.
+	CreateBulk(ctx context.Context, sectionID int, readings []models.SectionTemperatureReading) ([]models.SectionTemperatureReading, error)
+	// GetBySectionID returns the readings recorded between from and to (inclusive), oldest first.
+	GetBySectionID(ctx context.Context, sectionID int, from, to time.Time) ([]models.SectionTemperatureReading, error)
+	// GetLatestBySectionID returns the most recent reading of a section, or nil if it has none.
+	GetLatestBySectionID(ctx context.Context, sectionID int) (*models.SectionTemperatureReading, error)
+}
+
+// AlertRepository stores the temperature breach alerts of sections.
+type AlertRepository interface {
+	// GetTemperatureTargets returns the section minimum temperature and the
+	// recommended temperature of every batch with stock stored in it.
+	GetTemperatureTargets(ctx context.Context, sectionID int) (models.SectionTemperatureTargets, error)
+	// Sync makes breaches the set of active alerts of the section: breaches without an
+	// active alert are opened and active alerts missing from breaches are resolved at at.
+	// It returns the alerts it opened.
+	Sync(ctx context.Context, sectionID int, breaches []models.Alert, at time.Time) ([]models.Alert, error)
+	GetAll(ctx context.Context, filter models.AlertFilter) (models.Page[models.Alert], error)
+	// Acknowledge moves an open alert to acknowledged.
+	Acknowledge(ctx context.Context, id int, at time.Time) (models.Alert, error)
+}
+
+// HealthRepository checks the database the api depends on.
+type HealthRepository interface {
+	// Ping checks the database can be reached.
+	Ping(ctx context.Context) error
+	// GetAppliedMigrations returns the file names recorded in schema_migrations by cmd/migrate.
+	GetAppliedMigrations(ctx context.Context) ([]string, error)
+}
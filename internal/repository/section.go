@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
@@ -49,35 +50,81 @@ func (repository *SectionRepositoryDB) Create(ctx context.Context, section model
 			case 1062:
 				return models.Section{}, httperrors.ConflictError{Message: "Section number already exists."}
 			case 1452:
-				return models.Section{}, httperrors.ConflictError{Message: "Warehouse does not exist."}
+				return models.Section{}, sectionForeignKeyError(mysqlErr)
 			default:
-				return models.Section{}, httperrors.InternalServerError{}
+				return models.Section{}, internalError(ctx, "", err)
 			}
 		}
-		return models.Section{}, httperrors.InternalServerError{}
+		return models.Section{}, internalError(ctx, "", err)
 	}
 
 	// Get the last inserted ID
 	lastId, err := result.LastInsertId()
 	if err != nil {
-		return models.Section{}, httperrors.InternalServerError{}
+		return models.Section{}, internalError(ctx, "", err)
 	}
 	section.ID = int(lastId)
 	return section, nil
 }
 
-// Update, updates a section in the repository
+// sectionForeignKeyError maps a MySQL error 1452 on sections to a ConflictError
+// naming the missing warehouse or product type.
+func sectionForeignKeyError(mysqlErr *mysql.MySQLError) error {
+	if strings.Contains(mysqlErr.Message, "fk_sections_product_type") {
+		return httperrors.ConflictError{Message: "Product type does not exist."}
+	}
+	return httperrors.ConflictError{Message: "Warehouse does not exist."}
+}
+
+// Update, updates a section in the repository.
+// current_capacity is kept by the product batches and stock movements, so it is never
+// written here: the section is locked and returned with its current occupancy.
+// Returns a ConflictError if maximum_capacity is below that occupancy, or if it stores
+// batches of products of another product type, so a product type change cannot orphan them.
 func (repository *SectionRepositoryDB) Update(ctx context.Context, id int, data models.Section) (models.Section, error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Section{}, internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	const lockQuery = `SELECT current_capacity FROM sections WHERE id = ? FOR UPDATE`
+	if err := tx.QueryRowContext(ctx, lockQuery, id).Scan(&data.CurrentCapacity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Section{}, httperrors.NotFoundError{Message: "Section not found"}
+		}
+		return models.Section{}, internalError(ctx, "", err)
+	}
+	if data.MaximumCapacity < data.CurrentCapacity {
+		return models.Section{}, httperrors.ConflictError{Message: "Maximum capacity cannot be below the current occupancy of the section."}
+	}
+
+	const mismatchQuery = `
+        SELECT EXISTS(
+            SELECT 1
+            FROM product_batches pb
+            JOIN products p ON p.id = pb.product_id
+            WHERE pb.section_id = ? AND p.product_type_id <> ?
+        )
+    `
+	var mismatch bool
+	if err := tx.QueryRowContext(ctx, mismatchQuery, id, data.ProductTypeID).Scan(&mismatch); err != nil {
+		return models.Section{}, internalError(ctx, "", err)
+	}
+	if mismatch {
+		return models.Section{}, httperrors.ConflictError{Message: "Product type cannot change while the section stores batches of another product type."}
+	}
+
 	const query = `
         UPDATE sections SET
             section_number = ?, current_temperature = ?, minimum_temperature = ?,
-            current_capacity = ?, minimum_capacity = ?, maximum_capacity = ?,
+            minimum_capacity = ?, maximum_capacity = ?,
             warehouse_id = ?, product_type_id = ?
         WHERE id = ?
     `
-	_, err := repository.db.ExecContext(ctx, query,
+	_, err = tx.ExecContext(ctx, query,
 		data.SectionNumber, data.CurrentTemperature, data.MinimumTemperature,
-		data.CurrentCapacity, data.MinimumCapacity, data.MaximumCapacity,
+		data.MinimumCapacity, data.MaximumCapacity,
 		data.WarehouseID, data.ProductTypeID, id,
 	)
 
@@ -88,14 +135,17 @@ func (repository *SectionRepositoryDB) Update(ctx context.Context, id int, data
 			case 1062:
 				return models.Section{}, httperrors.ConflictError{Message: "Section number already exists."}
 			case 1452:
-				return models.Section{}, httperrors.ConflictError{Message: "Warehouse does not exist."}
+				return models.Section{}, sectionForeignKeyError(mysqlErr)
 			default:
-				return models.Section{}, httperrors.InternalServerError{}
+				return models.Section{}, internalError(ctx, "", err)
 			}
 		}
-		return models.Section{}, httperrors.InternalServerError{}
+		return models.Section{}, internalError(ctx, "", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return models.Section{}, internalError(ctx, "", err)
+	}
 	return data, nil
 }
 
@@ -110,23 +160,33 @@ func (repository *SectionRepositoryDB) Delete(ctx context.Context, id int) error
     // Execute the query
     result, err := repository.db.ExecContext(ctx, query, id)
     if err != nil {
-        return httperrors.InternalServerError{}
+        return internalError(ctx, "", err)
     }
 
     // Get the number of rows affected
     count, err := result.RowsAffected()
     if err != nil {
-        return httperrors.InternalServerError{}
+        return internalError(ctx, "", err)
     } else if count == 0 {
         return httperrors.NotFoundError{Message: "Section not found"}
     }
     return nil
 }
 
-// GetAll returns all sections in the repository
-func (repository *SectionRepositoryDB) GetAll(ctx context.Context) ([]models.Section, error) {
-    const query = `
-        SELECT
+// sectionListColumns are the fields sections can be sorted and filtered by
+var sectionListColumns = listColumns{
+    "id":               "id",
+    "section_number":   "section_number",
+    "current_capacity": "current_capacity",
+    "maximum_capacity": "maximum_capacity",
+    "warehouse_id":     "warehouse_id",
+    "product_type_id":  "product_type_id",
+}
+
+// GetAll returns the page of sections described by opts
+func (repository *SectionRepositoryDB) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Section], error) {
+    query := listQuery{
+        selectColumns: `
             id,
             section_number,
             current_temperature,
@@ -135,21 +195,14 @@ func (repository *SectionRepositoryDB) GetAll(ctx context.Context) ([]models.Sec
             minimum_capacity,
             maximum_capacity,
             warehouse_id,
-            product_type_id
-        FROM sections
-    `
-
-    // Execute the query
-    rows, err := repository.db.QueryContext(ctx, query)
-    if err != nil {
-        return nil, httperrors.InternalServerError{}
+            product_type_id`,
+        from:    "sections",
+        columns: sectionListColumns,
     }
-    defer rows.Close()
 
-    var sections []models.Section
-    for rows.Next() {
+    return queryPage(ctx, repository.db, query, opts, sectionListFields, func(rows *sql.Rows) (models.Section, error) {
         var section models.Section
-        err = rows.Scan(
+        err := rows.Scan(
             &section.ID,
             &section.SectionNumber,
             &section.CurrentTemperature,
@@ -160,17 +213,8 @@ func (repository *SectionRepositoryDB) GetAll(ctx context.Context) ([]models.Sec
             &section.WarehouseID,
             &section.ProductTypeID,
         )
-        if err != nil {
-            return nil, httperrors.InternalServerError{}
-        }
-
-        sections = append(sections, section)
-    }
-
-    if err := rows.Err(); err != nil {
-        return nil, httperrors.InternalServerError{}
-    }
-    return sections, nil
+        return section, err
+    })
 }
 
 // GetByID returns a section by its ID
@@ -192,7 +236,7 @@ func (repository *SectionRepositoryDB) GetByID(ctx context.Context, id int) (mod
 
     row := repository.db.QueryRowContext(ctx, query, id)
     if err := row.Err(); err != nil {
-        return models.Section{}, httperrors.InternalServerError{}
+        return models.Section{}, internalError(ctx, "", err)
     }
 
     var section models.Section
@@ -235,7 +279,7 @@ func (repository *SectionRepositoryDB) GetProductsReport(ctx context.Context, id
 			return models.SectionProductsReport{}, httperrors.NotFoundError{Message: "Section not found"}
 		}
 		// Any other error is treated as an internal server error.
-		return models.SectionProductsReport{}, httperrors.InternalServerError{}
+		return models.SectionProductsReport{}, internalError(ctx, "", err)
 	}
 
 	return report, nil
@@ -252,7 +296,7 @@ func (repository *SectionRepositoryDB) GetAllProductsReport(ctx context.Context)
 	`
 	rows, err := repository.db.QueryContext(ctx, query)
 	if err != nil {
-		return nil, httperrors.InternalServerError{}
+		return nil, internalError(ctx, "", err)
 	}
 	defer rows.Close()
 
@@ -261,14 +305,75 @@ func (repository *SectionRepositoryDB) GetAllProductsReport(ctx context.Context)
 		var report models.SectionProductsReport
 		err := rows.Scan(&report.SectionID, &report.SectionNumber, &report.ProductsCount)
 		if err != nil {
-			return nil, httperrors.InternalServerError{}
+			return nil, internalError(ctx, "", err)
 		}
 		reports = append(reports, report)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, httperrors.InternalServerError{}
+		return nil, internalError(ctx, "", err)
+	}
+
+	return reports, nil
+}
+
+// GetExpiringBatchesReport lists the batches with stock due between dueDateFrom and dueDateTo,
+// grouped by section and ordered by due date inside each section.
+// Returns a NotFoundError if warehouseID is set and the warehouse does not exist.
+func (repository *SectionRepositoryDB) GetExpiringBatchesReport(ctx context.Context, dueDateFrom, dueDateTo string, warehouseID int) ([]models.SectionExpiringBatchesReport, error) {
+	query := `
+		SELECT s.id, s.section_number, s.warehouse_id,
+			pb.id, pb.batch_number, pb.product_id, p.description, pb.current_quantity,
+			DATE_FORMAT(pb.due_date, '%Y-%m-%d')
+		FROM product_batches pb
+		JOIN sections s ON s.id = pb.section_id
+		JOIN products p ON p.id = pb.product_id
+		WHERE pb.current_quantity > 0 AND pb.due_date BETWEEN ? AND ?`
+	args := []any{dueDateFrom, dueDateTo}
+
+	if warehouseID != 0 {
+		const existsQuery = `SELECT EXISTS(SELECT 1 FROM warehouses WHERE id = ?)`
+		var exists bool
+		if err := repository.db.QueryRowContext(ctx, existsQuery, warehouseID).Scan(&exists); err != nil {
+			return nil, internalError(ctx, "", err)
+		}
+		if !exists {
+			return nil, httperrors.NotFoundError{Message: "warehouse not found"}
+		}
+		query += " AND s.warehouse_id = ?"
+		args = append(args, warehouseID)
+	}
+	query += "\n\t\tORDER BY s.id, pb.due_date, pb.id"
+
+	rows, err := repository.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, internalError(ctx, "", err)
 	}
+	defer rows.Close()
+
+	reports := make([]models.SectionExpiringBatchesReport, 0)
+	for rows.Next() {
+		var section models.SectionExpiringBatchesReport
+		var batch models.ExpiringBatch
+		err := rows.Scan(
+			&section.SectionID, &section.SectionNumber, &section.WarehouseID,
+			&batch.ProductBatchID, &batch.BatchNumber, &batch.ProductID, &batch.ProductDescription, &batch.CurrentQuantity,
+			&batch.DueDate,
+		)
+		if err != nil {
+			return nil, internalError(ctx, "", err)
+		}
 
+		// Rows come ordered by section, so a new section starts a new group
+		if len(reports) == 0 || reports[len(reports)-1].SectionID != section.SectionID {
+			reports = append(reports, section)
+		}
+		last := &reports[len(reports)-1]
+		last.Batches = append(last.Batches, batch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, internalError(ctx, "", err)
+	}
 	return reports, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// SectionRepositoryMemory implements SectionRepository in memory
+type SectionRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// NewSectionRepositoryMemory constructs a SectionRepositoryMemory backed by the given store.
+func NewSectionRepositoryMemory(store *MemoryStore) SectionRepository {
+	return &SectionRepositoryMemory{store: store}
+}
+
+// Create, creates a new section in the repository
+func (repository *SectionRepositoryMemory) Create(ctx context.Context, section models.Section) (models.Section, error) {
+	repository.store.mu.Lock()
+	defer repository.store.mu.Unlock()
+
+	if err := repository.checkConstraints(0, section); err != nil {
+		return models.Section{}, err
+	}
+
+	section.ID = repository.store.nextID("sections")
+	repository.store.sections[section.ID] = section
+	return section, nil
+}
+
+// Update, updates a section in the repository, keeping its current occupancy.
+// Returns a ConflictError if maximum_capacity is below that occupancy or if it stores
+// batches of products of another product type.
+func (repository *SectionRepositoryMemory) Update(ctx context.Context, id int, data models.Section) (models.Section, error) {
+	repository.store.mu.Lock()
+	defer repository.store.mu.Unlock()
+
+	stored, ok := repository.store.sections[id]
+	if !ok {
+		return models.Section{}, httperrors.NotFoundError{Message: "Section not found"}
+	}
+	data.CurrentCapacity = stored.CurrentCapacity
+	if data.MaximumCapacity < data.CurrentCapacity {
+		return models.Section{}, httperrors.ConflictError{Message: "Maximum capacity cannot be below the current occupancy of the section."}
+	}
+	if err := repository.checkConstraints(id, data); err != nil {
+		return models.Section{}, err
+	}
+	for _, batch := range repository.store.productBatches {
+		if batch.SectionID == id && repository.store.products[batch.ProductID].ProductTypeID != data.ProductTypeID {
+			return models.Section{}, httperrors.ConflictError{Message: "Product type cannot change while the section stores batches of another product type."}
+		}
+	}
+
+	stored = data
+	stored.ID = id
+	repository.store.sections[id] = stored
+	return data, nil
+}
+
+// Delete, deletes a section from the repository
+func (repository *SectionRepositoryMemory) Delete(ctx context.Context, id int) error {
+	repository.store.mu.Lock()
+	defer repository.store.mu.Unlock()
+
+	if _, ok := repository.store.sections[id]; !ok {
+		return httperrors.NotFoundError{Message: "Section not found"}
+	}
+	for _, batch := range repository.store.productBatches {
+		if batch.SectionID == id {
+			return httperrors.ConflictError{Message: "Section still has product batches."}
+		}
+	}
+
+	// Readings and alerts are removed with their section, as ON DELETE CASCADE does
+	for readingID, reading := range repository.store.temperatureReadings {
+		if reading.SectionID == id {
+			delete(repository.store.temperatureReadings, readingID)
+		}
+	}
+	for alertID, alert := range repository.store.alerts {
+		if alert.SectionID == id {
+			delete(repository.store.alerts, alertID)
+		}
+	}
+	delete(repository.store.sections, id)
+	return nil
+}
+
+// sectionListFields are the fields sections can be sorted and filtered by
+var sectionListFields = listFields[models.Section]{
+	"id":               func(s models.Section) any { return s.ID },
+	"section_number":   func(s models.Section) any { return s.SectionNumber },
+	"current_capacity": func(s models.Section) any { return s.CurrentCapacity },
+	"maximum_capacity": func(s models.Section) any { return s.MaximumCapacity },
+	"warehouse_id":     func(s models.Section) any { return s.WarehouseID },
+	"product_type_id":  func(s models.Section) any { return s.ProductTypeID },
+}
+
+// GetAll returns the page of sections described by opts
+func (repository *SectionRepositoryMemory) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Section], error) {
+	repository.store.mu.RLock()
+	defer repository.store.mu.RUnlock()
+
+	return pageOf(sortedByID(repository.store.sections), opts, sectionListFields)
+}
+
+// GetByID returns a section by its ID
+func (repository *SectionRepositoryMemory) GetByID(ctx context.Context, id int) (models.Section, error) {
+	repository.store.mu.RLock()
+	defer repository.store.mu.RUnlock()
+
+	section, ok := repository.store.sections[id]
+	if !ok {
+		return models.Section{}, httperrors.NotFoundError{Message: "Section not found"}
+	}
+	return section, nil
+}
+
+// GetProductsReport gets a product count report for a specific section.
+func (repository *SectionRepositoryMemory) GetProductsReport(ctx context.Context, id int) (models.SectionProductsReport, error) {
+	repository.store.mu.RLock()
+	defer repository.store.mu.RUnlock()
+
+	section, ok := repository.store.sections[id]
+	if !ok {
+		return models.SectionProductsReport{}, httperrors.NotFoundError{Message: "Section not found"}
+	}
+	return repository.productsReport(section), nil
+}
+
+// GetAllProductsReport gets a product count report for all sections.
+func (repository *SectionRepositoryMemory) GetAllProductsReport(ctx context.Context) ([]models.SectionProductsReport, error) {
+	repository.store.mu.RLock()
+	defer repository.store.mu.RUnlock()
+
+	var reports []models.SectionProductsReport
+	for _, section := range sortedByID(repository.store.sections) {
+		reports = append(reports, repository.productsReport(section))
+	}
+	return reports, nil
+}
+
+// productsReport counts the product batches stored in the given section.
+// The caller must hold the lock.
+func (repository *SectionRepositoryMemory) productsReport(section models.Section) models.SectionProductsReport {
+	report := models.SectionProductsReport{
+		SectionID:     section.ID,
+		SectionNumber: section.SectionNumber,
+	}
+	for _, batch := range repository.store.productBatches {
+		if batch.SectionID == section.ID {
+			report.ProductsCount++
+		}
+	}
+	return report
+}
+
+// GetExpiringBatchesReport lists the batches with stock due between dueDateFrom and dueDateTo,
+// grouped by section and ordered by due date inside each section.
+func (repository *SectionRepositoryMemory) GetExpiringBatchesReport(ctx context.Context, dueDateFrom, dueDateTo string, warehouseID int) ([]models.SectionExpiringBatchesReport, error) {
+	repository.store.mu.RLock()
+	defer repository.store.mu.RUnlock()
+
+	if warehouseID != 0 {
+		if _, ok := repository.store.warehouses[warehouseID]; !ok {
+			return nil, httperrors.NotFoundError{Message: "warehouse not found"}
+		}
+	}
+
+	reports := make([]models.SectionExpiringBatchesReport, 0)
+	batches := sortedByID(repository.store.productBatches)
+	for _, section := range sortedByID(repository.store.sections) {
+		if warehouseID != 0 && section.WarehouseID != warehouseID {
+			continue
+		}
+
+		var expiring []models.ExpiringBatch
+		for _, batch := range batches {
+			// Dates are YYYY-MM-DD, so they compare in calendar order as strings
+			if batch.SectionID != section.ID || batch.CurrentQuantity <= 0 ||
+				batch.DueDate < dueDateFrom || batch.DueDate > dueDateTo {
+				continue
+			}
+			expiring = append(expiring, models.ExpiringBatch{
+				ProductBatchID:     batch.ID,
+				BatchNumber:        batch.BatchNumber,
+				ProductID:          batch.ProductID,
+				ProductDescription: repository.store.products[batch.ProductID].Description,
+				CurrentQuantity:    batch.CurrentQuantity,
+				DueDate:            batch.DueDate,
+			})
+		}
+		if len(expiring) == 0 {
+			continue
+		}
+
+		sort.SliceStable(expiring, func(i, j int) bool {
+			return expiring[i].DueDate < expiring[j].DueDate
+		})
+		reports = append(reports, models.SectionExpiringBatchesReport{
+			SectionID:     section.ID,
+			SectionNumber: section.SectionNumber,
+			WarehouseID:   section.WarehouseID,
+			Batches:       expiring,
+		})
+	}
+	return reports, nil
+}
+
+// checkConstraints validates the unique section_number and the warehouse and product type
+// foreign keys for the section identified by id (0 for a new section).
+// The caller must hold the lock.
+func (repository *SectionRepositoryMemory) checkConstraints(id int, section models.Section) error {
+	for _, existing := range repository.store.sections {
+		if existing.ID != id && existing.SectionNumber == section.SectionNumber {
+			return httperrors.ConflictError{Message: "Section number already exists."}
+		}
+	}
+	if _, ok := repository.store.warehouses[section.WarehouseID]; !ok {
+		return httperrors.ConflictError{Message: "Warehouse does not exist."}
+	}
+	if _, ok := repository.store.productTypes[section.ProductTypeID]; !ok {
+		return httperrors.ConflictError{Message: "Product type does not exist."}
+	}
+	return nil
+}
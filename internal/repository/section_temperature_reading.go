@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// SectionTemperatureReadingRepositoryDB implements SectionTemperatureReadingRepository
+type SectionTemperatureReadingRepositoryDB struct {
+	db *sql.DB
+}
+
+/*
+NewSectionTemperatureReadingRepositoryDB constructs a SectionTemperatureReadingRepositoryDB
+that uses the given *sql.DB for all data operations.
+*/
+func NewSectionTemperatureReadingRepositoryDB(db *sql.DB) SectionTemperatureReadingRepository {
+	return &SectionTemperatureReadingRepositoryDB{
+		db: db,
+	}
+}
+
+// CreateBulk stores the readings and refreshes the section current_temperature in one transaction.
+// Returns a NotFoundError if the section does not exist.
+func (repository *SectionTemperatureReadingRepositoryDB) CreateBulk(ctx context.Context, sectionID int, readings []models.SectionTemperatureReading) ([]models.SectionTemperatureReading, error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the section so concurrent ingestions refresh current_temperature one after the other
+	const lockQuery = `SELECT id FROM sections WHERE id = ? FOR UPDATE`
+	var id int
+	if err := tx.QueryRowContext(ctx, lockQuery, sectionID).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperrors.NotFoundError{Message: "Section not found"}
+		}
+		return nil, internalError(ctx, "", err)
+	}
+
+	const insertQuery = `
+        INSERT INTO section_temperature_readings (section_id, temperature, recorded_at)
+        VALUES (?, ?, ?)
+    `
+	stmt, err := tx.PrepareContext(ctx, insertQuery)
+	if err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	defer stmt.Close()
+
+	created := make([]models.SectionTemperatureReading, 0, len(readings))
+	for _, reading := range readings {
+		result, err := stmt.ExecContext(ctx, sectionID, reading.Temperature, reading.RecordedAt)
+		if err != nil {
+			return nil, internalError(ctx, "", err)
+		}
+		lastId, err := result.LastInsertId()
+		if err != nil {
+			return nil, internalError(ctx, "", err)
+		}
+		reading.ID = int(lastId)
+		reading.SectionID = sectionID
+		created = append(created, reading)
+	}
+
+	// The current temperature is the most recent reading, which may be an older one
+	// if this request only carried late readings
+	const updateQuery = `
+        UPDATE sections SET current_temperature = (
+            SELECT temperature FROM section_temperature_readings
+            WHERE section_id = ?
+            ORDER BY recorded_at DESC, id DESC
+            LIMIT 1
+        )
+        WHERE id = ?
+    `
+	if _, err := tx.ExecContext(ctx, updateQuery, sectionID, sectionID); err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	return created, nil
+}
+
+// GetLatestBySectionID returns the most recent reading of a section, or nil if it has none
+func (repository *SectionTemperatureReadingRepositoryDB) GetLatestBySectionID(ctx context.Context, sectionID int) (*models.SectionTemperatureReading, error) {
+	const query = `
+        SELECT id, section_id, temperature, recorded_at
+        FROM section_temperature_readings
+        WHERE section_id = ?
+        ORDER BY recorded_at DESC, id DESC
+        LIMIT 1
+    `
+	var reading models.SectionTemperatureReading
+	err := repository.db.QueryRowContext(ctx, query, sectionID).
+		Scan(&reading.ID, &reading.SectionID, &reading.Temperature, &reading.RecordedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, internalError(ctx, "", err)
+	}
+	return &reading, nil
+}
+
+// GetBySectionID returns the readings of a section recorded between from and to, oldest first.
+// Returns a NotFoundError if the section does not exist.
+func (repository *SectionTemperatureReadingRepositoryDB) GetBySectionID(ctx context.Context, sectionID int, from, to time.Time) ([]models.SectionTemperatureReading, error) {
+	const existsQuery = `SELECT EXISTS(SELECT 1 FROM sections WHERE id = ?)`
+	var exists bool
+	if err := repository.db.QueryRowContext(ctx, existsQuery, sectionID).Scan(&exists); err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	if !exists {
+		return nil, httperrors.NotFoundError{Message: "Section not found"}
+	}
+
+	const query = `
+        SELECT id, section_id, temperature, recorded_at
+        FROM section_temperature_readings
+        WHERE section_id = ? AND recorded_at BETWEEN ? AND ?
+        ORDER BY recorded_at, id
+    `
+	rows, err := repository.db.QueryContext(ctx, query, sectionID, from, to)
+	if err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	defer rows.Close()
+
+	readings := make([]models.SectionTemperatureReading, 0)
+	for rows.Next() {
+		var reading models.SectionTemperatureReading
+		if err := rows.Scan(&reading.ID, &reading.SectionID, &reading.Temperature, &reading.RecordedAt); err != nil {
+			return nil, internalError(ctx, "", err)
+		}
+		readings = append(readings, reading)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	return readings, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// SectionTemperatureReadingRepositoryMemory implements SectionTemperatureReadingRepository in memory
+type SectionTemperatureReadingRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// NewSectionTemperatureReadingRepositoryMemory constructs a SectionTemperatureReadingRepositoryMemory
+// backed by the given store.
+func NewSectionTemperatureReadingRepositoryMemory(store *MemoryStore) SectionTemperatureReadingRepository {
+	return &SectionTemperatureReadingRepositoryMemory{store: store}
+}
+
+// CreateBulk stores the readings and sets the section current_temperature to its most recent reading.
+func (repository *SectionTemperatureReadingRepositoryMemory) CreateBulk(ctx context.Context, sectionID int, readings []models.SectionTemperatureReading) ([]models.SectionTemperatureReading, error) {
+	repository.store.mu.Lock()
+	defer repository.store.mu.Unlock()
+
+	section, ok := repository.store.sections[sectionID]
+	if !ok {
+		return nil, httperrors.NotFoundError{Message: "Section not found"}
+	}
+
+	created := make([]models.SectionTemperatureReading, 0, len(readings))
+	for _, reading := range readings {
+		reading.ID = repository.store.nextID("section_temperature_readings")
+		reading.SectionID = sectionID
+		repository.store.temperatureReadings[reading.ID] = reading
+		created = append(created, reading)
+	}
+
+	if latest := repository.latest(sectionID); latest != nil {
+		section.CurrentTemperature = latest.Temperature
+		repository.store.sections[sectionID] = section
+	}
+	return created, nil
+}
+
+// GetLatestBySectionID returns the most recent reading of a section, or nil if it has none
+func (repository *SectionTemperatureReadingRepositoryMemory) GetLatestBySectionID(ctx context.Context, sectionID int) (*models.SectionTemperatureReading, error) {
+	repository.store.mu.RLock()
+	defer repository.store.mu.RUnlock()
+
+	return repository.latest(sectionID), nil
+}
+
+// latest returns the most recent reading of a section, the newest one on ties. The caller holds the lock.
+func (repository *SectionTemperatureReadingRepositoryMemory) latest(sectionID int) *models.SectionTemperatureReading {
+	var latest *models.SectionTemperatureReading
+	for _, reading := range sortedByID(repository.store.temperatureReadings) {
+		if reading.SectionID == sectionID && (latest == nil || !reading.RecordedAt.Before(latest.RecordedAt)) {
+			latest = &reading
+		}
+	}
+	return latest
+}
+
+// GetBySectionID returns the readings of a section recorded between from and to, oldest first.
+func (repository *SectionTemperatureReadingRepositoryMemory) GetBySectionID(ctx context.Context, sectionID int, from, to time.Time) ([]models.SectionTemperatureReading, error) {
+	repository.store.mu.RLock()
+	defer repository.store.mu.RUnlock()
+
+	if _, ok := repository.store.sections[sectionID]; !ok {
+		return nil, httperrors.NotFoundError{Message: "Section not found"}
+	}
+
+	readings := make([]models.SectionTemperatureReading, 0)
+	for _, reading := range sortedByID(repository.store.temperatureReadings) {
+		if reading.SectionID == sectionID && !reading.RecordedAt.Before(from) && !reading.RecordedAt.After(to) {
+			readings = append(readings, reading)
+		}
+	}
+	sort.SliceStable(readings, func(i, j int) bool {
+		return readings[i].RecordedAt.Before(readings[j].RecordedAt)
+	})
+	return readings, nil
+}
@@ -69,6 +69,14 @@ func TestSectionRepository_Create(t *testing.T) {
 			expectedResp:  models.Section{},
 			expectedError: httperrors.ConflictError{Message: "Warehouse does not exist."},
 		},
+		{
+			testName: "Fail: Conflict on non-existent product type (1452)",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).WillReturnError(&mysql.MySQLError{Number: 1452, Message: "a foreign key constraint fails (CONSTRAINT `fk_sections_product_type`)"})
+			},
+			expectedResp:  models.Section{},
+			expectedError: httperrors.ConflictError{Message: "Product type does not exist."},
+		},
 		{
 			testName: "Fail: Internal Server Error on other mysql error",
 			mockSetup: func(mock sqlmock.Sqlmock) {
@@ -135,10 +143,23 @@ func TestSectionRepository_Update(t *testing.T) {
 	expectedQuery := regexp.QuoteMeta(`
         UPDATE sections SET
             section_number = ?, current_temperature = ?, minimum_temperature = ?,
-            current_capacity = ?, minimum_capacity = ?, maximum_capacity = ?,
+            minimum_capacity = ?, maximum_capacity = ?,
             warehouse_id = ?, product_type_id = ?
         WHERE id = ?
     `)
+	lockQuery := regexp.QuoteMeta(`SELECT current_capacity FROM sections WHERE id = ? FOR UPDATE`)
+	// The stored occupancy wins over the one of the section given
+	storedOccupancy := 60
+	updatedSection := sectionToUpdate
+	updatedSection.CurrentCapacity = storedOccupancy
+	mismatchQuery := `SELECT EXISTS\(\s*SELECT 1\s*FROM product_batches pb\s*JOIN products p ON p.id = pb.product_id\s*WHERE pb.section_id = \? AND p.product_type_id <> \?\s*\)`
+	expectTypeCheck := func(mock sqlmock.Sqlmock, mismatch bool) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(inputID).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity"}).AddRow(storedOccupancy))
+		mock.ExpectQuery(mismatchQuery).WithArgs(inputID, sectionToUpdate.ProductTypeID).
+			WillReturnRows(sqlmock.NewRows([]string{"mismatch"}).AddRow(mismatch))
+	}
 
 	tests := []struct {
 		testName      string
@@ -149,17 +170,42 @@ func TestSectionRepository_Update(t *testing.T) {
 		{
 			testName: "Success: Should update section correctly",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTypeCheck(mock, false)
 				mock.ExpectExec(expectedQuery).
-					WithArgs(sectionToUpdate.SectionNumber, sectionToUpdate.CurrentTemperature, sectionToUpdate.MinimumTemperature, sectionToUpdate.CurrentCapacity, sectionToUpdate.MinimumCapacity, sectionToUpdate.MaximumCapacity, sectionToUpdate.WarehouseID, sectionToUpdate.ProductTypeID, inputID).
+					WithArgs(sectionToUpdate.SectionNumber, sectionToUpdate.CurrentTemperature, sectionToUpdate.MinimumTemperature, sectionToUpdate.MinimumCapacity, sectionToUpdate.MaximumCapacity, sectionToUpdate.WarehouseID, sectionToUpdate.ProductTypeID, inputID).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
-			expectedResp:  sectionToUpdate,
+			expectedResp:  updatedSection,
 			expectedError: nil,
 		},
+		{
+			testName: "Fail: Section not found",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(inputID).WillReturnRows(sqlmock.NewRows([]string{"current_capacity"}))
+				mock.ExpectRollback()
+			},
+			expectedResp:  models.Section{},
+			expectedError: httperrors.NotFoundError{Message: "Section not found"},
+		},
+		{
+			testName: "Fail: Conflict on maximum capacity below the occupancy",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(inputID).
+					WillReturnRows(sqlmock.NewRows([]string{"current_capacity"}).AddRow(sectionToUpdate.MaximumCapacity + 1))
+				mock.ExpectRollback()
+			},
+			expectedResp:  models.Section{},
+			expectedError: httperrors.ConflictError{Message: "Maximum capacity cannot be below the current occupancy of the section."},
+		},
 		{
 			testName: "Fail: Conflict on duplicate section number (1062)",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTypeCheck(mock, false)
 				mock.ExpectExec(expectedQuery).WillReturnError(&mysql.MySQLError{Number: 1062})
+				mock.ExpectRollback()
 			},
 			expectedResp:  models.Section{},
 			expectedError: httperrors.ConflictError{Message: "Section number already exists."},
@@ -167,15 +213,28 @@ func TestSectionRepository_Update(t *testing.T) {
 		{
 			testName: "Fail: Conflict on non-existent warehouse (1452)",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTypeCheck(mock, false)
 				mock.ExpectExec(expectedQuery).WillReturnError(&mysql.MySQLError{Number: 1452})
+				mock.ExpectRollback()
 			},
 			expectedResp:  models.Section{},
 			expectedError: httperrors.ConflictError{Message: "Warehouse does not exist."},
 		},
+		{
+			testName: "Fail: Conflict on product type change that orphans batches",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTypeCheck(mock, true)
+				mock.ExpectRollback()
+			},
+			expectedResp:  models.Section{},
+			expectedError: httperrors.ConflictError{Message: "Product type cannot change while the section stores batches of another product type."},
+		},
 		{
 			testName: "Fail: Internal Server Error on other mysql error",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTypeCheck(mock, false)
 				mock.ExpectExec(expectedQuery).WillReturnError(&mysql.MySQLError{Number: 1146})
+				mock.ExpectRollback()
 			},
 			expectedResp:  models.Section{},
 			expectedError: httperrors.InternalServerError{},
@@ -183,7 +242,9 @@ func TestSectionRepository_Update(t *testing.T) {
 		{
 			testName: "Fail: Internal Server Error on generic db error",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTypeCheck(mock, false)
 				mock.ExpectExec(expectedQuery).WillReturnError(errors.New("any database error"))
+				mock.ExpectRollback()
 			},
 			expectedResp:  models.Section{},
 			expectedError: httperrors.InternalServerError{},
@@ -286,6 +347,7 @@ func TestSectionRepository_GetAll(t *testing.T) {
             warehouse_id, product_type_id
         FROM sections
     `)
+	countQuery := regexp.QuoteMeta("SELECT COUNT(*) FROM sections")
 
 	columns := []string{
 		"id", "section_number", "current_temperature", "minimum_temperature",
@@ -301,41 +363,97 @@ func TestSectionRepository_GetAll(t *testing.T) {
 	tests := []struct {
 		testName      string
 		mockSetup     func(mock sqlmock.Sqlmock)
-		expectedResp  []models.Section
+		opts          models.QueryOptions
+		expectedResp  models.Page[models.Section]
 		expectedError error
 	}{
 		{
 			testName: "Success: Should return all sections",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(countQuery).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
 				rows := sqlmock.NewRows(columns).
 					AddRow(expectedSections[0].ID, expectedSections[0].SectionNumber, expectedSections[0].CurrentTemperature, expectedSections[0].MinimumTemperature, expectedSections[0].CurrentCapacity, expectedSections[0].MinimumCapacity, expectedSections[0].MaximumCapacity, expectedSections[0].WarehouseID, expectedSections[0].ProductTypeID).
 					AddRow(expectedSections[1].ID, expectedSections[1].SectionNumber, expectedSections[1].CurrentTemperature, expectedSections[1].MinimumTemperature, expectedSections[1].CurrentCapacity, expectedSections[1].MinimumCapacity, expectedSections[1].MaximumCapacity, expectedSections[1].WarehouseID, expectedSections[1].ProductTypeID)
 				
 				mock.ExpectQuery(expectedQuery).WillReturnRows(rows)
 			},
-			expectedResp:  expectedSections,
+			expectedResp:  models.NewPage(expectedSections, 2, nil),
+			expectedError: nil,
+		},
+		{
+			testName: "Success: Should return the first page with the cursor of the next one",
+			opts:     models.QueryOptions{Limit: 1, Sort: "section_number"},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(countQuery).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+				rows := sqlmock.NewRows(columns).
+					AddRow(expectedSections[0].ID, expectedSections[0].SectionNumber, expectedSections[0].CurrentTemperature, expectedSections[0].MinimumTemperature, expectedSections[0].CurrentCapacity, expectedSections[0].MinimumCapacity, expectedSections[0].MaximumCapacity, expectedSections[0].WarehouseID, expectedSections[0].ProductTypeID).
+					AddRow(expectedSections[1].ID, expectedSections[1].SectionNumber, expectedSections[1].CurrentTemperature, expectedSections[1].MinimumTemperature, expectedSections[1].CurrentCapacity, expectedSections[1].MinimumCapacity, expectedSections[1].MaximumCapacity, expectedSections[1].WarehouseID, expectedSections[1].ProductTypeID)
+
+				// One row more than the limit is read to know there is a next page
+				mock.ExpectQuery(expectedQuery+regexp.QuoteMeta(" ORDER BY section_number ASC, id ASC LIMIT ?")).
+					WithArgs(2).
+					WillReturnRows(rows)
+			},
+			expectedResp:  models.NewPage(expectedSections[:1], 2, &models.Cursor{Sort: "section_number", Value: "SEC-101", ID: 1}),
 			expectedError: nil,
 		},
+		{
+			testName: "Success: Should return a page of the sections of a warehouse",
+			opts: models.QueryOptions{
+				Limit: 1, Sort: "section_number", Filters: map[string]string{"warehouse_id": "1"},
+				After: &models.Cursor{Sort: "section_number", Value: "SEC-101", ID: 1},
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM sections WHERE warehouse_id = ?")).
+					WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+				rows := sqlmock.NewRows(columns).
+					AddRow(expectedSections[1].ID, expectedSections[1].SectionNumber, expectedSections[1].CurrentTemperature, expectedSections[1].MinimumTemperature, expectedSections[1].CurrentCapacity, expectedSections[1].MinimumCapacity, expectedSections[1].MaximumCapacity, expectedSections[1].WarehouseID, expectedSections[1].ProductTypeID)
+
+				mock.ExpectQuery(expectedQuery+regexp.QuoteMeta(" WHERE warehouse_id = ? AND (section_number > ? OR (section_number = ? AND id > ?)) ORDER BY section_number ASC, id ASC LIMIT ?")).
+					WithArgs("1", "SEC-101", "SEC-101", 1, 2).
+					WillReturnRows(rows)
+			},
+			expectedResp:  models.NewPage(expectedSections[1:], 2, nil),
+			expectedError: nil,
+		},
+		{
+			testName:      "Fail: Should return bad request on unknown filter field",
+			opts:          models.QueryOptions{Filters: map[string]string{"current_temperature": "20"}},
+			mockSetup:     func(mock sqlmock.Sqlmock) {},
+			expectedResp:  models.Page[models.Section]{},
+			expectedError: httperrors.BadRequestError{Message: "Invalid filter field"},
+		},
+		{
+			testName:      "Fail: Should return bad request on a cursor of another sort field",
+			opts:          models.QueryOptions{After: &models.Cursor{Sort: "id", ID: 1}, Sort: "section_number"},
+			mockSetup:     func(mock sqlmock.Sqlmock) {},
+			expectedResp:  models.Page[models.Section]{},
+			expectedError: httperrors.BadRequestError{Message: "Invalid cursor"},
+		},
 		{
 			testName: "Fail: Should return internal server error on query error",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(countQuery).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
 				mock.ExpectQuery(expectedQuery).WillReturnError(errors.New("db query error"))
 			},
-			expectedResp:  nil,
+			expectedResp:  models.Page[models.Section]{},
 			expectedError: httperrors.InternalServerError{},
 		},
 		{
 			testName: "Fail: Should return internal server error on scan error",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(countQuery).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
 				rows := sqlmock.NewRows(columns).AddRow("invalid_id", "SEC-101", 20, 15, 50, 10, 100, 1, 1)
 				mock.ExpectQuery(expectedQuery).WillReturnRows(rows)
 			},
-			expectedResp:  nil,
+			expectedResp:  models.Page[models.Section]{},
 			expectedError: httperrors.InternalServerError{},
 		},
         {
             testName: "Fail: Should return internal server error on rows error",
             mockSetup: func(mock sqlmock.Sqlmock) {
+                mock.ExpectQuery(countQuery).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
                 rows := sqlmock.NewRows(columns).
                     AddRow(expectedSections[0].ID, expectedSections[0].SectionNumber, expectedSections[0].CurrentTemperature, expectedSections[0].MinimumTemperature, expectedSections[0].CurrentCapacity, expectedSections[0].MinimumCapacity, expectedSections[0].MaximumCapacity, expectedSections[0].WarehouseID, expectedSections[0].ProductTypeID)
                 
@@ -343,7 +461,7 @@ func TestSectionRepository_GetAll(t *testing.T) {
                 
                 mock.ExpectQuery(expectedQuery).WillReturnRows(rows)
             },
-            expectedResp:  nil,
+            expectedResp:  models.Page[models.Section]{},
             expectedError: httperrors.InternalServerError{},
         },
 	}
@@ -357,7 +475,7 @@ func TestSectionRepository_GetAll(t *testing.T) {
 			repo := NewSectionRepositoryDB(db)
 			tt.mockSetup(mock)
 
-			result, err := repo.GetAll(context.Background())
+			result, err := repo.GetAll(context.Background(), tt.opts)
 
 			assert.Equal(t, tt.expectedError, err)
 			assert.Equal(t, tt.expectedResp, result)
@@ -601,4 +719,82 @@ func TestSectionRepository_GetAllProductsReport(t *testing.T) {
 			assert.NoError(t, err)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestSectionRepository_GetExpiringBatchesReport(t *testing.T) {
+	columns := []string{"id", "section_number", "warehouse_id", "id", "batch_number", "product_id", "description", "current_quantity", "due_date"}
+	reportQuery := regexp.QuoteMeta(`FROM product_batches pb`)
+	existsQuery := regexp.QuoteMeta(`SELECT EXISTS(SELECT 1 FROM warehouses WHERE id = ?)`)
+
+	tests := []struct {
+		testName      string
+		warehouseID   int
+		mockSetup     func(mock sqlmock.Sqlmock)
+		expectedResp  []models.SectionExpiringBatchesReport
+		expectedError error
+	}{
+		{
+			testName: "Success: batches are grouped by section",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(reportQuery).WithArgs("2026-01-01", "2026-01-08").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "SEC-101", 1, 10, 100, 5, "Frozen peas", 20, "2026-01-02").
+						AddRow(1, "SEC-101", 1, 11, 101, 6, "Ice cream", 5, "2026-01-05").
+						AddRow(2, "SEC-102", 1, 12, 102, 5, "Frozen peas", 8, "2026-01-03"))
+			},
+			expectedResp: []models.SectionExpiringBatchesReport{
+				{SectionID: 1, SectionNumber: "SEC-101", WarehouseID: 1, Batches: []models.ExpiringBatch{
+					{ProductBatchID: 10, BatchNumber: 100, ProductID: 5, ProductDescription: "Frozen peas", CurrentQuantity: 20, DueDate: "2026-01-02"},
+					{ProductBatchID: 11, BatchNumber: 101, ProductID: 6, ProductDescription: "Ice cream", CurrentQuantity: 5, DueDate: "2026-01-05"},
+				}},
+				{SectionID: 2, SectionNumber: "SEC-102", WarehouseID: 1, Batches: []models.ExpiringBatch{
+					{ProductBatchID: 12, BatchNumber: 102, ProductID: 5, ProductDescription: "Frozen peas", CurrentQuantity: 8, DueDate: "2026-01-03"},
+				}},
+			},
+		},
+		{
+			testName:    "Success: filtered by warehouse without results",
+			warehouseID: 1,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(existsQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectQuery(reportQuery).WithArgs("2026-01-01", "2026-01-08", 1).WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedResp: []models.SectionExpiringBatchesReport{},
+		},
+		{
+			testName:    "Fail: warehouse not found",
+			warehouseID: 9,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(existsQuery).WithArgs(9).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			expectedError: httperrors.NotFoundError{Message: "warehouse not found"},
+		},
+		{
+			testName: "Fail: query error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(reportQuery).WillReturnError(errors.New("db error"))
+			},
+			expectedError: httperrors.InternalServerError{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			repo := NewSectionRepositoryDB(db)
+			result, err := repo.GetExpiringBatchesReport(context.Background(), "2026-01-01", "2026-01-08", tc.warehouseID)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResp, result)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
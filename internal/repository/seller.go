@@ -1,9 +1,9 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
-	"log"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
@@ -20,7 +20,7 @@ func NewSellerRepository(db *sql.DB) SellerRepository {
 
 // This function gets a seller by its ID from the database.
 // If the seller is not found, it returns a NotFoundError.
-func (r SellerRepositoryDB) GetByID(id int) (models.Seller, error) {
+func (r SellerRepositoryDB) GetByID(ctx context.Context, id int) (models.Seller, error) {
 	var seller models.Seller
 	const query = `
 		SELECT id, 
@@ -32,50 +32,46 @@ func (r SellerRepositoryDB) GetByID(id int) (models.Seller, error) {
 		FROM sellers 
 		WHERE id = ?
 	`
-	err := r.db.QueryRow(query, id).Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone, &seller.LocalityID)
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone, &seller.LocalityID)
 	if err == sql.ErrNoRows {
 		return models.Seller{}, httperrors.NotFoundError{Message: "Seller not found"}
 	}
 	return seller, err
 }
 
-// This function retrieves all sellers from the database.
-// It returns a slice of Seller models or an error if the query fails.
-func (r SellerRepositoryDB) GetAll() ([]models.Seller, error) {
-	const query = `
-		SELECT id, cid, company_name, address, telephone, locality_id 
-		FROM sellers
-		`
-	rows, err := r.db.Query(query)
-	if err != nil {
-		return nil, err
+// sellerListColumns are the fields sellers can be sorted and filtered by.
+var sellerListColumns = listColumns{
+	"id":           "id",
+	"cid":          "cid",
+	"company_name": "company_name",
+	"locality_id":  "locality_id",
+}
+
+// This function retrieves the page of sellers described by opts from the database.
+// It returns a BadRequestError for unknown sort or filter fields.
+func (r SellerRepositoryDB) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Seller], error) {
+	query := listQuery{
+		selectColumns: "id, cid, company_name, address, telephone, locality_id",
+		from:          "sellers",
+		columns:       sellerListColumns,
 	}
-	defer func() {
-		if err := rows.Close(); err != nil {
-			log.Printf("error closing rows: %v", err)
-		}
-	}()
 
-	var sellers []models.Seller
-	for rows.Next() {
+	return queryPage(ctx, r.db, query, opts, sellerListFields, func(rows *sql.Rows) (models.Seller, error) {
 		var s models.Seller
-		if err := rows.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityID); err != nil {
-			return nil, err
-		}
-		sellers = append(sellers, s)
-	}
-	return sellers, nil
+		err := rows.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityID)
+		return s, err
+	})
 }
 
 // This function creates a new seller in the database.
 // It takes a SellerAttributes struct as input and returns the created Seller or an error.
 // It checks for unique constraints on the CID and foreign key constraints on the locality_id.
-func (r *SellerRepositoryDB) Create(att models.SellerAttributes) (models.Seller, error) {
+func (r *SellerRepositoryDB) Create(ctx context.Context, att models.SellerAttributes) (models.Seller, error) {
 	const queryInsertSeller = `
         INSERT INTO sellers (cid, company_name, address, telephone, locality_id) 
         VALUES (?, ?, ?, ?, ?)
     `
-	res, err := r.db.Exec(queryInsertSeller, att.CID, att.CompanyName, att.Address, att.Telephone, att.LocalityID)
+	res, err := r.db.ExecContext(ctx, queryInsertSeller, att.CID, att.CompanyName, att.Address, att.Telephone, att.LocalityID)
 	if err != nil {
 		var sqlErr *mysql.MySQLError
 		if errors.As(err, &sqlErr) {
@@ -87,7 +83,7 @@ func (r *SellerRepositoryDB) Create(att models.SellerAttributes) (models.Seller,
 				return models.Seller{}, httperrors.ConflictError{Message: "Locality ID does not exist"}
 			}
 		}
-		return models.Seller{}, httperrors.InternalServerError{Message: "Error creating seller"}
+		return models.Seller{}, internalError(ctx, "Error creating seller", err)
 	}
 	id, _ := res.LastInsertId()
 	return models.Seller{
@@ -98,9 +94,9 @@ func (r *SellerRepositoryDB) Create(att models.SellerAttributes) (models.Seller,
 
 // This function deletes a seller by its ID from the database.
 // If the seller does not exist, it returns a NotFoundError.
-func (r *SellerRepositoryDB) Delete(id int) error {
+func (r *SellerRepositoryDB) Delete(ctx context.Context, id int) error {
 	const queryDelete = `DELETE FROM sellers WHERE id = ?`
-	res, err := r.db.Exec(queryDelete, id)
+	res, err := r.db.ExecContext(ctx, queryDelete, id)
 	if err != nil {
 		return err
 	}
@@ -113,8 +109,8 @@ func (r *SellerRepositoryDB) Delete(id int) error {
 
 // This function updates an existing seller's attributes in the database.
 // It first retrieves the seller by ID, then updates the fields that are provided in the attributes
-func (r *SellerRepositoryDB) Update(id int, att *models.SellerAttributes) (models.Seller, error) {
-	actual, err := r.GetByID(id)
+func (r *SellerRepositoryDB) Update(ctx context.Context, id int, att *models.SellerAttributes) (models.Seller, error) {
+	actual, err := r.GetByID(ctx, id)
 	if err != nil {
 		return models.Seller{}, err
 	}
@@ -142,9 +138,9 @@ func (r *SellerRepositoryDB) Update(id int, att *models.SellerAttributes) (model
             telephone = ?,
             locality_id = ?
         WHERE id = ?`
-	_, err = r.db.Exec(queryUpdate, actual.CID, actual.CompanyName, actual.Address, actual.Telephone, actual.LocalityID, id)
+	_, err = r.db.ExecContext(ctx, queryUpdate, actual.CID, actual.CompanyName, actual.Address, actual.Telephone, actual.LocalityID, id)
 	if err != nil {
 		return models.Seller{}, err
 	}
-	return r.GetByID(id)
+	return r.GetByID(ctx, id)
 }
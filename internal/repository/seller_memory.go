@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// SellerRepositoryMemory is an in-memory implementation of SellerRepository.
+type SellerRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// NewSellerRepositoryMemory constructs a SellerRepositoryMemory backed by the given store.
+func NewSellerRepositoryMemory(store *MemoryStore) SellerRepository {
+	return &SellerRepositoryMemory{store: store}
+}
+
+// GetByID returns the seller with the given ID or a NotFoundError.
+func (r *SellerRepositoryMemory) GetByID(ctx context.Context, id int) (models.Seller, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	seller, ok := r.store.sellers[id]
+	if !ok {
+		return models.Seller{}, httperrors.NotFoundError{Message: "Seller not found"}
+	}
+	return seller, nil
+}
+
+// sellerListFields are the fields sellers can be sorted and filtered by.
+var sellerListFields = listFields[models.Seller]{
+	"id":           func(s models.Seller) any { return s.ID },
+	"cid":          func(s models.Seller) any { return s.CID },
+	"company_name": func(s models.Seller) any { return s.CompanyName },
+	"locality_id":  func(s models.Seller) any { return s.LocalityID },
+}
+
+// GetAll returns the page of sellers described by opts.
+func (r *SellerRepositoryMemory) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Seller], error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return pageOf(sortedByID(r.store.sellers), opts, sellerListFields)
+}
+
+// Create stores a new seller, checking the unique CID and the locality foreign key.
+func (r *SellerRepositoryMemory) Create(ctx context.Context, att models.SellerAttributes) (models.Seller, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkConstraints(0, att); err != nil {
+		return models.Seller{}, err
+	}
+
+	seller := models.Seller{
+		ID:               r.store.nextID("sellers"),
+		SellerAttributes: att,
+	}
+	r.store.sellers[seller.ID] = seller
+	return seller, nil
+}
+
+// Delete removes the seller with the given ID.
+// Products that referenced it keep existing with a NULL seller_id (ON DELETE SET NULL).
+func (r *SellerRepositoryMemory) Delete(ctx context.Context, id int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.sellers[id]; !ok {
+		return httperrors.NotFoundError{Message: "Seller not found"}
+	}
+
+	for productID, product := range r.store.products {
+		if product.SellerID != nil && *product.SellerID == id {
+			product.SellerID = nil
+			r.store.products[productID] = product
+		}
+	}
+
+	delete(r.store.sellers, id)
+	return nil
+}
+
+// Update applies the non-zero attributes to the stored seller and returns the result.
+func (r *SellerRepositoryMemory) Update(ctx context.Context, id int, att *models.SellerAttributes) (models.Seller, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	actual, ok := r.store.sellers[id]
+	if !ok {
+		return models.Seller{}, httperrors.NotFoundError{Message: "Seller not found"}
+	}
+	if att.CID != 0 {
+		actual.CID = att.CID
+	}
+	if att.CompanyName != "" {
+		actual.CompanyName = att.CompanyName
+	}
+	if att.Address != "" {
+		actual.Address = att.Address
+	}
+	if att.Telephone != "" {
+		actual.Telephone = att.Telephone
+	}
+	if att.LocalityID != "" {
+		actual.LocalityID = att.LocalityID
+	}
+
+	if err := r.checkConstraints(id, actual.SellerAttributes); err != nil {
+		return models.Seller{}, err
+	}
+
+	r.store.sellers[id] = actual
+	return actual, nil
+}
+
+// checkConstraints validates the unique cid and the locality foreign key
+// for the seller identified by id (0 for a new seller).
+// The caller must hold the lock.
+func (r *SellerRepositoryMemory) checkConstraints(id int, att models.SellerAttributes) error {
+	for _, seller := range r.store.sellers {
+		if seller.ID != id && seller.CID == att.CID {
+			return httperrors.ConflictError{Message: "Seller CID already exists"}
+		}
+	}
+	if _, ok := r.store.localities[att.LocalityID]; !ok {
+		return httperrors.ConflictError{Message: "Locality ID does not exist"}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package repository_test
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
@@ -24,7 +25,7 @@ func TestSellerRepositoryDB_GetByID_OK(t *testing.T) {
 		WithArgs(1).
 		WillReturnRows(rows)
 
-	seller, err := repo.GetByID(1)
+	seller, err := repo.GetByID(context.Background(), 1)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, seller.ID)
 	assert.Equal(t, "Alkemy", seller.CompanyName)
@@ -40,7 +41,7 @@ func TestSellerRepositoryDB_GetByID_NotFound(t *testing.T) {
 		WithArgs(2).
 		WillReturnError(sql.ErrNoRows)
 
-	_, err := repo.GetByID(2)
+	_, err := repo.GetByID(context.Background(), 2)
 	assert.Error(t, err)
 	assert.IsType(t, httperrors.NotFoundError{}, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -62,7 +63,7 @@ func TestSellerRepositoryDB_Create_OK(t *testing.T) {
 		WithArgs(attr.CID, attr.CompanyName, attr.Address, attr.Telephone, attr.LocalityID).
 		WillReturnResult(sqlmock.NewResult(5, 1)) // LastInsertID=5
 
-	seller, err := repo.(*repository.SellerRepositoryDB).Create(attr)
+	seller, err := repo.(*repository.SellerRepositoryDB).Create(context.Background(), attr)
 	assert.NoError(t, err)
 	assert.Equal(t, 5, seller.ID)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -81,7 +82,7 @@ func TestSellerRepositoryDB_Create_DuplicateCID(t *testing.T) {
 		WithArgs(attr.CID, attr.CompanyName, attr.Address, attr.Telephone, attr.LocalityID).
 		WillReturnError(mysqlErr)
 
-	_, err := repo.(*repository.SellerRepositoryDB).Create(attr)
+	_, err := repo.(*repository.SellerRepositoryDB).Create(context.Background(), attr)
 	assert.Error(t, err)
 	assert.IsType(t, httperrors.ConflictError{}, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -95,7 +96,7 @@ func TestSellerRepositoryDB_Delete_OK(t *testing.T) {
 		WithArgs(3).
 		WillReturnResult(sqlmock.NewResult(0, 1)) // 1 row affected
 
-	err := repo.(*repository.SellerRepositoryDB).Delete(3)
+	err := repo.(*repository.SellerRepositoryDB).Delete(context.Background(), 3)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -109,7 +110,7 @@ func TestSellerRepositoryDB_Delete_NotFound(t *testing.T) {
 		WithArgs(444).
 		WillReturnResult(sqlmock.NewResult(0, 0)) // 0 rows affected
 
-	err := repo.(*repository.SellerRepositoryDB).Delete(444)
+	err := repo.(*repository.SellerRepositoryDB).Delete(context.Background(), 444)
 	assert.Error(t, err)
 	assert.IsType(t, httperrors.NotFoundError{}, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -120,15 +121,37 @@ func TestSellerRepositoryDB_GetAll_OK(t *testing.T) {
 	repo := repository.NewSellerRepository(db)
 
 	rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "locality_id"}).
-		AddRow(1, 1001, "A", "B", "C", "1001").
-		AddRow(2, 2002, "B", "C", "D", "2002")
-
-	mock.ExpectQuery("SELECT id, cid,").
+		AddRow(2, 2002, "C", "C", "D", "1001").
+		AddRow(1, 1001, "B", "B", "C", "1001").
+		AddRow(3, 3003, "A", "D", "E", "1001")
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM sellers WHERE locality_id = \?`).
+		WithArgs("1001").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3))
+	mock.ExpectQuery("SELECT id, cid,.* ORDER BY company_name DESC, id DESC LIMIT").
+		WithArgs("1001", 3).
 		WillReturnRows(rows)
 
-	result, err := repo.GetAll()
+	result, err := repo.GetAll(context.Background(), models.QueryOptions{
+		Limit:   2,
+		Sort:    "company_name",
+		Desc:    true,
+		Filters: map[string]string{"locality_id": "1001"},
+	})
 	assert.NoError(t, err)
-	assert.Len(t, result, 2)
+	assert.Len(t, result.Data, 2)
+	assert.Equal(t, 3, result.Meta.Total)
+	assert.Equal(t, models.Cursor{Sort: "company_name", Value: "B", ID: 1}.Encode(), *result.Meta.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSellerRepositoryDB_GetAll_InvalidSort(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := repository.NewSellerRepository(db)
+
+	_, err := repo.GetAll(context.Background(), models.QueryOptions{Sort: "telephone"})
+	assert.Equal(t, httperrors.BadRequestError{Message: "Invalid sort field"}, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -176,7 +199,7 @@ func TestSellerRepositoryDB_Update_OK(t *testing.T) {
 			AddRow(10, update.CID, update.CompanyName, update.Address, update.Telephone, update.LocalityID),
 		)
 
-	result, err := repo.(*repository.SellerRepositoryDB).Update(10, update)
+	result, err := repo.(*repository.SellerRepositoryDB).Update(context.Background(), 10, update)
 	assert.NoError(t, err)
 	assert.Equal(t, update.CID, result.CID)
 	assert.Equal(t, update.CompanyName, result.CompanyName)
@@ -197,7 +220,7 @@ func TestSellerRepositoryDB_Update_NotFound(t *testing.T) {
 		WithArgs(99).
 		WillReturnError(sql.ErrNoRows)
 
-	_, err := repo.(*repository.SellerRepositoryDB).Update(99, update)
+	_, err := repo.(*repository.SellerRepositoryDB).Update(context.Background(), 99, update)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Seller not found")
 	assert.NoError(t, mock.ExpectationsWereMet())
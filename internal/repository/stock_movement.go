@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// StockMovementRepositoryDB implements StockMovementRepository
+type StockMovementRepositoryDB struct {
+	db *sql.DB
+}
+
+/*
+NewStockMovementRepositoryDB constructs a StockMovementRepositoryDB that uses
+the given *sql.DB for all data operations.
+*/
+func NewStockMovementRepositoryDB(db *sql.DB) StockMovementRepository {
+	return &StockMovementRepositoryDB{
+		db: db,
+	}
+}
+
+// Create applies the movement to the batch and stores it in a single transaction.
+// Returns a NotFoundError if the batch does not exist and a ConflictError
+// if the batch would end up with a negative quantity.
+func (repository *StockMovementRepositoryDB) Create(ctx context.Context, movement models.StockMovement) (models.StockMovement, error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.StockMovement{}, internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	created, err := applyStockMovementTx(ctx, tx, movement)
+	if err != nil {
+		return models.StockMovement{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.StockMovement{}, internalError(ctx, "", err)
+	}
+	return created, nil
+}
+
+// Transfer moves stock between two batches of the same product in a single transaction.
+// It stores the outgoing movement of the source batch followed by the incoming
+// movement of the target batch.
+func (repository *StockMovementRepositoryDB) Transfer(ctx context.Context, movement models.StockMovement) ([]models.StockMovement, error) {
+	sourceID, targetID := movement.ProductBatchID, *movement.RelatedProductBatchID
+
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	// Lock both rows always in the same order so two opposite transfers cannot deadlock
+	firstID, secondID := min(sourceID, targetID), max(sourceID, targetID)
+	first, err := lockProductBatchTx(ctx, tx, firstID)
+	if err != nil {
+		return nil, err
+	}
+	second, err := lockProductBatchTx(ctx, tx, secondID)
+	if err != nil {
+		return nil, err
+	}
+	if first.productID != second.productID {
+		return nil, httperrors.ConflictError{Message: "Stock can only be transferred between batches of the same product."}
+	}
+
+	outgoing, incoming := transferMovements(movement)
+
+	outgoing, err = applyStockMovementTx(ctx, tx, outgoing)
+	if err != nil {
+		return nil, err
+	}
+	incoming, err = applyStockMovementTx(ctx, tx, incoming)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	return []models.StockMovement{outgoing, incoming}, nil
+}
+
+// GetByProductBatchID returns the movements of a batch ordered from oldest to newest.
+// Returns a NotFoundError if the batch does not exist.
+func (repository *StockMovementRepositoryDB) GetByProductBatchID(ctx context.Context, productBatchID int) ([]models.StockMovement, error) {
+	const existsQuery = `SELECT EXISTS(SELECT 1 FROM product_batches WHERE id = ?)`
+	var exists bool
+	if err := repository.db.QueryRowContext(ctx, existsQuery, productBatchID).Scan(&exists); err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	if !exists {
+		return nil, httperrors.NotFoundError{Message: "Product batch not found"}
+	}
+
+	const query = `
+        SELECT
+            id,
+            product_batch_id,
+            movement_type,
+            quantity,
+            resulting_quantity,
+            related_product_batch_id,
+            reason,
+            created_at
+        FROM stock_movements
+        WHERE product_batch_id = ?
+        ORDER BY created_at, id
+    `
+	rows, err := repository.db.QueryContext(ctx, query, productBatchID)
+	if err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	defer rows.Close()
+
+	movements := make([]models.StockMovement, 0)
+	for rows.Next() {
+		var movement models.StockMovement
+		err := rows.Scan(
+			&movement.ID,
+			&movement.ProductBatchID,
+			&movement.MovementType,
+			&movement.Quantity,
+			&movement.ResultingQuantity,
+			&movement.RelatedProductBatchID,
+			&movement.Reason,
+			&movement.CreatedAt,
+		)
+		if err != nil {
+			return nil, internalError(ctx, "", err)
+		}
+		movements = append(movements, movement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, internalError(ctx, "", err)
+	}
+	return movements, nil
+}
+
+// transferMovements splits a transfer into the outgoing movement of the source
+// batch and the incoming movement of the target batch, each one pointing to the other.
+func transferMovements(movement models.StockMovement) (models.StockMovement, models.StockMovement) {
+	sourceID, targetID := movement.ProductBatchID, *movement.RelatedProductBatchID
+
+	outgoing := movement
+	outgoing.MovementType = models.StockMovementTransfer
+	outgoing.Quantity = -movement.Quantity
+	outgoing.RelatedProductBatchID = &targetID
+
+	incoming := movement
+	incoming.MovementType = models.StockMovementTransfer
+	incoming.ProductBatchID = targetID
+	incoming.RelatedProductBatchID = &sourceID
+
+	return outgoing, incoming
+}
+
+// lockedProductBatch holds the columns read by lockProductBatchTx
+type lockedProductBatch struct {
+	currentQuantity int
+	productID       int
+	sectionID       int
+}
+
+// lockProductBatchTx locks the batch row until tx ends and returns its current
+// quantity, product and section. Returns a NotFoundError if the batch does not exist.
+func lockProductBatchTx(ctx context.Context, tx *sql.Tx, productBatchID int) (lockedProductBatch, error) {
+	const query = `SELECT current_quantity, product_id, section_id FROM product_batches WHERE id = ? FOR UPDATE`
+
+	var batch lockedProductBatch
+	err := tx.QueryRowContext(ctx, query, productBatchID).Scan(&batch.currentQuantity, &batch.productID, &batch.sectionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return lockedProductBatch{}, httperrors.NotFoundError{Message: "Product batch not found"}
+		}
+		return lockedProductBatch{}, internalError(ctx, "", err)
+	}
+	return batch, nil
+}
+
+// occupySectionTx adds quantity (negative to release space) to the section
+// current_capacity, locking the section row until tx ends.
+// Returns a ConflictError if the section would go over its maximum_capacity.
+func occupySectionTx(ctx context.Context, tx *sql.Tx, sectionID, quantity int) error {
+	const lockQuery = `SELECT current_capacity, maximum_capacity FROM sections WHERE id = ? FOR UPDATE`
+
+	var currentCapacity, maximumCapacity int
+	err := tx.QueryRowContext(ctx, lockQuery, sectionID).Scan(&currentCapacity, &maximumCapacity)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return httperrors.NotFoundError{Message: "Section not found"}
+		}
+		return internalError(ctx, "", err)
+	}
+
+	resultingCapacity, err := sectionOccupancy(currentCapacity, maximumCapacity, quantity)
+	if err != nil {
+		return err
+	}
+
+	const query = `UPDATE sections SET current_capacity = ? WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, query, resultingCapacity, sectionID); err != nil {
+		return internalError(ctx, "", err)
+	}
+	return nil
+}
+
+// sectionOccupancy returns the current capacity of a section after adding quantity to it.
+// Releasing space never goes below zero, and only adding stock is checked against the maximum,
+// so sections already over their limit can still be emptied.
+func sectionOccupancy(currentCapacity, maximumCapacity, quantity int) (int, error) {
+	resultingCapacity := currentCapacity + quantity
+	if quantity > 0 && resultingCapacity > maximumCapacity {
+		return 0, httperrors.ConflictError{Message: "Section does not have enough capacity for the product batch."}
+	}
+	return max(resultingCapacity, 0), nil
+}
+
+// applyStockMovementTx adds the signed quantity of the movement to the batch
+// current_quantity and to the occupancy of its section, and stores the movement
+// with the resulting quantity.
+// It must run inside tx so both writes commit together.
+func applyStockMovementTx(ctx context.Context, tx *sql.Tx, movement models.StockMovement) (models.StockMovement, error) {
+	batch, err := lockProductBatchTx(ctx, tx, movement.ProductBatchID)
+	if err != nil {
+		return models.StockMovement{}, err
+	}
+
+	resultingQuantity := batch.currentQuantity + movement.Quantity
+	if resultingQuantity < 0 {
+		return models.StockMovement{}, httperrors.ConflictError{Message: "Not enough stock in the product batch."}
+	}
+
+	const query = `UPDATE product_batches SET current_quantity = ? WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, query, resultingQuantity, movement.ProductBatchID); err != nil {
+		return models.StockMovement{}, internalError(ctx, "", err)
+	}
+	if err := occupySectionTx(ctx, tx, batch.sectionID, movement.Quantity); err != nil {
+		return models.StockMovement{}, err
+	}
+
+	movement.ResultingQuantity = resultingQuantity
+	return insertStockMovementTx(ctx, tx, movement)
+}
+
+// insertStockMovementTx stores the movement as given, without touching the batch.
+func insertStockMovementTx(ctx context.Context, tx *sql.Tx, movement models.StockMovement) (models.StockMovement, error) {
+	const query = `
+        INSERT INTO stock_movements (
+            product_batch_id, movement_type, quantity, resulting_quantity,
+            related_product_batch_id, reason, created_at
+        ) VALUES (?, ?, ?, ?, ?, ?, ?)
+    `
+	if movement.CreatedAt.IsZero() {
+		movement.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	}
+
+	result, err := tx.ExecContext(ctx, query,
+		movement.ProductBatchID, movement.MovementType, movement.Quantity, movement.ResultingQuantity,
+		movement.RelatedProductBatchID, movement.Reason, movement.CreatedAt,
+	)
+	if err != nil {
+		return models.StockMovement{}, internalError(ctx, "", err)
+	}
+
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		return models.StockMovement{}, internalError(ctx, "", err)
+	}
+	movement.ID = int(lastId)
+	return movement, nil
+}
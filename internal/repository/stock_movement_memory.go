@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// StockMovementRepositoryMemory implements StockMovementRepository in memory
+type StockMovementRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// NewStockMovementRepositoryMemory constructs a StockMovementRepositoryMemory
+// backed by the given store.
+func NewStockMovementRepositoryMemory(store *MemoryStore) StockMovementRepository {
+	return &StockMovementRepositoryMemory{store: store}
+}
+
+// Create applies the movement to the batch and stores it.
+// Returns a NotFoundError if the batch does not exist and a ConflictError
+// if the batch would end up with a negative quantity.
+func (repository *StockMovementRepositoryMemory) Create(ctx context.Context, movement models.StockMovement) (models.StockMovement, error) {
+	repository.store.mu.Lock()
+	defer repository.store.mu.Unlock()
+
+	if err := repository.store.checkStockMovement(movement); err != nil {
+		return models.StockMovement{}, err
+	}
+	return repository.store.applyStockMovement(movement), nil
+}
+
+// Transfer moves stock between two batches of the same product, storing
+// the outgoing movement of the source batch followed by the incoming one of the target.
+func (repository *StockMovementRepositoryMemory) Transfer(ctx context.Context, movement models.StockMovement) ([]models.StockMovement, error) {
+	repository.store.mu.Lock()
+	defer repository.store.mu.Unlock()
+
+	source, sourceExists := repository.store.productBatches[movement.ProductBatchID]
+	target, targetExists := repository.store.productBatches[*movement.RelatedProductBatchID]
+	if !sourceExists || !targetExists {
+		return nil, httperrors.NotFoundError{Message: "Product batch not found"}
+	}
+	if source.ProductID != target.ProductID {
+		return nil, httperrors.ConflictError{Message: "Stock can only be transferred between batches of the same product."}
+	}
+
+	outgoing, incoming := transferMovements(movement)
+	if err := repository.store.checkStockMovement(outgoing); err != nil {
+		return nil, err
+	}
+	// Within one section the outgoing movement frees the room the incoming one takes
+	if source.SectionID != target.SectionID {
+		if err := repository.store.checkSectionOccupancy(target.SectionID, incoming.Quantity); err != nil {
+			return nil, err
+		}
+	}
+	return []models.StockMovement{
+		repository.store.applyStockMovement(outgoing),
+		repository.store.applyStockMovement(incoming),
+	}, nil
+}
+
+// GetByProductBatchID returns the movements of a batch ordered from oldest to newest.
+func (repository *StockMovementRepositoryMemory) GetByProductBatchID(ctx context.Context, productBatchID int) ([]models.StockMovement, error) {
+	repository.store.mu.RLock()
+	defer repository.store.mu.RUnlock()
+
+	if _, ok := repository.store.productBatches[productBatchID]; !ok {
+		return nil, httperrors.NotFoundError{Message: "Product batch not found"}
+	}
+
+	movements := make([]models.StockMovement, 0)
+	for _, movement := range sortedByID(repository.store.stockMovements) {
+		if movement.ProductBatchID == productBatchID {
+			movements = append(movements, movement)
+		}
+	}
+	sort.SliceStable(movements, func(i, j int) bool {
+		return movements[i].CreatedAt.Before(movements[j].CreatedAt)
+	})
+	return movements, nil
+}
+
+// checkStockMovement verifies the batch exists and has enough stock for the movement,
+// and that its section has room for it. The caller must hold the lock.
+func (s *MemoryStore) checkStockMovement(movement models.StockMovement) error {
+	batch, ok := s.productBatches[movement.ProductBatchID]
+	if !ok {
+		return httperrors.NotFoundError{Message: "Product batch not found"}
+	}
+	if batch.CurrentQuantity+movement.Quantity < 0 {
+		return httperrors.ConflictError{Message: "Not enough stock in the product batch."}
+	}
+	return s.checkSectionOccupancy(batch.SectionID, movement.Quantity)
+}
+
+// applyStockMovement adds the signed quantity to the batch and to the occupancy of its
+// section, and stores the movement.
+// The movement must have passed checkStockMovement. The caller must hold the write lock.
+func (s *MemoryStore) applyStockMovement(movement models.StockMovement) models.StockMovement {
+	batch := s.productBatches[movement.ProductBatchID]
+	batch.CurrentQuantity += movement.Quantity
+	s.productBatches[batch.ID] = batch
+	s.occupySection(batch.SectionID, movement.Quantity)
+
+	movement.ResultingQuantity = batch.CurrentQuantity
+	return s.insertStockMovement(movement)
+}
+
+// checkSectionOccupancy verifies the section can take quantity more units (negative to release).
+// The caller must hold the lock.
+func (s *MemoryStore) checkSectionOccupancy(sectionID, quantity int) error {
+	section := s.sections[sectionID]
+	_, err := sectionOccupancy(section.CurrentCapacity, section.MaximumCapacity, quantity)
+	return err
+}
+
+// occupySection adds quantity to the section current_capacity.
+// The change must have passed checkSectionOccupancy. The caller must hold the write lock.
+func (s *MemoryStore) occupySection(sectionID, quantity int) {
+	section, ok := s.sections[sectionID]
+	if !ok {
+		return
+	}
+	section.CurrentCapacity, _ = sectionOccupancy(section.CurrentCapacity, section.MaximumCapacity, quantity)
+	s.sections[sectionID] = section
+}
+
+// insertStockMovement stores the movement as given, without touching the batch.
+// The caller must hold the write lock.
+func (s *MemoryStore) insertStockMovement(movement models.StockMovement) models.StockMovement {
+	if movement.CreatedAt.IsZero() {
+		movement.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	}
+	movement.ID = s.nextID("stock_movements")
+	movement.RelatedProductBatchID = copyIntPtr(movement.RelatedProductBatchID)
+	s.stockMovements[movement.ID] = movement
+	return movement
+}
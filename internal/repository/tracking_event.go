@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// TrackingEventRepositoryDB implements TrackingEventRepository
+type TrackingEventRepositoryDB struct {
+	db *sql.DB
+}
+
+// NewTrackingEventRepositoryDB constructs a TrackingEventRepositoryDB that uses
+// the given *sql.DB for all data operations.
+func NewTrackingEventRepositoryDB(db *sql.DB) TrackingEventRepository {
+	return &TrackingEventRepositoryDB{db: db}
+}
+
+// Create appends the event to the timeline of its order, recording the carry assigned
+// to it. The order row is read with FOR SHARE so its carry and status cannot change
+// until the event is stored.
+// Returns a NotFoundError if the order does not exist and a ConflictError if it has
+// no carry, is not out for delivery or is delivered before being shipped.
+func (r *TrackingEventRepositoryDB) Create(ctx context.Context, event models.TrackingEvent) (models.TrackingEvent, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.TrackingEvent{}, internalError(ctx, "", err)
+	}
+	defer tx.Rollback()
+
+	const orderQuery = `SELECT status, carry_id FROM purchase_orders WHERE id = ? FOR SHARE`
+	var status string
+	var carryID *int
+	if err := tx.QueryRowContext(ctx, orderQuery, event.PurchaseOrderId).Scan(&status, &carryID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.TrackingEvent{}, httperrors.NotFoundError{Message: "Purchase order not found"}
+		}
+		return models.TrackingEvent{}, internalError(ctx, "", err)
+	}
+	if err := checkTrackable(status, carryID, event.EventType); err != nil {
+		return models.TrackingEvent{}, err
+	}
+	event.CarryId = *carryID
+
+	const query = `
+		INSERT INTO tracking_events
+			(purchase_order_id, carry_id, event_type, location, notes, occurred_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	result, err := tx.ExecContext(ctx, query,
+		event.PurchaseOrderId, event.CarryId, event.EventType, event.Location, event.Notes, event.OccurredAt,
+	)
+	if err != nil {
+		return models.TrackingEvent{}, internalError(ctx, "", err)
+	}
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		return models.TrackingEvent{}, internalError(ctx, "", err)
+	}
+	event.Id = int(lastId)
+
+	if err := tx.Commit(); err != nil {
+		return models.TrackingEvent{}, internalError(ctx, "", err)
+	}
+	return event, nil
+}
+
+// checkTrackable verifies an order in status with the given carry can receive an event of eventType.
+// Only orders handed to their carry, picked or shipped, are out for delivery, and only
+// shipped ones can be delivered.
+func checkTrackable(status string, carryID *int, eventType string) error {
+	if carryID == nil {
+		return httperrors.ConflictError{Message: "Purchase order has no carry assigned"}
+	}
+	if status != models.PurchaseOrderStatusPicked && status != models.PurchaseOrderStatusShipped {
+		return httperrors.ConflictError{Message: "A " + status + " purchase order cannot receive tracking events"}
+	}
+	if eventType == models.TrackingEventDelivered && status != models.PurchaseOrderStatusShipped {
+		return httperrors.ConflictError{Message: "A " + status + " purchase order cannot receive delivered events"}
+	}
+	return nil
+}
+
+// GetTimeline returns the order with the tracking code and its events ordered
+// from oldest to newest. Returns a NotFoundError if no order has the tracking code.
+func (r *TrackingEventRepositoryDB) GetTimeline(ctx context.Context, trackingCode string) (models.TrackingTimeline, error) {
+	const orderQuery = `SELECT id, status, carry_id FROM purchase_orders WHERE tracking_code = ?`
+	timeline := models.TrackingTimeline{TrackingCode: trackingCode, Events: []models.TrackingEvent{}}
+	err := r.db.QueryRowContext(ctx, orderQuery, trackingCode).
+		Scan(&timeline.PurchaseOrderId, &timeline.Status, &timeline.CarryId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.TrackingTimeline{}, httperrors.NotFoundError{Message: "Tracking code not found"}
+		}
+		return models.TrackingTimeline{}, internalError(ctx, "", err)
+	}
+
+	const query = `
+		SELECT id, purchase_order_id, carry_id, event_type, location, notes, occurred_at
+		FROM tracking_events
+		WHERE purchase_order_id = ?
+		ORDER BY occurred_at, id
+	`
+	rows, err := r.db.QueryContext(ctx, query, timeline.PurchaseOrderId)
+	if err != nil {
+		return models.TrackingTimeline{}, internalError(ctx, "", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event models.TrackingEvent
+		err := rows.Scan(
+			&event.Id, &event.PurchaseOrderId, &event.CarryId, &event.EventType,
+			&event.Location, &event.Notes, &event.OccurredAt,
+		)
+		if err != nil {
+			return models.TrackingTimeline{}, internalError(ctx, "", err)
+		}
+		timeline.Events = append(timeline.Events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return models.TrackingTimeline{}, internalError(ctx, "", err)
+	}
+	return timeline, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// TrackingEventRepositoryMemory implements TrackingEventRepository in memory
+type TrackingEventRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// NewTrackingEventRepositoryMemory constructs a TrackingEventRepositoryMemory
+// backed by the given store.
+func NewTrackingEventRepositoryMemory(store *MemoryStore) TrackingEventRepository {
+	return &TrackingEventRepositoryMemory{store: store}
+}
+
+// Create appends the event to the timeline of its order, recording the carry assigned to it.
+// Returns a NotFoundError if the order does not exist and a ConflictError if it has
+// no carry, is not out for delivery or is delivered before being shipped.
+func (r *TrackingEventRepositoryMemory) Create(ctx context.Context, event models.TrackingEvent) (models.TrackingEvent, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	order, ok := r.store.purchaseOrders[event.PurchaseOrderId]
+	if !ok {
+		return models.TrackingEvent{}, httperrors.NotFoundError{Message: "Purchase order not found"}
+	}
+	if err := checkTrackable(order.Status, order.CarryId, event.EventType); err != nil {
+		return models.TrackingEvent{}, err
+	}
+
+	event.CarryId = *order.CarryId
+	event.Id = r.store.nextID("tracking_events")
+	r.store.trackingEvents[event.Id] = event
+	return event, nil
+}
+
+// GetTimeline returns the order with the tracking code and its events ordered
+// from oldest to newest. Returns a NotFoundError if no order has the tracking code.
+func (r *TrackingEventRepositoryMemory) GetTimeline(ctx context.Context, trackingCode string) (models.TrackingTimeline, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, order := range r.store.purchaseOrders {
+		if order.TrackingCode != trackingCode {
+			continue
+		}
+
+		timeline := models.TrackingTimeline{
+			TrackingCode:    trackingCode,
+			PurchaseOrderId: order.Id,
+			Status:          order.Status,
+			CarryId:         copyIntPtr(order.CarryId),
+			Events:          []models.TrackingEvent{},
+		}
+		for _, event := range sortedByID(r.store.trackingEvents) {
+			if event.PurchaseOrderId == order.Id {
+				timeline.Events = append(timeline.Events, event)
+			}
+		}
+		sort.SliceStable(timeline.Events, func(i, j int) bool {
+			return timeline.Events[i].OccurredAt.Before(timeline.Events[j].OccurredAt)
+		})
+		return timeline, nil
+	}
+	return models.TrackingTimeline{}, httperrors.NotFoundError{Message: "Tracking code not found"}
+}
@@ -1,12 +1,12 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
 	"github.com/go-sql-driver/mysql"
-	"log"
 )
 
 type WarehouseRepositoryDB struct {
@@ -19,7 +19,7 @@ func NewWarehouseRepositoryDb(db *sql.DB) *WarehouseRepositoryDB {
 }
 
 // Create adds a new warehouse and returns the created warehouse.
-func (p *WarehouseRepositoryDB) Create(warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
+func (p *WarehouseRepositoryDB) Create(ctx context.Context, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
 	query := `
 		INSERT INTO warehouses (
 			warehouse_code, 
@@ -29,7 +29,7 @@ func (p *WarehouseRepositoryDB) Create(warehouseAttributes models.WarehouseAttri
 			minimun_temperature
 		) VALUES (?, ?, ?, ?, ?)
 	`
-	result, err := p.db.Exec(query,
+	result, err := p.db.ExecContext(ctx, query,
 		warehouseAttributes.WarehouseCode,
 		warehouseAttributes.Address,
 		warehouseAttributes.Telephone,
@@ -42,12 +42,12 @@ func (p *WarehouseRepositoryDB) Create(warehouseAttributes models.WarehouseAttri
 			if sqlErrors.Number == 1062 {
 				return models.Warehouse{}, httperrors.ConflictError{Message: "the WarehouseCode already exists"}
 			}
-			return models.Warehouse{}, httperrors.InternalServerError{Message: "error creating warehouse"}
+			return models.Warehouse{}, internalError(ctx, "error creating warehouse", err)
 		}
 	}
 	lastInsertId, err := result.LastInsertId()
 	if err != nil {
-		return models.Warehouse{}, httperrors.InternalServerError{Message: "error obtaining last insert ID"}
+		return models.Warehouse{}, internalError(ctx, "error obtaining last insert ID", err)
 	}
 	newWarehouse := models.Warehouse{
 		Id:                  int(lastInsertId),
@@ -56,47 +56,43 @@ func (p *WarehouseRepositoryDB) Create(warehouseAttributes models.WarehouseAttri
 	return newWarehouse, nil
 }
 
-// GetAll returns all warehouses.
-func (p *WarehouseRepositoryDB) GetAll() ([]models.Warehouse, error) {
-	query := `
-		SELECT 
+// warehouseListColumns are the fields warehouses can be sorted and filtered by.
+var warehouseListColumns = listColumns{
+	"id":                  "id",
+	"warehouse_code":      "warehouse_code",
+	"minimun_capacity":    "minimun_capacity",
+	"minimun_temperature": "minimun_temperature",
+}
+
+// GetAll returns the page of warehouses described by opts.
+func (p *WarehouseRepositoryDB) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Warehouse], error) {
+	query := listQuery{
+		selectColumns: `
 			id,
 			warehouse_code, 
 			address, 
 			telephone,
 			minimun_capacity,
-			minimun_temperature
-		FROM warehouses
-	`
-	rows, err := p.db.Query(query)
-	if err != nil {
-		return nil, httperrors.InternalServerError{Message: "error obtaining warehouses"}
+			minimun_temperature`,
+		from:    "warehouses",
+		columns: warehouseListColumns,
 	}
-	defer func() {
-		if err := rows.Close(); err != nil {
-			log.Printf("error closing rows: %v", err)
-		}
-	}()
-	var warehouses []models.Warehouse
-	for rows.Next() {
+	return queryPage(ctx, p.db, query, opts, warehouseListFields, func(rows *sql.Rows) (models.Warehouse, error) {
 		var warehouse models.Warehouse
-		if err := rows.Scan(
+		err := rows.Scan(
 			&warehouse.Id,
 			&warehouse.WarehouseCode,
 			&warehouse.Address,
 			&warehouse.Telephone,
 			&warehouse.MinimunCapacity,
 			&warehouse.MinimunTemperature,
-		); err != nil {
-			return nil, httperrors.InternalServerError{Message: "error reading warehouse data"}
-		}
-		warehouses = append(warehouses, warehouse)
-	}
-	return warehouses, nil
+		)
+		return warehouse, err
+	})
 }
 
 // GetByID returns a warehouse by its ID.
-func (p *WarehouseRepositoryDB) GetByID(id int) (models.Warehouse, error) {
+func (p *WarehouseRepositoryDB) GetByID(ctx context.Context, id int) (models.Warehouse, error) {
 	query := `
 		SELECT 
 			id,
@@ -108,7 +104,7 @@ func (p *WarehouseRepositoryDB) GetByID(id int) (models.Warehouse, error) {
 		FROM warehouses
 		WHERE id = ?
 	`
-	row := p.db.QueryRow(query, id)
+	row := p.db.QueryRowContext(ctx, query, id)
 	var warehouse models.Warehouse
 	if err := row.Scan(
 		&warehouse.Id,
@@ -121,13 +117,13 @@ func (p *WarehouseRepositoryDB) GetByID(id int) (models.Warehouse, error) {
 		if err == sql.ErrNoRows {
 			return models.Warehouse{}, httperrors.NotFoundError{Message: "warehouse not found"}
 		}
-		return models.Warehouse{}, httperrors.InternalServerError{Message: "error obtaining warehouse by ID"}
+		return models.Warehouse{}, internalError(ctx, "error obtaining warehouse by ID", err)
 	}
 	return warehouse, nil
 }
 
 // Update modifies an existing warehouse and returns the updated warehouse.
-func (p *WarehouseRepositoryDB) Update(id int, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
+func (p *WarehouseRepositoryDB) Update(ctx context.Context, id int, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
 	query := `
 		UPDATE warehouses SET
 			warehouse_code = ?,
@@ -137,7 +133,7 @@ func (p *WarehouseRepositoryDB) Update(id int, warehouseAttributes models.Wareho
 			minimun_temperature = ?
 		WHERE id = ?
 	`
-	result, err := p.db.Exec(query,
+	result, err := p.db.ExecContext(ctx, query,
 		warehouseAttributes.WarehouseCode,
 		warehouseAttributes.Address,
 		warehouseAttributes.Telephone,
@@ -146,11 +142,11 @@ func (p *WarehouseRepositoryDB) Update(id int, warehouseAttributes models.Wareho
 		id,
 	)
 	if err != nil {
-		return models.Warehouse{}, httperrors.InternalServerError{Message: "error updating warehouse"}
+		return models.Warehouse{}, internalError(ctx, "error updating warehouse", err)
 	}
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return models.Warehouse{}, httperrors.InternalServerError{Message: "error checking update"}
+		return models.Warehouse{}, internalError(ctx, "error checking update", err)
 	}
 	if rowsAffected == 0 {
 		return models.Warehouse{}, httperrors.NotFoundError{Message: "warehouse not found or no changes made"}
@@ -163,21 +159,21 @@ func (p *WarehouseRepositoryDB) Update(id int, warehouseAttributes models.Wareho
 }
 
 // Delete removes a warehouse by its ID.
-func (p *WarehouseRepositoryDB) Delete(id int) error {
+func (p *WarehouseRepositoryDB) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM warehouses WHERE id = ?`
-	result, err := p.db.Exec(query, id)
+	result, err := p.db.ExecContext(ctx, query, id)
 	if err != nil {
 		var sqlErrors *mysql.MySQLError
 		if errors.As(err, &sqlErrors) {
 			if sqlErrors.Number == 1451 {
-				return httperrors.InternalServerError{Message: "This warehouse cannot be deleted because it is associated with other entities"}
+				return internalError(ctx, "This warehouse cannot be deleted because it is associated with other entities", err)
 			}
 		}
-		return httperrors.InternalServerError{Message: "error deleting warehouse"}
+		return internalError(ctx, "error deleting warehouse", err)
 	}
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return httperrors.InternalServerError{Message: "error checking delete"}
+		return internalError(ctx, "error checking delete", err)
 	}
 	if rowsAffected == 0 {
 		return httperrors.NotFoundError{Message: "warehouse not found"}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// WarehouseRepositoryMemory is an in-memory implementation of WarehouseRepository.
+type WarehouseRepositoryMemory struct {
+	store *MemoryStore
+}
+
+// NewWarehouseRepositoryMemory creates a new instance of WarehouseRepositoryMemory.
+func NewWarehouseRepositoryMemory(store *MemoryStore) WarehouseRepository {
+	return &WarehouseRepositoryMemory{store: store}
+}
+
+// Create adds a new warehouse and returns the created warehouse.
+func (p *WarehouseRepositoryMemory) Create(ctx context.Context, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	if p.codeTaken(0, warehouseAttributes.WarehouseCode) {
+		return models.Warehouse{}, httperrors.ConflictError{Message: "the WarehouseCode already exists"}
+	}
+
+	newWarehouse := models.Warehouse{
+		Id:                  p.store.nextID("warehouses"),
+		WarehouseAttributes: warehouseAttributes,
+	}
+	p.store.warehouses[newWarehouse.Id] = newWarehouse
+	return newWarehouse, nil
+}
+
+// warehouseListFields are the fields warehouses can be sorted and filtered by.
+var warehouseListFields = listFields[models.Warehouse]{
+	"id":                  func(w models.Warehouse) any { return w.Id },
+	"warehouse_code":      func(w models.Warehouse) any { return w.WarehouseCode },
+	"minimun_capacity":    func(w models.Warehouse) any { return w.MinimunCapacity },
+	"minimun_temperature": func(w models.Warehouse) any { return w.MinimunTemperature },
+}
+
+// GetAll returns the page of warehouses described by opts.
+func (p *WarehouseRepositoryMemory) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Warehouse], error) {
+	p.store.mu.RLock()
+	defer p.store.mu.RUnlock()
+
+	return pageOf(sortedByID(p.store.warehouses), opts, warehouseListFields)
+}
+
+// GetByID returns a warehouse by its ID.
+func (p *WarehouseRepositoryMemory) GetByID(ctx context.Context, id int) (models.Warehouse, error) {
+	p.store.mu.RLock()
+	defer p.store.mu.RUnlock()
+
+	warehouse, ok := p.store.warehouses[id]
+	if !ok {
+		return models.Warehouse{}, httperrors.NotFoundError{Message: "warehouse not found"}
+	}
+	return warehouse, nil
+}
+
+// Update modifies an existing warehouse and returns the updated warehouse.
+func (p *WarehouseRepositoryMemory) Update(ctx context.Context, id int, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	if _, ok := p.store.warehouses[id]; !ok {
+		return models.Warehouse{}, httperrors.NotFoundError{Message: "warehouse not found or no changes made"}
+	}
+	if p.codeTaken(id, warehouseAttributes.WarehouseCode) {
+		return models.Warehouse{}, httperrors.ConflictError{Message: "the WarehouseCode already exists"}
+	}
+
+	updatedWarehouse := models.Warehouse{
+		Id:                  id,
+		WarehouseAttributes: warehouseAttributes,
+	}
+	p.store.warehouses[id] = updatedWarehouse
+	return updatedWarehouse, nil
+}
+
+// Delete removes a warehouse by its ID.
+// Returns a ConflictError while sections, employees or inbound orders reference it.
+func (p *WarehouseRepositoryMemory) Delete(ctx context.Context, id int) error {
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	if _, ok := p.store.warehouses[id]; !ok {
+		return httperrors.NotFoundError{Message: "warehouse not found"}
+	}
+
+	referenced := false
+	for _, section := range p.store.sections {
+		referenced = referenced || section.WarehouseID == id
+	}
+	for _, employee := range p.store.employees {
+		referenced = referenced || employee.WarehouseID == id
+	}
+	for _, order := range p.store.inboundOrders {
+		referenced = referenced || order.WarehouseID == id
+	}
+	if referenced {
+		return httperrors.ConflictError{Message: "This warehouse cannot be deleted because it is associated with other entities"}
+	}
+
+	delete(p.store.warehouses, id)
+	return nil
+}
+
+// codeTaken reports whether another warehouse already uses the given code.
+// The caller must hold the lock.
+func (p *WarehouseRepositoryMemory) codeTaken(id int, code string) bool {
+	for _, warehouse := range p.store.warehouses {
+		if warehouse.Id != id && warehouse.WarehouseCode == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+)
+
+// AlertServiceDefault implements AlertService
+type AlertServiceDefault struct {
+	repository repository.AlertRepository
+}
+
+/*
+NewAlertServiceDefault constructs an AlertServiceDefault
+with the given repository.
+*/
+func NewAlertServiceDefault(repo repository.AlertRepository) AlertService {
+	return &AlertServiceDefault{repository: repo}
+}
+
+// GetAll returns the page of alerts matching the filter
+func (service AlertServiceDefault) GetAll(ctx context.Context, filter models.AlertFilter) (models.Page[models.Alert], error) {
+	return service.repository.GetAll(ctx, filter)
+}
+
+// Acknowledge marks an open alert as acknowledged now
+func (service AlertServiceDefault) Acknowledge(ctx context.Context, id int) (models.Alert, error) {
+	return service.repository.Acknowledge(ctx, id, time.Now().UTC().Truncate(time.Second))
+}
+
+// temperatureSample is a section temperature observed at a given time
+type temperatureSample struct {
+	temperature float64
+	at          time.Time
+}
+
+// temperatureRule returns the breaches of a temperature against the section thresholds
+type temperatureRule func(targets models.SectionTemperatureTargets, temperature float64) []models.Alert
+
+// temperatureRules are checked, in order, on every section temperature change
+var temperatureRules = []temperatureRule{
+	sectionMinimumRule,
+	productRecommendedRule,
+}
+
+// sectionMinimumRule breaches when the section is colder than its minimum temperature
+func sectionMinimumRule(targets models.SectionTemperatureTargets, temperature float64) []models.Alert {
+	if temperature >= targets.MinimumTemperature {
+		return nil
+	}
+	return []models.Alert{{
+		AlertType:   models.AlertTypeSectionMinimum,
+		Temperature: temperature,
+		Threshold:   targets.MinimumTemperature,
+		Message:     fmt.Sprintf("Section temperature %.2f is below its minimum of %.2f", temperature, targets.MinimumTemperature),
+	}}
+}
+
+// productRecommendedRule breaches, once per batch, when the section is warmer than
+// the recommended freezing temperature of the product of the batch
+func productRecommendedRule(targets models.SectionTemperatureTargets, temperature float64) []models.Alert {
+	var breaches []models.Alert
+	for _, batch := range targets.Batches {
+		if temperature <= batch.RecommendedFreezingTemperature {
+			continue
+		}
+		batchID := batch.ProductBatchID
+		breaches = append(breaches, models.Alert{
+			ProductBatchID: &batchID,
+			AlertType:      models.AlertTypeProductRecommended,
+			Temperature:    temperature,
+			Threshold:      batch.RecommendedFreezingTemperature,
+			Message: fmt.Sprintf("Section temperature %.2f is above the recommended %.2f of product %d in batch %d",
+				temperature, batch.RecommendedFreezingTemperature, batch.ProductID, batch.ProductBatchID),
+		})
+	}
+	return breaches
+}
+
+// evaluateTemperature runs every rule against the temperature
+func evaluateTemperature(targets models.SectionTemperatureTargets, temperature float64) []models.Alert {
+	var breaches []models.Alert
+	for _, rule := range temperatureRules {
+		breaches = append(breaches, rule(targets, temperature)...)
+	}
+	return breaches
+}
+
+// checkTemperatureAlerts evaluates the samples of a section in order and opens or resolves
+// its alerts accordingly. The alerts are only synced when the set of breached rules changes,
+// so a long run of readings in the same state costs a single write.
+func checkTemperatureAlerts(ctx context.Context, alertRepository repository.AlertRepository, sectionID int, samples []temperatureSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	targets, err := alertRepository.GetTemperatureTargets(ctx, sectionID)
+	if err != nil {
+		return err
+	}
+
+	var previous []string
+	for i, sample := range samples {
+		breaches := evaluateTemperature(targets, sample.temperature)
+		current := breachKeys(breaches)
+		if i > 0 && slices.Equal(previous, current) {
+			continue
+		}
+		if _, err := alertRepository.Sync(ctx, sectionID, breaches, sample.at); err != nil {
+			return err
+		}
+		previous = current
+	}
+	return nil
+}
+
+// breachKeys identifies each breach by its rule and batch, in rule order
+func breachKeys(breaches []models.Alert) []string {
+	keys := make([]string, 0, len(breaches))
+	for _, breach := range breaches {
+		key := breach.AlertType
+		if breach.ProductBatchID != nil {
+			key += fmt.Sprintf("/%d", *breach.ProductBatchID)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
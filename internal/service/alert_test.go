@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+// TestEvaluateTemperature tests the temperature rules against the section thresholds
+func TestEvaluateTemperature(t *testing.T) {
+	targets := models.SectionTemperatureTargets{
+		SectionID:          1,
+		MinimumTemperature: -25,
+		Batches: []models.BatchTemperatureTarget{
+			{ProductBatchID: 10, ProductID: 1, RecommendedFreezingTemperature: -18},
+			{ProductBatchID: 11, ProductID: 2, RecommendedFreezingTemperature: -12},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		temperature float64
+		expected    []string
+	}{
+		{name: "in range", temperature: -20, expected: []string{}},
+		{name: "on the thresholds", temperature: -18, expected: []string{}},
+		{name: "too cold for the section", temperature: -26, expected: []string{"section_minimum_temperature"}},
+		{name: "too warm for one product", temperature: -15, expected: []string{"product_recommended_temperature/10"}},
+		{name: "too warm for every product", temperature: -5, expected: []string{"product_recommended_temperature/10", "product_recommended_temperature/11"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaches := evaluateTemperature(targets, tt.temperature)
+			assert.Equal(t, tt.expected, breachKeys(breaches))
+			for _, breach := range breaches {
+				assert.Equal(t, tt.temperature, breach.Temperature)
+				assert.NotEmpty(t, breach.Message)
+			}
+		})
+	}
+}
+
+// TestCheckTemperatureAlerts tests that alerts are only synced when the breached rules change
+func TestCheckTemperatureAlerts(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	targets := models.SectionTemperatureTargets{
+		SectionID:          1,
+		MinimumTemperature: -25,
+		Batches:            []models.BatchTemperatureTarget{{ProductBatchID: 10, ProductID: 1, RecommendedFreezingTemperature: -18}},
+	}
+	samples := []temperatureSample{
+		{temperature: -20, at: start},
+		{temperature: -19, at: start.Add(time.Minute)},
+		{temperature: -15, at: start.Add(2 * time.Minute)},
+		{temperature: -14, at: start.Add(3 * time.Minute)},
+		{temperature: -20, at: start.Add(4 * time.Minute)},
+	}
+
+	alertRepo := new(mocks.AlertRepositoryDBMock)
+	alertRepo.On("GetTemperatureTargets", testifyMock.Anything, 1).Return(targets, nil).Once()
+	alertRepo.On("Sync", testifyMock.Anything, 1, testifyMock.Anything, testifyMock.Anything).Return([]models.Alert{}, nil)
+
+	err := checkTemperatureAlerts(context.Background(), alertRepo, 1, samples)
+
+	assert.NoError(t, err)
+	// First sample, breach opened, breach resolved
+	alertRepo.AssertNumberOfCalls(t, "Sync", 3)
+	calls := alertRepo.Calls[1:]
+	assert.Equal(t, start, calls[0].Arguments.Get(3))
+	assert.Len(t, calls[1].Arguments.Get(2), 1)
+	assert.Equal(t, start.Add(2*time.Minute), calls[1].Arguments.Get(3))
+	assert.Empty(t, calls[2].Arguments.Get(2))
+	assert.Equal(t, start.Add(4*time.Minute), calls[2].Arguments.Get(3))
+}
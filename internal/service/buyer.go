@@ -23,9 +23,9 @@ func (s *BuyerServiceDefault) Create(ctx context.Context, newBuyer models.BuyerA
 	return buyer, err
 }
 
-// Get all buyers
-func (s *BuyerServiceDefault) GetAll(ctx context.Context) ([]models.Buyer, error) {
-	buyerData, err := s.repository.GetAll(ctx)
+// Get a page of buyers
+func (s *BuyerServiceDefault) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Buyer], error) {
+	buyerData, err := s.repository.GetAll(ctx, opts)
 	return buyerData, err
 }
 
@@ -58,6 +58,9 @@ func (s *BuyerServiceDefault) Update(ctx context.Context, id int, BuyerData mode
 	if BuyerData.LastName != nil {
 		buyer.LastName = *BuyerData.LastName
 	}
+	if BuyerData.DeliveryLocalityId != nil {
+		buyer.DeliveryLocalityId = BuyerData.DeliveryLocalityId
+	}
 
 	updatedBuyer, err := s.repository.Update(ctx, id, buyer)
 	return updatedBuyer, err
@@ -255,19 +255,19 @@ func TestBuyerServiceDefault_GetAll(t *testing.T) {
 		serviceDefault := service.NewBuyerServiceDefault(repoMock)
 
 		ctx := context.Background()
-		repoMock.On("GetAll", ctx).
-			Return([]models.Buyer{}, errors.New("some random error from repository")).
+		repoMock.On("GetAll", ctx, models.QueryOptions{}).
+			Return(models.Page[models.Buyer]{}, errors.New("some random error from repository")).
 			Once()
 
-		got, err := serviceDefault.GetAll(ctx)
+		got, err := serviceDefault.GetAll(ctx, models.QueryOptions{})
 
-		assert.Equal(t, []models.Buyer{}, got)
+		assert.Equal(t, models.Page[models.Buyer]{}, got)
 		assert.Error(t, err)
 		repoMock.AssertNumberOfCalls(t, "GetAll", 1)
 		repoMock.AssertExpectations(t)
 	})
 
-	t.Run("repository pass the buyers, service pass the slice as it is", func(t *testing.T) {
+	t.Run("repository pass the buyers, service pass the page as it is", func(t *testing.T) {
 		repoMock := mocks.NewBuyerRepositoryDBMock()
 		serviceDefault := service.NewBuyerServiceDefault(repoMock)
 
@@ -291,13 +291,15 @@ func TestBuyerServiceDefault_GetAll(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		repoMock.On("GetAll", ctx).
-			Return(expectedBuyer, nil).
+		opts := models.QueryOptions{Limit: 2, Sort: "last_name"}
+		expectedPage := models.NewPage(expectedBuyer, 5, nil)
+		repoMock.On("GetAll", ctx, opts).
+			Return(expectedPage, nil).
 			Once()
 
-		got, err := serviceDefault.GetAll(ctx)
+		got, err := serviceDefault.GetAll(ctx, opts)
 
-		assert.Equal(t, expectedBuyer, got)
+		assert.Equal(t, expectedPage, got)
 		assert.Nil(t, err)
 		repoMock.AssertNumberOfCalls(t, "GetAll", 1)
 		repoMock.AssertExpectations(t)
@@ -1,9 +1,10 @@
 package service
 
 import (
+	"context"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
-	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
 )
 
 type CarryServiceDefault struct {
@@ -15,22 +16,46 @@ func NewCarryService(repo repository.CarryRepository) *CarryServiceDefault {
 	return &CarryServiceDefault{repo: repo}
 }
 
-// Create validates and creates a new carry.
-func (c *CarryServiceDefault) Create(carryAttributes models.CarryAttributes) (models.Carry, error) {
-	if carryAttributes.Cid == "" {
-		return models.Carry{}, httperrors.BadRequestError{Message: "the field Cid must not be empty"}
+// Create creates a new carry. The attributes are validated by the handler.
+func (c *CarryServiceDefault) Create(ctx context.Context, carryAttributes models.CarryAttributes) (models.Carry, error) {
+	return c.repo.Create(ctx, carryAttributes)
+}
+
+// GetAll returns the page of carries described by opts.
+func (c *CarryServiceDefault) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Carry], error) {
+	return c.repo.GetAll(ctx, opts)
+}
+
+// GetByID returns a carry by ID.
+func (c *CarryServiceDefault) GetByID(ctx context.Context, id int) (models.Carry, error) {
+	return c.repo.GetByID(ctx, id)
+}
+
+// Update retrieves the carry by ID, applies the non-nil fields of carryPatch and saves it.
+func (c *CarryServiceDefault) Update(ctx context.Context, id int, carryPatch models.CarryPatchRequest) (models.Carry, error) {
+	carry, err := c.repo.GetByID(ctx, id)
+	if err != nil {
+		return models.Carry{}, err
+	}
+	if carryPatch.Cid != nil {
+		carry.Cid = *carryPatch.Cid
 	}
-	if carryAttributes.CompanyName == "" {
-		return models.Carry{}, httperrors.BadRequestError{Message: "the field CompanyName must not be empty"}
+	if carryPatch.CompanyName != nil {
+		carry.CompanyName = *carryPatch.CompanyName
 	}
-	if carryAttributes.Address == "" {
-		return models.Carry{}, httperrors.BadRequestError{Message: "the field Address must not be empty"}
+	if carryPatch.Address != nil {
+		carry.Address = *carryPatch.Address
 	}
-	if carryAttributes.Telephone == "" {
-		return models.Carry{}, httperrors.BadRequestError{Message: "the field Telephone must not be empty"}
+	if carryPatch.Telephone != nil {
+		carry.Telephone = *carryPatch.Telephone
 	}
-	if carryAttributes.LocalityId == "" {
-		return models.Carry{}, httperrors.BadRequestError{Message: "the field LocalityId must not be empty"}
+	if carryPatch.LocalityId != nil {
+		carry.LocalityId = *carryPatch.LocalityId
 	}
-	return c.repo.Create(carryAttributes)
+	return c.repo.Update(ctx, id, carry.CarryAttributes)
+}
+
+// Delete removes a carry by ID.
+func (c *CarryServiceDefault) Delete(ctx context.Context, id int) error {
+	return c.repo.Delete(ctx, id)
 }
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+)
+
+// CountryServiceDefault is the implementation of CountryService,
+// delegating persistence to a CountryRepository.
+type CountryServiceDefault struct {
+	repo repository.CountryRepository
+}
+
+// NewCountryServiceDefault constructs a CountryServiceDefault
+// with the given repository.
+func NewCountryServiceDefault(repo repository.CountryRepository) CountryService {
+	return &CountryServiceDefault{repo: repo}
+}
+
+// Create creates a new country in the repository.
+func (s *CountryServiceDefault) Create(ctx context.Context, country models.CountryAttributes) (models.Country, error) {
+	return s.repo.Create(ctx, country)
+}
+
+// GetAll returns the page of countries described by opts.
+func (s *CountryServiceDefault) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Country], error) {
+	return s.repo.GetAll(ctx, opts)
+}
+
+// GetByID returns the country with the given ID.
+func (s *CountryServiceDefault) GetByID(ctx context.Context, id int) (models.Country, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// Update retrieves the country, applies the non-nil fields of the patch
+// and persists it. Returns the updated country or an error.
+func (s *CountryServiceDefault) Update(ctx context.Context, id int, patch models.CountryPatchRequest) (models.Country, error) {
+	country, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return models.Country{}, err
+	}
+
+	if patch.CountryName != nil {
+		country.CountryName = *patch.CountryName
+	}
+
+	return s.repo.Update(ctx, id, country)
+}
+
+// Delete removes the country with the given ID.
+func (s *CountryServiceDefault) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// GetSellerReport counts the sellers of each country, or only of id when it is not nil.
+func (s *CountryServiceDefault) GetSellerReport(ctx context.Context, id *int) ([]models.RegionSellerReport, error) {
+	return s.repo.GetSellerReport(ctx, id)
+}
+
+// GetCarryReport counts the carries of each country, or only of id when it is not nil.
+func (s *CountryServiceDefault) GetCarryReport(ctx context.Context, id *int) ([]models.RegionCarryReport, error) {
+	return s.repo.GetCarryReport(ctx, id)
+}
@@ -0,0 +1,107 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	mocks "github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountryServiceDefault_Update(t *testing.T) {
+	argentina := models.Country{ID: 1, CountryAttributes: models.CountryAttributes{CountryName: "Argentina"}}
+
+	t.Run("applies the name of the patch", func(t *testing.T) {
+		// arrange
+		ctx := context.Background()
+		renamed := models.Country{ID: 1, CountryAttributes: models.CountryAttributes{CountryName: "Uruguay"}}
+		repoMock := new(mocks.CountryRepositoryMock)
+		repoMock.On("GetByID", ctx, 1).Return(argentina, nil)
+		repoMock.On("Update", ctx, 1, renamed).Return(renamed, nil)
+		srv := service.NewCountryServiceDefault(repoMock)
+
+		// act
+		got, err := srv.Update(ctx, 1, models.CountryPatchRequest{CountryName: utils.Ptr("Uruguay")})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, renamed, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("empty patch keeps the stored name", func(t *testing.T) {
+		// arrange
+		ctx := context.Background()
+		repoMock := new(mocks.CountryRepositoryMock)
+		repoMock.On("GetByID", ctx, 1).Return(argentina, nil)
+		repoMock.On("Update", ctx, 1, argentina).Return(argentina, nil)
+		srv := service.NewCountryServiceDefault(repoMock)
+
+		// act
+		got, err := srv.Update(ctx, 1, models.CountryPatchRequest{})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, argentina, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("missing country is not updated", func(t *testing.T) {
+		// arrange
+		ctx := context.Background()
+		notFound := httperrors.NotFoundError{Message: "Country not found"}
+		repoMock := new(mocks.CountryRepositoryMock)
+		repoMock.On("GetByID", ctx, 9).Return(models.Country{}, notFound)
+		srv := service.NewCountryServiceDefault(repoMock)
+
+		// act
+		_, err := srv.Update(ctx, 9, models.CountryPatchRequest{CountryName: utils.Ptr("Uruguay")})
+
+		// assert
+		assert.Equal(t, notFound, err)
+		repoMock.AssertExpectations(t)
+		repoMock.AssertNotCalled(t, "Update")
+	})
+}
+
+func TestCountryServiceDefault_Delete(t *testing.T) {
+	t.Run("referenced country returns the ConflictError of the repository", func(t *testing.T) {
+		// arrange
+		ctx := context.Background()
+		conflict := httperrors.ConflictError{Message: "Country is still referenced by provinces."}
+		repoMock := new(mocks.CountryRepositoryMock)
+		repoMock.On("Delete", ctx, 1).Return(conflict)
+		srv := service.NewCountryServiceDefault(repoMock)
+
+		// act
+		err := srv.Delete(ctx, 1)
+
+		// assert
+		assert.Equal(t, conflict, err)
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestCountryServiceDefault_GetSellerReport(t *testing.T) {
+	t.Run("returns the report of the repository", func(t *testing.T) {
+		// arrange
+		ctx := context.Background()
+		reports := []models.RegionSellerReport{{ID: 1, Name: "Argentina", SellersCount: 3}}
+		repoMock := new(mocks.CountryRepositoryMock)
+		repoMock.On("GetSellerReport", ctx, utils.Ptr(1)).Return(reports, nil)
+		srv := service.NewCountryServiceDefault(repoMock)
+
+		// act
+		got, err := srv.GetSellerReport(ctx, utils.Ptr(1))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, reports, got)
+		repoMock.AssertExpectations(t)
+	})
+}
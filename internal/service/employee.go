@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
@@ -18,44 +20,39 @@ func NewEmployeeService(repo repository.EmployeeRepository, inboundOrderRepo rep
 // Create creates a new employee with the provided attributes.
 // It checks for duplicate CardNumberID before persisting the new employee.
 // Returns the created employee or an error if the operation fails.
-func (e EmployeeServiceDefault) Create(employee models.EmployeeAttributes) (models.Employee, error) {
-	existing, err := e.repo.GetAll()
+func (e EmployeeServiceDefault) Create(ctx context.Context, employee models.EmployeeAttributes) (models.Employee, error) {
+	existing, err := e.repo.GetAll(ctx, models.QueryOptions{})
 	if err != nil {
 		return models.Employee{}, err
 	}
-	for _, emp := range existing {
+	for _, emp := range existing.Data {
 		if emp.CardNumberID == employee.CardNumberID {
 			return models.Employee{}, httperrors.ConflictError{Message: "duplicate card number"}
 		}
 	}
 
 	newEmployee := models.Employee{EmployeeAttributes: employee}
-	return e.repo.Create(newEmployee)
+	return e.repo.Create(ctx, newEmployee)
 }
 
-// GetAll retrieves all employees from the repository.
-// The returned list is sorted by employee ID in ascending order.
-// Returns the list of employees or an error if the operation fails.
-func (e EmployeeServiceDefault) GetAll() ([]models.Employee, error) {
-	employees, err := e.repo.GetAll()
-	if err != nil {
-		return nil, err
-	}
-
-	return employees, nil
+// GetAll retrieves the page of employees described by opts from the repository.
+// Without a sort field the page is sorted by employee ID in ascending order.
+// Returns the page of employees or an error if the operation fails.
+func (e EmployeeServiceDefault) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Employee], error) {
+	return e.repo.GetAll(ctx, opts)
 }
 
 // GetByID retrieves a single employee by its unique ID from the repository.
 // Returns the employee or an error if not found.
-func (e EmployeeServiceDefault) GetByID(id int) (models.Employee, error) {
-	return e.repo.GetByID(id)
+func (e EmployeeServiceDefault) GetByID(ctx context.Context, id int) (models.Employee, error) {
+	return e.repo.GetByID(ctx, id)
 }
 
 // Update updates an existing employee identified by id with the provided attributes.
 // It updates only the non-zero fields and checks for CardNumberID duplication.
 // Returns the updated employee or an error if the operation fails.
-func (e EmployeeServiceDefault) Update(id int, attrs models.EmployeeAttributes) (models.Employee, error) {
-	dbEmployee, err := e.repo.GetByID(id)
+func (e EmployeeServiceDefault) Update(ctx context.Context, id int, attrs models.EmployeeAttributes) (models.Employee, error) {
+	dbEmployee, err := e.repo.GetByID(ctx, id)
 	if err != nil {
 		return models.Employee{}, err
 	}
@@ -74,37 +71,37 @@ func (e EmployeeServiceDefault) Update(id int, attrs models.EmployeeAttributes)
 	}
 
 	if attrs.CardNumberID != "" {
-		existing, err := e.repo.GetAll()
+		existing, err := e.repo.GetAll(ctx, models.QueryOptions{})
 		if err != nil {
 			return models.Employee{}, err
 		}
-		for _, emp := range existing {
+		for _, emp := range existing.Data {
 			if emp.CardNumberID == dbEmployee.CardNumberID && emp.Id != id {
 				return models.Employee{}, httperrors.ConflictError{Message: "duplicated card number"}
 			}
 		}
 	}
-	return e.repo.Update(id, dbEmployee)
+	return e.repo.Update(ctx, id, dbEmployee)
 }
 
 // Delete removes the employee identified by id from the repository.
 // Returns an error if the employee does not exist or the operation fails.
-func (e EmployeeServiceDefault) Delete(id int) error {
-	return e.repo.Delete(id)
+func (e EmployeeServiceDefault) Delete(ctx context.Context, id int) error {
+	return e.repo.Delete(ctx, id)
 }
 
 // ReportInboundOrders returns a report of inbound order counts per employee.
 // - If an employeeID is provided (non-zero), it returns the inbound order count for the specified employee.
 // - If employeeID is zero, it returns inbound order counts for all employees.
 // Returns an error if the employee does not exist or if a data access error occurs.
-func (s *EmployeeServiceDefault) ReportInboundOrders(employeeID int) ([]models.EmployeeWithInboundCount, error) {
+func (s *EmployeeServiceDefault) ReportInboundOrders(ctx context.Context, employeeID int) ([]models.EmployeeWithInboundCount, error) {
 	// Check for one employee
 	if employeeID != 0 {
-		emp, err := s.repo.GetByID(employeeID)
+		emp, err := s.repo.GetByID(ctx, employeeID)
 		if err != nil || emp.Id == 0 {
 			return nil, httperrors.NotFoundError{Message: "employee not found"}
 		}
-		count, err := s.inboundOrderRepo.CountInboundOrdersForEmployee(employeeID)
+		count, err := s.inboundOrderRepo.CountInboundOrdersForEmployee(ctx, employeeID)
 		if err != nil {
 			return nil, err
 		}
@@ -115,12 +112,13 @@ func (s *EmployeeServiceDefault) ReportInboundOrders(employeeID int) ([]models.E
 	}
 
 	// Check for all employees
-	employees, err := s.repo.GetAll()
+	page, err := s.repo.GetAll(ctx, models.QueryOptions{})
 	if err != nil {
 		return nil, err
 	}
+	employees := page.Data
 
-	counts, err := s.inboundOrderRepo.CountInboundOrdersForEmployees()
+	counts, err := s.inboundOrderRepo.CountInboundOrdersForEmployees(ctx)
 	if err != nil {
 		return nil, err
 	}
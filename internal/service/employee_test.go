@@ -1,6 +1,7 @@
 package service_test
 
 import (
+	"context"
 	"errors"
 	mocks "github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/repository"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,9 +28,9 @@ func TestEmployeeServiceDefault_Create(t *testing.T) {
 		mockRepo := mocks.MockEmployeeRepository{}
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
-		mockRepo.On("GetAll").Return([]models.Employee{}, nil)
-		mockRepo.On("Create", toCreateEmp).Return(expectedEmp, nil)
-		emp, err := serviceEmp.Create(attrs)
+		mockRepo.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.Page[models.Employee]{}, nil)
+		mockRepo.On("Create", mock.Anything, toCreateEmp).Return(expectedEmp, nil)
+		emp, err := serviceEmp.Create(context.Background(), attrs)
 		require.NoError(t, err)
 		require.Equal(t, expectedEmp, emp)
 		mockRepo.AssertExpectations(t)
@@ -39,8 +41,8 @@ func TestEmployeeServiceDefault_Create(t *testing.T) {
 		mockRepo := mocks.MockEmployeeRepository{}
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
-		mockRepo.On("GetAll").Return([]models.Employee{expectedEmp}, nil)
-		emp, err := serviceEmp.Create(attrs)
+		mockRepo.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.NewPage([]models.Employee{expectedEmp}, 1, nil), nil)
+		emp, err := serviceEmp.Create(context.Background(), attrs)
 		require.Error(t, err)
 		require.ErrorAs(t, err, &httperrors.ConflictError{})
 		require.Equal(t, models.Employee{}, emp)
@@ -52,8 +54,8 @@ func TestEmployeeServiceDefault_Create(t *testing.T) {
 		mockRepo := mocks.MockEmployeeRepository{}
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
-		mockRepo.On("GetAll").Return(nil, errors.New("db error"))
-		emp, err := serviceEmp.Create(attrs)
+		mockRepo.On("GetAll", mock.Anything, models.QueryOptions{}).Return(nil, errors.New("db error"))
+		emp, err := serviceEmp.Create(context.Background(), attrs)
 		require.Error(t, err)
 		require.Equal(t, models.Employee{}, emp)
 		mockRepo.AssertExpectations(t)
@@ -78,10 +80,10 @@ func TestEmployeeServiceDefault_GetAll(t *testing.T) {
 			WarehouseID:  2,
 		}}
 		employees := []models.Employee{employeeA, employeeB}
-		mockRepo.On("GetAll").Return(employees, nil)
-		result, err := serviceEmp.GetAll()
+		mockRepo.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.NewPage(employees, len(employees), nil), nil)
+		result, err := serviceEmp.GetAll(context.Background(), models.QueryOptions{})
 		require.NoError(t, err)
-		require.Equal(t, employees, result)
+		require.Equal(t, employees, result.Data)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -90,10 +92,10 @@ func TestEmployeeServiceDefault_GetAll(t *testing.T) {
 		mockRepo := mocks.MockEmployeeRepository{}
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
-		mockRepo.On("GetAll").Return(nil, errors.New("db error"))
-		result, err := serviceEmp.GetAll()
+		mockRepo.On("GetAll", mock.Anything, models.QueryOptions{}).Return(nil, errors.New("db error"))
+		result, err := serviceEmp.GetAll(context.Background(), models.QueryOptions{})
 		require.Error(t, err)
-		require.Nil(t, result)
+		require.Nil(t, result.Data)
 		mockRepo.AssertExpectations(t)
 	})
 }
@@ -108,8 +110,8 @@ func TestEmployeeServiceDefault_GetByID(t *testing.T) {
 		mockRepo := mocks.MockEmployeeRepository{}
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
-		mockRepo.On("GetByID", 99).Return(models.Employee{}, errors.New("not found"))
-		emp, err := serviceEmp.GetByID(99)
+		mockRepo.On("GetByID", mock.Anything, 99).Return(models.Employee{}, errors.New("not found"))
+		emp, err := serviceEmp.GetByID(context.Background(), 99)
 		require.Error(t, err)
 		require.Equal(t, models.Employee{}, emp)
 		mockRepo.AssertExpectations(t)
@@ -120,8 +122,8 @@ func TestEmployeeServiceDefault_GetByID(t *testing.T) {
 		mockRepo := mocks.MockEmployeeRepository{}
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
-		mockRepo.On("GetByID", 1).Return(expectedEmp, nil)
-		emp, err := serviceEmp.GetByID(1)
+		mockRepo.On("GetByID", mock.Anything, 1).Return(expectedEmp, nil)
+		emp, err := serviceEmp.GetByID(context.Background(), 1)
 		require.NoError(t, err)
 		require.Equal(t, expectedEmp, emp)
 		mockRepo.AssertExpectations(t)
@@ -138,8 +140,8 @@ func TestEmployeeServiceDefault_Update(t *testing.T) {
 		mockRepo := mocks.MockEmployeeRepository{}
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
-		mockRepo.On("GetByID", 1).Return(expectedEmp, nil)
-		mockRepo.On("GetAll").Return([]models.Employee{expectedEmp}, nil)
+		mockRepo.On("GetByID", mock.Anything, 1).Return(expectedEmp, nil)
+		mockRepo.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.NewPage([]models.Employee{expectedEmp}, 1, nil), nil)
 		updatedAttrs := models.EmployeeAttributes{
 			CardNumberID: "10000002",
 			FirstName:    "Tommy",
@@ -147,8 +149,8 @@ func TestEmployeeServiceDefault_Update(t *testing.T) {
 			WarehouseID:  1,
 		}
 		expectedUpdated := models.Employee{Id: 1, EmployeeAttributes: updatedAttrs}
-		mockRepo.On("Update", 1, expectedUpdated).Return(expectedUpdated, nil)
-		emp, err := serviceEmp.Update(1, updatedAttrs)
+		mockRepo.On("Update", mock.Anything, 1, expectedUpdated).Return(expectedUpdated, nil)
+		emp, err := serviceEmp.Update(context.Background(), 1, updatedAttrs)
 		require.NoError(t, err)
 		require.Equal(t, expectedUpdated, emp)
 		mockRepo.AssertExpectations(t)
@@ -159,8 +161,8 @@ func TestEmployeeServiceDefault_Update(t *testing.T) {
 		mockRepo := mocks.MockEmployeeRepository{}
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
-		mockRepo.On("GetByID", 99).Return(models.Employee{}, errors.New("not found"))
-		emp, err := serviceEmp.Update(99, attrs)
+		mockRepo.On("GetByID", mock.Anything, 99).Return(models.Employee{}, errors.New("not found"))
+		emp, err := serviceEmp.Update(context.Background(), 99, attrs)
 		require.Error(t, err)
 		require.Equal(t, models.Employee{}, emp)
 		mockRepo.AssertExpectations(t)
@@ -181,9 +183,9 @@ func TestEmployeeServiceDefault_Update(t *testing.T) {
 			LastName:     "Shelby",
 			WarehouseID:  1,
 		}
-		mockRepo.On("GetByID", 1).Return(originalEmp, nil)
-		mockRepo.On("GetAll").Return([]models.Employee{originalEmp, existingEmp}, nil)
-		emp, err := serviceEmp.Update(1, updatedAttrs)
+		mockRepo.On("GetByID", mock.Anything, 1).Return(originalEmp, nil)
+		mockRepo.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.NewPage([]models.Employee{originalEmp, existingEmp}, 2, nil), nil)
+		emp, err := serviceEmp.Update(context.Background(), 1, updatedAttrs)
 		require.Error(t, err)
 		require.ErrorAs(t, err, &httperrors.ConflictError{})
 		require.Contains(t, err.Error(), "duplicated card number")
@@ -199,8 +201,8 @@ func TestEmployeeServiceDefault_Delete(t *testing.T) {
 		mockRepo := mocks.MockEmployeeRepository{}
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
-		mockRepo.On("Delete", 99).Return(errors.New("not found"))
-		err := serviceEmp.Delete(99)
+		mockRepo.On("Delete", mock.Anything, 99).Return(errors.New("not found"))
+		err := serviceEmp.Delete(context.Background(), 99)
 		require.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
@@ -210,8 +212,8 @@ func TestEmployeeServiceDefault_Delete(t *testing.T) {
 		mockRepo := mocks.MockEmployeeRepository{}
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
-		mockRepo.On("Delete", 1).Return(nil)
-		err := serviceEmp.Delete(1)
+		mockRepo.On("Delete", mock.Anything, 1).Return(nil)
+		err := serviceEmp.Delete(context.Background(), 1)
 		require.NoError(t, err)
 		mockRepo.AssertExpectations(t)
 	})
@@ -224,8 +226,8 @@ func TestEmployeeServiceDefault_ReportInboundOrders(t *testing.T) {
 		mockRepo := mocks.MockEmployeeRepository{}
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
-		mockRepo.On("GetByID", 123).Return(models.Employee{}, errors.New("db error"))
-		res, err := serviceEmp.ReportInboundOrders(123)
+		mockRepo.On("GetByID", mock.Anything, 123).Return(models.Employee{}, errors.New("db error"))
+		res, err := serviceEmp.ReportInboundOrders(context.Background(), 123)
 		require.Error(t, err)
 		require.Nil(t, res)
 		require.ErrorAs(t, err, &httperrors.NotFoundError{})
@@ -237,8 +239,8 @@ func TestEmployeeServiceDefault_ReportInboundOrders(t *testing.T) {
 		mockRepo := mocks.MockEmployeeRepository{}
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
-		mockRepo.On("GetByID", 123).Return(models.Employee{}, nil)
-		res, err := serviceEmp.ReportInboundOrders(123)
+		mockRepo.On("GetByID", mock.Anything, 123).Return(models.Employee{}, nil)
+		res, err := serviceEmp.ReportInboundOrders(context.Background(), 123)
 		require.Error(t, err)
 		require.Nil(t, res)
 		require.ErrorAs(t, err, &httperrors.NotFoundError{})
@@ -251,9 +253,9 @@ func TestEmployeeServiceDefault_ReportInboundOrders(t *testing.T) {
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
 		emp := models.Employee{Id: 1, EmployeeAttributes: getValidEmployeeAttributes()}
-		mockRepo.On("GetByID", 1).Return(emp, nil)
-		mockInboundOrderRepo.On("CountInboundOrdersForEmployee", 1).Return(0, errors.New("count error"))
-		res, err := serviceEmp.ReportInboundOrders(1)
+		mockRepo.On("GetByID", mock.Anything, 1).Return(emp, nil)
+		mockInboundOrderRepo.On("CountInboundOrdersForEmployee", mock.Anything, 1).Return(0, errors.New("count error"))
+		res, err := serviceEmp.ReportInboundOrders(context.Background(), 1)
 		require.Error(t, err)
 		require.Nil(t, res)
 		mockRepo.AssertExpectations(t)
@@ -265,8 +267,8 @@ func TestEmployeeServiceDefault_ReportInboundOrders(t *testing.T) {
 		mockRepo := mocks.MockEmployeeRepository{}
 		mockInboundOrderRepo := mocks.MockInboundOrderRepository{}
 		serviceEmp := service.NewEmployeeService(&mockRepo, &mockInboundOrderRepo)
-		mockRepo.On("GetAll").Return([]models.Employee{}, errors.New("db error"))
-		res, err := serviceEmp.ReportInboundOrders(0)
+		mockRepo.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.Page[models.Employee]{}, errors.New("db error"))
+		res, err := serviceEmp.ReportInboundOrders(context.Background(), 0)
 		require.Error(t, err)
 		require.Nil(t, res)
 		mockRepo.AssertExpectations(t)
@@ -280,9 +282,9 @@ func TestEmployeeServiceDefault_ReportInboundOrders(t *testing.T) {
 		emps := []models.Employee{
 			{Id: 1, EmployeeAttributes: getValidEmployeeAttributes()},
 		}
-		mockRepo.On("GetAll").Return(emps, nil)
-		mockInboundOrderRepo.On("CountInboundOrdersForEmployees").Return(map[int]int{}, errors.New("count error"))
-		res, err := serviceEmp.ReportInboundOrders(0)
+		mockRepo.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.NewPage(emps, len(emps), nil), nil)
+		mockInboundOrderRepo.On("CountInboundOrdersForEmployees", mock.Anything).Return(map[int]int{}, errors.New("count error"))
+		res, err := serviceEmp.ReportInboundOrders(context.Background(), 0)
 		require.Error(t, err)
 		require.Nil(t, res)
 		mockRepo.AssertExpectations(t)
@@ -302,14 +304,14 @@ func TestEmployeeServiceDefault_ReportInboundOrders(t *testing.T) {
 			},
 		}
 		count := 123
-		mockRepo.On("GetByID", employeeID).Return(emp, nil)
-		mockInboundOrderRepo.On("CountInboundOrdersForEmployee", employeeID).Return(count, nil)
+		mockRepo.On("GetByID", mock.Anything, employeeID).Return(emp, nil)
+		mockInboundOrderRepo.On("CountInboundOrdersForEmployee", mock.Anything, employeeID).Return(count, nil)
 
 		expected := []models.EmployeeWithInboundCount{
 			{Id: emp.Id, CardNumberID: emp.CardNumberID, FirstName: emp.FirstName, LastName: emp.LastName, WarehouseID: emp.WarehouseID, InboundOrdersCount: count},
 		}
 
-		res, err := serviceEmp.ReportInboundOrders(employeeID)
+		res, err := serviceEmp.ReportInboundOrders(context.Background(), employeeID)
 		require.NoError(t, err)
 		require.Equal(t, expected, res)
 		mockRepo.AssertExpectations(t)
@@ -331,15 +333,15 @@ func TestEmployeeServiceDefault_ReportInboundOrders(t *testing.T) {
 		}
 		counts := map[int]int{1: 10, 2: 20}
 
-		mockRepo.On("GetAll").Return(emps, nil)
-		mockInboundOrderRepo.On("CountInboundOrdersForEmployees").Return(counts, nil)
+		mockRepo.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.NewPage(emps, len(emps), nil), nil)
+		mockInboundOrderRepo.On("CountInboundOrdersForEmployees", mock.Anything).Return(counts, nil)
 
 		expected := []models.EmployeeWithInboundCount{
 			{Id: 1, CardNumberID: "1", FirstName: "A", LastName: "AA", WarehouseID: 1, InboundOrdersCount: 10},
 			{Id: 2, CardNumberID: "2", FirstName: "B", LastName: "BB", WarehouseID: 2, InboundOrdersCount: 20},
 		}
 
-		res, err := serviceEmp.ReportInboundOrders(0)
+		res, err := serviceEmp.ReportInboundOrders(context.Background(), 0)
 		require.NoError(t, err)
 		require.Equal(t, expected, res)
 		mockRepo.AssertExpectations(t)
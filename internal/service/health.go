@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+)
+
+// healthCheckTimeout bounds each dependency check, so a hung database fails
+// the readiness probe instead of stalling it
+const healthCheckTimeout = 2 * time.Second
+
+// HealthServiceDefault implements HealthService
+type HealthServiceDefault struct {
+	// repository is nil with the in-memory storage, which has nothing to check
+	repository repository.HealthRepository
+	migrations []string
+	version    string
+}
+
+// NewHealthService creates a HealthService checking that every one of migrations
+// is applied. repository may be nil when the api runs without a database.
+func NewHealthService(repository repository.HealthRepository, migrations []string, version string) HealthService {
+	return &HealthServiceDefault{repository: repository, migrations: migrations, version: version}
+}
+
+// Live reports the api is up.
+func (service *HealthServiceDefault) Live(ctx context.Context) models.HealthReport {
+	return models.HealthReport{Status: models.HealthStatusUp, Version: service.version}
+}
+
+// Ready pings the database and compares the migrations with the ones recorded
+// in schema_migrations. The report is down when any check is.
+func (service *HealthServiceDefault) Ready(ctx context.Context) models.HealthReport {
+	report := models.HealthReport{Status: models.HealthStatusUp, Version: service.version}
+	if service.repository == nil {
+		return report
+	}
+
+	database := models.HealthCheck{Name: "database", Status: models.HealthStatusUp}
+	pingCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	if err := service.repository.Ping(pingCtx); err != nil {
+		database.Status, database.Error = models.HealthStatusDown, err.Error()
+	}
+
+	migrations := models.HealthCheck{Name: "migrations", Status: models.HealthStatusUp}
+	if database.Status == models.HealthStatusDown {
+		migrations.Status, migrations.Error = models.HealthStatusDown, "database unreachable"
+	} else {
+		migrationsCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		defer cancel()
+		applied, err := service.repository.GetAppliedMigrations(migrationsCtx)
+		if err != nil {
+			migrations.Status, migrations.Error = models.HealthStatusDown, err.Error()
+		} else if pending := pendingMigrations(service.migrations, applied); len(pending) > 0 {
+			migrations.Status, migrations.Error = models.HealthStatusDown, "schema is behind"
+			migrations.PendingMigrations = pending
+		}
+	}
+
+	report.Checks = []models.HealthCheck{database, migrations}
+	for _, check := range report.Checks {
+		if check.Status == models.HealthStatusDown {
+			report.Status = models.HealthStatusDown
+		}
+	}
+	return report
+}
+
+// pendingMigrations returns the migrations missing from applied, in order
+func pendingMigrations(migrations, applied []string) []string {
+	recorded := make(map[string]struct{}, len(applied))
+	for _, name := range applied {
+		recorded[name] = struct{}{}
+	}
+	var pending []string
+	for _, name := range migrations {
+		if _, ok := recorded[name]; !ok {
+			pending = append(pending, name)
+		}
+	}
+	return pending
+}
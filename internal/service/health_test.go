@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+func TestHealthService_Ready(t *testing.T) {
+	migrations := []string{"0001_seller.sql", "0002_warehouses.sql", "0003_products.sql"}
+
+	tests := []struct {
+		testName       string
+		pingError      error
+		applied        []string
+		appliedError   error
+		expectedReport models.HealthReport
+	}{
+		{
+			testName: "every migration applied is up",
+			applied:  []string{"0003_products.sql", "0001_seller.sql", "0002_warehouses.sql"},
+			expectedReport: models.HealthReport{Status: models.HealthStatusUp, Version: "v1.2.0", Checks: []models.HealthCheck{
+				{Name: "database", Status: models.HealthStatusUp},
+				{Name: "migrations", Status: models.HealthStatusUp},
+			}},
+		},
+		{
+			testName: "schema behind is down with the pending migrations",
+			applied:  []string{"0001_seller.sql"},
+			expectedReport: models.HealthReport{Status: models.HealthStatusDown, Version: "v1.2.0", Checks: []models.HealthCheck{
+				{Name: "database", Status: models.HealthStatusUp},
+				{Name: "migrations", Status: models.HealthStatusDown, Error: "schema is behind",
+					PendingMigrations: []string{"0002_warehouses.sql", "0003_products.sql"}},
+			}},
+		},
+		{
+			testName:     "missing schema_migrations is down",
+			appliedError: errors.New("Table 'fresh.schema_migrations' doesn't exist"),
+			expectedReport: models.HealthReport{Status: models.HealthStatusDown, Version: "v1.2.0", Checks: []models.HealthCheck{
+				{Name: "database", Status: models.HealthStatusUp},
+				{Name: "migrations", Status: models.HealthStatusDown, Error: "Table 'fresh.schema_migrations' doesn't exist"},
+			}},
+		},
+		{
+			testName:  "unreachable database is down without reading the migrations",
+			pingError: errors.New("dial tcp 127.0.0.1:3306: connect: connection refused"),
+			expectedReport: models.HealthReport{Status: models.HealthStatusDown, Version: "v1.2.0", Checks: []models.HealthCheck{
+				{Name: "database", Status: models.HealthStatusDown, Error: "dial tcp 127.0.0.1:3306: connect: connection refused"},
+				{Name: "migrations", Status: models.HealthStatusDown, Error: "database unreachable"},
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// arrange
+			repo := new(mocks.HealthRepositoryMock)
+			repo.On("Ping", testifyMock.Anything).Return(tc.pingError)
+			if tc.pingError == nil {
+				repo.On("GetAppliedMigrations", testifyMock.Anything).Return(tc.applied, tc.appliedError)
+			}
+			service := NewHealthService(repo, migrations, "v1.2.0")
+
+			// act
+			report := service.Ready(context.Background())
+
+			// assert
+			assert.Equal(t, tc.expectedReport, report)
+			repo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("without a database there is nothing to check", func(t *testing.T) {
+		// arrange
+		service := NewHealthService(nil, migrations, "dev")
+
+		// act
+		report := service.Ready(context.Background())
+
+		// assert
+		assert.Equal(t, models.HealthReport{Status: models.HealthStatusUp, Version: "dev"}, report)
+	})
+}
@@ -1,53 +1,33 @@
 package service
 
 import (
+	"context"
+	"time"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
-	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
 )
 
 // InboundOrderServiceDefault implements the InboundOrderService interface.
 type InboundOrderServiceDefault struct {
-	repo          repository.InboundOrderRepository
-	employeeRepo  repository.EmployeeRepository
-	warehouseRepo repository.WarehouseRepository
+	repo repository.InboundOrderRepository
 }
 
 // NewInboundOrderService returns a new InboundOrderService.
-func NewInboundOrderService(repo repository.InboundOrderRepository, employeeRepo repository.EmployeeRepository, warehouseRepo repository.WarehouseRepository) InboundOrderService {
+func NewInboundOrderService(repo repository.InboundOrderRepository) InboundOrderService {
 	return &InboundOrderServiceDefault{
-		repo:          repo,
-		employeeRepo:  employeeRepo,
-		warehouseRepo: warehouseRepo,
+		repo: repo,
 	}
 }
 
-// Create creates a new inbound order with the provided attributes.
-// It checks for duplicate OrderNumber before persisting the new inbound order,
-// and verifies that the given EmployeeID exists.
+// Create receives a new inbound order with the provided attributes.
+// Orders without an order date are received now. The repository checks the
+// order number, the employee and the warehouse of the batch section, and
+// creates or tops up the batch, all in the same transaction.
 // Returns the created inbound order or an error if the operation fails.
-func (s InboundOrderServiceDefault) Create(attrs models.InboundOrderAttributes) (models.InboundOrder, error) {
-	// Check for duplicate order number (unique)
-	existing, err := s.repo.GetByOrderNumber(attrs.OrderNumber)
-	if err != nil {
-		return models.InboundOrder{}, err
-	}
-	if existing.ID != 0 {
-		return models.InboundOrder{}, httperrors.ConflictError{Message: "duplicate order number"}
-	}
-
-	// Check if employee exists
-	employee, err := s.employeeRepo.GetByID(attrs.EmployeeID)
-	if err != nil || employee.Id == 0 {
-		return models.InboundOrder{}, httperrors.ConflictError{Message: "employee does not exist"}
+func (s InboundOrderServiceDefault) Create(ctx context.Context, attrs models.InboundOrderAttributes) (models.InboundOrder, error) {
+	if attrs.OrderDate.IsZero() {
+		attrs.OrderDate = time.Now().UTC().Truncate(time.Second)
 	}
-
-	// Check if warehouse exists
-	warehouse, err := s.warehouseRepo.GetByID(attrs.WarehouseID)
-	if err != nil || warehouse.Id == 0 {
-		return models.InboundOrder{}, httperrors.ConflictError{Message: "warehouse does not exist"}
-	}
-
-	newInboundOrder := models.InboundOrder{InboundOrderAttributes: attrs}
-	return s.repo.Create(newInboundOrder)
+	return s.repo.Create(ctx, attrs)
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
@@ -15,29 +17,51 @@ func NewLocalityService(repo repository.LocalityRepository) LocalityService {
 }
 
 // This function creates a new locality
-// It checks if all required fields are provided and returns an error if any are missing
-func (s *LocalityServiceDefault) Create(locality models.Locality) (models.Locality, error) {
-	if locality.ID == "" || locality.LocalityName == "" || locality.ProvinceName == "" || locality.CountryName == "" {
-		return models.Locality{}, httperrors.UnprocessableEntityError{
-			Message: "Invalid locality data",
-		}
-	}
-	return s.repository.Create(locality)
+// The locality data is validated by the handler
+func (s *LocalityServiceDefault) Create(ctx context.Context, locality models.Locality) (models.Locality, error) {
+	return s.repository.Create(ctx, locality)
+}
+
+// GetAll returns the page of localities described by opts
+func (s *LocalityServiceDefault) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Locality], error) {
+	return s.repository.GetAll(ctx, opts)
 }
 
 // This function retrieves a locality by its ID
-func (s *LocalityServiceDefault) GetByID(id string) (models.Locality, error) {
-	return s.repository.GetByID(id)
+func (s *LocalityServiceDefault) GetByID(ctx context.Context, id string) (models.Locality, error) {
+	return s.repository.GetByID(ctx, id)
+}
+
+// Update retrieves the locality, applies the non-nil fields of the patch and persists it
+func (s *LocalityServiceDefault) Update(ctx context.Context, id string, patch models.LocalityPatchRequest) (models.Locality, error) {
+	locality, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return models.Locality{}, err
+	}
+
+	if patch.LocalityName != nil {
+		locality.LocalityName = *patch.LocalityName
+	}
+	if patch.ProvinceID != nil {
+		locality.ProvinceID = *patch.ProvinceID
+	}
+
+	return s.repository.Update(ctx, id, locality)
+}
+
+// Delete removes the locality with the given ID
+func (s *LocalityServiceDefault) Delete(ctx context.Context, id string) error {
+	return s.repository.Delete(ctx, id)
 }
 
 // This function retrieves the seller report by locality ID
-func (s *LocalityServiceDefault) GetSellerReport(id *string) ([]models.SellerReport, error) {
-	return s.repository.GetSellerReport(id)
+func (s *LocalityServiceDefault) GetSellerReport(ctx context.Context, id *string) ([]models.SellerReport, error) {
+	return s.repository.GetSellerReport(ctx, id)
 }
 
 // GetReportByLocalityId retrieves a report of carries by locality ID.
-func (s *LocalityServiceDefault) GetReportByLocalityId(localityId string) ([]models.CarryReport, error) {
-	result, err := s.repository.GetReportByLocalityId(localityId)
+func (s *LocalityServiceDefault) GetReportByLocalityId(ctx context.Context, localityId string) ([]models.CarryReport, error) {
+	result, err := s.repository.GetReportByLocalityId(ctx, localityId)
 	if result == nil && localityId != "" {
 		return nil, httperrors.NotFoundError{Message: "locality not found"}
 	}
@@ -23,4 +23,64 @@ func NewProductBatchServiceDefault(repo repository.ProductBatchRepository) Produ
 // Create creates a new product batch in the repository
 func (service ProductBatchServiceDefault) Create(ctx context.Context, productBatch models.ProductBatchAttibutes) (models.ProductBatch, error) {
 	return service.repository.Create(ctx, productBatch)
-}
\ No newline at end of file
+}
+// GetAll returns the page of product batches matching the filter
+func (service ProductBatchServiceDefault) GetAll(ctx context.Context, filter models.ProductBatchFilter) (models.Page[models.ProductBatch], error) {
+	return service.repository.GetAll(ctx, filter)
+}
+
+// GetByID returns a product batch by its ID
+func (service ProductBatchServiceDefault) GetByID(ctx context.Context, id int) (models.ProductBatch, error) {
+	return service.repository.GetByID(ctx, id)
+}
+
+// Update, updates a product batch in the repository
+func (service ProductBatchServiceDefault) Update(ctx context.Context, id int, patchData models.UpdateProductBatchRequest) (models.ProductBatch, error) {
+	productBatch, err := service.repository.GetByID(ctx, id)
+	if err != nil {
+		return models.ProductBatch{}, err
+	}
+
+	service.applyChanges(&productBatch.ProductBatchAttibutes, patchData)
+
+	return service.repository.Update(ctx, id, productBatch.ProductBatchAttibutes)
+}
+
+// Delete, deletes a product batch from the repository
+func (service ProductBatchServiceDefault) Delete(ctx context.Context, id int) error {
+	return service.repository.Delete(ctx, id)
+}
+
+// applyChanges, applies the changes to the product batch
+func (service ProductBatchServiceDefault) applyChanges(productBatchToUpdate *models.ProductBatchAttibutes, patchData models.UpdateProductBatchRequest) {
+	if patchData.BatchNumber != nil {
+		productBatchToUpdate.BatchNumber = *patchData.BatchNumber
+	}
+	if patchData.CurrentQuantity != nil {
+		productBatchToUpdate.CurrentQuantity = *patchData.CurrentQuantity
+	}
+	if patchData.CurrentTemperature != nil {
+		productBatchToUpdate.CurrentTemperature = *patchData.CurrentTemperature
+	}
+	if patchData.DueDate != nil {
+		productBatchToUpdate.DueDate = *patchData.DueDate
+	}
+	if patchData.InitialQuantity != nil {
+		productBatchToUpdate.InitialQuantity = *patchData.InitialQuantity
+	}
+	if patchData.ManufacturingDate != nil {
+		productBatchToUpdate.ManufacturingDate = *patchData.ManufacturingDate
+	}
+	if patchData.ManufacturingHour != nil {
+		productBatchToUpdate.ManufacturingHour = *patchData.ManufacturingHour
+	}
+	if patchData.MinimumTemperature != nil {
+		productBatchToUpdate.MinimumTemperature = *patchData.MinimumTemperature
+	}
+	if patchData.ProductID != nil {
+		productBatchToUpdate.ProductID = *patchData.ProductID
+	}
+	if patchData.SectionID != nil {
+		productBatchToUpdate.SectionID = *patchData.SectionID
+	}
+}
@@ -25,10 +25,10 @@ func (s *ProductServiceDefault) Create(ctx context.Context, product models.Produ
 	return s.repo.Create(ctx, product)
 }
 
-// GetAll retrieves all products from the repository, returning a slice
-// containing every product or an error.
-func (s *ProductServiceDefault) GetAll(ctx context.Context) ([]models.Product, error) {
-	return s.repo.GetAll(ctx)
+// GetAll retrieves the page of products described by opts from the
+// repository, together with the total number of matching products.
+func (s *ProductServiceDefault) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Product], error) {
+	return s.repo.GetAll(ctx, opts)
 }
 
 // GetByID fetches a single product by its ID.
@@ -44,6 +44,16 @@ func (s *ProductServiceDefault) GetRecordsPerProduct(ctx context.Context, id *in
 	return s.repo.GetRecordsPerProduct(ctx, id)
 }
 
+// GetMarginReport returns the profitability of the products sold in the period of the
+// filter, with the margins of each product rolled up per seller.
+func (s *ProductServiceDefault) GetMarginReport(ctx context.Context, filter models.MarginReportFilter) (models.MarginReport, error) {
+	productMargins, err := s.repo.GetMargins(ctx, filter)
+	if err != nil {
+		return models.MarginReport{}, err
+	}
+	return models.NewMarginReport(filter, productMargins), nil
+}
+
 // Update retrieves an existing Product by its ID, applies any non-nil fields
 // from the provided ProductPatchRequest, and then persists the updated Product
 // via the repository. Returns the updated Product or an error
@@ -26,3 +26,13 @@ func NewProductRecordServiceDefault(repo repository.ProductRecordRepository) Pro
 func (p *ProductRecordServiceDefault) Create(ctx context.Context, attributes models.ProductRecordAttributes) (models.ProductRecord, error) {
 	return p.repo.Create(ctx, attributes)
 }
+
+// GetAll returns the page of the price history of filter.ProductID
+func (p *ProductRecordServiceDefault) GetAll(ctx context.Context, filter models.ProductRecordFilter) (models.Page[models.ProductRecord], error) {
+	return p.repo.GetAll(ctx, filter)
+}
+
+// GetEffective returns the record of the product in effect on the date at (YYYY-MM-DD)
+func (p *ProductRecordServiceDefault) GetEffective(ctx context.Context, productID int, at string) (models.ProductRecord, error) {
+	return p.repo.GetEffective(ctx, productID, at)
+}
@@ -135,29 +135,29 @@ func TestProductService_GetAll(t *testing.T) {
 
 	// Each test case is constructed by:
 	// testName            — human‐readable description
-	// repositoryData      — the Products slice returned by the mocked repository
+	// repositoryData      — the Products page returned by the mocked repository
 	// repositoryError     — the error returned by the mocked repository
 	// expectedData        — the data we expect the service to produce
 	// expectedError       — the error we expect the service to produce
 	tests := []struct {
 		testName        string
-		repositoryData  []models.Product
+		repositoryData  models.Page[models.Product]
 		repositoryError error
-		expectedData    []models.Product
+		expectedData    models.Page[models.Product]
 		expectedError   error
 	}{
 		{
 			testName:        "Success case: Should return all products",
-			repositoryData:  products,
+			repositoryData:  models.NewPage(products, 2, nil),
 			repositoryError: nil,
-			expectedData:    products,
+			expectedData:    models.NewPage(products, 2, nil),
 			expectedError:   nil,
 		},
 		{
 			testName:        "Error case: Process an error from the repository layer",
-			repositoryData:  []models.Product{},
+			repositoryData:  models.Page[models.Product]{},
 			repositoryError: errors.New("db error"),
-			expectedData:    []models.Product{},
+			expectedData:    models.Page[models.Product]{},
 			expectedError:   errors.New("db error"),
 		},
 	}
@@ -169,11 +169,11 @@ func TestProductService_GetAll(t *testing.T) {
 			service := service.NewProductServiceDefault(&repositoryMock)
 
 			repositoryMock.
-				On("GetAll", mock.Anything).
+				On("GetAll", mock.Anything, models.QueryOptions{}).
 				Return(tc.repositoryData, tc.repositoryError)
 
 			// Act
-			result, err := service.GetAll(context.Background())
+			result, err := service.GetAll(context.Background(), models.QueryOptions{})
 
 			// Assert
 			require.Equal(t, tc.expectedError, err)
@@ -350,6 +350,74 @@ func TestProductService_GetRecordsPerProduct(t *testing.T) {
 	}
 }
 
+// Verifies the behavior of the service layer responsible for the margin report.
+// It covers:
+// - Margins of every product rolled up per seller, products without seller last
+// - Error propagation from the repository layer
+func TestProductService_GetMarginReport(t *testing.T) {
+	filter := models.MarginReportFilter{From: "2024-01-01", To: "2024-01-31"}
+
+	tests := []struct {
+		testName        string
+		repositoryData  []models.ProductMargin
+		repositoryError error
+		expectedData    models.MarginReport
+		expectedError   error
+	}{
+		{
+			testName: "Success: Should complete the margins and roll them up per seller",
+			repositoryData: []models.ProductMargin{
+				{ProductID: 1, Description: "Yogurt helado", SellerID: utils.Ptr(2), UnitsSold: 10, Revenue: 100, Cost: 75},
+				{ProductID: 2, Description: "Pechuga de pollo", UnitsSold: 3, Revenue: 30, Cost: 30},
+				{ProductID: 3, Description: "Helado de fresa", SellerID: utils.Ptr(1), UnitsSold: 4, Revenue: 0, Cost: 8},
+				{ProductID: 4, Description: "Yogurt natural", SellerID: utils.Ptr(2), UnitsSold: 5, Revenue: 60, Cost: 25},
+			},
+			expectedData: models.MarginReport{
+				From: "2024-01-01",
+				To:   "2024-01-31",
+				Products: []models.ProductMargin{
+					{ProductID: 1, Description: "Yogurt helado", SellerID: utils.Ptr(2), UnitsSold: 10, Revenue: 100, Cost: 75, Margin: 25, MarginPercentage: 25},
+					{ProductID: 2, Description: "Pechuga de pollo", UnitsSold: 3, Revenue: 30, Cost: 30, Margin: 0, MarginPercentage: 0},
+					{ProductID: 3, Description: "Helado de fresa", SellerID: utils.Ptr(1), UnitsSold: 4, Revenue: 0, Cost: 8, Margin: -8, MarginPercentage: 0},
+					{ProductID: 4, Description: "Yogurt natural", SellerID: utils.Ptr(2), UnitsSold: 5, Revenue: 60, Cost: 25, Margin: 35, MarginPercentage: 58.33},
+				},
+				Sellers: []models.SellerMargin{
+					{SellerID: utils.Ptr(1), UnitsSold: 4, Revenue: 0, Cost: 8, Margin: -8, MarginPercentage: 0},
+					{SellerID: utils.Ptr(2), UnitsSold: 15, Revenue: 160, Cost: 100, Margin: 60, MarginPercentage: 37.5},
+					{UnitsSold: 3, Revenue: 30, Cost: 30, Margin: 0, MarginPercentage: 0},
+				},
+			},
+		},
+		{
+			testName:        "Error case: Process an error from the repository layer",
+			repositoryData:  []models.ProductMargin{},
+			repositoryError: errors.New("db error"),
+			expectedData:    models.MarginReport{},
+			expectedError:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Arrange
+			repositoryMock := mocks.ProductRepositoryDBMock{}
+			service := service.NewProductServiceDefault(&repositoryMock)
+
+			repositoryMock.
+				On("GetMargins", mock.Anything, filter).
+				Return(tc.repositoryData, tc.repositoryError)
+
+			// Act
+			result, err := service.GetMarginReport(context.Background(), filter)
+
+			// Assert
+			require.Equal(t, tc.expectedError, err)
+			require.Equal(t, tc.expectedData, result)
+			repositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
 // Verifies the behavior of service layer responsible for updating a product.
 // It covers:
 // - Successful update all fields of a product
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+)
+
+// ProductTypeServiceDefault is the implementation of ProductTypeService,
+// delegating persistence to a ProductTypeRepository.
+type ProductTypeServiceDefault struct {
+	repo repository.ProductTypeRepository
+}
+
+// NewProductTypeServiceDefault constructs a ProductTypeServiceDefault
+// with the given repository.
+func NewProductTypeServiceDefault(repo repository.ProductTypeRepository) ProductTypeService {
+	return &ProductTypeServiceDefault{repo: repo}
+}
+
+// Create creates a new product type in the repository.
+func (s *ProductTypeServiceDefault) Create(ctx context.Context, productType models.ProductTypeAttributes) (models.ProductType, error) {
+	return s.repo.Create(ctx, productType)
+}
+
+// GetAll returns the page of product types described by opts.
+func (s *ProductTypeServiceDefault) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.ProductType], error) {
+	return s.repo.GetAll(ctx, opts)
+}
+
+// GetByID returns the product type with the given ID.
+func (s *ProductTypeServiceDefault) GetByID(ctx context.Context, id int) (models.ProductType, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// Update retrieves the product type, applies the non-nil fields of the patch
+// and persists it. Returns the updated product type or an error.
+func (s *ProductTypeServiceDefault) Update(ctx context.Context, id int, patch models.ProductTypePatchRequest) (models.ProductType, error) {
+	productType, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return models.ProductType{}, err
+	}
+
+	if patch.Description != nil {
+		productType.Description = *patch.Description
+	}
+
+	return s.repo.Update(ctx, id, productType)
+}
+
+// Delete removes the product type with the given ID.
+func (s *ProductTypeServiceDefault) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
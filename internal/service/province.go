@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+)
+
+// ProvinceServiceDefault is the implementation of ProvinceService,
+// delegating persistence to a ProvinceRepository.
+type ProvinceServiceDefault struct {
+	repo repository.ProvinceRepository
+}
+
+// NewProvinceServiceDefault constructs a ProvinceServiceDefault
+// with the given repository.
+func NewProvinceServiceDefault(repo repository.ProvinceRepository) ProvinceService {
+	return &ProvinceServiceDefault{repo: repo}
+}
+
+// Create creates a new province in the repository.
+func (s *ProvinceServiceDefault) Create(ctx context.Context, province models.ProvinceAttributes) (models.Province, error) {
+	return s.repo.Create(ctx, province)
+}
+
+// GetAll returns the page of provinces described by opts.
+func (s *ProvinceServiceDefault) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Province], error) {
+	return s.repo.GetAll(ctx, opts)
+}
+
+// GetByID returns the province with the given ID.
+func (s *ProvinceServiceDefault) GetByID(ctx context.Context, id int) (models.Province, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// Update retrieves the province, applies the non-nil fields of the patch
+// and persists it. Returns the updated province or an error.
+func (s *ProvinceServiceDefault) Update(ctx context.Context, id int, patch models.ProvincePatchRequest) (models.Province, error) {
+	province, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return models.Province{}, err
+	}
+
+	if patch.ProvinceName != nil {
+		province.ProvinceName = *patch.ProvinceName
+	}
+	if patch.CountryID != nil {
+		province.CountryID = *patch.CountryID
+	}
+
+	return s.repo.Update(ctx, id, province)
+}
+
+// Delete removes the province with the given ID.
+func (s *ProvinceServiceDefault) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// GetSellerReport counts the sellers of each province, or only of id when it is not nil.
+func (s *ProvinceServiceDefault) GetSellerReport(ctx context.Context, id *int) ([]models.RegionSellerReport, error) {
+	return s.repo.GetSellerReport(ctx, id)
+}
+
+// GetCarryReport counts the carries of each province, or only of id when it is not nil.
+func (s *ProvinceServiceDefault) GetCarryReport(ctx context.Context, id *int) ([]models.RegionCarryReport, error) {
+	return s.repo.GetCarryReport(ctx, id)
+}
@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	mocks "github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvinceServiceDefault_Update(t *testing.T) {
+	buenosAires := models.Province{ID: 1, ProvinceAttributes: models.ProvinceAttributes{ProvinceName: "Buenos Aires", CountryID: 1}}
+
+	t.Run("applies the fields of the patch", func(t *testing.T) {
+		// arrange
+		ctx := context.Background()
+		moved := models.Province{ID: 1, ProvinceAttributes: models.ProvinceAttributes{ProvinceName: "Montevideo", CountryID: 2}}
+		repoMock := new(mocks.ProvinceRepositoryMock)
+		repoMock.On("GetByID", ctx, 1).Return(buenosAires, nil)
+		repoMock.On("Update", ctx, 1, moved).Return(moved, nil)
+		srv := service.NewProvinceServiceDefault(repoMock)
+
+		// act
+		got, err := srv.Update(ctx, 1, models.ProvincePatchRequest{ProvinceName: utils.Ptr("Montevideo"), CountryID: utils.Ptr(2)})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, moved, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("keeps the fields missing from the patch", func(t *testing.T) {
+		// arrange
+		ctx := context.Background()
+		renamed := models.Province{ID: 1, ProvinceAttributes: models.ProvinceAttributes{ProvinceName: "CABA", CountryID: 1}}
+		repoMock := new(mocks.ProvinceRepositoryMock)
+		repoMock.On("GetByID", ctx, 1).Return(buenosAires, nil)
+		repoMock.On("Update", ctx, 1, renamed).Return(renamed, nil)
+		srv := service.NewProvinceServiceDefault(repoMock)
+
+		// act
+		got, err := srv.Update(ctx, 1, models.ProvincePatchRequest{ProvinceName: utils.Ptr("CABA")})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, renamed, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("missing country returns the ConflictError of the repository", func(t *testing.T) {
+		// arrange
+		ctx := context.Background()
+		conflict := httperrors.ConflictError{Message: "Country does not exist"}
+		moved := models.Province{ID: 1, ProvinceAttributes: models.ProvinceAttributes{ProvinceName: "Buenos Aires", CountryID: 9}}
+		repoMock := new(mocks.ProvinceRepositoryMock)
+		repoMock.On("GetByID", ctx, 1).Return(buenosAires, nil)
+		repoMock.On("Update", ctx, 1, moved).Return(models.Province{}, conflict)
+		srv := service.NewProvinceServiceDefault(repoMock)
+
+		// act
+		_, err := srv.Update(ctx, 1, models.ProvincePatchRequest{CountryID: utils.Ptr(9)})
+
+		// assert
+		assert.Equal(t, conflict, err)
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestProvinceServiceDefault_Delete(t *testing.T) {
+	t.Run("referenced province returns the ConflictError of the repository", func(t *testing.T) {
+		// arrange
+		ctx := context.Background()
+		conflict := httperrors.ConflictError{Message: "Province is still referenced by localities."}
+		repoMock := new(mocks.ProvinceRepositoryMock)
+		repoMock.On("Delete", ctx, 1).Return(conflict)
+		srv := service.NewProvinceServiceDefault(repoMock)
+
+		// act
+		err := srv.Delete(ctx, 1)
+
+		// assert
+		assert.Equal(t, conflict, err)
+		repoMock.AssertExpectations(t)
+	})
+}
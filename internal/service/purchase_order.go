@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
@@ -23,3 +24,23 @@ func (s *PurchaseOrderDefault) Create(
 	purchaseOrder, err := s.repository.Create(ctx, newPurchaseOrder)
 	return purchaseOrder, err
 }
+
+// GetAll returns the page of purchase orders described by opts
+func (s *PurchaseOrderDefault) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.PurchaseOrder], error) {
+	return s.repository.GetAll(ctx, opts)
+}
+
+// GetByID returns the purchase order with the given id
+func (s *PurchaseOrderDefault) GetByID(ctx context.Context, id int) (models.PurchaseOrder, error) {
+	return s.repository.GetByID(ctx, id)
+}
+
+// Transition moves the order to status, timestamped now
+func (s *PurchaseOrderDefault) Transition(ctx context.Context, id int, status string) (models.PurchaseOrder, error) {
+	return s.repository.Transition(ctx, id, status, time.Now().UTC().Truncate(time.Second))
+}
+
+// AssignCarry hands the order to carryID, or to the best matching carry when carryID is nil
+func (s *PurchaseOrderDefault) AssignCarry(ctx context.Context, id int, carryID *int) (models.PurchaseOrder, error) {
+	return s.repository.AssignCarry(ctx, id, carryID)
+}
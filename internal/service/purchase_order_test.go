@@ -9,6 +9,7 @@ import (
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestPurchaseOrderDefault_Create(t *testing.T) {
@@ -17,16 +18,22 @@ func TestPurchaseOrderDefault_Create(t *testing.T) {
 	serviceDefault := service.NewPurchaseOrderDefault(repoMock)
 
 	purchaseOrderAtt := models.PurchaseOrderAttributes{
-		OrderNumber:     "ORD-1",
-		OrderDate:       time.Date(2025, 4, 4, 15, 4, 5, 0, time.UTC),
-		TrackingCode:    "abc123asd",
-		BuyerId:         1,
-		ProductRecordId: 1,
+		OrderNumber:  "ORD-1",
+		OrderDate:    time.Date(2025, 4, 4, 15, 4, 5, 0, time.UTC),
+		TrackingCode: "abc123asd",
+		BuyerId:      1,
+		Lines:        []models.PurchaseOrderLineAttributes{{ProductId: 1, Quantity: 2}},
 	}
 
 	expectedPurchaseOrder := models.PurchaseOrder{
-		Id:                      1,
-		PurchaseOrderAttributes: purchaseOrderAtt,
+		Id:           1,
+		OrderNumber:  purchaseOrderAtt.OrderNumber,
+		OrderDate:    purchaseOrderAtt.OrderDate,
+		TrackingCode: purchaseOrderAtt.TrackingCode,
+		BuyerId:      purchaseOrderAtt.BuyerId,
+		Lines:        []models.PurchaseOrderLine{{Id: 1, PurchaseOrderId: 1, ProductId: 1, Quantity: 2, UnitPrice: 10}},
+		Total:        20,
+		Status:       models.PurchaseOrderStatusPending,
 	}
 
 	ctx := context.Background()
@@ -42,3 +49,25 @@ func TestPurchaseOrderDefault_Create(t *testing.T) {
 	assert.Equal(t, got, expectedPurchaseOrder)
 	repoMock.AssertNumberOfCalls(t, "Create", 1)
 }
+
+func TestPurchaseOrderDefault_Transition(t *testing.T) {
+	// arrange
+	repoMock := mocks.NewPurchaseOrderRepositoryDBMock()
+	serviceDefault := service.NewPurchaseOrderDefault(repoMock)
+
+	ctx := context.Background()
+	expectedPurchaseOrder := models.PurchaseOrder{Id: 1, Status: models.PurchaseOrderStatusConfirmed}
+	repoMock.On("Transition", ctx, 1, models.PurchaseOrderStatusConfirmed, mock.AnythingOfType("time.Time")).
+		Return(expectedPurchaseOrder, nil).
+		Once()
+
+	// act
+	got, err := serviceDefault.Transition(ctx, 1, models.PurchaseOrderStatusConfirmed)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, expectedPurchaseOrder, got)
+	at := repoMock.Calls[0].Arguments.Get(3).(time.Time)
+	assert.Equal(t, time.UTC, at.Location())
+	assert.Zero(t, at.Nanosecond())
+}
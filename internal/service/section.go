@@ -2,22 +2,29 @@ package service
 
 import (
 	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/logging"
 )
 
 // SectionServiceDefault implements SectionService
 type SectionServiceDefault struct {
-	repository repository.SectionRepository
+	repository      repository.SectionRepository
+	alertRepository repository.AlertRepository
 }
 
 /*
 NewSectionServiceDefault constructs a SectionServiceDefault
-with the given repository.
+with the given repositories.
 */
-func NewSectionServiceDefault(repo repository.SectionRepository) SectionService {
-	return &SectionServiceDefault{repository: repo}
+func NewSectionServiceDefault(repo repository.SectionRepository, alertRepo repository.AlertRepository) SectionService {
+	return &SectionServiceDefault{repository: repo, alertRepository: alertRepo}
 }
 
 // Create, creates a new section in the repository
@@ -30,13 +37,9 @@ func (service SectionServiceDefault) Delete(ctx context.Context, id int) error {
 	return service.repository.Delete(ctx, id)
 }
 
-// GetAll returns all sections in the repository
-func (service SectionServiceDefault) GetAll(ctx context.Context) ([]models.Section, error) {
-	data, err := service.repository.GetAll(ctx)
-	if err != nil {
-		return []models.Section{}, err
-	}
-	return data, nil
+// GetAll returns the page of sections described by opts
+func (service SectionServiceDefault) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Section], error) {
+	return service.repository.GetAll(ctx, opts)
 }
 
 // GetByID returns a section by its ID
@@ -53,7 +56,20 @@ func (service *SectionServiceDefault) Update(ctx context.Context, id int, patchD
 
 	service.applyChanges(&section, patchData)
 
-	return service.repository.Update(ctx, id, section)
+	updated, err := service.repository.Update(ctx, id, section)
+	if err != nil {
+		return models.Section{}, err
+	}
+
+	// A new temperature or threshold may open or resolve alerts. The section is
+	// already updated, so a failure here must not fail the request.
+	if patchData.CurrentTemperature != nil || patchData.MinimumTemperature != nil {
+		sample := temperatureSample{temperature: updated.CurrentTemperature, at: time.Now().UTC().Truncate(time.Second)}
+		if err := checkTemperatureAlerts(ctx, service.alertRepository, id, []temperatureSample{sample}); err != nil {
+			logging.FromContext(ctx).Error("checking temperature alerts", slog.Int("section_id", id), slog.Any("error", err))
+		}
+	}
+	return updated, nil
 }
 
 // applyChanges, applies the changes to the section
@@ -70,10 +86,6 @@ func (service *SectionServiceDefault) applyChanges(sectionToUpdate *models.Secti
 	if patchData.MinimumTemperature != nil {
 		sectionToUpdate.MinimumTemperature = *patchData.MinimumTemperature
 	}
-	// Update current capacity
-	if patchData.CurrentCapacity != nil {
-		sectionToUpdate.CurrentCapacity = *patchData.CurrentCapacity
-	}
 	// Update minimum capacity
 	if patchData.MinimumCapacity != nil {
 		sectionToUpdate.MinimumCapacity = *patchData.MinimumCapacity
@@ -101,3 +113,88 @@ func (service SectionServiceDefault) GetProductsReport(ctx context.Context, id i
 func (service SectionServiceDefault) GetAllProductsReport(ctx context.Context) ([]models.SectionProductsReport, error) {
 	return service.repository.GetAllProductsReport(ctx)
 }
+
+// GetExpiringBatchesReport lists the batches due from today up to days days ahead.
+func (service SectionServiceDefault) GetExpiringBatchesReport(ctx context.Context, days int, warehouseID int) ([]models.SectionExpiringBatchesReport, error) {
+	today := time.Now()
+	return service.repository.GetExpiringBatchesReport(ctx,
+		today.Format(time.DateOnly), today.AddDate(0, 0, days).Format(time.DateOnly), warehouseID)
+}
+
+// GetCapacityReport groups the sections by warehouse, ordered by warehouse ID,
+// with the utilization of each section and of the whole warehouse.
+func (service SectionServiceDefault) GetCapacityReport(ctx context.Context, warehouseID int) ([]models.WarehouseCapacityReport, error) {
+	sections, err := service.warehouseSections(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	byWarehouse := make(map[int]*models.WarehouseCapacityReport)
+	var warehouseIDs []int
+	for _, section := range sections {
+		warehouse, ok := byWarehouse[section.WarehouseID]
+		if !ok {
+			warehouse = &models.WarehouseCapacityReport{WarehouseID: section.WarehouseID}
+			byWarehouse[section.WarehouseID] = warehouse
+			warehouseIDs = append(warehouseIDs, section.WarehouseID)
+		}
+		warehouse.CurrentCapacity += section.CurrentCapacity
+		warehouse.MaximumCapacity += section.MaximumCapacity
+		warehouse.Sections = append(warehouse.Sections, models.SectionCapacityReport{
+			SectionID:       section.ID,
+			SectionNumber:   section.SectionNumber,
+			CurrentCapacity: section.CurrentCapacity,
+			MinimumCapacity: section.MinimumCapacity,
+			MaximumCapacity: section.MaximumCapacity,
+			Utilization:     utilization(section.CurrentCapacity, section.MaximumCapacity),
+			BelowMinimum:    section.CurrentCapacity < section.MinimumCapacity,
+		})
+	}
+	slices.Sort(warehouseIDs)
+
+	reports := make([]models.WarehouseCapacityReport, 0, len(warehouseIDs))
+	for _, id := range warehouseIDs {
+		warehouse := byWarehouse[id]
+		warehouse.Utilization = utilization(warehouse.CurrentCapacity, warehouse.MaximumCapacity)
+		reports = append(reports, *warehouse)
+	}
+	return reports, nil
+}
+
+// capacityReportPageSize is the number of sections GetCapacityReport reads per query.
+const capacityReportPageSize = 500
+
+// warehouseSections returns every section of the warehouse, or of all the
+// warehouses when warehouseID is 0, following the pages of the repository.
+func (service SectionServiceDefault) warehouseSections(ctx context.Context, warehouseID int) ([]models.Section, error) {
+	opts := models.QueryOptions{Limit: capacityReportPageSize}
+	if warehouseID != 0 {
+		opts.Filters = map[string]string{"warehouse_id": strconv.Itoa(warehouseID)}
+	}
+
+	var sections []models.Section
+	for {
+		page, err := service.repository.GetAll(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, page.Data...)
+		if page.Meta.NextCursor == nil {
+			return sections, nil
+		}
+		after, err := models.DecodeCursor(*page.Meta.NextCursor)
+		if err != nil {
+			return nil, err
+		}
+		opts.After = &after
+	}
+}
+
+// utilization returns the percentage of maximum in use, rounded to two decimals.
+// A section without maximum capacity has no utilization.
+func utilization(current, maximum int) float64 {
+	if maximum <= 0 {
+		return 0
+	}
+	return math.Round(float64(current)/float64(maximum)*10000) / 100
+}
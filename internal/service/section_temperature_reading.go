@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/logging"
+)
+
+// SectionTemperatureReadingServiceDefault implements SectionTemperatureReadingService
+type SectionTemperatureReadingServiceDefault struct {
+	repository      repository.SectionTemperatureReadingRepository
+	alertRepository repository.AlertRepository
+}
+
+/*
+NewSectionTemperatureReadingServiceDefault constructs a SectionTemperatureReadingServiceDefault
+with the given repositories.
+*/
+func NewSectionTemperatureReadingServiceDefault(repo repository.SectionTemperatureReadingRepository, alertRepo repository.AlertRepository) SectionTemperatureReadingService {
+	return &SectionTemperatureReadingServiceDefault{repository: repo, alertRepository: alertRepo}
+}
+
+// CreateBulk stores the readings of a section. Timestamps are kept in UTC
+// with second precision, the same as the DATETIME column.
+func (service SectionTemperatureReadingServiceDefault) CreateBulk(ctx context.Context, sectionID int, requests []models.CreateTemperatureReadingRequest) ([]models.SectionTemperatureReading, error) {
+	// Readings older than the latest one already stored are history: they change
+	// neither the current temperature nor the alerts
+	latest, err := service.repository.GetLatestBySectionID(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	readings := make([]models.SectionTemperatureReading, 0, len(requests))
+	for _, request := range requests {
+		readings = append(readings, models.SectionTemperatureReading{
+			SectionID:   sectionID,
+			Temperature: *request.Temperature,
+			RecordedAt:  request.RecordedAt.UTC().Truncate(time.Second),
+		})
+	}
+	created, err := service.repository.CreateBulk(ctx, sectionID, readings)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check every reading, in the order it was recorded, against the alert rules.
+	// The readings are already stored, so a failure here must not fail the request.
+	samples := make([]temperatureSample, 0, len(created))
+	for _, reading := range created {
+		if latest != nil && reading.RecordedAt.Before(latest.RecordedAt) {
+			continue
+		}
+		samples = append(samples, temperatureSample{temperature: reading.Temperature, at: reading.RecordedAt})
+	}
+	slices.SortStableFunc(samples, func(a, b temperatureSample) int {
+		return a.at.Compare(b.at)
+	})
+	if err := checkTemperatureAlerts(ctx, service.alertRepository, sectionID, samples); err != nil {
+		logging.FromContext(ctx).Error("checking temperature alerts", slog.Int("section_id", sectionID), slog.Any("error", err))
+	}
+	return created, nil
+}
+
+// GetSeries returns the readings of a section between from and to and their aggregates.
+// With an interval the readings are grouped in buckets starting at from, and empty
+// buckets are left out. Without it the raw readings are returned.
+func (service SectionTemperatureReadingServiceDefault) GetSeries(ctx context.Context, sectionID int, from, to time.Time, interval time.Duration) (models.SectionTemperatureSeries, error) {
+	from, to = from.UTC(), to.UTC()
+	readings, err := service.repository.GetBySectionID(ctx, sectionID, from, to)
+	if err != nil {
+		return models.SectionTemperatureSeries{}, err
+	}
+
+	series := models.SectionTemperatureSeries{
+		SectionID: sectionID,
+		From:      from,
+		To:        to,
+		Summary:   aggregateTemperatures(from, readings),
+	}
+	if interval == 0 {
+		series.Readings = readings
+		return series, nil
+	}
+
+	series.Buckets = make([]models.TemperatureAggregate, 0)
+	// Readings come ordered by time, so each bucket is a contiguous run
+	start := 0
+	for start < len(readings) {
+		bucketFrom := from.Add(readings[start].RecordedAt.Sub(from) / interval * interval)
+		end := start
+		for end < len(readings) && readings[end].RecordedAt.Before(bucketFrom.Add(interval)) {
+			end++
+		}
+		series.Buckets = append(series.Buckets, aggregateTemperatures(bucketFrom, readings[start:end]))
+		start = end
+	}
+	return series, nil
+}
+
+// aggregateTemperatures computes count, min, max and average (rounded to 2 decimals) of the readings.
+func aggregateTemperatures(from time.Time, readings []models.SectionTemperatureReading) models.TemperatureAggregate {
+	aggregate := models.TemperatureAggregate{From: from, Count: len(readings)}
+	if len(readings) == 0 {
+		return aggregate
+	}
+
+	aggregate.Min, aggregate.Max = readings[0].Temperature, readings[0].Temperature
+	var sum float64
+	for _, reading := range readings {
+		aggregate.Min = math.Min(aggregate.Min, reading.Temperature)
+		aggregate.Max = math.Max(aggregate.Max, reading.Temperature)
+		sum += reading.Temperature
+	}
+	aggregate.Avg = math.Round(sum/float64(len(readings))*100) / 100
+	return aggregate
+}
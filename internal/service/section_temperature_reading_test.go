@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+// TestSectionTemperatureReadingService_GetSeries tests the aggregation of the time series
+func TestSectionTemperatureReadingService_GetSeries(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(3 * time.Hour)
+	reading := func(id int, offset time.Duration, temperature float64) models.SectionTemperatureReading {
+		return models.SectionTemperatureReading{ID: id, SectionID: 1, Temperature: temperature, RecordedAt: from.Add(offset)}
+	}
+	readings := []models.SectionTemperatureReading{
+		reading(1, 10*time.Minute, -18),
+		reading(2, 50*time.Minute, -16),
+		reading(3, 2*time.Hour+5*time.Minute, -20.5),
+	}
+
+	tests := []struct {
+		name        string
+		interval    time.Duration
+		repoOutput  []models.SectionTemperatureReading
+		repoError   error
+		expected    models.SectionTemperatureSeries
+		expectedErr error
+	}{
+		{
+			name:       "raw readings with summary",
+			repoOutput: readings,
+			expected: models.SectionTemperatureSeries{
+				SectionID: 1, From: from, To: to,
+				Summary:  models.TemperatureAggregate{From: from, Count: 3, Min: -20.5, Max: -16, Avg: -18.17},
+				Readings: readings,
+			},
+		},
+		{
+			name:       "hourly buckets skip empty hours",
+			interval:   time.Hour,
+			repoOutput: readings,
+			expected: models.SectionTemperatureSeries{
+				SectionID: 1, From: from, To: to,
+				Summary: models.TemperatureAggregate{From: from, Count: 3, Min: -20.5, Max: -16, Avg: -18.17},
+				Buckets: []models.TemperatureAggregate{
+					{From: from, Count: 2, Min: -18, Max: -16, Avg: -17},
+					{From: from.Add(2 * time.Hour), Count: 1, Min: -20.5, Max: -20.5, Avg: -20.5},
+				},
+			},
+		},
+		{
+			name:       "no readings",
+			interval:   time.Hour,
+			repoOutput: []models.SectionTemperatureReading{},
+			expected: models.SectionTemperatureSeries{
+				SectionID: 1, From: from, To: to,
+				Summary: models.TemperatureAggregate{From: from},
+				Buckets: []models.TemperatureAggregate{},
+			},
+		},
+		{
+			name:        "section not found",
+			repoError:   httperrors.NotFoundError{Message: "Section not found"},
+			expectedErr: httperrors.NotFoundError{Message: "Section not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := new(mocks.SectionTemperatureReadingRepositoryDBMock)
+			repoMock.On("GetBySectionID", testifyMock.Anything, 1, from, to).Return(tt.repoOutput, tt.repoError)
+			svc := NewSectionTemperatureReadingServiceDefault(repoMock, new(mocks.AlertRepositoryDBMock))
+
+			result, err := svc.GetSeries(context.Background(), 1, from, to, tt.interval)
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			repoMock.AssertExpectations(t)
+		})
+	}
+}
+
+// TestSectionTemperatureReadingService_CreateBulk tests that timestamps are normalized to UTC seconds
+func TestSectionTemperatureReadingService_CreateBulk(t *testing.T) {
+	temperature := -18.0
+	recordedAt := time.Date(2026, 1, 1, 10, 0, 0, 500, time.FixedZone("ART", -3*60*60))
+	expected := []models.SectionTemperatureReading{
+		{SectionID: 1, Temperature: -18, RecordedAt: time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC)},
+	}
+
+	repoMock := new(mocks.SectionTemperatureReadingRepositoryDBMock)
+	repoMock.On("GetLatestBySectionID", testifyMock.Anything, 1).Return(nil, nil)
+	repoMock.On("CreateBulk", testifyMock.Anything, 1, expected).Return(expected, nil)
+	alertRepoMock := new(mocks.AlertRepositoryDBMock)
+	alertRepoMock.On("GetTemperatureTargets", testifyMock.Anything, 1).
+		Return(models.SectionTemperatureTargets{SectionID: 1, MinimumTemperature: -25}, nil)
+	alertRepoMock.On("Sync", testifyMock.Anything, 1, []models.Alert(nil), expected[0].RecordedAt).Return([]models.Alert{}, nil)
+	svc := NewSectionTemperatureReadingServiceDefault(repoMock, alertRepoMock)
+
+	result, err := svc.CreateBulk(context.Background(), 1, []models.CreateTemperatureReadingRequest{
+		{Temperature: &temperature, RecordedAt: recordedAt},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	repoMock.AssertExpectations(t)
+	alertRepoMock.AssertExpectations(t)
+}
+
+// TestSectionTemperatureReadingService_CreateBulk_Backfill tests that readings older than the
+// latest stored one do not resolve the alerts it opened
+func TestSectionTemperatureReadingService_CreateBulk_Backfill(t *testing.T) {
+	latestAt := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	// The latest stored reading is too warm for batch 10, whose alert is open
+	latest := &models.SectionTemperatureReading{ID: 7, SectionID: 1, Temperature: -10, RecordedAt: latestAt}
+	targets := models.SectionTemperatureTargets{
+		SectionID:          1,
+		MinimumTemperature: -25,
+		Batches:            []models.BatchTemperatureTarget{{ProductBatchID: 10, ProductID: 1, RecommendedFreezingTemperature: -18}},
+	}
+	inRange, breaching := -20.0, -12.0
+
+	tests := []struct {
+		name          string
+		requests      []models.CreateTemperatureReadingRequest
+		expectedSyncs []time.Time
+	}{
+		{
+			name:     "in range reading behind the latest is not evaluated",
+			requests: []models.CreateTemperatureReadingRequest{{Temperature: &inRange, RecordedAt: latestAt.Add(-time.Hour)}},
+		},
+		{
+			name: "only readings from the latest on are evaluated",
+			requests: []models.CreateTemperatureReadingRequest{
+				{Temperature: &inRange, RecordedAt: latestAt.Add(-time.Hour)},
+				{Temperature: &breaching, RecordedAt: latestAt.Add(time.Hour)},
+			},
+			expectedSyncs: []time.Time{latestAt.Add(time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			repoMock := new(mocks.SectionTemperatureReadingRepositoryDBMock)
+			repoMock.On("GetLatestBySectionID", testifyMock.Anything, 1).Return(latest, nil)
+			var readings []models.SectionTemperatureReading
+			for _, request := range tt.requests {
+				readings = append(readings, models.SectionTemperatureReading{SectionID: 1, Temperature: *request.Temperature, RecordedAt: request.RecordedAt})
+			}
+			repoMock.On("CreateBulk", testifyMock.Anything, 1, readings).Return(readings, nil)
+			alertRepoMock := new(mocks.AlertRepositoryDBMock)
+			alertRepoMock.On("GetTemperatureTargets", testifyMock.Anything, 1).Return(targets, nil).Maybe()
+			alertRepoMock.On("Sync", testifyMock.Anything, 1, testifyMock.Anything, testifyMock.Anything).Return([]models.Alert{}, nil).Maybe()
+			svc := NewSectionTemperatureReadingServiceDefault(repoMock, alertRepoMock)
+
+			// act
+			result, err := svc.CreateBulk(context.Background(), 1, tt.requests)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Len(t, result, len(tt.requests))
+			alertRepoMock.AssertNumberOfCalls(t, "Sync", len(tt.expectedSyncs))
+			for _, call := range alertRepoMock.Calls {
+				if call.Method != "Sync" {
+					continue
+				}
+				// The open alert of batch 10 is still breached, so Sync keeps it open
+				breaches := call.Arguments.Get(2).([]models.Alert)
+				assert.Equal(t, []string{"product_recommended_temperature/10"}, breachKeys(breaches))
+				assert.Equal(t, tt.expectedSyncs[0], call.Arguments.Get(3))
+			}
+		})
+	}
+}
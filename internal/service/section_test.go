@@ -40,7 +40,7 @@ func TestSectionService_Update(t *testing.T) {
 
 	patchDataPartial := models.UpdateSectionRequest{
 		SectionNumber:   stringPtr("SEC-102"),
-		CurrentCapacity: intPtr(75),
+		MaximumCapacity: intPtr(120),
 	}
 	
 	updatedSectionPartial := models.Section{
@@ -48,9 +48,9 @@ func TestSectionService_Update(t *testing.T) {
 		SectionNumber:      "SEC-102", // Actualizado
 		CurrentTemperature: 20,
 		MinimumTemperature: 15,
-		CurrentCapacity:    75, // Actualizado
+		CurrentCapacity:    50,
 		MinimumCapacity:    10,
-		MaximumCapacity:    100,
+		MaximumCapacity:    120, // Actualizado
 		WarehouseID:        1,
 		ProductTypeID:      1,
 	}
@@ -59,7 +59,6 @@ func TestSectionService_Update(t *testing.T) {
 		SectionNumber:      stringPtr("SEC-ALL"),
 		CurrentTemperature: float64Ptr(25.5),
 		MinimumTemperature: float64Ptr(18.5),
-		CurrentCapacity:    intPtr(99),
 		MinimumCapacity:    intPtr(22),
 		MaximumCapacity:    intPtr(111),
 		WarehouseID:        intPtr(3),
@@ -71,7 +70,7 @@ func TestSectionService_Update(t *testing.T) {
 		SectionNumber:      "SEC-ALL",
 		CurrentTemperature: 25.5,
 		MinimumTemperature: 18.5,
-		CurrentCapacity:    99,
+		CurrentCapacity:    50,
 		MinimumCapacity:    22,
 		MaximumCapacity:    111,
 		WarehouseID:        3,
@@ -126,12 +125,17 @@ func TestSectionService_Update(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
 			mockRepo := new(mocks.SectionRepositoryDBMock)
-			service := NewSectionServiceDefault(mockRepo)
+			mockAlertRepo := new(mocks.AlertRepositoryDBMock)
+			service := NewSectionServiceDefault(mockRepo, mockAlertRepo)
 			
 			mockRepo.On("GetByID", testifyMock.Anything, tt.inputID).Return(tt.mockGetByIDResp, tt.mockGetByIDError)
+			// Temperature changes are checked against the alert rules
+			mockAlertRepo.On("GetTemperatureTargets", testifyMock.Anything, tt.inputID).Return(models.SectionTemperatureTargets{}, nil).Maybe()
+			mockAlertRepo.On("Sync", testifyMock.Anything, tt.inputID, testifyMock.Anything, testifyMock.Anything).Return(nil, nil).Maybe()
 
 			if tt.mockGetByIDError == nil {
-				mockRepo.On("Update", testifyMock.Anything, tt.inputID, testifyMock.AnythingOfType("models.Section")).Return(tt.mockUpdateResp, tt.mockUpdateError)
+				// The occupancy is never patched, the repository keeps the stored one
+				mockRepo.On("Update", testifyMock.Anything, tt.inputID, tt.mockUpdateResp).Return(tt.mockUpdateResp, tt.mockUpdateError)
 			}
 
 			result, err := service.Update(context.Background(), tt.inputID, tt.patchData)
@@ -157,25 +161,27 @@ func TestSectionService_GetAll(t *testing.T) {
 	}
 	expectedError := errors.New("database error")
 
+	opts := models.QueryOptions{Limit: 2, Filters: map[string]string{"warehouse_id": "1"}}
+
 	tests := []struct {
 		testName      string
-		mockResp      []models.Section
+		mockResp      models.Page[models.Section]
 		mockErr       error
-		expectedResp  []models.Section
+		expectedResp  models.Page[models.Section]
 		expectedError error
 	}{
 		{
-			testName:      "Success: should return all sections",
-			mockResp:      expectedSections,
+			testName:      "Success: should return the page of sections",
+			mockResp:      models.NewPage(expectedSections, 3, nil),
 			mockErr:       nil,
-			expectedResp:  expectedSections,
+			expectedResp:  models.NewPage(expectedSections, 3, nil),
 			expectedError: nil,
 		},
 		{
 			testName:      "Fail: should return an error",
-			mockResp:      nil,
+			mockResp:      models.Page[models.Section]{},
 			mockErr:       expectedError,
-			expectedResp:  []models.Section{},
+			expectedResp:  models.Page[models.Section]{},
 			expectedError: expectedError,
 		},
 	}
@@ -183,12 +189,12 @@ func TestSectionService_GetAll(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
 			mockRepo := new(mocks.SectionRepositoryDBMock)
-			service := NewSectionServiceDefault(mockRepo)
+			service := NewSectionServiceDefault(mockRepo, new(mocks.AlertRepositoryDBMock))
 
-			mockRepo.On("GetAll", testifyMock.Anything).Return(tt.mockResp, tt.mockErr)
+			mockRepo.On("GetAll", testifyMock.Anything, opts).Return(tt.mockResp, tt.mockErr)
 
 			// Act
-			result, err := service.GetAll(context.Background())
+			result, err := service.GetAll(context.Background(), opts)
 
 			// Assert
 			assert.Equal(t, tt.expectedError, err)
@@ -203,7 +209,7 @@ func TestSectionService_GetByID(t *testing.T) {
 	expectedSection := models.Section{ID: 1, SectionNumber: "SEC-101"}
 	inputID := 1
 	mockRepo := new(mocks.SectionRepositoryDBMock)
-	service := NewSectionServiceDefault(mockRepo)
+	service := NewSectionServiceDefault(mockRepo, new(mocks.AlertRepositoryDBMock))
 
 	mockRepo.On("GetByID", testifyMock.Anything, inputID).Return(expectedSection, nil)
 
@@ -218,7 +224,7 @@ func TestSectionService_GetByID(t *testing.T) {
 func TestSectionService_Delete(t *testing.T) {
 	inputID := 1
 	mockRepo := new(mocks.SectionRepositoryDBMock)
-	service := NewSectionServiceDefault(mockRepo)
+	service := NewSectionServiceDefault(mockRepo, new(mocks.AlertRepositoryDBMock))
 
 	mockRepo.On("Delete", testifyMock.Anything, inputID).Return(nil)
 
@@ -233,7 +239,7 @@ func TestSectionService_Create(t *testing.T) {
 	sectionToCreate := models.Section{SectionNumber: "SEC-NEW"}
 	expectedSection := models.Section{ID: 1, SectionNumber: "SEC-NEW"}
 	mockRepo := new(mocks.SectionRepositoryDBMock)
-	service := NewSectionServiceDefault(mockRepo)
+	service := NewSectionServiceDefault(mockRepo, new(mocks.AlertRepositoryDBMock))
 	mockRepo.On("Create", testifyMock.Anything, sectionToCreate).Return(expectedSection, nil)
 
 	result, err := service.Create(context.Background(), sectionToCreate)
@@ -248,7 +254,7 @@ func TestSectionService_GetProductsReport(t *testing.T) {
 	expectedReport := models.SectionProductsReport{SectionID: 1, ProductsCount: 10}
 	inputID := 1
 	mockRepo := new(mocks.SectionRepositoryDBMock)
-	service := NewSectionServiceDefault(mockRepo)
+	service := NewSectionServiceDefault(mockRepo, new(mocks.AlertRepositoryDBMock))
 	mockRepo.On("GetProductsReport", testifyMock.Anything, inputID).Return(expectedReport, nil)
 
 	result, err := service.GetProductsReport(context.Background(), inputID)
@@ -265,7 +271,7 @@ func TestSectionService_GetAllProductsReport(t *testing.T) {
 		{SectionID: 2, ProductsCount: 20},
 	}
 	mockRepo := new(mocks.SectionRepositoryDBMock)
-	service := NewSectionServiceDefault(mockRepo)
+	service := NewSectionServiceDefault(mockRepo, new(mocks.AlertRepositoryDBMock))
 
 	mockRepo.On("GetAllProductsReport", testifyMock.Anything).Return(expectedReports, nil)
 
@@ -274,4 +280,106 @@ func TestSectionService_GetAllProductsReport(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedReports, result)
 	mockRepo.AssertExpectations(t)
-}
\ No newline at end of file
+}
+// TestSectionService_GetCapacityReport tests the grouping and flags of GetCapacityReport
+func TestSectionService_GetCapacityReport(t *testing.T) {
+	sections := []models.Section{
+		{ID: 1, SectionNumber: "SEC-101", CurrentCapacity: 50, MinimumCapacity: 10, MaximumCapacity: 100, WarehouseID: 2},
+		{ID: 2, SectionNumber: "SEC-102", CurrentCapacity: 5, MinimumCapacity: 10, MaximumCapacity: 50, WarehouseID: 1},
+		{ID: 3, SectionNumber: "SEC-103", CurrentCapacity: 1, MinimumCapacity: 0, MaximumCapacity: 3, WarehouseID: 2},
+	}
+
+	tests := []struct {
+		testName      string
+		warehouseID   int
+		repoSections  []models.Section
+		repoError     error
+		expectedOpts  models.QueryOptions
+		expectedResp  []models.WarehouseCapacityReport
+		expectedError error
+	}{
+		{
+			testName:     "Success: sections grouped by warehouse",
+			repoSections: sections,
+			expectedOpts: models.QueryOptions{Limit: 500},
+			expectedResp: []models.WarehouseCapacityReport{
+				{WarehouseID: 1, CurrentCapacity: 5, MaximumCapacity: 50, Utilization: 10, Sections: []models.SectionCapacityReport{
+					{SectionID: 2, SectionNumber: "SEC-102", CurrentCapacity: 5, MinimumCapacity: 10, MaximumCapacity: 50, Utilization: 10, BelowMinimum: true},
+				}},
+				{WarehouseID: 2, CurrentCapacity: 51, MaximumCapacity: 103, Utilization: 49.51, Sections: []models.SectionCapacityReport{
+					{SectionID: 1, SectionNumber: "SEC-101", CurrentCapacity: 50, MinimumCapacity: 10, MaximumCapacity: 100, Utilization: 50},
+					{SectionID: 3, SectionNumber: "SEC-103", CurrentCapacity: 1, MinimumCapacity: 0, MaximumCapacity: 3, Utilization: 33.33},
+				}},
+			},
+		},
+		{
+			testName:     "Success: only the sections of the warehouse",
+			warehouseID:  1,
+			repoSections: sections[1:2],
+			expectedOpts: models.QueryOptions{Limit: 500, Filters: map[string]string{"warehouse_id": "1"}},
+			expectedResp: []models.WarehouseCapacityReport{
+				{WarehouseID: 1, CurrentCapacity: 5, MaximumCapacity: 50, Utilization: 10, Sections: []models.SectionCapacityReport{
+					{SectionID: 2, SectionNumber: "SEC-102", CurrentCapacity: 5, MinimumCapacity: 10, MaximumCapacity: 50, Utilization: 10, BelowMinimum: true},
+				}},
+			},
+		},
+		{
+			testName:     "Success: warehouse without sections",
+			warehouseID:  9,
+			expectedOpts: models.QueryOptions{Limit: 500, Filters: map[string]string{"warehouse_id": "9"}},
+			expectedResp: []models.WarehouseCapacityReport{},
+		},
+		{
+			testName:      "Fail: repository error",
+			repoError:     httperrors.InternalServerError{},
+			expectedOpts:  models.QueryOptions{Limit: 500},
+			expectedError: httperrors.InternalServerError{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			mockRepo := new(mocks.SectionRepositoryDBMock)
+			service := NewSectionServiceDefault(mockRepo, new(mocks.AlertRepositoryDBMock))
+			mockRepo.On("GetAll", testifyMock.Anything, tc.expectedOpts).Return(models.NewPage(tc.repoSections, len(tc.repoSections), nil), tc.repoError)
+
+			result, err := service.GetCapacityReport(context.Background(), tc.warehouseID)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResp, result)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// TestSectionService_GetCapacityReport_Pages tests that GetCapacityReport follows
+// the next cursor until the last page of sections
+func TestSectionService_GetCapacityReport_Pages(t *testing.T) {
+	first := []models.Section{{ID: 1, SectionNumber: "SEC-101", CurrentCapacity: 50, MaximumCapacity: 100, WarehouseID: 2}}
+	second := []models.Section{{ID: 2, SectionNumber: "SEC-102", CurrentCapacity: 25, MaximumCapacity: 100, WarehouseID: 2}}
+	next := models.Cursor{Sort: "id", ID: 1}
+	after, err := models.DecodeCursor(next.Encode())
+	assert.NoError(t, err)
+
+	mockRepo := new(mocks.SectionRepositoryDBMock)
+	service := NewSectionServiceDefault(mockRepo, new(mocks.AlertRepositoryDBMock))
+	filters := map[string]string{"warehouse_id": "2"}
+	mockRepo.On("GetAll", testifyMock.Anything, models.QueryOptions{Limit: 500, Filters: filters}).Return(models.NewPage(first, 2, &next), nil)
+	mockRepo.On("GetAll", testifyMock.Anything, models.QueryOptions{Limit: 500, Filters: filters, After: &after}).Return(models.NewPage(second, 2, nil), nil)
+
+	result, err := service.GetCapacityReport(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.WarehouseCapacityReport{
+		{WarehouseID: 2, CurrentCapacity: 75, MaximumCapacity: 200, Utilization: 37.5, Sections: []models.SectionCapacityReport{
+			{SectionID: 1, SectionNumber: "SEC-101", CurrentCapacity: 50, MaximumCapacity: 100, Utilization: 50},
+			{SectionID: 2, SectionNumber: "SEC-102", CurrentCapacity: 25, MaximumCapacity: 100, Utilization: 25},
+		}},
+	}, result)
+	mockRepo.AssertExpectations(t)
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
@@ -14,35 +16,35 @@ func NewSellerService(repo repository.SellerRepository) SellerService {
 	return &SellerServiceDefault{rp: repo}
 }
 
-func (s SellerServiceDefault) Create(seller models.SellerAttributes) (models.Seller, error) {
+func (s SellerServiceDefault) Create(ctx context.Context, seller models.SellerAttributes) (models.Seller, error) {
 	if seller.CID <= 0 || seller.CompanyName == "" || seller.Address == "" || seller.Telephone == "" || seller.LocalityID == "" {
 		return models.Seller{}, httperrors.UnprocessableEntityError{
 			Message: "Invalid seller data",
 		}
 	}
 
-	return s.rp.Create(seller)
+	return s.rp.Create(ctx, seller)
 }
 
-func (s SellerServiceDefault) Delete(id int) error {
-	return s.rp.Delete(id)
+func (s SellerServiceDefault) Delete(ctx context.Context, id int) error {
+	return s.rp.Delete(ctx, id)
 }
 
-func (s SellerServiceDefault) GetAll() ([]models.Seller, error) {
-	return s.rp.GetAll()
+func (s SellerServiceDefault) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Seller], error) {
+	return s.rp.GetAll(ctx, opts)
 }
 
-func (s SellerServiceDefault) GetByID(id int) (models.Seller, error) {
-	return s.rp.GetByID(id)
+func (s SellerServiceDefault) GetByID(ctx context.Context, id int) (models.Seller, error) {
+	return s.rp.GetByID(ctx, id)
 }
 
-func (s SellerServiceDefault) Update(id int, data *models.SellerAttributes) (models.Seller, error) {
+func (s SellerServiceDefault) Update(ctx context.Context, id int, data *models.SellerAttributes) (models.Seller, error) {
 	if data.CID != 0 {
-		all, err := s.rp.GetAll()
+		all, err := s.rp.GetAll(ctx, models.QueryOptions{})
 		if err != nil {
 			return models.Seller{}, err
 		}
-		for _, existing := range all {
+		for _, existing := range all.Data {
 			if existing.CID == data.CID && existing.ID != id {
 				return models.Seller{}, httperrors.ConflictError{
 					Message: "CID already exists",
@@ -50,5 +52,5 @@ func (s SellerServiceDefault) Update(id int, data *models.SellerAttributes) (mod
 			}
 		}
 	}
-	return s.rp.Update(id, data)
+	return s.rp.Update(ctx, id, data)
 }
@@ -1,6 +1,7 @@
 package service_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 // TestSellerService_Create tests the Create method of the SellerService.
@@ -29,7 +31,7 @@ func TestSellerService_Create(t *testing.T) {
 			name:  "create_ok",
 			input: attrOK,
 			setupMock: func(r *mocks.SellerRepositoryDBMock) {
-				r.On("Create", attrOK).Return(models.Seller{ID: 99, SellerAttributes: attrOK}, nil)
+				r.On("Create", mock.Anything, attrOK).Return(models.Seller{ID: 99, SellerAttributes: attrOK}, nil)
 			},
 			wantResult:  models.Seller{ID: 99, SellerAttributes: attrOK},
 			wantErr:     false,
@@ -39,7 +41,7 @@ func TestSellerService_Create(t *testing.T) {
 			name:  "create_conflict",
 			input: attrConflict,
 			setupMock: func(r *mocks.SellerRepositoryDBMock) {
-				r.On("Create", attrConflict).Return(models.Seller{}, httperrors.ConflictError{Message: "CID already exists"})
+				r.On("Create", mock.Anything, attrConflict).Return(models.Seller{}, httperrors.ConflictError{Message: "CID already exists"})
 			},
 			wantResult:  models.Seller{},
 			wantErr:     true,
@@ -60,7 +62,7 @@ func TestSellerService_Create(t *testing.T) {
 			repository := new(mocks.SellerRepositoryDBMock)
 			tc.setupMock(repository)
 			service := service.NewSellerService(repository)
-			result, err := service.Create(tc.input)
+			result, err := service.Create(context.Background(), tc.input)
 
 			if tc.wantErr {
 				assert.Error(t, err)
@@ -93,7 +95,7 @@ func TestSellerService_GetAll(t *testing.T) {
 		{
 			name: "find_all",
 			setupMock: func(r *mocks.SellerRepositoryDBMock) {
-				r.On("GetAll").Return(list, nil)
+				r.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.NewPage(list, len(list), nil), nil)
 			},
 			wantRes: list,
 			wantErr: false,
@@ -101,9 +103,9 @@ func TestSellerService_GetAll(t *testing.T) {
 		{
 			name: "find_all_fail",
 			setupMock: func(r *mocks.SellerRepositoryDBMock) {
-				r.On("GetAll").Return([]models.Seller{}, errors.New("db error"))
+				r.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.Page[models.Seller]{}, errors.New("db error"))
 			},
-			wantRes: []models.Seller{},
+			wantRes: nil,
 			wantErr: true,
 		},
 	}
@@ -112,13 +114,13 @@ func TestSellerService_GetAll(t *testing.T) {
 			repository := new(mocks.SellerRepositoryDBMock)
 			tc.setupMock(repository)
 			service := service.NewSellerService(repository)
-			got, err := service.GetAll()
+			got, err := service.GetAll(context.Background(), models.QueryOptions{})
 			if tc.wantErr {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
 			}
-			assert.Equal(t, tc.wantRes, got)
+			assert.Equal(t, tc.wantRes, got.Data)
 			repository.AssertExpectations(t)
 		})
 	}
@@ -140,7 +142,7 @@ func TestSellerService_GetByID(t *testing.T) {
 			input: 5,
 			setupMock: func(r *mocks.SellerRepositoryDBMock) {
 				s := models.Seller{ID: 5, SellerAttributes: models.SellerAttributes{CID: 55}}
-				r.On("GetByID", 5).Return(s, nil)
+				r.On("GetByID", mock.Anything, 5).Return(s, nil)
 			},
 			wantResult: models.Seller{ID: 5, SellerAttributes: models.SellerAttributes{CID: 55}},
 			wantErr:    false,
@@ -149,7 +151,7 @@ func TestSellerService_GetByID(t *testing.T) {
 			name:  "find_by_id_non_existent",
 			input: 99,
 			setupMock: func(r *mocks.SellerRepositoryDBMock) {
-				r.On("GetByID", 99).Return(models.Seller{}, httperrors.NotFoundError{Message: "not found"})
+				r.On("GetByID", mock.Anything, 99).Return(models.Seller{}, httperrors.NotFoundError{Message: "not found"})
 			},
 			wantResult:  models.Seller{},
 			wantErr:     true,
@@ -161,7 +163,7 @@ func TestSellerService_GetByID(t *testing.T) {
 			repository := new(mocks.SellerRepositoryDBMock)
 			tc.setupMock(repository)
 			service := service.NewSellerService(repository)
-			res, err := service.GetByID(tc.input)
+			res, err := service.GetByID(context.Background(), tc.input)
 			if tc.wantErr {
 				assert.Error(t, err)
 				if tc.errContains != "" {
@@ -191,7 +193,7 @@ func TestSellerService_Delete(t *testing.T) {
 			name: "delete_ok",
 			id:   1,
 			setupMock: func(r *mocks.SellerRepositoryDBMock) {
-				r.On("Delete", 1).Return(nil)
+				r.On("Delete", mock.Anything, 1).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -199,7 +201,7 @@ func TestSellerService_Delete(t *testing.T) {
 			name: "delete_non_existent",
 			id:   5,
 			setupMock: func(r *mocks.SellerRepositoryDBMock) {
-				r.On("Delete", 5).Return(httperrors.NotFoundError{Message: "Seller not found"})
+				r.On("Delete", mock.Anything, 5).Return(httperrors.NotFoundError{Message: "Seller not found"})
 			},
 			wantErr:     true,
 			errContains: "Seller not found",
@@ -210,7 +212,7 @@ func TestSellerService_Delete(t *testing.T) {
 			r := new(mocks.SellerRepositoryDBMock)
 			tc.setupMock(r)
 			svc := service.NewSellerService(r)
-			err := svc.Delete(tc.id)
+			err := svc.Delete(context.Background(), tc.id)
 			if tc.wantErr {
 				assert.Error(t, err)
 				if tc.errContains != "" {
@@ -244,8 +246,8 @@ func TestSellerService_Update(t *testing.T) {
 			id:    2,
 			input: attr,
 			setupMock: func(r *mocks.SellerRepositoryDBMock) {
-				r.On("GetAll").Return([]models.Seller{}, nil)
-				r.On("Update", 2, attr).Return(updated, nil)
+				r.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.Page[models.Seller]{}, nil)
+				r.On("Update", mock.Anything, 2, attr).Return(updated, nil)
 			},
 			wantResult: updated,
 			wantErr:    false,
@@ -255,8 +257,8 @@ func TestSellerService_Update(t *testing.T) {
 			id:    77,
 			input: attr,
 			setupMock: func(r *mocks.SellerRepositoryDBMock) {
-				r.On("GetAll").Return([]models.Seller{}, nil)
-				r.On("Update", 77, attr).Return(models.Seller{}, httperrors.NotFoundError{Message: "Seller not found"})
+				r.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.Page[models.Seller]{}, nil)
+				r.On("Update", mock.Anything, 77, attr).Return(models.Seller{}, httperrors.NotFoundError{Message: "Seller not found"})
 			},
 			wantResult:  models.Seller{},
 			wantErr:     true,
@@ -268,7 +270,7 @@ func TestSellerService_Update(t *testing.T) {
 			r := new(mocks.SellerRepositoryDBMock)
 			tc.setupMock(r)
 			svc := service.NewSellerService(r)
-			res, err := svc.Update(tc.id, tc.input)
+			res, err := svc.Update(context.Background(), tc.id, tc.input)
 			if tc.wantErr {
 				assert.Error(t, err)
 				if tc.errContains != "" {
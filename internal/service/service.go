@@ -2,56 +2,105 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 )
 
 type ProductService interface {
 	Create(ctx context.Context, product models.ProductAttributes) (models.Product, error)
-	GetAll(ctx context.Context) ([]models.Product, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Product], error)
 	GetByID(ctx context.Context, id int) (models.Product, error)
 	GetRecordsPerProduct(ctx context.Context, id *int) ([]models.ProductRecordCount, error)
+	// GetMarginReport returns the profitability of the products sold in the period of the filter,
+	// per product and per seller.
+	GetMarginReport(ctx context.Context, filter models.MarginReportFilter) (models.MarginReport, error)
 	Update(ctx context.Context, id int, productAttributes models.ProductPatchRequest) (models.Product, error)
 	Delete(ctx context.Context, id int) error
 }
 
+// ProductTypeService defines the operations over the product type catalog.
+type ProductTypeService interface {
+	Create(ctx context.Context, productType models.ProductTypeAttributes) (models.ProductType, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.ProductType], error)
+	GetByID(ctx context.Context, id int) (models.ProductType, error)
+	Update(ctx context.Context, id int, productType models.ProductTypePatchRequest) (models.ProductType, error)
+	Delete(ctx context.Context, id int) error
+}
+
 type ProductRecordService interface {
 	Create(ctx context.Context, productRecord models.ProductRecordAttributes) (models.ProductRecord, error)
+	// GetAll returns the page of the price history of filter.ProductID.
+	GetAll(ctx context.Context, filter models.ProductRecordFilter) (models.Page[models.ProductRecord], error)
+	// GetEffective returns the record of the product in effect on the date at (YYYY-MM-DD).
+	GetEffective(ctx context.Context, productID int, at string) (models.ProductRecord, error)
 }
 
 type SellerService interface {
-	Create(seller models.SellerAttributes) (models.Seller, error)
-	GetAll() ([]models.Seller, error)
-	GetByID(id int) (models.Seller, error)
-	Update(id int, data *models.SellerAttributes) (models.Seller, error)
-	Delete(id int) error
+	Create(ctx context.Context, seller models.SellerAttributes) (models.Seller, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Seller], error)
+	GetByID(ctx context.Context, id int) (models.Seller, error)
+	Update(ctx context.Context, id int, data *models.SellerAttributes) (models.Seller, error)
+	Delete(ctx context.Context, id int) error
+}
+
+// CountryService defines the operations over the countries of the locality hierarchy.
+type CountryService interface {
+	Create(ctx context.Context, country models.CountryAttributes) (models.Country, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Country], error)
+	GetByID(ctx context.Context, id int) (models.Country, error)
+	Update(ctx context.Context, id int, country models.CountryPatchRequest) (models.Country, error)
+	Delete(ctx context.Context, id int) error
+	GetSellerReport(ctx context.Context, id *int) ([]models.RegionSellerReport, error)
+	GetCarryReport(ctx context.Context, id *int) ([]models.RegionCarryReport, error)
+}
+
+// ProvinceService defines the operations over the provinces of the locality hierarchy.
+type ProvinceService interface {
+	Create(ctx context.Context, province models.ProvinceAttributes) (models.Province, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Province], error)
+	GetByID(ctx context.Context, id int) (models.Province, error)
+	Update(ctx context.Context, id int, province models.ProvincePatchRequest) (models.Province, error)
+	Delete(ctx context.Context, id int) error
+	GetSellerReport(ctx context.Context, id *int) ([]models.RegionSellerReport, error)
+	GetCarryReport(ctx context.Context, id *int) ([]models.RegionCarryReport, error)
 }
 
 type LocalityService interface {
-	Create(l models.Locality) (models.Locality, error)
-	GetByID(id string) (models.Locality, error)
-	GetSellerReport(id *string) ([]models.SellerReport, error)
+	Create(ctx context.Context, l models.Locality) (models.Locality, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Locality], error)
+	GetByID(ctx context.Context, id string) (models.Locality, error)
+	Update(ctx context.Context, id string, patch models.LocalityPatchRequest) (models.Locality, error)
+	Delete(ctx context.Context, id string) error
+	GetSellerReport(ctx context.Context, id *string) ([]models.SellerReport, error)
 	// GetReportByLocalityId retrieves a report of carries by locality ID.
-	GetReportByLocalityId(localityId string) ([]models.CarryReport, error)
+	GetReportByLocalityId(ctx context.Context, localityId string) ([]models.CarryReport, error)
+}
+
+// WarehouseDashboardService combines the figures of a warehouse kept by several repositories.
+type WarehouseDashboardService interface {
+	// GetDashboard returns the dashboard of the warehouse, looking days back for inbound
+	// orders and temperature readings and expiringDays ahead for expiring stock.
+	GetDashboard(ctx context.Context, warehouseID, days, expiringDays int) (models.WarehouseDashboard, error)
 }
 
 // WarehouseService defines warehouse operations.
 type WarehouseService interface {
-	// GetAll returns all warehouses.
-	GetAll() ([]models.Warehouse, error)
+	// GetAll returns the page of warehouses described by opts.
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Warehouse], error)
 	// Create adds a new warehouse.
-	Create(warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error)
+	Create(ctx context.Context, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error)
 	// GetByID returns a warehouse by ID.
-	GetByID(id int) (models.Warehouse, error)
+	GetByID(ctx context.Context, id int) (models.Warehouse, error)
 	// Update modifies a warehouse by ID.
-	Update(id int, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error)
+	Update(ctx context.Context, id int, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error)
 	// Delete removes a warehouse by ID.
-	Delete(id int) error
+	Delete(ctx context.Context, id int) error
 }
 
 type BuyerService interface {
 	Create(ctx context.Context, newBuyer models.BuyerAttributes) (models.Buyer, error)
-	GetAll(ctx context.Context) ([]models.Buyer, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Buyer], error)
 	GetByID(ctx context.Context, id int) (models.Buyer, error)
 	Update(ctx context.Context, id int, BuyerData models.BuyerPatchRequest) (models.Buyer, error)
 	Delete(ctx context.Context, id int) error
@@ -59,40 +108,110 @@ type BuyerService interface {
 }
 
 type EmployeeService interface {
-	Create(Employee models.EmployeeAttributes) (models.Employee, error)
-	GetAll() ([]models.Employee, error)
-	GetByID(id int) (models.Employee, error)
-	Update(id int, employee models.EmployeeAttributes) (models.Employee, error)
-	Delete(id int) error
-	ReportInboundOrders(employeeID int) ([]models.EmployeeWithInboundCount, error)
+	Create(ctx context.Context, Employee models.EmployeeAttributes) (models.Employee, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Employee], error)
+	GetByID(ctx context.Context, id int) (models.Employee, error)
+	Update(ctx context.Context, id int, employee models.EmployeeAttributes) (models.Employee, error)
+	Delete(ctx context.Context, id int) error
+	ReportInboundOrders(ctx context.Context, employeeID int) ([]models.EmployeeWithInboundCount, error)
 }
 
 type SectionService interface {
 	Create(ctx context.Context, section models.Section) (models.Section, error)
-	GetAll(ctx context.Context) ([]models.Section, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Section], error)
 	GetByID(ctx context.Context, id int) (models.Section, error)
 	Update(ctx context.Context, id int, data models.UpdateSectionRequest) (models.Section, error)
 	Delete(ctx context.Context, id int) error
 	GetProductsReport(ctx context.Context, id int) (models.SectionProductsReport, error)
 	GetAllProductsReport(ctx context.Context) ([]models.SectionProductsReport, error)
+	// GetExpiringBatchesReport lists the batches due within the next days days, grouped by section.
+	GetExpiringBatchesReport(ctx context.Context, days int, warehouseID int) ([]models.SectionExpiringBatchesReport, error)
+	// GetCapacityReport shows the utilization of every section grouped by warehouse.
+	// A warehouseID of 0 means every warehouse.
+	GetCapacityReport(ctx context.Context, warehouseID int) ([]models.WarehouseCapacityReport, error)
 }
 
 type PurchaseOrderService interface {
 	Create(ctx context.Context, newPurchaseOrder models.PurchaseOrderAttributes) (models.PurchaseOrder, error)
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.PurchaseOrder], error)
+	GetByID(ctx context.Context, id int) (models.PurchaseOrder, error)
+	// Transition moves the order to status now.
+	// Returns a ConflictError if the current status of the order cannot move to status.
+	Transition(ctx context.Context, id int, status string) (models.PurchaseOrder, error)
+	// AssignCarry sets the carry that delivers the order, or picks one when carryID is nil.
+	AssignCarry(ctx context.Context, id int, carryID *int) (models.PurchaseOrder, error)
+}
+
+// TrackingEventService defines operations over the delivery timeline of purchase orders.
+type TrackingEventService interface {
+	// Create appends a tracking event to the given purchase order.
+	Create(ctx context.Context, purchaseOrderID int, event models.CreateTrackingEventRequest) (models.TrackingEvent, error)
+	// GetTimeline returns the delivery timeline of the order with the tracking code.
+	GetTimeline(ctx context.Context, trackingCode string) (models.TrackingTimeline, error)
 }
 
 // CarryService defines operations for managing carries.
 type CarryService interface {
-	// Create validates and creates a new carry.
-	Create(carryAttributes models.CarryAttributes) (models.Carry, error)
+	// Create creates a new carry.
+	Create(ctx context.Context, carryAttributes models.CarryAttributes) (models.Carry, error)
+	// GetAll returns the page of carries described by opts.
+	GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Carry], error)
+	// GetByID returns a carry by ID.
+	GetByID(ctx context.Context, id int) (models.Carry, error)
+	// Update modifies the given fields of a carry by ID.
+	Update(ctx context.Context, id int, carryPatch models.CarryPatchRequest) (models.Carry, error)
+	// Delete removes a carry by ID. Only a carry that never had purchase orders can be
+	// deleted; otherwise it returns a ConflictError, whether its shipments are open or closed.
+	Delete(ctx context.Context, id int) error
 }
 
 type InboundOrderService interface {
-	Create(attrs models.InboundOrderAttributes) (models.InboundOrder, error)
+	Create(ctx context.Context, attrs models.InboundOrderAttributes) (models.InboundOrder, error)
 }
 
 // ProductBatchService defines operations for managing product batches.
 type ProductBatchService interface {
 	// Create validates and creates a new product batch.
 	Create(ctx context.Context, productBatch models.ProductBatchAttibutes) (models.ProductBatch, error)
-}
\ No newline at end of file
+	// GetAll returns the page of product batches matching the filter.
+	GetAll(ctx context.Context, filter models.ProductBatchFilter) (models.Page[models.ProductBatch], error)
+	// GetByID returns a product batch by its ID.
+	GetByID(ctx context.Context, id int) (models.ProductBatch, error)
+	// Update applies a partial update to a product batch.
+	Update(ctx context.Context, id int, data models.UpdateProductBatchRequest) (models.ProductBatch, error)
+	// Delete removes a product batch.
+	Delete(ctx context.Context, id int) error
+}
+
+// StockMovementService defines operations over the stock ledger of product batches.
+type StockMovementService interface {
+	// Create records a movement against the given batch and updates its current quantity.
+	Create(ctx context.Context, productBatchID int, movement models.CreateStockMovementRequest) ([]models.StockMovement, error)
+	// GetByProductBatchID returns the movement history of the given batch.
+	GetByProductBatchID(ctx context.Context, productBatchID int) ([]models.StockMovement, error)
+}
+
+// SectionTemperatureReadingService defines operations over the temperature telemetry of sections.
+type SectionTemperatureReadingService interface {
+	// CreateBulk stores validated readings of a section and refreshes its current temperature.
+	CreateBulk(ctx context.Context, sectionID int, readings []models.CreateTemperatureReadingRequest) ([]models.SectionTemperatureReading, error)
+	// GetSeries returns the readings between from and to with their min/max/avg,
+	// grouped in buckets of interval when interval is not zero.
+	GetSeries(ctx context.Context, sectionID int, from, to time.Time, interval time.Duration) (models.SectionTemperatureSeries, error)
+}
+
+// AlertService defines operations over temperature breach alerts.
+type AlertService interface {
+	// GetAll returns the page of alerts matching the filter.
+	GetAll(ctx context.Context, filter models.AlertFilter) (models.Page[models.Alert], error)
+	// Acknowledge marks an open alert as seen by an operator.
+	Acknowledge(ctx context.Context, id int) (models.Alert, error)
+}
+
+// HealthService reports whether the api is alive and ready to serve traffic.
+type HealthService interface {
+	// Live reports the api is running, without checking its dependencies.
+	Live(ctx context.Context) models.HealthReport
+	// Ready checks the database is reachable and its schema has every migration applied.
+	Ready(ctx context.Context) models.HealthReport
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+)
+
+// StockMovementServiceDefault implements StockMovementService
+type StockMovementServiceDefault struct {
+	repository repository.StockMovementRepository
+}
+
+/*
+NewStockMovementServiceDefault constructs a StockMovementServiceDefault
+with the given repository.
+*/
+func NewStockMovementServiceDefault(repo repository.StockMovementRepository) StockMovementService {
+	return &StockMovementServiceDefault{repository: repo}
+}
+
+// Create turns the request into a signed movement and records it against the batch.
+// Inbound adds stock, outbound removes it, adjustments use the sign of the quantity
+// and transfers move stock to the target batch. A transfer returns both of its movements.
+func (service StockMovementServiceDefault) Create(ctx context.Context, productBatchID int, request models.CreateStockMovementRequest) ([]models.StockMovement, error) {
+	if request.MovementType != models.StockMovementAdjustment && request.Quantity < 0 {
+		return nil, httperrors.UnprocessableEntityError{Message: "Quantity must be positive, use an adjustment to correct stock."}
+	}
+	if request.MovementType != models.StockMovementTransfer && request.TargetProductBatchID != nil {
+		return nil, httperrors.UnprocessableEntityError{Message: "Only transfers accept a target product batch."}
+	}
+
+	movement := models.StockMovement{
+		ProductBatchID: productBatchID,
+		MovementType:   request.MovementType,
+		Quantity:       request.Quantity,
+		Reason:         request.Reason,
+	}
+
+	switch request.MovementType {
+	case models.StockMovementTransfer:
+		if request.TargetProductBatchID == nil {
+			return nil, httperrors.UnprocessableEntityError{Message: "A transfer requires a target product batch."}
+		}
+		if *request.TargetProductBatchID == productBatchID {
+			return nil, httperrors.UnprocessableEntityError{Message: "A transfer target must be a different product batch."}
+		}
+		movement.RelatedProductBatchID = request.TargetProductBatchID
+		return service.repository.Transfer(ctx, movement)
+	case models.StockMovementOutbound:
+		movement.Quantity = -request.Quantity
+	}
+
+	created, err := service.repository.Create(ctx, movement)
+	if err != nil {
+		return nil, err
+	}
+	return []models.StockMovement{created}, nil
+}
+
+// GetByProductBatchID returns the movement history of the given batch
+func (service StockMovementServiceDefault) GetByProductBatchID(ctx context.Context, productBatchID int) ([]models.StockMovement, error) {
+	return service.repository.GetByProductBatchID(ctx, productBatchID)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+// TestStockMovementService_Create tests how Create signs and routes each movement type
+func TestStockMovementService_Create(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     models.CreateStockMovementRequest
+		setupMock   func(m *mocks.StockMovementRepositoryDBMock)
+		expected    []models.StockMovement
+		expectedErr error
+	}{
+		{
+			name:    "inbound adds stock",
+			request: models.CreateStockMovementRequest{MovementType: models.StockMovementInbound, Quantity: 10},
+			setupMock: func(m *mocks.StockMovementRepositoryDBMock) {
+				m.On("Create", testifyMock.Anything, models.StockMovement{ProductBatchID: 1, MovementType: models.StockMovementInbound, Quantity: 10}).
+					Return(models.StockMovement{ID: 2, ProductBatchID: 1, MovementType: models.StockMovementInbound, Quantity: 10, ResultingQuantity: 30}, nil)
+			},
+			expected: []models.StockMovement{{ID: 2, ProductBatchID: 1, MovementType: models.StockMovementInbound, Quantity: 10, ResultingQuantity: 30}},
+		},
+		{
+			name:    "outbound is recorded as a negative quantity",
+			request: models.CreateStockMovementRequest{MovementType: models.StockMovementOutbound, Quantity: 5},
+			setupMock: func(m *mocks.StockMovementRepositoryDBMock) {
+				m.On("Create", testifyMock.Anything, models.StockMovement{ProductBatchID: 1, MovementType: models.StockMovementOutbound, Quantity: -5}).
+					Return(models.StockMovement{ID: 3, ProductBatchID: 1, MovementType: models.StockMovementOutbound, Quantity: -5, ResultingQuantity: 15}, nil)
+			},
+			expected: []models.StockMovement{{ID: 3, ProductBatchID: 1, MovementType: models.StockMovementOutbound, Quantity: -5, ResultingQuantity: 15}},
+		},
+		{
+			name:    "transfer goes through the repository transfer",
+			request: models.CreateStockMovementRequest{MovementType: models.StockMovementTransfer, Quantity: 4, TargetProductBatchID: intPtr(2)},
+			setupMock: func(m *mocks.StockMovementRepositoryDBMock) {
+				m.On("Transfer", testifyMock.Anything, models.StockMovement{ProductBatchID: 1, MovementType: models.StockMovementTransfer, Quantity: 4, RelatedProductBatchID: intPtr(2)}).
+					Return([]models.StockMovement{{ID: 4}, {ID: 5}}, nil)
+			},
+			expected: []models.StockMovement{{ID: 4}, {ID: 5}},
+		},
+		{
+			name:        "negative quantity is only allowed for adjustments",
+			request:     models.CreateStockMovementRequest{MovementType: models.StockMovementInbound, Quantity: -1},
+			setupMock:   func(m *mocks.StockMovementRepositoryDBMock) {},
+			expectedErr: httperrors.UnprocessableEntityError{Message: "Quantity must be positive, use an adjustment to correct stock."},
+		},
+		{
+			name:        "transfer without target",
+			request:     models.CreateStockMovementRequest{MovementType: models.StockMovementTransfer, Quantity: 4},
+			setupMock:   func(m *mocks.StockMovementRepositoryDBMock) {},
+			expectedErr: httperrors.UnprocessableEntityError{Message: "A transfer requires a target product batch."},
+		},
+		{
+			name:        "transfer to the same batch",
+			request:     models.CreateStockMovementRequest{MovementType: models.StockMovementTransfer, Quantity: 4, TargetProductBatchID: intPtr(1)},
+			setupMock:   func(m *mocks.StockMovementRepositoryDBMock) {},
+			expectedErr: httperrors.UnprocessableEntityError{Message: "A transfer target must be a different product batch."},
+		},
+		{
+			name:        "target on a non transfer",
+			request:     models.CreateStockMovementRequest{MovementType: models.StockMovementAdjustment, Quantity: -2, TargetProductBatchID: intPtr(2)},
+			setupMock:   func(m *mocks.StockMovementRepositoryDBMock) {},
+			expectedErr: httperrors.UnprocessableEntityError{Message: "Only transfers accept a target product batch."},
+		},
+		{
+			name:    "not enough stock",
+			request: models.CreateStockMovementRequest{MovementType: models.StockMovementAdjustment, Quantity: -50},
+			setupMock: func(m *mocks.StockMovementRepositoryDBMock) {
+				m.On("Create", testifyMock.Anything, testifyMock.Anything).
+					Return(nil, httperrors.ConflictError{Message: "Not enough stock in the product batch."})
+			},
+			expectedErr: httperrors.ConflictError{Message: "Not enough stock in the product batch."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := new(mocks.StockMovementRepositoryDBMock)
+			tt.setupMock(repoMock)
+			svc := NewStockMovementServiceDefault(repoMock)
+
+			result, err := svc.Create(context.Background(), 1, tt.request)
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			repoMock.AssertExpectations(t)
+		})
+	}
+}
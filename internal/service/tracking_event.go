@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+)
+
+// TrackingEventDefault contains the repository
+type TrackingEventDefault struct {
+	repository repository.TrackingEventRepository
+}
+
+// NewTrackingEventDefault implements TrackingEventDefault
+func NewTrackingEventDefault(repositoryInstance repository.TrackingEventRepository) TrackingEventService {
+	return &TrackingEventDefault{repository: repositoryInstance}
+}
+
+// Create appends an event to the timeline of the purchase order, timestamped now
+// unless the request says when it occurred
+func (s *TrackingEventDefault) Create(ctx context.Context, purchaseOrderID int, request models.CreateTrackingEventRequest) (models.TrackingEvent, error) {
+	occurredAt := time.Now().UTC().Truncate(time.Second)
+	if request.OccurredAt != nil {
+		occurredAt = request.OccurredAt.UTC()
+	}
+
+	return s.repository.Create(ctx, models.TrackingEvent{
+		PurchaseOrderId: purchaseOrderID,
+		EventType:       request.EventType,
+		Location:        request.Location,
+		Notes:           request.Notes,
+		OccurredAt:      occurredAt,
+	})
+}
+
+// GetTimeline returns the events of the order with the tracking code
+func (s *TrackingEventDefault) GetTimeline(ctx context.Context, trackingCode string) (models.TrackingTimeline, error) {
+	return s.repository.GetTimeline(ctx, trackingCode)
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
@@ -17,7 +19,7 @@ func NewWarehouseService(repo repository.WarehouseRepository) *WarehouseServiceD
 }
 
 // Create validates and adds a new warehouse.
-func (w *WarehouseServiceDefault) Create(warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
+func (w *WarehouseServiceDefault) Create(ctx context.Context, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
 	if warehouseAttributes.WarehouseCode == "" {
 		return models.Warehouse{}, httperrors.BadRequestError{Message: "the field WarehouseCode must not be empty"}
 	}
@@ -30,27 +32,22 @@ func (w *WarehouseServiceDefault) Create(warehouseAttributes models.WarehouseAtt
 	if warehouseAttributes.MinimunCapacity <= 0 {
 		return models.Warehouse{}, httperrors.BadRequestError{Message: "the field MinimunCapacity must not be zero or negative"}
 	}
-	return w.repo.Create(warehouseAttributes)
+	return w.repo.Create(ctx, warehouseAttributes)
 }
 
-// GetAll returns all warehouses.
-func (w *WarehouseServiceDefault) GetAll() ([]models.Warehouse, error) {
-	result, err := w.repo.GetAll()
-	if err != nil {
-		return []models.Warehouse{}, err
-	}
-	return result, nil
-
+// GetAll returns the page of warehouses described by opts.
+func (w *WarehouseServiceDefault) GetAll(ctx context.Context, opts models.QueryOptions) (models.Page[models.Warehouse], error) {
+	return w.repo.GetAll(ctx, opts)
 }
 
 // GetByID returns a warehouse by ID.
-func (w *WarehouseServiceDefault) GetByID(id int) (models.Warehouse, error) {
-	return w.repo.GetByID(id)
+func (w *WarehouseServiceDefault) GetByID(ctx context.Context, id int) (models.Warehouse, error) {
+	return w.repo.GetByID(ctx, id)
 }
 
 // Update modifies a warehouse by ID.
-func (w *WarehouseServiceDefault) Update(id int, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
-	warehouse, err := w.repo.GetByID(id)
+func (w *WarehouseServiceDefault) Update(ctx context.Context, id int, warehouseAttributes models.WarehouseAttributes) (models.Warehouse, error) {
+	warehouse, err := w.repo.GetByID(ctx, id)
 	if err != nil {
 		return models.Warehouse{}, err
 	}
@@ -58,19 +55,19 @@ func (w *WarehouseServiceDefault) Update(id int, warehouseAttributes models.Ware
 	if errZeroVelue != nil {
 		return models.Warehouse{}, httperrors.BadRequestError{Message: "the input fields are not valid"}
 	}
-	warehouses, err := w.repo.GetAll()
+	warehouses, err := w.repo.GetAll(ctx, models.QueryOptions{})
 	if err != nil {
 		return models.Warehouse{}, err
 	}
-	for _, w := range warehouses {
+	for _, w := range warehouses.Data {
 		if w.WarehouseCode == warehouse.WarehouseAttributes.WarehouseCode && w.Id != id {
 			return models.Warehouse{}, httperrors.ConflictError{Message: "the WarehouseCode already exists"}
 		}
 	}
-	return w.repo.Update(id, warehouse.WarehouseAttributes)
+	return w.repo.Update(ctx, id, warehouse.WarehouseAttributes)
 }
 
 // Delete removes a warehouse by ID.
-func (w *WarehouseServiceDefault) Delete(id int) error {
-	return w.repo.Delete(id)
+func (w *WarehouseServiceDefault) Delete(ctx context.Context, id int) error {
+	return w.repo.Delete(ctx, id)
 }
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/repository"
+)
+
+// WarehouseDashboardRepositories are the repositories the warehouse dashboard reads from.
+type WarehouseDashboardRepositories struct {
+	Warehouse                 repository.WarehouseRepository
+	Section                   repository.SectionRepository
+	Employee                  repository.EmployeeRepository
+	ProductType               repository.ProductTypeRepository
+	ProductBatch              repository.ProductBatchRepository
+	InboundOrder              repository.InboundOrderRepository
+	SectionTemperatureReading repository.SectionTemperatureReadingRepository
+	Alert                     repository.AlertRepository
+}
+
+// WarehouseDashboardServiceDefault implements WarehouseDashboardService
+type WarehouseDashboardServiceDefault struct {
+	repos WarehouseDashboardRepositories
+}
+
+// NewWarehouseDashboardServiceDefault constructs a WarehouseDashboardServiceDefault
+// reading from the given repositories.
+func NewWarehouseDashboardServiceDefault(repos WarehouseDashboardRepositories) WarehouseDashboardService {
+	return &WarehouseDashboardServiceDefault{repos: repos}
+}
+
+// GetDashboard returns the dashboard of the warehouse. Inbound orders and temperature
+// readings are looked up days back from now, expiring stock expiringDays ahead of today.
+// Returns a NotFoundError if the warehouse does not exist.
+func (service WarehouseDashboardServiceDefault) GetDashboard(ctx context.Context, warehouseID, days, expiringDays int) (models.WarehouseDashboard, error) {
+	warehouse, err := service.repos.Warehouse.GetByID(ctx, warehouseID)
+	if err != nil {
+		return models.WarehouseDashboard{}, err
+	}
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -days)
+	dashboard := models.WarehouseDashboard{Warehouse: warehouse, Days: days, ExpiringDays: expiringDays}
+
+	warehouseFilter := map[string]string{"warehouse_id": fmt.Sprint(warehouseID)}
+	sections, err := service.repos.Section.GetAll(ctx, models.QueryOptions{Filters: warehouseFilter})
+	if err != nil {
+		return models.WarehouseDashboard{}, err
+	}
+	dashboard.Sections = summarizeSections(sections.Data)
+
+	// Only the total is needed, so a single row is read
+	employees, err := service.repos.Employee.GetAll(ctx, models.QueryOptions{Limit: 1, Filters: warehouseFilter})
+	if err != nil {
+		return models.WarehouseDashboard{}, err
+	}
+	dashboard.EmployeesCount = employees.Meta.Total
+
+	if dashboard.BatchesByProductType, err = service.batchesByProductType(ctx, sections.Data); err != nil {
+		return models.WarehouseDashboard{}, err
+	}
+
+	dashboard.ExpiringSoon = models.ExpiringStock{
+		From: now.Format(time.DateOnly),
+		To:   now.AddDate(0, 0, expiringDays).Format(time.DateOnly),
+	}
+	expiring, err := service.repos.Section.GetExpiringBatchesReport(ctx, dashboard.ExpiringSoon.From, dashboard.ExpiringSoon.To, warehouseID)
+	if err != nil {
+		return models.WarehouseDashboard{}, err
+	}
+	for _, section := range expiring {
+		for _, batch := range section.Batches {
+			dashboard.ExpiringSoon.BatchesCount++
+			dashboard.ExpiringSoon.Quantity += batch.CurrentQuantity
+		}
+	}
+
+	if dashboard.InboundOrders, err = service.repos.InboundOrder.CountInboundOrdersForWarehouse(ctx, warehouseID, since); err != nil {
+		return models.WarehouseDashboard{}, err
+	}
+
+	if dashboard.WorstTemperatureDeviation, err = service.worstTemperatureDeviation(ctx, sections.Data, since, now); err != nil {
+		return models.WarehouseDashboard{}, err
+	}
+	return dashboard, nil
+}
+
+// summarizeSections adds up the capacity of the sections.
+func summarizeSections(sections []models.Section) models.DashboardSections {
+	summary := models.DashboardSections{Count: len(sections)}
+	for _, section := range sections {
+		summary.CurrentCapacity += section.CurrentCapacity
+		summary.MaximumCapacity += section.MaximumCapacity
+		if section.CurrentCapacity < section.MinimumCapacity {
+			summary.BelowMinimumCount++
+		}
+	}
+	summary.Utilization = utilization(summary.CurrentCapacity, summary.MaximumCapacity)
+	return summary
+}
+
+// batchesByProductType counts the batches stored in the sections, ordered by product type ID.
+// A section only stores batches of its own product type, so each batch counts for the type of its section.
+func (service WarehouseDashboardServiceDefault) batchesByProductType(ctx context.Context, sections []models.Section) ([]models.ProductTypeBatches, error) {
+	byType := make(map[int]*models.ProductTypeBatches)
+	var typeIDs []int
+	for _, section := range sections {
+		batches, err := service.repos.ProductBatch.GetAll(ctx, models.ProductBatchFilter{SectionID: section.ID})
+		if err != nil {
+			return nil, err
+		}
+		if len(batches.Data) == 0 {
+			continue
+		}
+
+		productType, ok := byType[section.ProductTypeID]
+		if !ok {
+			productType = &models.ProductTypeBatches{ProductTypeID: section.ProductTypeID}
+			byType[section.ProductTypeID] = productType
+			typeIDs = append(typeIDs, section.ProductTypeID)
+		}
+		for _, batch := range batches.Data {
+			productType.BatchesCount++
+			productType.Quantity += batch.CurrentQuantity
+		}
+	}
+	slices.Sort(typeIDs)
+
+	counts := make([]models.ProductTypeBatches, 0, len(typeIDs))
+	for _, id := range typeIDs {
+		productType, err := service.repos.ProductType.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		byType[id].Description = productType.Description
+		counts = append(counts, *byType[id])
+	}
+	return counts, nil
+}
+
+// worstTemperatureDeviation checks the readings of the sections between from and to against
+// the same rules that raise alerts and returns the breach furthest past its threshold,
+// or nil when no reading breached a rule.
+func (service WarehouseDashboardServiceDefault) worstTemperatureDeviation(ctx context.Context, sections []models.Section, from, to time.Time) (*models.SectionTemperatureDeviation, error) {
+	var worst *models.SectionTemperatureDeviation
+	for _, section := range sections {
+		readings, err := service.repos.SectionTemperatureReading.GetBySectionID(ctx, section.ID, from, to)
+		if err != nil {
+			return nil, err
+		}
+		if len(readings) == 0 {
+			continue
+		}
+		targets, err := service.repos.Alert.GetTemperatureTargets(ctx, section.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, reading := range readings {
+			for _, breach := range evaluateTemperature(targets, reading.Temperature) {
+				deviation := math.Round(math.Abs(breach.Temperature-breach.Threshold)*100) / 100
+				if worst != nil && deviation <= worst.Deviation {
+					continue
+				}
+				worst = &models.SectionTemperatureDeviation{
+					SectionID:     section.ID,
+					SectionNumber: section.SectionNumber,
+					AlertType:     breach.AlertType,
+					Temperature:   breach.Temperature,
+					Threshold:     breach.Threshold,
+					Deviation:     deviation,
+					RecordedAt:    reading.RecordedAt,
+				}
+			}
+		}
+	}
+	return worst, nil
+}
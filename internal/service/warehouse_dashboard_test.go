@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/repository"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarehouseDashboardService_GetDashboard(t *testing.T) {
+	ctx := context.Background()
+	anyTime := testifyMock.AnythingOfType("time.Time")
+	warehouse := models.Warehouse{Id: 1, WarehouseAttributes: models.WarehouseAttributes{WarehouseCode: "DHK"}}
+	warehouseFilter := map[string]string{"warehouse_id": "1"}
+
+	t.Run("combines the figures of every repository", func(t *testing.T) {
+		// arrange
+		warehouses := new(mocks.WarehouseRepositoryMock)
+		sections := new(mocks.SectionRepositoryDBMock)
+		employees := new(mocks.MockEmployeeRepository)
+		productTypes := new(mocks.ProductTypeRepositoryMock)
+		productBatches := new(mocks.ProductBatchRepositoryMock)
+		inboundOrders := new(mocks.MockInboundOrderRepository)
+		readings := new(mocks.SectionTemperatureReadingRepositoryDBMock)
+		alerts := new(mocks.AlertRepositoryDBMock)
+
+		warehouses.On("GetByID", ctx, 1).Return(warehouse, nil)
+		sections.On("GetAll", ctx, models.QueryOptions{Filters: warehouseFilter}).Return(models.Page[models.Section]{Data: []models.Section{
+			{ID: 10, SectionNumber: "S10", CurrentCapacity: 50, MinimumCapacity: 10, MaximumCapacity: 100, WarehouseID: 1, ProductTypeID: 2},
+			{ID: 11, SectionNumber: "S11", CurrentCapacity: 5, MinimumCapacity: 10, MaximumCapacity: 100, WarehouseID: 1, ProductTypeID: 1},
+		}}, nil)
+		employees.On("GetAll", ctx, models.QueryOptions{Limit: 1, Filters: warehouseFilter}).
+			Return(models.Page[models.Employee]{Meta: models.PageMeta{Total: 4}}, nil)
+		productBatches.On("GetAll", ctx, models.ProductBatchFilter{SectionID: 10}).Return(models.Page[models.ProductBatch]{Data: []models.ProductBatch{
+			{ID: 1, ProductBatchAttibutes: models.ProductBatchAttibutes{CurrentQuantity: 30}},
+			{ID: 2, ProductBatchAttibutes: models.ProductBatchAttibutes{CurrentQuantity: 20}},
+		}}, nil)
+		productBatches.On("GetAll", ctx, models.ProductBatchFilter{SectionID: 11}).Return(models.Page[models.ProductBatch]{Data: []models.ProductBatch{
+			{ID: 3, ProductBatchAttibutes: models.ProductBatchAttibutes{CurrentQuantity: 5}},
+		}}, nil)
+		productTypes.On("GetByID", ctx, 1).Return(models.ProductType{ID: 1, ProductTypeAttributes: models.ProductTypeAttributes{Description: "Carnes"}}, nil)
+		productTypes.On("GetByID", ctx, 2).Return(models.ProductType{ID: 2, ProductTypeAttributes: models.ProductTypeAttributes{Description: "Pescados"}}, nil)
+		sections.On("GetExpiringBatchesReport", ctx, testifyMock.Anything, testifyMock.Anything, 1).Return([]models.SectionExpiringBatchesReport{
+			{SectionID: 10, Batches: []models.ExpiringBatch{{ProductBatchID: 1, CurrentQuantity: 30}}},
+		}, nil)
+		inboundOrders.On("CountInboundOrdersForWarehouse", ctx, 1, anyTime).Return(models.InboundOrdersSummary{OrdersCount: 3, Quantity: 90}, nil)
+
+		recordedAt := time.Date(2026, 1, 3, 8, 0, 0, 0, time.UTC)
+		readings.On("GetBySectionID", ctx, 10, anyTime, anyTime).Return([]models.SectionTemperatureReading{
+			{SectionID: 10, Temperature: -21, RecordedAt: recordedAt.Add(-time.Hour)},
+			{SectionID: 10, Temperature: -10, RecordedAt: recordedAt},
+		}, nil)
+		readings.On("GetBySectionID", ctx, 11, anyTime, anyTime).Return([]models.SectionTemperatureReading(nil), nil)
+		alerts.On("GetTemperatureTargets", ctx, 10).Return(models.SectionTemperatureTargets{
+			SectionID: 10, MinimumTemperature: -20,
+			Batches: []models.BatchTemperatureTarget{{ProductBatchID: 1, ProductID: 7, RecommendedFreezingTemperature: -18}},
+		}, nil)
+
+		service := NewWarehouseDashboardServiceDefault(WarehouseDashboardRepositories{
+			Warehouse: warehouses, Section: sections, Employee: employees, ProductType: productTypes,
+			ProductBatch: productBatches, InboundOrder: inboundOrders, SectionTemperatureReading: readings, Alert: alerts,
+		})
+
+		// act
+		dashboard, err := service.GetDashboard(ctx, 1, 7, 14)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, warehouse, dashboard.Warehouse)
+		assert.Equal(t, models.DashboardSections{Count: 2, CurrentCapacity: 55, MaximumCapacity: 200, Utilization: 27.5, BelowMinimumCount: 1}, dashboard.Sections)
+		assert.Equal(t, 4, dashboard.EmployeesCount)
+		assert.Equal(t, []models.ProductTypeBatches{
+			{ProductTypeID: 1, Description: "Carnes", BatchesCount: 1, Quantity: 5},
+			{ProductTypeID: 2, Description: "Pescados", BatchesCount: 2, Quantity: 50},
+		}, dashboard.BatchesByProductType)
+		assert.Equal(t, 1, dashboard.ExpiringSoon.BatchesCount)
+		assert.Equal(t, 30, dashboard.ExpiringSoon.Quantity)
+		assert.Equal(t, 3, dashboard.InboundOrders.OrdersCount)
+		// -10 is 8 degrees above the recommended -18, worse than -21 being 1 below the minimum
+		assert.Equal(t, &models.SectionTemperatureDeviation{
+			SectionID: 10, SectionNumber: "S10", AlertType: models.AlertTypeProductRecommended,
+			Temperature: -10, Threshold: -18, Deviation: 8, RecordedAt: recordedAt,
+		}, dashboard.WorstTemperatureDeviation)
+		for _, m := range []interface {
+			AssertExpectations(testifyMock.TestingT) bool
+		}{
+			warehouses, sections, employees, productTypes, productBatches, inboundOrders, readings, alerts,
+		} {
+			m.AssertExpectations(t)
+		}
+	})
+
+	t.Run("missing warehouse returns NotFoundError", func(t *testing.T) {
+		// arrange
+		warehouses := new(mocks.WarehouseRepositoryMock)
+		warehouses.On("GetByID", ctx, 9).Return(models.Warehouse{}, httperrors.NotFoundError{Message: "warehouse not found"})
+		service := NewWarehouseDashboardServiceDefault(WarehouseDashboardRepositories{Warehouse: warehouses})
+
+		// act
+		_, err := service.GetDashboard(ctx, 9, 7, 7)
+
+		// assert
+		assert.Equal(t, httperrors.NotFoundError{Message: "warehouse not found"}, err)
+		warehouses.AssertExpectations(t)
+	})
+}
@@ -17,12 +17,14 @@
 package service_test
 
 import (
+	"context"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/mocks/repository"
 	"testing"
 
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/models"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/service"
 	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -60,11 +62,11 @@ func Test_Create(t *testing.T) {
 	t.Run("create warehouse success (create_ok)", func(t *testing.T) {
 		// Arrange: mock repository to return successful creation
 		mockRepository := new(mocks.WarehouseRepositoryMock)
-		mockRepository.On("Create", warehouseAtt).Return(warehouse, nil)
+		mockRepository.On("Create", mock.Anything, warehouseAtt).Return(warehouse, nil)
 		serviceTest := service.NewWarehouseService(mockRepository)
 
 		// Act: execute warehouse creation through service
-		result, err := serviceTest.Create(warehouseAtt)
+		result, err := serviceTest.Create(context.Background(), warehouseAtt)
 
 		// Assert: verify successful creation with correct warehouse data
 		require.NoError(t, err)
@@ -96,10 +98,10 @@ func Test_Create(t *testing.T) {
 				// Arrange: mock repository to return specific error
 				mockRepository := new(mocks.WarehouseRepositoryMock)
 				serviceTest := service.NewWarehouseService(mockRepository)
-				mockRepository.On("Create", warehouseAtt).Return(models.Warehouse{}, test.repositoryError)
+				mockRepository.On("Create", mock.Anything, warehouseAtt).Return(models.Warehouse{}, test.repositoryError)
 
 				// Act: attempt warehouse creation that will result in repository error
-				result, err := serviceTest.Create(warehouseAtt)
+				result, err := serviceTest.Create(context.Background(), warehouseAtt)
 
 				// Assert: verify error propagation and empty result
 				require.Equal(t, emptyWarehouse, result)
@@ -167,7 +169,7 @@ func Test_Create(t *testing.T) {
 				test.modifyStructFunc(&warehouseAtt)
 
 				// Act: attempt creation with invalid attributes
-				result, err := serviceTest.Create(warehouseAtt)
+				result, err := serviceTest.Create(context.Background(), warehouseAtt)
 
 				// Assert: verify validation error and no repository interaction
 				require.Equal(t, emptyWarehouse, result)
@@ -203,14 +205,14 @@ func Test_GetAll(t *testing.T) {
 			{Id: 2, WarehouseAttributes: models.WarehouseAttributes{WarehouseCode: "WH-002", Address: "Uwu 342", Telephone: "987654321", MinimunCapacity: 2, MinimunTemperature: 5.0}},
 		}
 
-		mockRepository.On("GetAll").Return(warehouses, nil)
+		mockRepository.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.NewPage(warehouses, len(warehouses), nil), nil)
 
 		// Act: execute warehouse retrieval through service
-		result, err := serviceTest.GetAll()
+		result, err := serviceTest.GetAll(context.Background(), models.QueryOptions{})
 
 		// Assert: verify successful retrieval with correct warehouse collection
 		require.NoError(t, err)
-		require.Equal(t, warehouses, result)
+		require.Equal(t, warehouses, result.Data)
 		mockRepository.AssertExpectations(t)
 		mockRepository.AssertNumberOfCalls(t, "GetAll", 1)
 	})
@@ -220,16 +222,16 @@ func Test_GetAll(t *testing.T) {
 		// Arrange: mock repository to return internal server error
 		errorReturned := httperrors.InternalServerError{Message: "error getting warehouses"}
 		mockRepository := new(mocks.WarehouseRepositoryMock)
-		mockRepository.On("GetAll").Return([]models.Warehouse{}, errorReturned)
+		mockRepository.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.Page[models.Warehouse]{}, errorReturned)
 		serviceTest := service.NewWarehouseService(mockRepository)
 
 		// Act: attempt warehouse retrieval that will result in repository error
-		result, err := serviceTest.GetAll()
+		result, err := serviceTest.GetAll(context.Background(), models.QueryOptions{})
 
 		// Assert: verify error propagation and empty result
 		require.Error(t, err)
 		require.ErrorIs(t, errorReturned, err)
-		require.Empty(t, result)
+		require.Empty(t, result.Data)
 		mockRepository.AssertExpectations(t)
 		mockRepository.AssertNumberOfCalls(t, "GetAll", 1)
 	})
@@ -265,10 +267,10 @@ func Test_GetByID(t *testing.T) {
 				MinimunTemperature: 5.0},
 		}
 
-		mockRepository.On("GetByID", warehouse.Id).Return(warehouse, nil)
+		mockRepository.On("GetByID", mock.Anything, warehouse.Id).Return(warehouse, nil)
 
 		// Act: execute warehouse retrieval by ID through service
-		result, err := serviceTest.GetByID(warehouse.Id)
+		result, err := serviceTest.GetByID(context.Background(), warehouse.Id)
 
 		// Assert: verify successful retrieval with correct warehouse data
 		require.NoError(t, err)
@@ -303,11 +305,11 @@ func Test_GetByID(t *testing.T) {
 			t.Run(test.name, func(t *testing.T) {
 				// Arrange: mock repository to return specific error
 				mockRepository := new(mocks.WarehouseRepositoryMock)
-				mockRepository.On("GetByID", idSearched).Return(models.Warehouse{}, test.repositoryError)
+				mockRepository.On("GetByID", mock.Anything, idSearched).Return(models.Warehouse{}, test.repositoryError)
 				serviceTest := service.NewWarehouseService(mockRepository)
 
 				// Act: attempt retrieval that will result in repository error
-				result, err := serviceTest.GetByID(idSearched)
+				result, err := serviceTest.GetByID(context.Background(), idSearched)
 
 				// Assert: verify error propagation and empty result
 				require.Equal(t, emptyWarehouse, result)
@@ -369,13 +371,13 @@ func Test_Update(t *testing.T) {
 	t.Run("on success", func(t *testing.T) {
 		// Arrange: mock repository for successful update workflow
 		mockRepository := new(mocks.WarehouseRepositoryMock)
-		mockRepository.On("GetByID", warehouseTest.Id).Return(warehouseTest, nil)
-		mockRepository.On("GetAll").Return(warehouses, nil)
-		mockRepository.On("Update", warehouseTest.Id, warehouseAttUpdated).Return(wareHouseUpdated, nil)
+		mockRepository.On("GetByID", mock.Anything, warehouseTest.Id).Return(warehouseTest, nil)
+		mockRepository.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.NewPage(warehouses, len(warehouses), nil), nil)
+		mockRepository.On("Update", mock.Anything, warehouseTest.Id, warehouseAttUpdated).Return(wareHouseUpdated, nil)
 		serviceTest := service.NewWarehouseService(mockRepository)
 
 		// Act: execute warehouse update through service
-		result, err := serviceTest.Update(warehouseTest.Id, warehouseAttUpdated)
+		result, err := serviceTest.Update(context.Background(), warehouseTest.Id, warehouseAttUpdated)
 
 		// Assert: verify successful update with correct data
 		require.NoError(t, err)
@@ -389,11 +391,11 @@ func Test_Update(t *testing.T) {
 	t.Run("on id not found (update_non_existent)", func(t *testing.T) {
 		// Arrange: mock repository to return not found error during existence check
 		mockRepository := new(mocks.WarehouseRepositoryMock)
-		mockRepository.On("GetByID", warehouseTest.Id).Return(models.Warehouse{}, httperrors.NotFoundError{Message: "warehouse not found"})
+		mockRepository.On("GetByID", mock.Anything, warehouseTest.Id).Return(models.Warehouse{}, httperrors.NotFoundError{Message: "warehouse not found"})
 		serviceTest := service.NewWarehouseService(mockRepository)
 
 		// Act: attempt update of non-existent warehouse
-		result, err := serviceTest.Update(warehouseTest.Id, warehouseAttributesTest)
+		result, err := serviceTest.Update(context.Background(), warehouseTest.Id, warehouseAttributesTest)
 
 		// Assert: verify not found error and no update operation
 		require.Equal(t, emptyWarehouse, result)
@@ -412,12 +414,12 @@ func Test_Update(t *testing.T) {
 			{Id: 2, WarehouseAttributes: models.WarehouseAttributes{WarehouseCode: "WH-001", Address: "Uwu 342", Telephone: "987654321", MinimunCapacity: 2, MinimunTemperature: 5.0}},
 		}
 		mockRepository := new(mocks.WarehouseRepositoryMock)
-		mockRepository.On("GetByID", warehouseTest.Id).Return(warehouseTest, nil)
-		mockRepository.On("GetAll").Return(warehousesTest, nil)
+		mockRepository.On("GetByID", mock.Anything, warehouseTest.Id).Return(warehouseTest, nil)
+		mockRepository.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.NewPage(warehousesTest, len(warehousesTest), nil), nil)
 		serviceTest := service.NewWarehouseService(mockRepository)
 
 		// Act: attempt update that would create duplicate warehouse code
-		result, err := serviceTest.Update(warehouseTest.Id, warehouseAttUpdated)
+		result, err := serviceTest.Update(context.Background(), warehouseTest.Id, warehouseAttUpdated)
 
 		// Assert: verify conflict error and no update operation
 		require.Equal(t, emptyWarehouse, result)
@@ -434,12 +436,12 @@ func Test_Update(t *testing.T) {
 	t.Run("Repository error during validation", func(t *testing.T) {
 		// Arrange: mock repository to return error during GetAll operation
 		mockRepository := new(mocks.WarehouseRepositoryMock)
-		mockRepository.On("GetByID", warehouseTest.Id).Return(warehouseTest, nil)
-		mockRepository.On("GetAll").Return([]models.Warehouse{}, httperrors.InternalServerError{Message: "error reading warehouse data"})
+		mockRepository.On("GetByID", mock.Anything, warehouseTest.Id).Return(warehouseTest, nil)
+		mockRepository.On("GetAll", mock.Anything, models.QueryOptions{}).Return(models.Page[models.Warehouse]{}, httperrors.InternalServerError{Message: "error reading warehouse data"})
 		serviceTest := service.NewWarehouseService(mockRepository)
 
 		// Act: attempt update that fails during validation
-		result, err := serviceTest.Update(warehouseTest.Id, warehouseAttUpdated)
+		result, err := serviceTest.Update(context.Background(), warehouseTest.Id, warehouseAttUpdated)
 
 		// Assert: verify internal server error propagation
 		require.Equal(t, emptyWarehouse, result)
@@ -469,11 +471,11 @@ func Test_Delete(t *testing.T) {
 	t.Run("on success (delete_ok)", func(t *testing.T) {
 		// Arrange: mock repository to return successful deletion
 		mockRepository := new(mocks.WarehouseRepositoryMock)
-		mockRepository.On("Delete", idSearched).Return(nil)
+		mockRepository.On("Delete", mock.Anything, idSearched).Return(nil)
 		serviceTest := service.NewWarehouseService(mockRepository)
 
 		// Act: execute warehouse deletion through service
-		err := serviceTest.Delete(idSearched)
+		err := serviceTest.Delete(context.Background(), idSearched)
 
 		// Assert: verify successful deletion with no error
 		require.NoError(t, err)
@@ -485,11 +487,11 @@ func Test_Delete(t *testing.T) {
 	t.Run("on repository error (delete_non_existent)", func(t *testing.T) {
 		// Arrange: mock repository to return not found error
 		mockRepository := new(mocks.WarehouseRepositoryMock)
-		mockRepository.On("Delete", idSearched).Return(httperrors.NotFoundError{Message: "warehouse not found"})
+		mockRepository.On("Delete", mock.Anything, idSearched).Return(httperrors.NotFoundError{Message: "warehouse not found"})
 		serviceTest := service.NewWarehouseService(mockRepository)
 
 		// Act: attempt deletion of non-existent warehouse
-		err := serviceTest.Delete(idSearched)
+		err := serviceTest.Delete(context.Background(), idSearched)
 
 		// Assert: verify not found error propagation
 		require.Error(t, err)
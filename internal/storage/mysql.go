@@ -2,37 +2,56 @@ package storage
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
+	"github.com/aaguero_meli/W17-G6-Bootcamp/internal/config"
 	"github.com/go-sql-driver/mysql"
 )
 
-// formats the parameters of the connection with the standar structure
-// mysql.Config struct, adding extra parameters
-func NewMySQLConfig(host, port, user, pass, dbname string) mysql.Config {
-	return mysql.Config{
-		User:                 user,
-		Passwd:               pass,
-		Net:                  "tcp",
-		Addr:                 fmt.Sprintf("%s:%s", host, port),
-		DBName:               dbname,
-		ParseTime:            true,
-		AllowNativePasswords: true,
-		MultiStatements:      true,
-		Params:               map[string]string{"charset": "utf8mb4"},
-	}
-}
+// mysqlUnknownDatabase is the error number of connecting to a missing database
+const mysqlUnknownDatabase = 1049
 
-// generates a connector to mysql, check if it can be used with Ping
+// generates a connector to mysql with the pool settings of cfg, check if it can be used with Ping
 // returns error if it cant ping it, or the connector pointer for future use in the app
-func InitMySQLConnection(cfg mysql.Config) (*sql.DB, error) {
-	db, err := sql.Open("mysql", cfg.FormatDSN())
+func InitMySQLConnection(cfg config.Database) (*sql.DB, error) {
+	db, err := sql.Open("mysql", cfg.MySQLConfig().FormatDSN())
 	if err != nil {
 		return nil, fmt.Errorf("invalid args to mysql: %w", err)
 	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime))
+	db.SetConnMaxIdleTime(time.Duration(cfg.ConnMaxIdleTime))
 	if err := db.Ping(); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("could not connect to mysql: %w", err)
 	}
 	return db, nil
 }
+
+// InitOrCreateMySQLConnection connects like InitMySQLConnection, first creating
+// the database when it does not exist. It is meant for cmd/migrate and cmd/seed.
+func InitOrCreateMySQLConnection(cfg config.Database) (*sql.DB, error) {
+	db, err := InitMySQLConnection(cfg)
+	var mysqlErr *mysql.MySQLError
+	if err == nil || !errors.As(err, &mysqlErr) || mysqlErr.Number != mysqlUnknownDatabase {
+		return db, err
+	}
+
+	slog.Info("database does not exist, creating it", slog.String("database", cfg.Name))
+	// connects without DB to create the database
+	withoutDB := cfg
+	withoutDB.Name = ""
+	server, err := InitMySQLConnection(withoutDB)
+	if err != nil {
+		return nil, err
+	}
+	defer server.Close()
+	if _, err := server.Exec(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS `%s`", cfg.Name)); err != nil {
+		return nil, fmt.Errorf("creating the database %s: %w", cfg.Name, err)
+	}
+	return InitMySQLConnection(cfg)
+}
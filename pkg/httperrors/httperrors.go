@@ -2,6 +2,7 @@ package httperrors
 
 import (
 	"errors"
+	"sync/atomic"
 )
 
 type BadRequestError struct {
@@ -54,19 +55,39 @@ func (e InternalServerError) Error() string {
 // • InternalServerError       → 500 Internal Server Error
 //
 // If the error is none of the above, it returns 500 with a generic message.
+// The type of the error is reported to the function set with ObserveErrors.
 func GetErrorData(err error) (int, string) {
+	errorType, statusCode, message := classify(err)
+	if observer := errorObserver.Load(); observer != nil {
+		(*observer)(errorType)
+	}
+	return statusCode, message
+}
+
+// errorObserver is called by GetErrorData with the type name of every error it maps
+var errorObserver atomic.Pointer[func(errorType string)]
+
+// ObserveErrors sets fn to be called by GetErrorData with the type name of every
+// error it maps, such as "NotFoundError", or "UnknownError" for errors outside
+// this package. It is meant to count the errors returned to clients.
+func ObserveErrors(fn func(errorType string)) {
+	errorObserver.Store(&fn)
+}
+
+// classify returns the type name, status code and message of err
+func classify(err error) (string, int, string) {
 	switch {
 	case errors.As(err, &BadRequestError{}):
-		return 400, err.Error()
+		return "BadRequestError", 400, err.Error()
 	case errors.As(err, &NotFoundError{}):
-		return 404, err.Error()
+		return "NotFoundError", 404, err.Error()
 	case errors.As(err, &ConflictError{}):
-		return 409, err.Error()
+		return "ConflictError", 409, err.Error()
 	case errors.As(err, &UnprocessableEntityError{}):
-		return 422, err.Error()
+		return "UnprocessableEntityError", 422, err.Error()
 	case errors.As(err, &InternalServerError{}):
-		return 500, err.Error()
+		return "InternalServerError", 500, err.Error()
 	default:
-		return 500, "Internal Server Error"
+		return "UnknownError", 500, "Internal Server Error"
 	}
-}
\ No newline at end of file
+}
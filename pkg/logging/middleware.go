@@ -0,0 +1,139 @@
+package logging
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RequestIDHeader is the header the request ID is read from and echoed in.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the client supplied IDs copied into the logs
+const maxRequestIDLength = 128
+
+// Middleware tags every request with an ID, taken from the X-Request-ID header
+// or generated, and stores it with a logger carrying it in the request context.
+// The ID is echoed in the X-Request-ID response header and as request_id in JSON
+// error bodies. Once the request is served a line with its route, status and
+// latency is logged.
+func Middleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			requestID := r.Header.Get(RequestIDHeader)
+			if requestID == "" || len(requestID) > maxRequestIDLength {
+				requestID = newRequestID()
+			}
+			requestLogger := logger.With(slog.String("request_id", requestID))
+
+			ctx := WithRequestID(WithLogger(r.Context(), requestLogger), requestID)
+			w.Header().Set(RequestIDHeader, requestID)
+			rw := &responseWriter{ResponseWriter: w}
+			r = r.WithContext(ctx)
+			next.ServeHTTP(rw, r)
+			message := rw.finish(requestID)
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("route", routePattern(r)),
+				slog.Int("status", rw.status),
+				slog.Int("bytes", rw.bytes),
+				slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			}
+			level := slog.LevelInfo
+			switch {
+			case rw.status >= http.StatusInternalServerError:
+				level = slog.LevelError
+			case rw.status >= http.StatusBadRequest:
+				level = slog.LevelWarn
+			}
+			if message != "" {
+				attrs = append(attrs, slog.String("error", message))
+			}
+			requestLogger.LogAttrs(ctx, level, "request completed", attrs...)
+		})
+	}
+}
+
+// routePattern returns the chi pattern matched by r, or its path when no route matched
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return r.URL.Path
+}
+
+// newRequestID returns 16 random bytes hex encoded
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// responseWriter records the status and size of a response, and holds back JSON
+// error bodies so the request ID can be added to them.
+type responseWriter struct {
+	http.ResponseWriter
+	status    int
+	bytes     int
+	errorBody *bytes.Buffer
+}
+
+func (rw *responseWriter) WriteHeader(code int) {
+	if rw.status != 0 {
+		return
+	}
+	rw.status = code
+	if code >= http.StatusBadRequest && strings.HasPrefix(rw.Header().Get("Content-Type"), "application/json") {
+		rw.errorBody = new(bytes.Buffer)
+		rw.Header().Del("Content-Length")
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.WriteHeader(http.StatusOK)
+	}
+	if rw.errorBody != nil {
+		return rw.errorBody.Write(b)
+	}
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += n
+	return n, err
+}
+
+// finish writes the held back error body with the request ID added and returns
+// its message. Bodies that are not JSON objects are written untouched.
+func (rw *responseWriter) finish(requestID string) string {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	if rw.errorBody == nil {
+		return ""
+	}
+	body := rw.errorBody.Bytes()
+	var fields map[string]any
+	var message string
+	if err := json.Unmarshal(body, &fields); err == nil && fields != nil {
+		message, _ = fields["message"].(string)
+		fields["request_id"] = requestID
+		if tagged, err := json.Marshal(fields); err == nil {
+			body = tagged
+		}
+	}
+	n, _ := rw.ResponseWriter.Write(body)
+	rw.bytes += n
+	return message
+}
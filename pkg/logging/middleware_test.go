@@ -0,0 +1,89 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/logging"
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	newRouter := func(logs *bytes.Buffer) chi.Router {
+		router := chi.NewRouter()
+		router.Use(logging.Middleware(logging.New(logs, slog.LevelInfo)))
+		router.Get("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+			if chi.URLParam(r, "id") == "0" {
+				logging.FromContext(r.Context()).Error("lookup failed")
+				response.Error(w, http.StatusInternalServerError, "error reading item")
+				return
+			}
+			response.JSON(w, http.StatusOK, map[string]any{"request_id": logging.RequestID(r.Context())})
+		})
+		return router
+	}
+	logLines := func(t *testing.T, logs *bytes.Buffer) []map[string]any {
+		var lines []map[string]any
+		decoder := json.NewDecoder(logs)
+		for decoder.More() {
+			var line map[string]any
+			require.NoError(t, decoder.Decode(&line))
+			lines = append(lines, line)
+		}
+		return lines
+	}
+
+	t.Run("keeps the given request ID and logs the route", func(t *testing.T) {
+		// arrange
+		logs := new(bytes.Buffer)
+		req := httptest.NewRequest(http.MethodGet, "/items/4", nil)
+		req.Header.Set(logging.RequestIDHeader, "abc-123")
+		rec := httptest.NewRecorder()
+
+		// act
+		newRouter(logs).ServeHTTP(rec, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "abc-123", rec.Header().Get(logging.RequestIDHeader))
+		assert.JSONEq(t, `{"request_id": "abc-123"}`, rec.Body.String())
+		lines := logLines(t, logs)
+		require.Len(t, lines, 1)
+		assert.Equal(t, "INFO", lines[0]["level"])
+		assert.Equal(t, "request completed", lines[0]["msg"])
+		assert.Equal(t, "abc-123", lines[0]["request_id"])
+		assert.Equal(t, "/items/{id}", lines[0]["route"])
+		assert.Equal(t, float64(http.StatusOK), lines[0]["status"])
+		assert.Contains(t, lines[0], "latency_ms")
+	})
+
+	t.Run("generates an ID and echoes it in error bodies", func(t *testing.T) {
+		// arrange
+		logs := new(bytes.Buffer)
+		req := httptest.NewRequest(http.MethodGet, "/items/0", nil)
+		rec := httptest.NewRecorder()
+
+		// act
+		newRouter(logs).ServeHTTP(rec, req)
+
+		// assert
+		requestID := rec.Header().Get(logging.RequestIDHeader)
+		require.Len(t, requestID, 32)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(t, `{"status": "Internal Server Error", "message": "error reading item", "request_id": "`+requestID+`"}`, rec.Body.String())
+		lines := logLines(t, logs)
+		require.Len(t, lines, 2)
+		assert.Equal(t, "lookup failed", lines[0]["msg"])
+		assert.Equal(t, requestID, lines[0]["request_id"])
+		assert.Equal(t, "ERROR", lines[1]["level"])
+		assert.Equal(t, requestID, lines[1]["request_id"])
+		assert.Equal(t, "error reading item", lines[1]["error"])
+	})
+}
@@ -0,0 +1,100 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/httperrors"
+	"github.com/aaguero_meli/W17-G6-Bootcamp/pkg/metrics"
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Scrape(t *testing.T) {
+	// arrange
+	registry := metrics.NewRegistry()
+	errorCounter := metrics.NewErrorCounter(registry)
+	httperrors.ObserveErrors(func(errorType string) { errorCounter.Inc(errorType) })
+	t.Cleanup(func() { httperrors.ObserveErrors(func(string) {}) })
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	metrics.RegisterDBStats(registry, db)
+
+	router := chi.NewRouter()
+	router.Use(metrics.NewHTTPMetrics(registry).Middleware)
+	router.Get("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if chi.URLParam(r, "id") == "0" {
+			statusCode, msg := httperrors.GetErrorData(httperrors.NotFoundError{Message: "item not found"})
+			response.Error(w, statusCode, msg)
+			return
+		}
+		w.Write([]byte("OK"))
+	})
+	router.Handle("/metrics", registry.Handler())
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	for _, path := range []string{"/items/1", "/items/2", "/items/0", "/missing"} {
+		res, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		res.Body.Close()
+	}
+
+	// act
+	res, err := http.Get(server.URL + "/metrics")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	// assert
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", res.Header.Get("Content-Type"))
+	scrape := string(body)
+	for _, line := range []string{
+		"# TYPE http_requests_total counter",
+		`http_requests_total{route="/items/{id}",method="GET",status="200"} 2`,
+		`http_requests_total{route="/items/{id}",method="GET",status="404"} 1`,
+		`http_requests_total{route="unmatched",method="GET",status="404"} 1`,
+		"# TYPE http_request_duration_seconds histogram",
+		`http_request_duration_seconds_bucket{route="/items/{id}",method="GET",status="200",le="+Inf"} 2`,
+		`http_request_duration_seconds_count{route="/items/{id}",method="GET",status="200"} 2`,
+		`api_errors_total{type="NotFoundError"} 1`,
+		"# TYPE db_pool_open_connections gauge",
+		"db_pool_in_use_connections 0",
+		"# TYPE db_pool_wait_count_total counter",
+		"db_pool_wait_count_total 0",
+	} {
+		assert.Contains(t, scrape, line+"\n")
+	}
+}
+
+func TestHistogramVec_Buckets(t *testing.T) {
+	// arrange
+	registry := metrics.NewRegistry()
+	histogram := registry.NewHistogramVec("job_seconds", "Job duration.", []float64{1, 0.5}, "job")
+
+	// act
+	histogram.Observe(0.5, "import")
+	histogram.Observe(0.75, "import")
+	histogram.Observe(3, "import")
+	rec := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// assert
+	assert.Equal(t, `# HELP job_seconds Job duration.
+# TYPE job_seconds histogram
+job_seconds_bucket{job="import",le="0.5"} 1
+job_seconds_bucket{job="import",le="1"} 2
+job_seconds_bucket{job="import",le="+Inf"} 3
+job_seconds_sum{job="import"} 4.25
+job_seconds_count{job="import"} 3
+`, rec.Body.String())
+}